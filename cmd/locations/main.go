@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/location"
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+	"github.com/Zam83-AZE/logistics_system/pkg/config"
+	"github.com/Zam83-AZE/logistics_system/pkg/db"
+	"github.com/Zam83-AZE/logistics_system/pkg/logger"
+	_ "github.com/lib/pq"
+)
+
+const usage = `İstifadə: locations [-config-dir configs] import [-all] [-countries AZ,GE] <fayl.csv>...
+
+Əmrlər:
+  import   UNECE-nin rəsmi UN/LOCODE CSV buraxılışını (CodeListPart1.csv, CodeListPart2.csv,
+           CodeListPart3.csv) lokal fayldan idxal et. Təkrar idxal mövcud məntəqələri yeniləyir.
+
+import parametrləri:
+  -all         bütün məntəqələri idxal et (standart olaraq yalnız limanlar və sərhəd keçidləri)
+  -countries   yalnız verilmiş ölkələrin məntəqələrini idxal et (vergüllə ayrılmış ISO kodları)
+`
+
+func main() {
+	configDir := flag.String("config-dir", "configs", "app.yaml və db.yaml fayllarının qovluğu")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+
+	log := logger.NewLogger()
+
+	args := flag.Args()
+	if len(args) < 1 || args[0] != "import" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	importFlags := flag.NewFlagSet("import", flag.ExitOnError)
+	importFlags.Usage = flag.Usage
+	all := importFlags.Bool("all", false, "bütün məntəqələri idxal et")
+	countries := importFlags.String("countries", "", "vergüllə ayrılmış ölkə kodları")
+	importFlags.Parse(args[1:])
+
+	files := importFlags.Args()
+	if len(files) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	opts := location.ImportOptions{All: *all}
+	if *countries != "" {
+		opts.Countries = strings.Split(*countries, ",")
+	}
+
+	cfg, err := config.Load(*configDir)
+	if err != nil {
+		log.WithError(err).Fatal("Konfiqurasiyanın yüklənməsi xətası")
+	}
+
+	// Verilənlər bazasına qoşulma
+	database, err := db.Connect(cfg.DB)
+	if err != nil {
+		log.WithError(err).Fatal("Verilənlər bazasına qoşulma xətası")
+	}
+	defer database.Close()
+
+	service := location.NewLocationService(location.NewPostgresRepository(database), audit.NewRecorder(database))
+	ctx := context.Background()
+
+	for _, name := range files {
+		file, err := os.Open(name)
+		if err != nil {
+			log.WithError(err).Fatal("Fayl açılmadı")
+		}
+
+		result, err := service.Import(ctx, file, opts)
+		file.Close()
+		if err != nil {
+			log.WithError(err).WithField("file", name).Fatal("UN/LOCODE idxalı xətası")
+		}
+
+		for _, lineErr := range result.Errors {
+			log.WithField("file", name).Warn(lineErr.Error())
+		}
+		log.WithField("file", name).Infof("Oxundu: %d, əlavə edildi: %d, yeniləndi: %d, deaktiv edildi: %d, buraxıldı: %d",
+			result.Read, result.Inserted, result.Updated, result.Deactivated, result.Skipped)
+	}
+}
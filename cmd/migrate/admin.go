@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/auth"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/rbac"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/user"
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+	"github.com/Zam83-AZE/logistics_system/pkg/config"
+	"github.com/jmoiron/sqlx"
+)
+
+// Mühit dəyişənləri create-admin əmrinin parametrlərini flaqlar olmadan ötürmək üçündür
+// (məs. konteynerdə ilk işə salınma). Şifrə flaq kimi qəbul edilmir ki, proses siyahısında görünməsin.
+const (
+	envAdminUsername = "LOGISTICS_ADMIN_USERNAME"
+	envAdminEmail    = "LOGISTICS_ADMIN_EMAIL"
+	envAdminFullName = "LOGISTICS_ADMIN_FULL_NAME"
+	envAdminPassword = "LOGISTICS_ADMIN_PASSWORD"
+)
+
+// createAdmin administrator rolu ilə yeni istifadəçi yaradır. Boş verilənlər bazasında ilk hesab
+// yalnız bu əmrlə yaradıla bilər. İstifadəçi və rolu bir tranzaksiyada əlavə edilir, şifrə
+// auth.PasswordPolicy ilə yoxlanılır və bcrypt ilə heşlənir.
+func createAdmin(ctx context.Context, database *sqlx.DB, cfg *config.Config, args []string, stdin io.Reader) (*user.User, error) {
+	flags := flag.NewFlagSet("create-admin", flag.ContinueOnError)
+	username := flags.String("username", os.Getenv(envAdminUsername), "istifadəçi adı ("+envAdminUsername+")")
+	email := flags.String("email", os.Getenv(envAdminEmail), "e-poçt ünvanı ("+envAdminEmail+")")
+	fullName := flags.String("full-name", envOrDefault(envAdminFullName, "Administrator"), "tam ad ("+envAdminFullName+")")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if *username == "" || *email == "" {
+		return nil, errors.New("istifadəçi adı və e-poçt ünvanı tələb olunur")
+	}
+
+	password, err := readPassword(stdin)
+	if err != nil {
+		return nil, err
+	}
+
+	recorder := audit.NewRecorder(database)
+	roles := rbac.NewRBACService(rbac.NewPostgresRepository(database), recorder)
+
+	adminRoleID, err := roleID(ctx, roles, rbac.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	service := user.NewUserService(user.NewPostgresRepository(database), roles, recorder,
+		auth.PasswordPolicy{MinLength: cfg.Auth.PasswordMinLength})
+
+	return service.Create(ctx, user.UserForm{
+		Username:        strings.ToLower(strings.TrimSpace(*username)),
+		Email:           *email,
+		FullName:        *fullName,
+		Password:        password,
+		ConfirmPassword: password,
+		RoleIDs:         []int{adminRoleID},
+	})
+}
+
+// readPassword şifrəni mühit dəyişənindən, yoxdursa standart girişin ilk sətrindən oxuyur
+func readPassword(stdin io.Reader) (string, error) {
+	if password, ok := os.LookupEnv(envAdminPassword); ok {
+		return password, nil
+	}
+
+	fmt.Fprint(os.Stderr, "Şifrə: ")
+	line, err := bufio.NewReader(stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", errors.New("şifrə tələb olunur (" + envAdminPassword + " və ya standart giriş)")
+	}
+	return password, nil
+}
+
+// roleID verilmiş kodlu rolun ID-sini qaytarır
+func roleID(ctx context.Context, roles rbac.Service, code string) (int, error) {
+	list, err := roles.ListRoles(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, role := range list {
+		if role.Code == code {
+			return role.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("%s rolu tapılmadı; əvvəlcə miqrasiyaları tətbiq edin", code)
+}
+
+// envOrDefault mühit dəyişəninin dəyərini, təyin edilməyibsə standart dəyəri qaytarır
+func envOrDefault(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/config"
+	"github.com/Zam83-AZE/logistics_system/pkg/db"
+	"github.com/Zam83-AZE/logistics_system/pkg/logger"
+	"github.com/Zam83-AZE/logistics_system/pkg/migrate"
+	_ "github.com/lib/pq"
+)
+
+const usage = `İstifadə: migrate [-config-dir configs] <əmr>
+
+Əmrlər:
+  up       bütün tətbiq edilməmiş miqrasiyaları tətbiq et
+  down     son tətbiq edilmiş miqrasiyanı geri qaytar
+  status   miqrasiyaların vəziyyətini göstər
+  to N     sxemi N versiyasına gətir (0 - bütün miqrasiyaları geri qaytar)
+  create-admin -username U -email E [-full-name F]
+           administrator rolu ilə istifadəçi yarat; şifrə LOGISTICS_ADMIN_PASSWORD
+           mühit dəyişənindən və ya standart girişdən oxunur
+`
+
+func main() {
+	configDir := flag.String("config-dir", "configs", "app.yaml və db.yaml fayllarının qovluğu")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+
+	log := logger.NewLogger()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load(*configDir)
+	if err != nil {
+		log.WithError(err).Fatal("Konfiqurasiyanın yüklənməsi xətası")
+	}
+
+	// Verilənlər bazasına qoşulma
+	database, err := db.Connect(cfg.DB)
+	if err != nil {
+		log.WithError(err).Fatal("Verilənlər bazasına qoşulma xətası")
+	}
+	defer database.Close()
+
+	migrator, err := migrate.New(database)
+	if err != nil {
+		log.WithError(err).Fatal("Miqrasiyaların oxunması xətası")
+	}
+
+	ctx := context.Background()
+
+	var done []migrate.Migration
+	switch args[0] {
+	case "up":
+		done, err = migrator.Up(ctx)
+	case "down":
+		done, err = migrator.Down(ctx)
+	case "to":
+		if len(args) < 2 {
+			flag.Usage()
+			os.Exit(2)
+		}
+		target, convErr := strconv.Atoi(args[1])
+		if convErr != nil {
+			log.Fatalf("Hədəf versiya yanlışdır: %s", args[1])
+		}
+		done, err = migrator.To(ctx, target)
+	case "status":
+		statuses, statusErr := migrator.Status(ctx)
+		if statusErr != nil {
+			log.WithError(statusErr).Fatal("Miqrasiya vəziyyəti əldə edilmədi")
+		}
+		for _, s := range statuses {
+			state := "gözləyir"
+			if s.Applied {
+				state = "tətbiq edilib " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d  %-32s %s\n", s.Version, s.Name, state)
+		}
+		return
+	case "create-admin":
+		admin, adminErr := createAdmin(ctx, database, cfg, args[1:], os.Stdin)
+		if adminErr != nil {
+			log.WithError(adminErr).Fatal("Administrator yaradılmadı")
+		}
+		log.Infof("Administrator yaradıldı: %s (ID %d)", admin.Username, admin.ID)
+		return
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	for _, m := range done {
+		log.Infof("%04d_%s", m.Version, m.Name)
+	}
+
+	if err != nil {
+		log.WithError(err).Fatal("Miqrasiya xətası")
+	}
+
+	if len(done) == 0 {
+		log.Info("Sxem artıq aktualdır")
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/carrier"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/container"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/customer"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/dashboard"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/invoice"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/location"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/shipment"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/user"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/voyage"
+	"github.com/Zam83-AZE/logistics_system/pkg/api"
+	"github.com/Zam83-AZE/logistics_system/pkg/config"
+	"github.com/Zam83-AZE/logistics_system/pkg/openapi"
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/gorilla/mux"
+	"github.com/jmoiron/sqlx"
+)
+
+// registerAPI JSON API marşrutlarını (/api/v1) qeydə alır.
+// API HTML səhifələri ilə eyni servisləri, autentifikasiyanı və icazələri istifadə edir.
+func registerAPI(router *mux.Router, database *sqlx.DB, sessionManager *session.Manager, cfg *config.Config) {
+	apiRouter := router.PathPrefix(api.Prefix).Subrouter()
+	apiRouter.NotFoundHandler = http.HandlerFunc(api.NotFound)
+	apiRouter.MethodNotAllowedHandler = http.HandlerFunc(api.MethodNotAllowed)
+	apiRouter.Use(api.Negotiate)
+
+	user.RegisterAPIRoutes(apiRouter, database, sessionManager, cfg.Auth)
+	dashboard.RegisterAPIRoutes(apiRouter, database, sessionManager)
+	customer.RegisterAPIRoutes(apiRouter, database)
+	container.RegisterAPIRoutes(apiRouter, database)
+	shipment.RegisterAPIRoutes(apiRouter, database)
+	invoice.RegisterAPIRoutes(apiRouter, database)
+	location.RegisterAPIRoutes(apiRouter, database)
+	carrier.RegisterAPIRoutes(apiRouter, database)
+	voyage.RegisterAPIRoutes(apiRouter, database)
+}
+
+// apiSpec registerAPI-nin qeydə aldığı marşrutların OpenAPI sənədini qurur.
+// Yeni marşrut əlavə edildikdə onun təsviri də domenin DescribeAPI funksiyasına əlavə edilməlidir.
+func apiSpec(cfg *config.Config) *openapi.Spec {
+	spec := openapi.New(cfg.App.Name+" API", cfg.App.Version,
+		"Logistika sisteminin JSON API-si. Bütün cavablar {\"data\": ...} və ya {\"error\": {\"code\", \"message\"}} zərfindədir.",
+		api.Prefix)
+
+	user.DescribeAPI(spec)
+	dashboard.DescribeAPI(spec)
+	customer.DescribeAPI(spec)
+	container.DescribeAPI(spec)
+	shipment.DescribeAPI(spec)
+	invoice.DescribeAPI(spec)
+	location.DescribeAPI(spec)
+	carrier.DescribeAPI(spec)
+	voyage.DescribeAPI(spec)
+
+	return spec
+}
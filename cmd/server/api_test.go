@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/config"
+	"github.com/gorilla/mux"
+)
+
+// TestAPISpecCoversRoutes qeydə alınmış hər API marşrutunun OpenAPI sənədində təsvir edildiyini yoxlayır
+func TestAPISpecCoversRoutes(t *testing.T) {
+	cfg := &config.Config{}
+	router := mux.NewRouter()
+	registerAPI(router, nil, nil, cfg)
+	spec := apiSpec(cfg)
+
+	missing, err := spec.Undocumented(router)
+	if err != nil {
+		t.Fatalf("marşrutlar oxunmadı: %v", err)
+	}
+	for _, op := range missing {
+		t.Errorf("marşrut OpenAPI sənədində yoxdur: %s", op)
+	}
+
+	stale, err := spec.Unregistered(router)
+	if err != nil {
+		t.Fatalf("marşrutlar oxunmadı: %v", err)
+	}
+	for _, op := range stale {
+		t.Errorf("sənəddəki marşrut qeydə alınmayıb: %s", op)
+	}
+}
+
+// TestAPISpecDocument sənədin JSON-a çevrildiyini və istinad edilən sxemlərin mövcud olduğunu yoxlayır
+func TestAPISpecDocument(t *testing.T) {
+	body, err := json.Marshal(apiSpec(&config.Config{}).Document())
+	if err != nil {
+		t.Fatalf("sənəd JSON-a çevrilmədi: %v", err)
+	}
+
+	var doc struct {
+		OpenAPI    string `json:"openapi"`
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		t.Fatalf("sənəd oxunmadı: %v", err)
+	}
+	if doc.OpenAPI != "3.1.0" {
+		t.Errorf("openapi = %q, gözlənilən 3.1.0", doc.OpenAPI)
+	}
+
+	var refs []string
+	collectRefs(json.RawMessage(body), &refs)
+	for _, ref := range refs {
+		const prefix = "#/components/schemas/"
+		if len(ref) > len(prefix) && ref[:len(prefix)] == prefix {
+			if _, ok := doc.Components.Schemas[ref[len(prefix):]]; !ok {
+				t.Errorf("sxem tapılmadı: %s", ref)
+			}
+		}
+	}
+}
+
+// collectRefs JSON-dakı bütün $ref dəyərlərini toplayır
+func collectRefs(raw json.RawMessage, refs *[]string) {
+	var object map[string]json.RawMessage
+	if json.Unmarshal(raw, &object) == nil {
+		for key, value := range object {
+			var ref string
+			if key == "$ref" && json.Unmarshal(value, &ref) == nil {
+				*refs = append(*refs, ref)
+				continue
+			}
+			collectRefs(value, refs)
+		}
+		return
+	}
+
+	var array []json.RawMessage
+	if json.Unmarshal(raw, &array) == nil {
+		for _, value := range array {
+			collectRefs(value, refs)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/health"
+	"github.com/Zam83-AZE/logistics_system/pkg/migrate"
+	"github.com/jmoiron/sqlx"
+)
+
+// requiredTemplates bütün səhifələrin istifadə etdiyi ümumi şablonlardır; onlar olmadan heç bir səhifə göstərilə bilməz
+var requiredTemplates = []string{"header", "footer", "login.html"}
+
+// readinessChecks /readyz üçün verilənlər bazası, miqrasiya və şablon yoxlamalarını qurur
+func readinessChecks(checker *health.Checker, database *sqlx.DB, migrator *migrate.Migrator, tmpl *template.Template) {
+	checker.Add("database", database.PingContext)
+
+	checker.Add("migrations", func(ctx context.Context) error {
+		current, err := migrator.Current(ctx)
+		if err != nil {
+			return err
+		}
+		if latest := migrator.Latest(); current < latest {
+			return fmt.Errorf("sxem %d versiyasındadır, %d gözlənilir", current, latest)
+		}
+		return nil
+	})
+
+	checker.Add("templates", func(context.Context) error {
+		if tmpl == nil {
+			return errors.New("şablonlar yüklənməyib")
+		}
+		for _, name := range requiredTemplates {
+			if tmpl.Lookup(name) == nil {
+				return fmt.Errorf("%s şablonu tapılmadı", name)
+			}
+		}
+		return nil
+	})
+}
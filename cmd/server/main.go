@@ -2,43 +2,103 @@ package main
 
 import (
 	"context"
-	"html/template"
+	"flag"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/Zam83-AZE/logistics_system/internal/domain/apitoken"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/auditlog"
 	"github.com/Zam83-AZE/logistics_system/internal/domain/auth"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/carrier"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/container"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/customer"
 	"github.com/Zam83-AZE/logistics_system/internal/domain/dashboard"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/invoice"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/location"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/rbac"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/shipment"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/tracking"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/user"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/voyage"
 	"github.com/Zam83-AZE/logistics_system/internal/middleware"
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+	"github.com/Zam83-AZE/logistics_system/pkg/config"
 	"github.com/Zam83-AZE/logistics_system/pkg/db"
+	"github.com/Zam83-AZE/logistics_system/pkg/health"
 	"github.com/Zam83-AZE/logistics_system/pkg/logger"
+	"github.com/Zam83-AZE/logistics_system/pkg/mail"
+	"github.com/Zam83-AZE/logistics_system/pkg/metrics"
+	"github.com/Zam83-AZE/logistics_system/pkg/migrate"
+	"github.com/Zam83-AZE/logistics_system/pkg/openapi"
 	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/Zam83-AZE/logistics_system/pkg/view"
 	"github.com/gorilla/mux"
-	"github.com/gorilla/sessions"
 	_ "github.com/lib/pq"
 )
 
 func main() {
+	configDir := flag.String("config-dir", "configs", "app.yaml və db.yaml fayllarının qovluğu")
+	flag.Parse()
+
 	// Loq inisializasiyası
 	log := logger.NewLogger()
-	log.Info("Logistics System işə salınır")
+
+	// Konfiqurasiyanın yüklənməsi
+	cfg, err := config.Load(*configDir)
+	if err != nil {
+		log.WithError(err).Fatal("Konfiqurasiyanın yüklənməsi xətası")
+	}
+	if err := logger.Configure(log, cfg.Log); err != nil {
+		log.WithError(err).Fatal("Loqun konfiqurasiyası xətası")
+	}
+	log.Infof("%s %s işə salınır (%s)", cfg.App.Name, cfg.App.Version, cfg.App.Environment)
 
 	// Verilənlər bazasına qoşulma
-	database, err := db.Connect()
+	database, err := db.Connect(cfg.DB)
 	if err != nil {
 		log.WithError(err).Fatal("Verilənlər bazasına qoşulma xətası")
 	}
 	defer database.Close()
 
+	// Sxem miqrasiyalarının tətbiqi (advisory lock altında, paralel başlanğıcda təhlükəsizdir)
+	migrator, err := migrate.New(database)
+	if err != nil {
+		log.WithError(err).Fatal("Miqrasiyaların oxunması xətası")
+	}
+	applied, err := migrator.Up(context.Background())
+	if err != nil {
+		log.WithError(err).Fatal("Miqrasiyaların tətbiqi xətası")
+	}
+	for _, m := range applied {
+		log.Infof("Miqrasiya tətbiq edildi: %04d_%s", m.Version, m.Name)
+	}
+
+	// E-poçt göndəricisi (şifrə bərpası məktubları üçün)
+	mailer, err := mail.New(cfg.Mail, log)
+	if err != nil {
+		log.WithError(err).Fatal("E-poçt göndəricisinin yaradılması xətası")
+	}
+
 	// Router inisializasiyası
 	router := mux.NewRouter()
 
-	// Sessiya mağazası yaratma
-	store := sessions.NewCookieStore([]byte("logistics-system-secret-key"))
+	// Prometheus ölçüləri: HTTP sorğuları, bağlantı hovuzu və biznes göstəriciləri
+	db.RegisterMetrics(metrics.Default, database)
+	dashboard.RegisterMetrics(metrics.Default, database)
+	router.Handle("/metrics", metrics.Default.Handler(cfg.Metrics.Token)).Methods("GET")
+
+	// Sessiya mağazası yaratma (PostgreSQL, server tərəfdən ləğv edilə bilən)
+	store := session.NewPGStore(database, cfg.Session)
 	sessionManager := session.NewManager(store)
 
+	// Köhnə sessiyaların fon rejimində silinməsi
+	sweepCtx, stopSweeper := context.WithCancel(context.Background())
+	defer stopSweeper()
+	go store.RunSweeper(sweepCtx, cfg.Session.SweepInterval, log)
+
 	// // Mütləq yol istifadə edin
 	// wd, err := os.Getwd()
 	// fmt.Println("Cari işçi qovluq:", wd)
@@ -60,8 +120,8 @@ func main() {
 	// }
 	// fmt.Println("Tapılan şablonlar:", tmplFiles)
 
-	// Şablonların emalı
-	tmpl, err := template.ParseGlob("web/templates/**/*.html")
+	// Şablonların emalı (ümumi layout və domen səhifələri)
+	tmpl, err := view.Parse("web/templates/*.html", "web/templates/**/*.html")
 	//tmpl := template.New("templates")
 	// Hər bir şablonu açıq şəkildə yükləyin
 	// _, err = tmpl.ParseFiles(
@@ -74,31 +134,103 @@ func main() {
 	}
 
 	// Middleware tətbiqi
-	router.Use(middleware.Logging(log))
+	router.Use(middleware.RequestID)
+	router.Use(middleware.Metrics(metrics.Default))
 	router.Use(sessionManager.Middleware)
 
+	// Sorğu loqu (status, ölçü, müddət) və sorğu identifikatoru ilə zənginləşdirilmiş logger
+	router.Use(middleware.Logging(log, sessionManager))
+
+	// Audit yazıları üçün icraçı, IP və sorğu identifikatoru
+	router.Use(middleware.Audit(sessionManager))
+
+	// Vəziyyəti dəyişən bütün sorğular sessiyaya bağlı CSRF tokeni ilə qorunur
+	router.Use(middleware.CSRF(sessionManager))
+
 	// Marşrutların qeydiyyatı
-	auth.RegisterRoutes(router, database, tmpl, sessionManager)
+	auth.RegisterRoutes(router, database, tmpl, sessionManager, cfg, mailer)
+
+	// API sənədi (OpenAPI 3.1) və onun baxış səhifəsi hamıya açıqdır
+	router.Handle("/api/openapi.json", apiSpec(cfg).Handler()).Methods("GET")
+	router.HandleFunc("/api/docs", openapi.DocsHandler).Methods("GET")
+
+	// Müştərilər üçün açıq izləmə səhifəsi (giriş tələb etmir, sorğular IP üzrə məhdudlaşdırılır)
+	tracking.RegisterPublicRoutes(router, database, tmpl, cfg.Tracking)
+
+	// Orkestrator üçün canlılıq və hazırlıq yoxlamaları
+	healthChecker := health.New(cfg.App.Timeout.Ready)
+	readinessChecks(healthChecker, database, migrator, tmpl)
+	router.Handle("/healthz", healthChecker.LiveHandler()).Methods("GET")
+	router.Handle("/readyz", healthChecker.ReadyHandler()).Methods("GET")
 
 	// Autentifikasiya tələb edən marşrutlar üçün alt-router
 	secureRouter := router.PathPrefix("/").Subrouter()
+
+	// İnteqrasiyalar kuki sessiyası əvəzinə "Authorization: Bearer" API tokeni göndərə bilər
+	secureRouter.Use(middleware.TokenAuth(apitoken.NewService(database)))
 	secureRouter.Use(middleware.RequireAuth(sessionManager))
 
+	// Administrator şifrə dəyişikliyini tələb etdikdə istifadəçi yeni şifrə təyin edənə qədər digər səhifələrə keçə bilmir
+	secureRouter.Use(middleware.RequirePasswordChange(sessionManager, "/account/password"))
+
+	// Rolu iki faktorlu autentifikasiya tələb edən istifadəçilər onu qurana qədər ayarlar səhifəsində saxlanılır
+	secureRouter.Use(middleware.RequireTwoFactorEnrollment(sessionManager, "/account/2fa"))
+
+	// Rol əsaslı icazələrin yüklənməsi (hər marşrut öz icazəsini RequirePermission ilə yoxlayır)
+	rbacService := rbac.NewRBACService(rbac.NewPostgresRepository(database), audit.NewRecorder(database))
+	secureRouter.Use(middleware.LoadPermissions(sessionManager, rbacService))
+
+	// Giriş bloklamalarının idarəsi və hesab ayarları
+	auth.RegisterAdminRoutes(secureRouter, database, tmpl, sessionManager, cfg, mailer)
+	auth.RegisterAccountRoutes(secureRouter, database, tmpl, sessionManager, cfg, mailer)
+
+	// İstifadəçilərin idarəsi
+	user.RegisterRoutes(secureRouter, database, tmpl, sessionManager, cfg.Auth)
+
+	// Şəxsi API tokenləri
+	apitoken.RegisterRoutes(secureRouter, database, tmpl, sessionManager, middleware.SessionOnly)
+
+	// Audit jurnalı
+	auditlog.RegisterRoutes(secureRouter, database, tmpl, sessionManager)
+
+	// Rolların idarəsi
+	rbac.RegisterRoutes(secureRouter, database, tmpl, sessionManager, middleware.RequirePermission(rbac.UsersManage))
+
 	// Dashboard marşrutlarının qeydiyyatı
 	dashboard.RegisterRoutes(secureRouter, database, tmpl)
 
+	// Müştəri marşrutlarının qeydiyyatı
+	customer.RegisterRoutes(secureRouter, database, tmpl, sessionManager)
+
+	// Konteyner reyestri marşrutlarının qeydiyyatı
+	container.RegisterRoutes(secureRouter, database, tmpl, sessionManager)
+
+	// Daşınma marşrutlarının qeydiyyatı
+	shipment.RegisterRoutes(secureRouter, database, tmpl, sessionManager)
+
+	// Faktura marşrutlarının qeydiyyatı
+	invoice.RegisterRoutes(secureRouter, database, tmpl, sessionManager)
+
+	// Məntəqə (liman, terminal, anbar və s.) marşrutlarının qeydiyyatı
+	location.RegisterRoutes(secureRouter, database, tmpl, sessionManager)
+	carrier.RegisterRoutes(secureRouter, database, tmpl, sessionManager)
+	voyage.RegisterRoutes(secureRouter, database, tmpl, sessionManager)
+
+	// JSON API (/api/v1)
+	registerAPI(secureRouter, database, sessionManager, cfg)
+
 	// Server tərifləri
 	srv := &http.Server{
-		Addr:         ":8080",
+		Addr:         cfg.App.Addr(),
 		Handler:      router,
-		WriteTimeout: 15 * time.Second,
-		ReadTimeout:  15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+		WriteTimeout: cfg.App.Timeout.Write,
+		ReadTimeout:  cfg.App.Timeout.Read,
+		IdleTimeout:  cfg.App.Timeout.Idle,
 	}
 
 	// Serverin başladılması
 	go func() {
-		log.Info("Server :8080 portunda başladılır")
+		log.Infof("Server %s ünvanında başladılır", srv.Addr)
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.WithError(err).Fatal("Server başlatma xətası")
 		}
@@ -109,7 +241,16 @@ func main() {
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	<-c
 
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	// /readyz dərhal uğursuz olur; drain müddətində yeni sorğular hələ qəbul edilir ki,
+	// balanslaşdırıcı instansiyanı siyahıdan çıxara bilsin. İkinci siqnal gözləməni dayandırır.
+	healthChecker.Drain()
+	log.Infof("Server bağlanmağa hazırlaşır, trafik %s ərzində boşaldılır", cfg.App.Timeout.Drain)
+	select {
+	case <-time.After(cfg.App.Timeout.Drain):
+	case <-c:
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.App.Timeout.Server)
 	defer cancel()
 
 	log.Info("Server bağlanır")
@@ -0,0 +1,117 @@
+package apitoken
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"html/template"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/Zam83-AZE/logistics_system/pkg/view"
+	"github.com/gorilla/mux"
+)
+
+// Handler API tokenlərinin idarəsi HTTP sorğularını işləyir
+type Handler struct {
+	service        Service
+	tmpl           *template.Template
+	sessionManager *session.Manager
+}
+
+// NewHandler yeni token işləyicisi yaradır
+func NewHandler(service Service, tmpl *template.Template, sessionManager *session.Manager) *Handler {
+	return &Handler{
+		service:        service,
+		tmpl:           tmpl,
+		sessionManager: sessionManager,
+	}
+}
+
+// List istifadəçinin tokenlərini və yeni token formunu göstərir
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	h.render(w, r, TokenForm{ExpiresInDays: 90}, "", "", http.StatusOK)
+}
+
+// Create yeni token yaradır və açıq mətnini bir dəfə göstərir
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	form := parseForm(r)
+
+	raw, _, err := h.service.Create(r.Context(), h.sessionManager.GetUserID(r), form)
+	if err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			h.render(w, r, form, "", validationErr.Message, http.StatusUnprocessableEntity)
+			return
+		}
+		http.Error(w, "Token yaradılmadı", http.StatusInternalServerError)
+		return
+	}
+
+	h.render(w, r, TokenForm{ExpiresInDays: 90}, raw, "", http.StatusOK)
+}
+
+// Revoke tokeni ləğv edir
+func (h *Handler) Revoke(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := h.service.Revoke(r.Context(), h.sessionManager.GetUserID(r), id); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		http.Error(w, "Token ləğv edilmədi", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/account/tokens", http.StatusSeeOther)
+}
+
+// render tokenlər səhifəsini göstərir
+func (h *Handler) render(w http.ResponseWriter, r *http.Request, form TokenForm, newToken, message string, status int) {
+	ctx := r.Context()
+	userID := h.sessionManager.GetUserID(r)
+
+	tokens, err := h.service.List(ctx, userID)
+	if err != nil {
+		http.Error(w, "Tokenlər əldə edilərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	scopes, err := h.service.AvailableScopes(ctx, userID)
+	if err != nil {
+		http.Error(w, "İcazələr əldə edilərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	data := TokensPage{
+		Tokens:      tokens,
+		Form:        form,
+		Scopes:      scopes,
+		Expiries:    ExpiryOptions(),
+		NewToken:    newToken,
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "tokens",
+		Error:       message,
+	}
+
+	w.WriteHeader(status)
+	view.Render(w, r, h.tmpl, "apitoken/tokens.html", data)
+}
+
+// parseForm sorğudan token formunun dəyərlərini oxuyur
+func parseForm(r *http.Request) TokenForm {
+	r.ParseForm()
+
+	days, _ := strconv.Atoi(r.FormValue("expires_in_days"))
+
+	return TokenForm{
+		Name:          r.FormValue("name"),
+		Scopes:        r.PostForm["scopes"],
+		ExpiresInDays: days,
+	}
+}
@@ -0,0 +1,100 @@
+package apitoken
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/rbac"
+	"github.com/lib/pq"
+)
+
+// TokenPrefix bütün API tokenlərinin əvvəlinə əlavə edilir ki, sızmış tokenlər asan tanınsın
+const TokenPrefix = "lgs_"
+
+// Token istifadəçinin maşın müştəriləri üçün yaratdığı şəxsi API tokenini təmsil edir.
+// Tokenin özü saxlanılmır; yalnız heşi və tanınma üçün ilk simvolları (Prefix) saxlanılır.
+type Token struct {
+	ID         int            `db:"id" json:"id"`
+	UserID     int            `db:"user_id" json:"-"`
+	Username   string         `db:"username" json:"-"`
+	Name       string         `db:"name" json:"name"`
+	Prefix     string         `db:"prefix" json:"prefix"`
+	Scopes     pq.StringArray `db:"scopes" json:"scopes"`
+	ExpiresAt  time.Time      `db:"expires_at" json:"expiresAt"`
+	LastUsedAt *time.Time     `db:"last_used_at" json:"lastUsedAt"`
+	LastUsedIP string         `db:"last_used_ip" json:"lastUsedIp"`
+	RevokedAt  *time.Time     `db:"revoked_at" json:"revokedAt"`
+	CreatedAt  time.Time      `db:"created_at" json:"createdAt"`
+}
+
+// Permissions tokenin əhatə dairəsini icazələr kimi qaytarır
+func (t Token) Permissions() []rbac.Permission {
+	permissions := make([]rbac.Permission, 0, len(t.Scopes))
+	for _, scope := range t.Scopes {
+		permissions = append(permissions, rbac.Permission(scope))
+	}
+	return permissions
+}
+
+// Active tokenin ləğv edilmədiyini və vaxtının bitmədiyini göstərir
+func (t Token) Active() bool {
+	return t.RevokedAt == nil && t.ExpiresAt.After(time.Now())
+}
+
+// TokenForm yeni token formunu təmsil edir
+type TokenForm struct {
+	Name          string
+	Scopes        []string
+	ExpiresInDays int
+}
+
+// HasScope əhatə dairəsinin formda seçildiyini yoxlayır
+func (f TokenForm) HasScope(scope string) bool {
+	for _, s := range f.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// ExpiryOption formda seçilə bilən etibarlılıq müddətidir
+type ExpiryOption struct {
+	Days  int
+	Label string
+}
+
+// ExpiryOptions icazə verilən etibarlılıq müddətlərini qaytarır
+func ExpiryOptions() []ExpiryOption {
+	return []ExpiryOption{
+		{Days: 7, Label: "7 gün"},
+		{Days: 30, Label: "30 gün"},
+		{Days: 90, Label: "90 gün"},
+		{Days: 365, Label: "1 il"},
+	}
+}
+
+// TokensPage API tokenləri səhifəsinin məlumatlarını saxlayır
+type TokensPage struct {
+	Tokens      []Token
+	Form        TokenForm
+	Scopes      []string
+	Expiries    []ExpiryOption
+	NewToken    string
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+type contextKey struct{}
+
+// WithToken sorğunu autentifikasiya edən tokeni kontekstə əlavə edir
+func WithToken(ctx context.Context, token *Token) context.Context {
+	return context.WithValue(ctx, contextKey{}, token)
+}
+
+// FromContext sorğu token ilə autentifikasiya edilibsə tokeni, əks halda nil qaytarır
+func FromContext(ctx context.Context) *Token {
+	token, _ := ctx.Value(contextKey{}).(*Token)
+	return token
+}
@@ -0,0 +1,134 @@
+package apitoken
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// touchInterval last_used_at sahəsinin hər sorğuda deyil, ən çox bu intervalda yenilənməsini təmin edir
+const touchInterval = time.Minute
+
+// tokenColumns token sorğularında seçilən sütunlardır
+const tokenColumns = `t.id, t.user_id, u.username, t.name, t.prefix, t.scopes, t.expires_at,
+	t.last_used_at, t.last_used_ip, t.revoked_at, t.created_at`
+
+// Repository API token məlumatları əməliyyatlarını müəyyən edir
+type Repository interface {
+	ListByUser(ctx context.Context, userID int) ([]Token, error)
+	Create(ctx context.Context, token *Token, tokenHash string) error
+	Revoke(ctx context.Context, userID, id int) (bool, error)
+	RevokeUser(ctx context.Context, userID int) (int64, error)
+	GetActiveByHash(ctx context.Context, tokenHash string) (*Token, error)
+	Touch(ctx context.Context, id int, ip string) error
+}
+
+// PostgresRepository Repository interfeysini həyata keçirir
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRepository yeni PostgresRepository yaradır
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+// ListByUser istifadəçinin bütün tokenlərini (ləğv edilmişlər daxil) yeni olandan köhnəyə qaytarır
+func (r *PostgresRepository) ListByUser(ctx context.Context, userID int) ([]Token, error) {
+	query := `
+		SELECT ` + tokenColumns + `
+		FROM api_tokens t
+		JOIN users u ON u.id = t.user_id
+		WHERE t.user_id = $1
+		ORDER BY t.created_at DESC
+	`
+
+	tokens := []Token{}
+	if err := r.db.SelectContext(ctx, &tokens, query, userID); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// Create yeni token əlavə edir
+func (r *PostgresRepository) Create(ctx context.Context, token *Token, tokenHash string) error {
+	query := `
+		INSERT INTO api_tokens (user_id, name, prefix, token_hash, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+
+	row := r.db.QueryRowxContext(ctx, query, token.UserID, token.Name, token.Prefix, tokenHash,
+		pq.StringArray(token.Scopes), token.ExpiresAt)
+	return row.Scan(&token.ID, &token.CreatedAt)
+}
+
+// Revoke istifadəçiyə məxsus tokeni ləğv edir; token tapılmadıqda false qaytarır
+func (r *PostgresRepository) Revoke(ctx context.Context, userID, id int) (bool, error) {
+	query := `
+		UPDATE api_tokens
+		SET revoked_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// RevokeUser istifadəçinin bütün aktiv tokenlərini ləğv edir və ləğv edilənlərin sayını qaytarır
+func (r *PostgresRepository) RevokeUser(ctx context.Context, userID int) (int64, error) {
+	query := `
+		UPDATE api_tokens
+		SET revoked_at = NOW()
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// GetActiveByHash ləğv edilməmiş, vaxtı bitməmiş və aktiv istifadəçiyə məxsus tokeni heşə görə əldə edir
+func (r *PostgresRepository) GetActiveByHash(ctx context.Context, tokenHash string) (*Token, error) {
+	query := `
+		SELECT ` + tokenColumns + `
+		FROM api_tokens t
+		JOIN users u ON u.id = t.user_id
+		WHERE t.token_hash = $1 AND t.revoked_at IS NULL AND t.expires_at > NOW() AND u.is_active = true
+	`
+
+	token := &Token{}
+	err := r.db.GetContext(ctx, token, query, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// Touch tokenin son istifadə vaxtını və IP ünvanını yeniləyir
+func (r *PostgresRepository) Touch(ctx context.Context, id int, ip string) error {
+	query := `
+		UPDATE api_tokens
+		SET last_used_at = NOW(), last_used_ip = $2
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - $3 * INTERVAL '1 second' OR last_used_ip <> $2)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, id, ip, touchInterval.Seconds())
+	return err
+}
@@ -0,0 +1,28 @@
+package apitoken
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/rbac"
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/gorilla/mux"
+	"github.com/jmoiron/sqlx"
+)
+
+// RegisterRoutes istifadəçinin öz API tokenlərini idarə etdiyi marşrutları qeydə alır
+// sessionOnly token ilə gələn sorğuları rədd edir ki, sızmış token yeni tokenlər yarada bilməsin
+func RegisterRoutes(router *mux.Router, db *sqlx.DB, tmpl *template.Template, sessionManager *session.Manager, sessionOnly func(http.Handler) http.Handler) {
+	handler := NewHandler(NewService(db), tmpl, sessionManager)
+
+	router.Handle("/account/tokens", sessionOnly(http.HandlerFunc(handler.List))).Methods("GET")
+	router.Handle("/account/tokens", sessionOnly(http.HandlerFunc(handler.Create))).Methods("POST")
+	router.Handle("/account/tokens/{id:[0-9]+}/revoke", sessionOnly(http.HandlerFunc(handler.Revoke))).Methods("POST")
+}
+
+// NewService verilənlər bazası üzərində TokenService qurur
+func NewService(db *sqlx.DB) *TokenService {
+	recorder := audit.NewRecorder(db)
+	return NewTokenService(NewPostgresRepository(db), rbac.NewRBACService(rbac.NewPostgresRepository(db), recorder), recorder)
+}
@@ -0,0 +1,235 @@
+package apitoken
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/rbac"
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+	"github.com/Zam83-AZE/logistics_system/pkg/securetoken"
+)
+
+// maxActiveTokens bir istifadəçinin eyni vaxtda malik ola biləcəyi aktiv tokenlərin sayıdır
+const maxActiveTokens = 20
+
+var (
+	// ErrNotFound token tapılmadıqda və ya istifadəçiyə məxsus olmadıqda qaytarılır
+	ErrNotFound = errors.New("token tapılmadı")
+	// ErrInvalidToken token yanlış, ləğv edilmiş və ya vaxtı bitmiş olduqda qaytarılır
+	ErrInvalidToken = errors.New("API tokeni etibarsızdır və ya vaxtı bitib")
+)
+
+var tokenEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// ValidationError istifadəçiyə göstəriləcək form xətasını təmsil edir
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// Service API tokenlərinin biznes məntiqini müəyyən edir
+type Service interface {
+	List(ctx context.Context, userID int) ([]Token, error)
+	Create(ctx context.Context, userID int, form TokenForm) (string, *Token, error)
+	Revoke(ctx context.Context, userID, id int) error
+	RevokeAll(ctx context.Context, userID int) error
+	Authenticate(ctx context.Context, raw, ip string) (*Token, error)
+	AvailableScopes(ctx context.Context, userID int) ([]string, error)
+}
+
+// TokenService Service interfeysini həyata keçirir
+type TokenService struct {
+	repo  Repository
+	rbac  rbac.Service
+	audit audit.Recorder
+	now   func() time.Time
+}
+
+// NewTokenService yeni TokenService yaradır
+func NewTokenService(repo Repository, rbacService rbac.Service, recorder audit.Recorder) *TokenService {
+	return &TokenService{repo: repo, rbac: rbacService, audit: recorder, now: time.Now}
+}
+
+// List istifadəçinin tokenlərini qaytarır
+func (s *TokenService) List(ctx context.Context, userID int) ([]Token, error) {
+	return s.repo.ListByUser(ctx, userID)
+}
+
+// AvailableScopes istifadəçinin tokenə verə biləcəyi əhatə dairələrini (öz icazələrini) qaytarır
+func (s *TokenService) AvailableScopes(ctx context.Context, userID int) ([]string, error) {
+	permissions, err := s.rbac.Permissions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := make([]string, 0, len(permissions))
+	for permission := range permissions {
+		scopes = append(scopes, string(permission))
+	}
+	sort.Strings(scopes)
+
+	return scopes, nil
+}
+
+// Create yeni token yaradır və onun açıq mətnini qaytarır; açıq mətn yalnız bir dəfə göstərilir
+func (s *TokenService) Create(ctx context.Context, userID int, form TokenForm) (string, *Token, error) {
+	form.Name = strings.TrimSpace(form.Name)
+	if form.Name == "" || utf8.RuneCountInString(form.Name) > 100 {
+		return "", nil, &ValidationError{Message: "tokenin adı tələb olunur (ən çox 100 simvol)"}
+	}
+
+	if !validExpiry(form.ExpiresInDays) {
+		return "", nil, &ValidationError{Message: "etibarlılıq müddəti yanlışdır"}
+	}
+
+	if len(form.Scopes) == 0 {
+		return "", nil, &ValidationError{Message: "ən azı bir icazə seçilməlidir"}
+	}
+
+	// Token istifadəçinin özündə olmayan icazəni ala bilməz
+	permissions, err := s.rbac.Permissions(ctx, userID)
+	if err != nil {
+		return "", nil, err
+	}
+	scopes := make([]string, 0, len(form.Scopes))
+	seen := map[string]bool{}
+	for _, scope := range form.Scopes {
+		if !permissions.Can(scope) {
+			return "", nil, &ValidationError{Message: "sizdə olmayan icazə seçilib: " + scope}
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+
+	existing, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return "", nil, err
+	}
+	active := 0
+	for _, token := range existing {
+		if token.Active() {
+			active++
+		}
+	}
+	if active >= maxActiveTokens {
+		return "", nil, &ValidationError{Message: "aktiv tokenlərin sayı həddə çatıb; köhnə tokenləri ləğv edin"}
+	}
+
+	raw, hash, err := generateToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	token := &Token{
+		UserID:    userID,
+		Name:      form.Name,
+		Prefix:    raw[:len(TokenPrefix)+6],
+		Scopes:    scopes,
+		ExpiresAt: s.now().AddDate(0, 0, form.ExpiresInDays),
+	}
+	if err := s.repo.Create(ctx, token, hash); err != nil {
+		return "", nil, err
+	}
+
+	// Tokenin açıq mətni və heşi jurnala yazılmır
+	s.audit.Record(ctx, audit.Event{
+		Action:     "api_token.created",
+		EntityType: "api_token",
+		EntityID:   strconv.Itoa(token.ID),
+		After:      token,
+	})
+
+	return raw, token, nil
+}
+
+// Revoke istifadəçiyə məxsus tokeni ləğv edir
+func (s *TokenService) Revoke(ctx context.Context, userID, id int) error {
+	found, err := s.repo.Revoke(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrNotFound
+	}
+
+	s.audit.Record(ctx, audit.Event{
+		Action:     "api_token.revoked",
+		EntityType: "api_token",
+		EntityID:   strconv.Itoa(id),
+	})
+
+	return nil
+}
+
+// RevokeAll istifadəçinin bütün aktiv tokenlərini ləğv edir (məs. şifrə dəyişdirildikdə)
+func (s *TokenService) RevokeAll(ctx context.Context, userID int) error {
+	revoked, err := s.repo.RevokeUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if revoked == 0 {
+		return nil
+	}
+
+	s.audit.Record(ctx, audit.Event{
+		Action:     "api_token.revoked_all",
+		EntityType: "user",
+		EntityID:   strconv.Itoa(userID),
+		Details:    map[string]interface{}{"count": revoked},
+	})
+
+	return nil
+}
+
+// Authenticate açıq mətnli tokeni yoxlayır və son istifadə məlumatlarını yeniləyir
+func (s *TokenService) Authenticate(ctx context.Context, raw, ip string) (*Token, error) {
+	if !strings.HasPrefix(raw, TokenPrefix) {
+		return nil, ErrInvalidToken
+	}
+
+	token, err := s.repo.GetActiveByHash(ctx, securetoken.Hash(raw))
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return nil, ErrInvalidToken
+	}
+
+	if err := s.repo.Touch(ctx, token.ID, ip); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// validExpiry müddətin icazə verilənlərdən biri olduğunu yoxlayır
+func validExpiry(days int) bool {
+	for _, option := range ExpiryOptions() {
+		if option.Days == days {
+			return true
+		}
+	}
+	return false
+}
+
+// generateToken açıq mətnli tokeni və verilənlər bazasında saxlanılacaq heşini qaytarır
+func generateToken() (string, string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", "", err
+	}
+	raw := TokenPrefix + strings.ToLower(tokenEncoding.EncodeToString(key))
+	return raw, securetoken.Hash(raw), nil
+}
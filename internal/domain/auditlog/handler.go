@@ -0,0 +1,146 @@
+package auditlog
+
+import (
+	"encoding/csv"
+	"errors"
+	"html/template"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/Zam83-AZE/logistics_system/pkg/view"
+)
+
+// Handler audit jurnalı HTTP sorğularını işləyir
+type Handler struct {
+	service        Service
+	tmpl           *template.Template
+	sessionManager *session.Manager
+}
+
+// NewHandler yeni audit jurnalı işləyicisi yaradır
+func NewHandler(service Service, tmpl *template.Template, sessionManager *session.Manager) *Handler {
+	return &Handler{
+		service:        service,
+		tmpl:           tmpl,
+		sessionManager: sessionManager,
+	}
+}
+
+// List audit jurnalını filtr və səhifələmə ilə göstərir
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter := parseFilter(r)
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	filter.Page = page
+
+	data := ListPage{
+		Filter:      filter,
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "audit",
+	}
+
+	actions, err := h.service.Actions(ctx)
+	if err != nil {
+		http.Error(w, "Audit jurnalı əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+	entityTypes, err := h.service.EntityTypes(ctx)
+	if err != nil {
+		http.Error(w, "Audit jurnalı əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+	data.Actions = actions
+	data.EntityTypes = entityTypes
+
+	events, err := h.service.List(ctx, filter)
+	if err != nil {
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			http.Error(w, "Audit jurnalı əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+			return
+		}
+		data.Error = validationErr.Message
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	} else {
+		data.Events = *events
+	}
+
+	view.Render(w, r, h.tmpl, "auditlog/list.html", data)
+}
+
+// Export filtrə uyğun bütün yazıları CSV faylı kimi yükləyir
+func (h *Handler) Export(w http.ResponseWriter, r *http.Request) {
+	filter := parseFilter(r)
+
+	// Filtr yanlışdırsa, başlıqlar yazılmazdan əvvəl xəta qaytarılır
+	if _, err := prepareFilter(filter); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	filename := "audit-" + time.Now().Format("20060102-150405") + ".csv"
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+
+	out := csv.NewWriter(w)
+	out.Write([]string{"id", "occurred_at", "actor_user_id", "actor", "action",
+		"entity_type", "entity_id", "ip", "request_id", "details", "changes"})
+
+	err := h.service.Export(r.Context(), filter, func(e Event) error {
+		actorID := ""
+		if e.ActorUserID != nil {
+			actorID = strconv.Itoa(*e.ActorUserID)
+		}
+
+		return out.Write([]string{
+			strconv.FormatInt(e.ID, 10),
+			e.OccurredAt.Format(time.RFC3339),
+			actorID,
+			csvSafe(e.ActorUsername),
+			csvSafe(e.Action),
+			csvSafe(e.EntityType),
+			csvSafe(e.EntityID),
+			csvSafe(e.IP),
+			csvSafe(e.RequestID),
+			csvSafe(string(e.Details)),
+			csvSafe(string(e.Changes)),
+		})
+	})
+	out.Flush()
+
+	// Cavab artıq göndərilməyə başlayıb, ona görə status dəyişdirilə bilmir; natamam fayl qeyd ilə bitirilir
+	if err == nil {
+		err = out.Error()
+	}
+	if err != nil {
+		w.Write([]byte("# ixrac yarımçıq qaldı\n"))
+	}
+}
+
+// parseFilter sorğu parametrlərindən filtri oxuyur
+func parseFilter(r *http.Request) Filter {
+	query := r.URL.Query()
+	return Filter{
+		Actor:      query.Get("actor"),
+		Action:     query.Get("action"),
+		EntityType: query.Get("entity_type"),
+		EntityID:   query.Get("entity_id"),
+		From:       query.Get("from"),
+		To:         query.Get("to"),
+	}
+}
+
+// csvSafe cədvəl proqramlarının düstur kimi icra edə biləcəyi dəyərləri zərərsizləşdirir
+func csvSafe(value string) string {
+	if value == "" {
+		return value
+	}
+	switch value[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,186 @@
+package auditlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Event audit jurnalındakı bir yazını təmsil edir
+type Event struct {
+	ID            int64     `db:"id"`
+	OccurredAt    time.Time `db:"occurred_at"`
+	ActorUserID   *int      `db:"actor_user_id"`
+	ActorUsername string    `db:"actor_username"`
+	Action        string    `db:"action"`
+	EntityType    string    `db:"entity_type"`
+	EntityID      string    `db:"entity_id"`
+	IP            string    `db:"ip"`
+	RequestID     string    `db:"request_id"`
+	Details       []byte    `db:"details"`
+	Changes       []byte    `db:"changes"`
+}
+
+// Actor icraçının göstəriləcək adını qaytarır
+func (e Event) Actor() string {
+	switch {
+	case e.ActorUsername != "":
+		return e.ActorUsername
+	case e.ActorUserID != nil:
+		return "#" + strconv.Itoa(*e.ActorUserID)
+	default:
+		return ""
+	}
+}
+
+// FieldChange dəyişmiş sahəni göstərmək üçün təmsil edir
+type FieldChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// ChangeList dəyişiklikləri sahə adına görə sıralanmış siyahı kimi qaytarır
+func (e Event) ChangeList() []FieldChange {
+	var changes map[string]struct {
+		Before interface{} `json:"before"`
+		After  interface{} `json:"after"`
+	}
+	if err := json.Unmarshal(e.Changes, &changes); err != nil {
+		return nil
+	}
+
+	list := make([]FieldChange, 0, len(changes))
+	for field, change := range changes {
+		list = append(list, FieldChange{Field: field, Before: formatValue(change.Before), After: formatValue(change.After)})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Field < list[j].Field })
+	return list
+}
+
+// DetailsText əlavə məlumatları "açar=dəyər" formatında qaytarır; boşdursa boş sətir qaytarılır
+func (e Event) DetailsText() string {
+	var details map[string]interface{}
+	if err := json.Unmarshal(e.Details, &details); err != nil || len(details) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(details))
+	for key := range details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	text := ""
+	for i, key := range keys {
+		if i > 0 {
+			text += ", "
+		}
+		text += key + "=" + formatValue(details[key])
+	}
+	return text
+}
+
+// formatValue JSON dəyərini qısa mətnə çevirir
+func formatValue(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return "—"
+	case string:
+		return value
+	case float64, bool:
+		return fmt.Sprint(value)
+	default:
+		data, _ := json.Marshal(value)
+		return string(data)
+	}
+}
+
+// Filter audit jurnalının axtarış və səhifələmə parametrlərini saxlayır.
+// From və To "2006-01-02" formatında tarixlərdir (hər ikisi daxil olmaqla).
+type Filter struct {
+	Actor      string
+	Action     string
+	EntityType string
+	EntityID   string
+	From       string
+	To         string
+	Page       int
+	PerPage    int
+
+	// since və until servis tərəfindən From və To-dan hesablanır: [since, until)
+	since *time.Time
+	until *time.Time
+}
+
+// Values filtri sorğu parametrlərinə çevirir (səhifə nömrəsi daxil edilmir)
+func (f Filter) Values() url.Values {
+	values := url.Values{}
+	for key, value := range map[string]string{
+		"actor":       f.Actor,
+		"action":      f.Action,
+		"entity_type": f.EntityType,
+		"entity_id":   f.EntityID,
+		"from":        f.From,
+		"to":          f.To,
+	} {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	return values
+}
+
+// PageURL verilmiş səhifənin ünvanını filtrlə birlikdə qaytarır
+func (f Filter) PageURL(page int) string {
+	values := f.Values()
+	values.Set("page", strconv.Itoa(page))
+	return "/admin/audit?" + values.Encode()
+}
+
+// ExportURL filtrə uyğun CSV ixracının ünvanını qaytarır
+func (f Filter) ExportURL() string {
+	return "/admin/audit/export?" + f.Values().Encode()
+}
+
+// EventList səhifələnmiş audit yazıları siyahısını təmsil edir
+type EventList struct {
+	Items   []Event
+	Total   int
+	Page    int
+	PerPage int
+}
+
+// HasPrev əvvəlki səhifənin olub-olmadığını göstərir
+func (l EventList) HasPrev() bool {
+	return l.Page > 1
+}
+
+// HasNext növbəti səhifənin olub-olmadığını göstərir
+func (l EventList) HasNext() bool {
+	return l.Page*l.PerPage < l.Total
+}
+
+// PrevPage əvvəlki səhifənin nömrəsini qaytarır
+func (l EventList) PrevPage() int {
+	return l.Page - 1
+}
+
+// NextPage növbəti səhifənin nömrəsini qaytarır
+func (l EventList) NextPage() int {
+	return l.Page + 1
+}
+
+// ListPage audit jurnalı səhifəsinin məlumatlarını saxlayır
+type ListPage struct {
+	Events      EventList
+	Filter      Filter
+	Actions     []string
+	EntityTypes []string
+	UserName    string
+	CurrentPage string
+	Error       string
+}
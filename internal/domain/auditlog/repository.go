@@ -0,0 +1,131 @@
+package auditlog
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// eventColumns audit sorğularında seçilən sütunlardır
+const eventColumns = `e.id, e.occurred_at, e.actor_user_id, COALESCE(u.username, '') AS actor_username,
+	e.action, e.entity_type, e.entity_id, e.ip, e.request_id, e.details, e.changes`
+
+// Repository audit jurnalının oxunması əməliyyatlarını müəyyən edir.
+// Yazılar pkg/audit tərəfindən əlavə edilir; burada yalnız oxunur.
+type Repository interface {
+	List(ctx context.Context, filter Filter) ([]Event, int, error)
+	Each(ctx context.Context, filter Filter, fn func(Event) error) error
+	Actions(ctx context.Context) ([]string, error)
+	EntityTypes(ctx context.Context) ([]string, error)
+}
+
+// PostgresRepository Repository interfeysini həyata keçirir
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRepository yeni PostgresRepository yaradır
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+// where filtrə uyğun WHERE ifadəsini və arqumentləri qurur
+func where(filter Filter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, strings.ReplaceAll(condition, "?", "$"+strconv.Itoa(len(args))))
+	}
+
+	if filter.Actor != "" {
+		add("LOWER(u.username) = LOWER(?)", filter.Actor)
+	}
+	if filter.Action != "" {
+		add("e.action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		add("e.entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		add("e.entity_id = ?", filter.EntityID)
+	}
+	if filter.since != nil {
+		add("e.occurred_at >= ?", *filter.since)
+	}
+	if filter.until != nil {
+		add("e.occurred_at < ?", *filter.until)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// List filtrə uyğun yazıları (ən yenisi birinci) və ümumi sayı əldə edir
+func (r *PostgresRepository) List(ctx context.Context, filter Filter) ([]Event, int, error) {
+	clause, args := where(filter)
+	from := `FROM audit_events e LEFT JOIN users u ON u.id = e.actor_user_id ` + clause
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) "+from, args...); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + eventColumns + ` ` + from + `
+		ORDER BY e.occurred_at DESC, e.id DESC
+		LIMIT $` + strconv.Itoa(len(args)+1) + ` OFFSET $` + strconv.Itoa(len(args)+2)
+
+	args = append(args, filter.PerPage, (filter.Page-1)*filter.PerPage)
+
+	events := []Event{}
+	if err := r.db.SelectContext(ctx, &events, query, args...); err != nil {
+		return nil, 0, err
+	}
+
+	return events, total, nil
+}
+
+// Each filtrə uyğun bütün yazıları yaddaşa yığmadan ardıcıl olaraq fn-ə ötürür (ixrac üçün)
+func (r *PostgresRepository) Each(ctx context.Context, filter Filter, fn func(Event) error) error {
+	clause, args := where(filter)
+	query := `SELECT ` + eventColumns + `
+		FROM audit_events e LEFT JOIN users u ON u.id = e.actor_user_id ` + clause + `
+		ORDER BY e.occurred_at DESC, e.id DESC`
+
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event Event
+		if err := rows.StructScan(&event); err != nil {
+			return err
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// Actions jurnalda mövcud olan hadisə növlərini qaytarır
+func (r *PostgresRepository) Actions(ctx context.Context) ([]string, error) {
+	actions := []string{}
+	err := r.db.SelectContext(ctx, &actions, `SELECT DISTINCT action FROM audit_events ORDER BY action`)
+	return actions, err
+}
+
+// EntityTypes jurnalda mövcud olan obyekt növlərini qaytarır
+func (r *PostgresRepository) EntityTypes(ctx context.Context) ([]string, error) {
+	types := []string{}
+	err := r.db.SelectContext(ctx, &types, `SELECT DISTINCT entity_type FROM audit_events WHERE entity_type <> '' ORDER BY entity_type`)
+	return types, err
+}
@@ -0,0 +1,23 @@
+package auditlog
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/rbac"
+	"github.com/Zam83-AZE/logistics_system/internal/middleware"
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/gorilla/mux"
+	"github.com/jmoiron/sqlx"
+)
+
+// RegisterRoutes audit jurnalı marşrutlarını qeydə alır
+func RegisterRoutes(router *mux.Router, db *sqlx.DB, tmpl *template.Template, sessionManager *session.Manager) {
+	service := NewAuditService(NewPostgresRepository(db))
+	handler := NewHandler(service, tmpl, sessionManager)
+
+	canView := middleware.RequirePermission(rbac.AuditView)
+
+	router.Handle("/admin/audit", canView(http.HandlerFunc(handler.List))).Methods("GET")
+	router.Handle("/admin/audit/export", canView(http.HandlerFunc(handler.Export))).Methods("GET")
+}
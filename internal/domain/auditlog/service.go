@@ -0,0 +1,120 @@
+package auditlog
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+const (
+	defaultPerPage = 50
+
+	// dateLayout filtrdəki tarixlərin formatıdır (HTML date sahəsi)
+	dateLayout = "2006-01-02"
+)
+
+// ValidationError istifadəçiyə göstəriləcək filtr xətasını təmsil edir
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// Service audit jurnalına baxış və ixrac məntiqini müəyyən edir
+type Service interface {
+	List(ctx context.Context, filter Filter) (*EventList, error)
+	Export(ctx context.Context, filter Filter, fn func(Event) error) error
+	Actions(ctx context.Context) ([]string, error)
+	EntityTypes(ctx context.Context) ([]string, error)
+}
+
+// AuditService Service interfeysini həyata keçirir
+type AuditService struct {
+	repo Repository
+}
+
+// NewAuditService yeni AuditService yaradır
+func NewAuditService(repo Repository) *AuditService {
+	return &AuditService{repo: repo}
+}
+
+// List filtrə uyğun səhifələnmiş yazıları qaytarır
+func (s *AuditService) List(ctx context.Context, filter Filter) (*EventList, error) {
+	filter, err := prepareFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PerPage < 1 || filter.PerPage > 200 {
+		filter.PerPage = defaultPerPage
+	}
+
+	events, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EventList{
+		Items:   events,
+		Total:   total,
+		Page:    filter.Page,
+		PerPage: filter.PerPage,
+	}, nil
+}
+
+// Export filtrə uyğun bütün yazıları səhifələmədən fn-ə ötürür
+func (s *AuditService) Export(ctx context.Context, filter Filter, fn func(Event) error) error {
+	filter, err := prepareFilter(filter)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Each(ctx, filter, fn)
+}
+
+// Actions filtr üçün mövcud hadisə növlərini qaytarır
+func (s *AuditService) Actions(ctx context.Context) ([]string, error) {
+	return s.repo.Actions(ctx)
+}
+
+// EntityTypes filtr üçün mövcud obyekt növlərini qaytarır
+func (s *AuditService) EntityTypes(ctx context.Context) ([]string, error) {
+	return s.repo.EntityTypes(ctx)
+}
+
+// prepareFilter dəyərləri təmizləyir və tarix aralığını yoxlayır
+func prepareFilter(filter Filter) (Filter, error) {
+	filter.Actor = strings.TrimSpace(filter.Actor)
+	filter.Action = strings.TrimSpace(filter.Action)
+	filter.EntityType = strings.TrimSpace(filter.EntityType)
+	filter.EntityID = strings.TrimSpace(filter.EntityID)
+	filter.From = strings.TrimSpace(filter.From)
+	filter.To = strings.TrimSpace(filter.To)
+
+	if filter.From != "" {
+		since, err := time.ParseInLocation(dateLayout, filter.From, time.Local)
+		if err != nil {
+			return filter, &ValidationError{Message: "başlanğıc tarixi yanlışdır"}
+		}
+		filter.since = &since
+	}
+
+	if filter.To != "" {
+		to, err := time.ParseInLocation(dateLayout, filter.To, time.Local)
+		if err != nil {
+			return filter, &ValidationError{Message: "son tarix yanlışdır"}
+		}
+		until := to.AddDate(0, 0, 1)
+		filter.until = &until
+	}
+
+	if filter.since != nil && filter.until != nil && !filter.since.Before(*filter.until) {
+		return filter, &ValidationError{Message: "son tarix başlanğıc tarixindən əvvəl ola bilməz"}
+	}
+
+	return filter, nil
+}
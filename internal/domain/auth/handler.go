@@ -1,33 +1,41 @@
 package auth
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"html/template"
 
+	"github.com/Zam83-AZE/logistics_system/internal/domain/apitoken"
+	"github.com/Zam83-AZE/logistics_system/internal/middleware"
+	"github.com/Zam83-AZE/logistics_system/pkg/qrcode"
 	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/Zam83-AZE/logistics_system/pkg/view"
 )
 
 // Handler istifadəçi autentifikasiyası HTTP sorğularını işləyir
 type Handler struct {
-	service        Service
-	tmpl           *template.Template
-	sessionManager *session.Manager
+	service           Service
+	tokens            apitoken.Service
+	tmpl              *template.Template
+	sessionManager    *session.Manager
+	passwordMinLength int
 }
 
 // NewHandler yeni autentifikasiya işləyicisi yaradır
-func NewHandler(service Service, tmpl *template.Template, sessionManager *session.Manager) *Handler {
+func NewHandler(service Service, tokens apitoken.Service, tmpl *template.Template, sessionManager *session.Manager, passwordMinLength int) *Handler {
 	return &Handler{
-		service:        service,
-		tmpl:           tmpl,
-		sessionManager: sessionManager,
+		service:           service,
+		tokens:            tokens,
+		tmpl:              tmpl,
+		sessionManager:    sessionManager,
+		passwordMinLength: passwordMinLength,
 	}
 }
 
 // LoginPage login səhifəsini göstərir
 func (h *Handler) LoginPage(w http.ResponseWriter, r *http.Request) {
-	fmt.Println("LoginPage handler çağırıldı")
 	// Əgər istifadəçi artıq giriş edibsə, dashboard-a yönləndir
 	if h.sessionManager.IsAuthenticated(r) {
 		http.Redirect(w, r, "/dashboard", http.StatusFound)
@@ -35,9 +43,10 @@ func (h *Handler) LoginPage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	data := LoginForm{}
-	fmt.Println("222")
-	h.tmpl.ExecuteTemplate(w, "login.html", data)
-
+	if r.URL.Query().Get("reset") == "1" {
+		data.Notice = "Şifrəniz yeniləndi. Yeni şifrə ilə daxil olun"
+	}
+	view.Render(w, r, h.tmpl, "login.html", data)
 }
 
 // Login giriş əməliyyatını icra edir
@@ -53,34 +62,442 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 	username := r.FormValue("username")
 	password := r.FormValue("password")
 
-	user, err := h.service.Login(ctx, username, password)
+	user, err := h.service.Login(ctx, username, password, middleware.ClientIP(r))
 	if err != nil {
-		data := LoginForm{
-			Username: username,
-			Error:    err.Error(),
+		data := LoginForm{Username: username}
+		data.Error = writeAuthError(w, err)
+		view.Render(w, r, h.tmpl, "login.html", data)
+		return
+	}
+
+	// İki faktorlu autentifikasiya aktivdirsə, sessiya yalnız ikinci addımdan sonra yaradılır
+	if user.TOTPEnabled {
+		if err := h.sessionManager.BeginTwoFactor(w, r, user.ID); err != nil {
+			data := LoginForm{
+				Username: username,
+				Error:    "Giriş zamanı xəta baş verdi",
+			}
+			view.Render(w, r, h.tmpl, "login.html", data)
+			return
 		}
-		h.tmpl.ExecuteTemplate(w, "login.html", data)
+		http.Redirect(w, r, "/login/2fa", http.StatusSeeOther)
 		return
 	}
 
-	// Sessiyada istifadəçi məlumatlarını saxla
-	err = h.sessionManager.Login(w, r, user.ID, user.Username)
-	if err != nil {
+	if err := h.completeLogin(w, r, user); err != nil {
 		data := LoginForm{
 			Username: username,
 			Error:    "Giriş zamanı xəta baş verdi",
 		}
-		h.tmpl.ExecuteTemplate(w, "login.html", data)
+		view.Render(w, r, h.tmpl, "login.html", data)
+		return
+	}
+}
+
+// TwoFactorPage ikinci addım (TOTP kodu) səhifəsini göstərir
+func (h *Handler) TwoFactorPage(w http.ResponseWriter, r *http.Request) {
+	if h.sessionManager.PendingTwoFactor(r) == 0 {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	view.Render(w, r, h.tmpl, "auth/two_factor.html", TwoFactorForm{})
+}
+
+// TwoFactorVerify ikinci addımın kodunu yoxlayır və girişi tamamlayır
+func (h *Handler) TwoFactorVerify(w http.ResponseWriter, r *http.Request) {
+	userID := h.sessionManager.PendingTwoFactor(r)
+	if userID == 0 {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	user, err := h.service.VerifySecondFactor(r.Context(), userID, r.FormValue("code"), middleware.ClientIP(r))
+	if err != nil {
+		data := TwoFactorForm{Error: writeAuthError(w, err)}
+		view.Render(w, r, h.tmpl, "auth/two_factor.html", data)
+		return
+	}
+
+	if err := h.completeLogin(w, r, user); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		view.Render(w, r, h.tmpl, "auth/two_factor.html", TwoFactorForm{Error: "Giriş zamanı xəta baş verdi"})
 		return
 	}
+}
+
+// completeLogin son giriş vaxtını qeyd edir, sessiyanı yaradır və istifadəçini yönləndirir.
+// Administrator şifrə dəyişikliyini tələb edibsə, digər yoxlamalar şifrə dəyişdirildikdən sonra aparılır.
+func (h *Handler) completeLogin(w http.ResponseWriter, r *http.Request, user *User) error {
+	if err := h.service.RecordLogin(r.Context(), user.ID); err != nil {
+		return err
+	}
+
+	// Sessiyada istifadəçi məlumatlarını saxla
+	if err := h.sessionManager.Login(w, r, user.ID, user.Username); err != nil {
+		return err
+	}
+
+	if user.MustChangePassword {
+		if err := h.sessionManager.SetPasswordChange(w, r, true); err != nil {
+			return err
+		}
+		http.Redirect(w, r, "/account/password", http.StatusSeeOther)
+		return nil
+	}
+
+	return h.redirectAfterLogin(w, r, user.ID)
+}
+
+// redirectAfterLogin rolu iki faktorlu autentifikasiya tələb edən, lakin onu qurmamış istifadəçiləri
+// ayarlar səhifəsinə, digərlərini isə dashboard-a yönləndirir
+func (h *Handler) redirectAfterLogin(w http.ResponseWriter, r *http.Request, userID int) error {
+	state, err := h.service.TwoFactorStatus(r.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	if state.Required && !state.Enabled {
+		if err := h.sessionManager.SetTwoFactorEnrollment(w, r, true); err != nil {
+			return err
+		}
+		http.Redirect(w, r, "/account/2fa", http.StatusSeeOther)
+		return nil
+	}
 
 	// Dashboard səhifəsinə yönləndir
 	http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+	return nil
+}
 
+// writeAuthError giriş xətasına uyğun HTTP statusunu yazır və istifadəçiyə göstəriləcək mesajı qaytarır
+func writeAuthError(w http.ResponseWriter, err error) string {
+	var throttled *ThrottledError
+	switch {
+	case errors.As(err, &throttled):
+		w.Header().Set("Retry-After", strconv.Itoa(int(throttled.RetryAfter.Seconds())+1))
+		w.WriteHeader(http.StatusTooManyRequests)
+		return throttled.Error()
+	case errors.Is(err, ErrInvalidCredentials), errors.Is(err, ErrMissingCredentials), errors.Is(err, ErrInvalidCode):
+		w.WriteHeader(http.StatusUnauthorized)
+		return err.Error()
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+		return "Giriş zamanı xəta baş verdi"
+	}
 }
 
 // Logout çıxış əməliyyatını icra edir
 func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.RecordLogout(r.Context(), h.sessionManager.GetUserID(r), false); err != nil {
+		http.Error(w, "Çıxış zamanı xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
 	h.sessionManager.Logout(w, r)
 	http.Redirect(w, r, "/login", http.StatusSeeOther)
 }
+
+// LogoutEverywhere istifadəçinin bütün sessiyalarını ləğv edir
+func (h *Handler) LogoutEverywhere(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.RecordLogout(r.Context(), h.sessionManager.GetUserID(r), true); err != nil {
+		http.Error(w, "Çıxış zamanı xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+	if err := h.sessionManager.LogoutEverywhere(w, r); err != nil {
+		http.Error(w, "Sessiyalar ləğv edilmədi", http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/login", http.StatusSeeOther)
+}
+
+// ForgotPasswordPage şifrə bərpası sorğusu formunu göstərir
+func (h *Handler) ForgotPasswordPage(w http.ResponseWriter, r *http.Request) {
+	view.Render(w, r, h.tmpl, "auth/forgot_password.html", ForgotPasswordForm{})
+}
+
+// ForgotPassword bərpa keçidini göndərir; hesabın mövcudluğundan asılı olmayaraq eyni cavab qaytarılır
+func (h *Handler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	login := r.FormValue("login")
+
+	err := h.service.RequestPasswordReset(r.Context(), login, middleware.ClientIP(r))
+	if err != nil {
+		data := ForgotPasswordForm{Login: login}
+
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			data.Error = validationErr.Message
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		} else {
+			data.Error = "Sorğu qəbul edilmədi. Bir az sonra yenidən cəhd edin"
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+
+		view.Render(w, r, h.tmpl, "auth/forgot_password.html", data)
+		return
+	}
+
+	view.Render(w, r, h.tmpl, "auth/forgot_password.html", ForgotPasswordForm{Sent: true})
+}
+
+// ResetPasswordPage keçiddəki token etibarlıdırsa yeni şifrə formunu göstərir
+func (h *Handler) ResetPasswordPage(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	data := ResetPasswordForm{Token: token, MinLength: h.passwordMinLength}
+
+	if err := h.service.CheckResetToken(r.Context(), token); err != nil {
+		data.Token = ""
+		data.Error = writeResetError(w, err)
+	}
+
+	view.Render(w, r, h.tmpl, "auth/reset_password.html", data)
+}
+
+// ResetPassword yeni şifrəni təyin edir, istifadəçinin bütün sessiyalarını və API tokenlərini ləğv edir
+func (h *Handler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	token := r.FormValue("token")
+
+	userID, err := h.service.ResetPassword(r.Context(), token, r.FormValue("password"), r.FormValue("confirm_password"), middleware.ClientIP(r))
+	if err != nil {
+		data := ResetPasswordForm{Token: token, MinLength: h.passwordMinLength}
+		data.Error = writeResetError(w, err)
+		if errors.Is(err, ErrInvalidResetToken) {
+			data.Token = ""
+		}
+		view.Render(w, r, h.tmpl, "auth/reset_password.html", data)
+		return
+	}
+
+	// Köhnə şifrə ilə açılmış sessiyalar və yaradılmış API tokenləri etibarsız olur
+	if err := h.sessionManager.RevokeUser(r.Context(), userID); err != nil {
+		http.Error(w, "Sessiyalar ləğv edilmədi", http.StatusInternalServerError)
+		return
+	}
+	if err := h.tokens.RevokeAll(r.Context(), userID); err != nil {
+		http.Error(w, "API tokenləri ləğv edilmədi", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/login?reset=1", http.StatusSeeOther)
+}
+
+// writeResetError şifrə bərpası xətasına uyğun HTTP statusunu yazır və mesajı qaytarır
+func writeResetError(w http.ResponseWriter, err error) string {
+	var validationErr *ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		return validationErr.Message
+	case errors.Is(err, ErrInvalidResetToken):
+		w.WriteHeader(http.StatusGone)
+		return err.Error()
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+		return "Şifrə yenilənmədi"
+	}
+}
+
+// Lockouts bloklanmış istifadəçi adlarını və IP ünvanlarını göstərir
+func (h *Handler) Lockouts(w http.ResponseWriter, r *http.Request) {
+	h.renderLockouts(w, r, "", http.StatusOK)
+}
+
+// Unlock administratorun seçdiyi bloku açır
+func (h *Handler) Unlock(w http.ResponseWriter, r *http.Request) {
+	scope := r.FormValue("scope")
+	key := r.FormValue("key")
+
+	err := h.service.Unlock(r.Context(), scope, key, h.sessionManager.GetUserID(r), middleware.ClientIP(r))
+	if err != nil {
+		h.renderLockouts(w, r, "Blok açılmadı: "+err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/lockouts", http.StatusSeeOther)
+}
+
+// renderLockouts bloklamalar səhifəsini verilmiş status və xəta mesajı ilə göstərir
+func (h *Handler) renderLockouts(w http.ResponseWriter, r *http.Request, message string, status int) {
+	lockouts, err := h.service.Lockouts(r.Context())
+	if err != nil {
+		http.Error(w, "Bloklamalar əldə edilərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	data := LockoutsPage{
+		Lockouts:    lockouts,
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "lockouts",
+		Error:       message,
+	}
+
+	w.WriteHeader(status)
+	view.Render(w, r, h.tmpl, "auth/lockouts.html", data)
+}
+
+// ChangePasswordPage şifrə dəyişmə formunu göstərir
+func (h *Handler) ChangePasswordPage(w http.ResponseWriter, r *http.Request) {
+	h.renderChangePassword(w, r, "", http.StatusOK)
+}
+
+// ChangePassword cari şifrəni yoxlayır və yeni şifrəni təyin edir
+func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	userID := h.sessionManager.GetUserID(r)
+
+	err := h.service.ChangePassword(r.Context(), userID, r.FormValue("current_password"),
+		r.FormValue("password"), r.FormValue("confirm_password"), middleware.ClientIP(r))
+	if err != nil {
+		var validationErr *ValidationError
+		var throttled *ThrottledError
+		switch {
+		case errors.As(err, &validationErr):
+			h.renderChangePassword(w, r, validationErr.Message, http.StatusUnprocessableEntity)
+		case errors.As(err, &throttled):
+			w.Header().Set("Retry-After", strconv.Itoa(int(throttled.RetryAfter.Seconds())+1))
+			h.renderChangePassword(w, r, throttled.Error(), http.StatusTooManyRequests)
+		default:
+			http.Error(w, "Şifrə dəyişdirilmədi", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Köhnə şifrə ilə açılmış digər sessiyalar və yaradılmış API tokenləri etibarsız olur
+	if err := h.sessionManager.RevokeOthers(r, userID); err != nil {
+		http.Error(w, "Sessiyalar ləğv edilmədi", http.StatusInternalServerError)
+		return
+	}
+	if err := h.tokens.RevokeAll(r.Context(), userID); err != nil {
+		http.Error(w, "API tokenləri ləğv edilmədi", http.StatusInternalServerError)
+		return
+	}
+
+	if !h.sessionManager.PasswordChangeRequired(r) {
+		http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+		return
+	}
+
+	if err := h.sessionManager.SetPasswordChange(w, r, false); err != nil {
+		http.Error(w, "Sessiya yenilənmədi", http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.redirectAfterLogin(w, r, userID); err != nil {
+		http.Error(w, "Sessiya yenilənmədi", http.StatusInternalServerError)
+		return
+	}
+}
+
+// renderChangePassword şifrə dəyişmə səhifəsini verilmiş status və xəta mesajı ilə göstərir
+func (h *Handler) renderChangePassword(w http.ResponseWriter, r *http.Request, message string, status int) {
+	data := ChangePasswordPage{
+		Required:    h.sessionManager.PasswordChangeRequired(r),
+		MinLength:   h.passwordMinLength,
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "account",
+		Error:       message,
+	}
+
+	w.WriteHeader(status)
+	view.Render(w, r, h.tmpl, "auth/change_password.html", data)
+}
+
+// TwoFactorSettings iki faktorlu autentifikasiya ayarlarını göstərir; aktiv deyilsə qoşulma üçün QR kod yaradır
+func (h *Handler) TwoFactorSettings(w http.ResponseWriter, r *http.Request) {
+	h.renderTwoFactor(w, r, nil, "", http.StatusOK)
+}
+
+// TwoFactorConfirm autentifikator tətbiqinin kodunu təsdiqləyir və bərpa kodlarını bir dəfə göstərir
+func (h *Handler) TwoFactorConfirm(w http.ResponseWriter, r *http.Request) {
+	codes, err := h.service.ConfirmEnrollment(r.Context(), h.sessionManager.GetUserID(r), r.FormValue("code"), middleware.ClientIP(r))
+	if err != nil {
+		h.renderTwoFactorError(w, r, err)
+		return
+	}
+
+	if err := h.sessionManager.SetTwoFactorEnrollment(w, r, false); err != nil {
+		http.Error(w, "Sessiya yenilənmədi", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderTwoFactor(w, r, codes, "", http.StatusOK)
+}
+
+// TwoFactorDisable iki faktorlu autentifikasiyanı söndürür
+func (h *Handler) TwoFactorDisable(w http.ResponseWriter, r *http.Request) {
+	err := h.service.DisableTwoFactor(r.Context(), h.sessionManager.GetUserID(r), r.FormValue("code"), middleware.ClientIP(r))
+	if err != nil {
+		h.renderTwoFactorError(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/account/2fa", http.StatusSeeOther)
+}
+
+// TwoFactorRecoveryCodes yeni bərpa kodları yaradır və bir dəfə göstərir
+func (h *Handler) TwoFactorRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	codes, err := h.service.RegenerateRecoveryCodes(r.Context(), h.sessionManager.GetUserID(r), r.FormValue("code"), middleware.ClientIP(r))
+	if err != nil {
+		h.renderTwoFactorError(w, r, err)
+		return
+	}
+
+	h.renderTwoFactor(w, r, codes, "", http.StatusOK)
+}
+
+// renderTwoFactorError xətanı iki faktorlu autentifikasiya səhifəsində göstərir
+func (h *Handler) renderTwoFactorError(w http.ResponseWriter, r *http.Request, err error) {
+	var throttled *ThrottledError
+	switch {
+	case errors.As(err, &throttled):
+		w.Header().Set("Retry-After", strconv.Itoa(int(throttled.RetryAfter.Seconds())+1))
+		h.renderTwoFactor(w, r, nil, throttled.Error(), http.StatusTooManyRequests)
+	case errors.Is(err, ErrInvalidCode):
+		h.renderTwoFactor(w, r, nil, err.Error(), http.StatusUnprocessableEntity)
+	case errors.Is(err, ErrTwoFactorRequired), errors.Is(err, ErrTwoFactorEnabled), errors.Is(err, ErrTwoFactorDisabled):
+		h.renderTwoFactor(w, r, nil, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, "İki faktorlu autentifikasiya ayarları yenilənmədi", http.StatusInternalServerError)
+	}
+}
+
+// renderTwoFactor iki faktorlu autentifikasiya səhifəsini cari vəziyyətlə göstərir
+func (h *Handler) renderTwoFactor(w http.ResponseWriter, r *http.Request, codes []string, message string, status int) {
+	ctx := r.Context()
+	userID := h.sessionManager.GetUserID(r)
+
+	state, err := h.service.TwoFactorStatus(ctx, userID)
+	if err != nil {
+		http.Error(w, "İki faktorlu autentifikasiya vəziyyəti əldə edilmədi", http.StatusInternalServerError)
+		return
+	}
+
+	data := TwoFactorPage{
+		Enabled:        state.Enabled,
+		Required:       state.Required,
+		RemainingCodes: state.RemainingCodes,
+		RecoveryCodes:  codes,
+		UserName:       h.sessionManager.GetUsername(r),
+		CurrentPage:    "account",
+		Error:          message,
+	}
+
+	if !state.Enabled {
+		enrollment, err := h.service.BeginEnrollment(ctx, userID)
+		if err != nil {
+			http.Error(w, "TOTP açarı yaradılmadı", http.StatusInternalServerError)
+			return
+		}
+
+		code, err := qrcode.Encode(enrollment.URI)
+		if err != nil {
+			http.Error(w, "QR kod yaradılmadı", http.StatusInternalServerError)
+			return
+		}
+
+		data.Secret = enrollment.Secret
+		// SVG paketimiz tərəfindən yaradılır və istifadəçi məlumatı daxil etmir
+		data.QRCode = template.HTML(code.SVG(4))
+	}
+
+	w.WriteHeader(status)
+	view.Render(w, r, h.tmpl, "auth/two_factor_setup.html", data)
+}
@@ -0,0 +1,15 @@
+package auth
+
+import "github.com/Zam83-AZE/logistics_system/pkg/metrics"
+
+// Giriş cəhdlərinin nəticələri (logistics_auth_login_attempts_total ölçüsünün "result" etiketi)
+const (
+	loginSuccess   = "success"
+	loginFailure   = "failure"
+	loginThrottled = "throttled"
+)
+
+// loginAttempts şifrə və ikinci addım cəhdlərini nəticəyə görə sayır.
+// Uğurlu giriş yalnız bir dəfə, bütün addımlar tamamlandıqda (RecordLogin) sayılır.
+var loginAttempts = metrics.Default.NewCounter("logistics_auth_login_attempts_total",
+	"Giriş cəhdlərinin nəticəyə görə sayı", "result")
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"html/template"
 	"time"
 )
 
@@ -14,11 +15,107 @@ type User struct {
 	IsActive  bool      `db:"is_active" json:"isActive"`
 	CreatedAt time.Time `db:"created_at" json:"createdAt"`
 	UpdatedAt time.Time `db:"updated_at" json:"updatedAt"`
+
+	TOTPSecret   string `db:"totp_secret" json:"-"`
+	TOTPEnabled  bool   `db:"totp_enabled" json:"totpEnabled"`
+	TOTPLastStep int64  `db:"totp_last_step" json:"-"`
+
+	MustChangePassword bool       `db:"must_change_password" json:"mustChangePassword"`
+	LastLoginAt        *time.Time `db:"last_login_at" json:"lastLoginAt"`
 }
 
 // LoginForm istifadəçi giriş formunu təmsil edir
 type LoginForm struct {
 	Username string
 	Password string
+	Notice   string
 	Error    string
 }
+
+// ForgotPasswordForm şifrə bərpası sorğusu formunu təmsil edir
+type ForgotPasswordForm struct {
+	Login string
+	Sent  bool
+	Error string
+}
+
+// ChangePasswordPage şifrə dəyişmə səhifəsinin məlumatlarını saxlayır
+type ChangePasswordPage struct {
+	Required    bool
+	MinLength   int
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// ResetPasswordForm yeni şifrə təyin etmə formunu təmsil edir
+type ResetPasswordForm struct {
+	Token     string
+	MinLength int
+	Error     string
+}
+
+// Uğursuz cəhdlərin izlənmə sahələri
+const (
+	ScopeUsername = "username"
+	ScopeIP       = "ip"
+)
+
+// LoginFailure istifadəçi adı və ya IP üzrə uğursuz giriş cəhdlərini təmsil edir
+type LoginFailure struct {
+	Scope        string     `db:"scope"`
+	Key          string     `db:"key"`
+	Failures     int        `db:"failures"`
+	LastFailedAt time.Time  `db:"last_failed_at"`
+	BlockedUntil *time.Time `db:"blocked_until"`
+	LockedUntil  *time.Time `db:"locked_until"`
+}
+
+// ScopeLabel izlənmə sahəsinin adını qaytarır
+func (f LoginFailure) ScopeLabel() string {
+	if f.Scope == ScopeIP {
+		return "IP ünvanı"
+	}
+	return "İstifadəçi adı"
+}
+
+// RetryAfter növbəti cəhdə qədər gözlənilməli müddəti qaytarır
+func (f LoginFailure) RetryAfter(now time.Time) time.Duration {
+	var until time.Time
+	if f.BlockedUntil != nil && f.BlockedUntil.After(until) {
+		until = *f.BlockedUntil
+	}
+	if f.LockedUntil != nil && f.LockedUntil.After(until) {
+		until = *f.LockedUntil
+	}
+	if until.After(now) {
+		return until.Sub(now)
+	}
+	return 0
+}
+
+// LockoutsPage bloklanmış girişlər səhifəsinin məlumatlarını saxlayır
+type LockoutsPage struct {
+	Lockouts    []LoginFailure
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// TwoFactorForm ikinci addım (TOTP və ya bərpa kodu) formunu təmsil edir
+type TwoFactorForm struct {
+	Error string
+}
+
+// TwoFactorPage iki faktorlu autentifikasiya ayarları səhifəsinin məlumatlarını saxlayır
+type TwoFactorPage struct {
+	Enabled        bool
+	Required       bool
+	Secret         string
+	QRCode         template.HTML
+	RecoveryCodes  []string
+	RemainingCodes int
+	UserName       string
+	CurrentPage    string
+	Error          string
+}
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// bcryptMaxBytes bcrypt alqoritminin nəzərə aldığı maksimal şifrə uzunluğudur (bayt)
+const bcryptMaxBytes = 72
+
+// commonPasswords tez-tez istifadə edilən və qəbul edilməyən şifrələrdir
+var commonPasswords = map[string]bool{
+	"password":     true,
+	"password1":    true,
+	"password123":  true,
+	"1234567890":   true,
+	"qwerty123":    true,
+	"qwertyuiop":   true,
+	"logistics1":   true,
+	"logistics123": true,
+	"admin12345":   true,
+	"welcome123":   true,
+	"azerbaijan1":  true,
+}
+
+// PasswordPolicy yeni şifrələrə tətbiq edilən tələbləri müəyyən edir
+type PasswordPolicy struct {
+	MinLength int
+}
+
+// Validate şifrəni siyasətə görə yoxlayır və bütün pozuntuları bir ValidationError-da qaytarır
+func (p PasswordPolicy) Validate(password, username string) error {
+	var problems []string
+
+	if utf8.RuneCountInString(password) < p.MinLength {
+		problems = append(problems, "ən azı "+strconv.Itoa(p.MinLength)+" simvol olmalıdır")
+	}
+	if len(password) > bcryptMaxBytes {
+		problems = append(problems, "ən çox "+strconv.Itoa(bcryptMaxBytes)+" bayt ola bilər")
+	}
+
+	var hasLetter, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		problems = append(problems, "həm hərf, həm də rəqəm içərməlidir")
+	}
+
+	lower := strings.ToLower(password)
+	if name := strings.ToLower(strings.TrimSpace(username)); len(name) >= 3 && strings.Contains(lower, name) {
+		problems = append(problems, "istifadəçi adını içərməməlidir")
+	}
+	if commonPasswords[lower] {
+		problems = append(problems, "çox geniş yayılmış şifrədir")
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Message: "Şifrə " + strings.Join(problems, ", ")}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestPasswordPolicyValidate şifrə siyasətinin hər qaydasını ayrıca yoxlayır
+func TestPasswordPolicyValidate(t *testing.T) {
+	policy := PasswordPolicy{MinLength: 10}
+
+	tests := []struct {
+		name     string
+		password string
+		username string
+		problems []string
+	}{
+		{name: "uyğun şifrə", password: "Yük-Daşıma-2024", username: "ali"},
+		{name: "çoxbaytlı simvollar simvol kimi sayılır", password: "şəğüçöıəş1", username: "ali"},
+		{name: "qısa", password: "abc12345", username: "ali", problems: []string{"ən azı 10 simvol"}},
+		{name: "72 baytdan uzun", password: strings.Repeat("ə", 36) + "1", username: "ali", problems: []string{"ən çox 72 bayt"}},
+		{name: "rəqəm yoxdur", password: "yalnızhərflər", username: "ali", problems: []string{"həm hərf, həm də rəqəm"}},
+		{name: "hərf yoxdur", password: "1234567890123", username: "ali", problems: []string{"həm hərf, həm də rəqəm"}},
+		{name: "istifadəçi adı", password: "Kamran-2024-x", username: "kamran", problems: []string{"istifadəçi adını"}},
+		{name: "qısa istifadəçi adı nəzərə alınmır", password: "al-2024-şifrə", username: "al"},
+		{name: "geniş yayılmış", password: "Password123", username: "ali", problems: []string{"geniş yayılmış"}},
+		{
+			name: "bir neçə pozuntu", password: "kamran", username: "Kamran",
+			problems: []string{"ən azı 10 simvol", "həm hərf, həm də rəqəm", "istifadəçi adını"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Validate(tt.password, tt.username)
+
+			if len(tt.problems) == 0 {
+				if err != nil {
+					t.Errorf("gözlənilməz xəta: %v", err)
+				}
+				return
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("xəta = %v, gözlənilən ValidationError", err)
+			}
+			for _, problem := range tt.problems {
+				if !strings.Contains(validationErr.Message, problem) {
+					t.Errorf("mesaj %q %q içərmir", validationErr.Message, problem)
+				}
+			}
+		})
+	}
+}
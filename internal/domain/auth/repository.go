@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -10,8 +11,35 @@ import (
 // Repository istifadəçi məlumatları əməliyyatlarını müəyyən edir
 type Repository interface {
 	GetByUsername(ctx context.Context, username string) (*User, error)
+	GetByID(ctx context.Context, id int) (*User, error)
+
+	GetFailure(ctx context.Context, scope, key string) (*LoginFailure, error)
+	SwapFailure(ctx context.Context, scope, key string, current, next *LoginFailure) (bool, error)
+	ClearFailures(ctx context.Context, scope, key string) error
+	ListLockouts(ctx context.Context) ([]LoginFailure, error)
+
+	SetTOTPSecret(ctx context.Context, userID int, secret string) error
+	EnableTOTP(ctx context.Context, userID int, step int64, recoveryHashes []string) error
+	DisableTOTP(ctx context.Context, userID int) error
+	AdvanceTOTPStep(ctx context.Context, userID int, step int64) (bool, error)
+	ReplaceRecoveryCodes(ctx context.Context, userID int, hashes []string) error
+	UseRecoveryCode(ctx context.Context, userID int, hash string) (bool, error)
+	CountRecoveryCodes(ctx context.Context, userID int) (int, error)
+	TwoFactorRequired(ctx context.Context, userID int) (bool, error)
+
+	GetByLogin(ctx context.Context, login string) (*User, error)
+	CreatePasswordReset(ctx context.Context, userID int, tokenHash, ip string, expiresAt time.Time) error
+	CountPasswordResets(ctx context.Context, userID int, since time.Time) (int, error)
+	GetPasswordResetUser(ctx context.Context, tokenHash string) (int, error)
+	ResetPassword(ctx context.Context, tokenHash, passwordHash string) (int, error)
+	SetPassword(ctx context.Context, userID int, passwordHash string) error
+	RecordLogin(ctx context.Context, userID int) error
 }
 
+// userColumns istifadəçi sorğularında seçilən sütunlardır
+const userColumns = `id, username, password, email, full_name, is_active, created_at, updated_at,
+	totp_secret, totp_enabled, totp_last_step, must_change_password, last_login_at`
+
 // PostgresRepository Repository interfeysini həyata keçirir
 type PostgresRepository struct {
 	db *sqlx.DB
@@ -25,7 +53,7 @@ func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
 // GetByUsername istifadəçini username-ə görə əldə edir
 func (r *PostgresRepository) GetByUsername(ctx context.Context, username string) (*User, error) {
 	query := `
-		SELECT id, username, password, email, full_name, is_active, created_at, updated_at
+		SELECT ` + userColumns + `
 		FROM users
 		WHERE username = $1 AND is_active = true
 	`
@@ -41,3 +69,387 @@ func (r *PostgresRepository) GetByUsername(ctx context.Context, username string)
 
 	return user, nil
 }
+
+// GetByID aktiv istifadəçini ID-yə görə əldə edir
+func (r *PostgresRepository) GetByID(ctx context.Context, id int) (*User, error) {
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE id = $1 AND is_active = true
+	`
+
+	user := &User{}
+	err := r.db.GetContext(ctx, user, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// GetFailure istifadəçi adı və ya IP üzrə uğursuz cəhd qeydini qaytarır
+func (r *PostgresRepository) GetFailure(ctx context.Context, scope, key string) (*LoginFailure, error) {
+	query := `
+		SELECT scope, key, failures, last_failed_at, blocked_until, locked_until
+		FROM login_failures
+		WHERE scope = $1 AND key = $2
+	`
+
+	failure := &LoginFailure{}
+	err := r.db.GetContext(ctx, failure, query, scope, key)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return failure, nil
+}
+
+// SwapFailure qeydi yalnız o hələ də current vəziyyətindədirsə next ilə əvəz edir və əvəzin baş tutub-tutmadığını qaytarır.
+// current nil olduqda qeyd yalnız mövcud deyilsə yaradılır, next nil olduqda isə silinir.
+func (r *PostgresRepository) SwapFailure(ctx context.Context, scope, key string, current, next *LoginFailure) (bool, error) {
+	var (
+		result sql.Result
+		err    error
+	)
+
+	switch {
+	case current == nil && next == nil:
+		return true, nil
+	case current == nil:
+		result, err = r.db.ExecContext(ctx, `
+			INSERT INTO login_failures (scope, key, failures, last_failed_at, blocked_until, locked_until)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (scope, key) DO NOTHING
+		`, scope, key, next.Failures, next.LastFailedAt, next.BlockedUntil, next.LockedUntil)
+	case next == nil:
+		result, err = r.db.ExecContext(ctx, `
+			DELETE FROM login_failures
+			WHERE scope = $1 AND key = $2 AND failures = $3 AND last_failed_at = $4
+		`, scope, key, current.Failures, current.LastFailedAt)
+	default:
+		result, err = r.db.ExecContext(ctx, `
+			UPDATE login_failures
+			SET failures = $5, last_failed_at = $6, blocked_until = $7, locked_until = $8
+			WHERE scope = $1 AND key = $2 AND failures = $3 AND last_failed_at = $4
+		`, scope, key, current.Failures, current.LastFailedAt, next.Failures, next.LastFailedAt, next.BlockedUntil, next.LockedUntil)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+// ClearFailures uğursuz cəhd qeydini silir (uğurlu giriş və ya administrator tərəfindən blokun açılması)
+func (r *PostgresRepository) ClearFailures(ctx context.Context, scope, key string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM login_failures WHERE scope = $1 AND key = $2`, scope, key)
+	return err
+}
+
+// ListLockouts hazırda bloklanmış istifadəçi adlarını və IP ünvanlarını qaytarır
+func (r *PostgresRepository) ListLockouts(ctx context.Context) ([]LoginFailure, error) {
+	query := `
+		SELECT scope, key, failures, last_failed_at, blocked_until, locked_until
+		FROM login_failures
+		WHERE locked_until > NOW()
+		ORDER BY locked_until DESC
+	`
+
+	var lockouts []LoginFailure
+	if err := r.db.SelectContext(ctx, &lockouts, query); err != nil {
+		return nil, err
+	}
+
+	return lockouts, nil
+}
+
+// SetTOTPSecret təsdiq gözləyən yeni TOTP açarını yazır
+func (r *PostgresRepository) SetTOTPSecret(ctx context.Context, userID int, secret string) error {
+	query := `
+		UPDATE users
+		SET totp_secret = $2, totp_enabled = false, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, secret)
+	return err
+}
+
+// EnableTOTP iki faktorlu autentifikasiyanı aktivləşdirir və bərpa kodlarını eyni tranzaksiyada yazır
+func (r *PostgresRepository) EnableTOTP(ctx context.Context, userID int, step int64, recoveryHashes []string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE users
+		SET totp_enabled = true, totp_last_step = $2, updated_at = NOW()
+		WHERE id = $1 AND totp_secret <> ''
+	`
+	if _, err := tx.ExecContext(ctx, query, userID, step); err != nil {
+		return err
+	}
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, recoveryHashes); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DisableTOTP iki faktorlu autentifikasiyanı söndürür, açarı və bərpa kodlarını silir
+func (r *PostgresRepository) DisableTOTP(ctx context.Context, userID int) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE users
+		SET totp_secret = '', totp_enabled = false, totp_last_step = 0, updated_at = NOW()
+		WHERE id = $1
+	`
+	if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+		return err
+	}
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, nil); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// AdvanceTOTPStep istifadə edilmiş TOTP addımını yazır; addım əvvəlkindən böyük deyilsə (təkrar istifadə) false qaytarır
+func (r *PostgresRepository) AdvanceTOTPStep(ctx context.Context, userID int, step int64) (bool, error) {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE users SET totp_last_step = $2 WHERE id = $1 AND totp_last_step < $2`, userID, step)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+// ReplaceRecoveryCodes istifadəçinin bütün bərpa kodlarını yeniləri ilə əvəz edir
+func (r *PostgresRepository) ReplaceRecoveryCodes(ctx context.Context, userID int, hashes []string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := replaceRecoveryCodes(ctx, tx, userID, hashes); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UseRecoveryCode istifadə edilməmiş bərpa kodunu istifadə edilmiş kimi qeyd edir
+func (r *PostgresRepository) UseRecoveryCode(ctx context.Context, userID int, hash string) (bool, error) {
+	query := `
+		UPDATE recovery_codes
+		SET used_at = NOW()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, userID, hash)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// CountRecoveryCodes istifadə edilməmiş bərpa kodlarının sayını qaytarır
+func (r *PostgresRepository) CountRecoveryCodes(ctx context.Context, userID int) (int, error) {
+	var count int
+	err := r.db.GetContext(ctx, &count,
+		`SELECT COUNT(*) FROM recovery_codes WHERE user_id = $1 AND used_at IS NULL`, userID)
+	return count, err
+}
+
+// TwoFactorRequired istifadəçinin rollarından hər hansı biri iki faktorlu autentifikasiya tələb edirsə true qaytarır
+func (r *PostgresRepository) TwoFactorRequired(ctx context.Context, userID int) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM user_roles ur
+			JOIN roles r ON r.id = ur.role_id
+			WHERE ur.user_id = $1 AND r.require_two_factor
+		)
+	`
+
+	var required bool
+	err := r.db.GetContext(ctx, &required, query, userID)
+	return required, err
+}
+
+// replaceRecoveryCodes bərpa kodlarını tranzaksiya daxilində əvəz edir
+func replaceRecoveryCodes(ctx context.Context, tx *sqlx.Tx, userID int, hashes []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	for _, hash := range hashes {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO recovery_codes (user_id, code_hash) VALUES ($1, $2)`, userID, hash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetByLogin aktiv istifadəçini istifadəçi adına və ya e-poçt ünvanına görə əldə edir
+func (r *PostgresRepository) GetByLogin(ctx context.Context, login string) (*User, error) {
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE (username = $1 OR LOWER(email) = LOWER($1)) AND is_active = true
+		ORDER BY username = $1 DESC
+		LIMIT 1
+	`
+
+	user := &User{}
+	err := r.db.GetContext(ctx, user, query, login)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// CreatePasswordReset istifadəçinin istifadə edilməmiş köhnə tokenlərini ləğv edir və yenisini yazır
+func (r *PostgresRepository) CreatePasswordReset(ctx context.Context, userID int, tokenHash, ip string, expiresAt time.Time) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Köhnə tokenlər istifadə edilmiş kimi qeyd olunur ki, sorğu limiti üçün sayılmağa davam etsinlər
+	if _, err := tx.ExecContext(ctx, `UPDATE password_resets SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL`, userID); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO password_resets (user_id, token_hash, requested_ip, expires_at)
+		VALUES ($1, $2, $3, $4)
+	`
+	if _, err := tx.ExecContext(ctx, query, userID, tokenHash, ip, expiresAt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// CountPasswordResets istifadəçi üçün since vaxtından sonra yaradılmış tokenlərin sayını qaytarır
+func (r *PostgresRepository) CountPasswordResets(ctx context.Context, userID int, since time.Time) (int, error) {
+	var count int
+	err := r.db.GetContext(ctx, &count, `SELECT COUNT(*) FROM password_resets WHERE user_id = $1 AND created_at > $2`, userID, since)
+	return count, err
+}
+
+// GetPasswordResetUser etibarlı (istifadə edilməmiş və vaxtı bitməmiş) tokenin istifadəçi ID-sini qaytarır; tapılmadıqda 0
+func (r *PostgresRepository) GetPasswordResetUser(ctx context.Context, tokenHash string) (int, error) {
+	query := `
+		SELECT pr.user_id
+		FROM password_resets pr
+		JOIN users u ON u.id = pr.user_id
+		WHERE pr.token_hash = $1 AND pr.used_at IS NULL AND pr.expires_at > NOW() AND u.is_active = true
+	`
+
+	var userID int
+	err := r.db.GetContext(ctx, &userID, query, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return userID, nil
+}
+
+// ResetPassword tokeni istifadə edilmiş kimi qeyd edir və şifrəni yeniləyir.
+// Token artıq istifadə edilibsə və ya vaxtı bitibsə heç nə dəyişmir və 0 qaytarılır.
+func (r *PostgresRepository) ResetPassword(ctx context.Context, tokenHash, passwordHash string) (int, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE password_resets
+		SET used_at = NOW()
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING user_id
+	`
+
+	var userID int
+	if err := tx.GetContext(ctx, &userID, query, tokenHash); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	result, err := tx.ExecContext(ctx, `UPDATE users SET password = $2, must_change_password = false, updated_at = NOW() WHERE id = $1 AND is_active = true`, userID, passwordHash)
+	if err != nil {
+		return 0, err
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return 0, err
+	}
+
+	return userID, tx.Commit()
+}
+
+// SetPassword istifadəçinin şifrəsini yeniləyir və şifrə dəyişikliyi tələbini götürür
+func (r *PostgresRepository) SetPassword(ctx context.Context, userID int, passwordHash string) error {
+	query := `
+		UPDATE users
+		SET password = $2, must_change_password = false, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, passwordHash)
+	return err
+}
+
+// RecordLogin istifadəçinin son giriş vaxtını yeniləyir
+func (r *PostgresRepository) RecordLogin(ctx context.Context, userID int) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE users SET last_login_at = NOW() WHERE id = $1`, userID)
+	return err
+}
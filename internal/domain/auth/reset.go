@@ -0,0 +1,201 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+	"github.com/Zam83-AZE/logistics_system/pkg/logger"
+	"github.com/Zam83-AZE/logistics_system/pkg/mail"
+	"github.com/Zam83-AZE/logistics_system/pkg/securetoken"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// maxResetRequests bir istifadəçi üçün resetRequestWindow ərzində göndərilə biləcək bərpa məktublarının sayıdır
+const (
+	maxResetRequests   = 3
+	resetRequestWindow = time.Hour
+
+	// resetMailTimeout arxa fonda bərpa məktubunun hazırlanması və göndərilməsi üçün ayrılan vaxtdır
+	resetMailTimeout = time.Minute
+)
+
+// ErrInvalidResetToken bərpa keçidi yanlış, vaxtı bitmiş və ya artıq istifadə edilmiş olduqda qaytarılır
+var ErrInvalidResetToken = errors.New("şifrə bərpası keçidi etibarsızdır və ya vaxtı bitib")
+
+// RequestPasswordReset istifadəçi adı və ya e-poçta görə bərpa keçidi göndərir.
+// Hesabın axtarışı və məktubun göndərilməsi arxa fonda icra edilir, beləliklə cavab və onun müddəti
+// hesabın mövcudluğundan və SMTP serverinin vəziyyətindən asılı olmur; göndərmə xətaları loqa yazılır.
+func (s *AuthService) RequestPasswordReset(ctx context.Context, login, ip string) error {
+	login = strings.TrimSpace(login)
+	if login == "" {
+		return &ValidationError{Message: "İstifadəçi adı və ya e-poçt ünvanı tələb olunur"}
+	}
+
+	// Sorğu bitdikdən sonra da işləyən kontekst; audit mənbəyi və loq sahələri saxlanılır
+	background := audit.WithSource(context.Background(), audit.SourceFromContext(ctx))
+	background = logger.WithContext(background, logger.FromContext(ctx))
+
+	go func() {
+		ctx, cancel := context.WithTimeout(background, resetMailTimeout)
+		defer cancel()
+
+		if err := s.sendPasswordReset(ctx, login, ip); err != nil {
+			logger.FromContext(ctx).WithError(err).Error("Şifrə bərpası məktubu göndərilmədi")
+		}
+	}()
+
+	return nil
+}
+
+// sendPasswordReset hesab tapıldıqda bərpa tokenini yaradır və məktubu göndərir.
+// Tapılmayan hesablar və limitin aşılması xəta sayılmır.
+func (s *AuthService) sendPasswordReset(ctx context.Context, login, ip string) error {
+	user, err := s.repo.GetByLogin(ctx, login)
+	if err != nil {
+		return err
+	}
+	if user == nil || user.Email == "" {
+		return nil
+	}
+
+	// Məktub bombardmanının qarşısını almaq üçün limit aşıldıqda sorğu səssizcə nəzərə alınmır
+	recent, err := s.repo.CountPasswordResets(ctx, user.ID, s.now().Add(-resetRequestWindow))
+	if err != nil {
+		return err
+	}
+	if recent >= maxResetRequests {
+		return nil
+	}
+
+	if s.mailer == nil {
+		return errors.New("e-poçt göndəricisi konfiqurasiya edilməyib")
+	}
+
+	token, hash, err := generateResetToken()
+	if err != nil {
+		return err
+	}
+
+	expiresAt := s.now().Add(s.policy.ResetTokenTTL)
+	if err := s.repo.CreatePasswordReset(ctx, user.ID, hash, ip, expiresAt); err != nil {
+		return err
+	}
+
+	link := s.baseURL + "/password/reset?" + url.Values{"token": {token}}.Encode()
+	msg := mail.Message{
+		To:      user.Email,
+		Subject: "Şifrənin bərpası",
+		Body: fmt.Sprintf("Salam, %s!\n\n"+
+			"Hesabınız üçün şifrənin bərpası tələb edildi. Yeni şifrə təyin etmək üçün keçidi açın:\n\n%s\n\n"+
+			"Keçid %s ərzində və yalnız bir dəfə etibarlıdır.\n"+
+			"Bu sorğunu siz göndərməmisinizsə, məktubu nəzərə almayın.\n",
+			user.FullName, link, formatWait(s.policy.ResetTokenTTL)),
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, audit.Event{
+		Action:     "auth.password_reset_requested",
+		EntityType: "user",
+		EntityID:   fmt.Sprint(user.ID),
+	})
+
+	return nil
+}
+
+// CheckResetToken bərpa keçidinin hələ etibarlı olduğunu yoxlayır
+func (s *AuthService) CheckResetToken(ctx context.Context, token string) error {
+	userID, err := s.repo.GetPasswordResetUser(ctx, hashResetToken(token))
+	if err != nil {
+		return err
+	}
+	if userID == 0 {
+		return ErrInvalidResetToken
+	}
+	return nil
+}
+
+// ResetPassword tokeni yoxlayır, yeni şifrəni siyasətə görə yoxlayır və təyin edir.
+// Token yalnız bir dəfə istifadə edilə bilər; uğurlu nəticədə istifadəçinin ID-si qaytarılır.
+func (s *AuthService) ResetPassword(ctx context.Context, token, password, confirm, ip string) (int, error) {
+	hash := hashResetToken(token)
+
+	userID, err := s.repo.GetPasswordResetUser(ctx, hash)
+	if err != nil {
+		return 0, err
+	}
+	if userID == 0 {
+		return 0, ErrInvalidResetToken
+	}
+
+	user, err := s.activeUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return 0, ErrInvalidResetToken
+		}
+		return 0, err
+	}
+
+	if password != confirm {
+		return 0, &ValidationError{Message: "Şifrələr üst-üstə düşmür"}
+	}
+	if err := s.passwordPolicy().Validate(password, user.Username); err != nil {
+		return 0, err
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return 0, err
+	}
+
+	// Eyni token paralel sorğularda istifadə edilərsə yalnız biri uğurlu olur
+	userID, err = s.repo.ResetPassword(ctx, hash, string(passwordHash))
+	if err != nil {
+		return 0, err
+	}
+	if userID == 0 {
+		return 0, ErrInvalidResetToken
+	}
+
+	// Yeni şifrə ilə dərhal daxil olmaq mümkün olsun
+	if err := s.repo.ClearFailures(ctx, ScopeUsername, strings.ToLower(user.Username)); err != nil {
+		return 0, err
+	}
+
+	s.audit.Record(ctx, audit.Event{
+		ActorUserID: &userID,
+		Action:      "auth.password_reset",
+		EntityType:  "user",
+		EntityID:    fmt.Sprint(userID),
+	})
+
+	return userID, nil
+}
+
+// passwordPolicy konfiqurasiyaya əsasən şifrə siyasətini qaytarır
+func (s *AuthService) passwordPolicy() PasswordPolicy {
+	return PasswordPolicy{MinLength: s.policy.PasswordMinLength}
+}
+
+// generateResetToken keçiddə göndəriləcək tokeni və verilənlər bazasında saxlanılacaq heşini qaytarır
+func generateResetToken() (string, string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	return token, hashResetToken(token), nil
+}
+
+// hashResetToken linkdən köçürülmüş tokenin kənar boşluqlarını atıb heşini qaytarır
+func hashResetToken(token string) string {
+	return securetoken.Hash(strings.TrimSpace(token))
+}
@@ -4,26 +4,73 @@ import (
 	"html/template"
 	"net/http"
 
+	"github.com/Zam83-AZE/logistics_system/internal/domain/apitoken"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/rbac"
+	"github.com/Zam83-AZE/logistics_system/internal/middleware"
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+	"github.com/Zam83-AZE/logistics_system/pkg/config"
+	"github.com/Zam83-AZE/logistics_system/pkg/mail"
 	"github.com/Zam83-AZE/logistics_system/pkg/session"
 	"github.com/gorilla/mux"
 	"github.com/jmoiron/sqlx"
 )
 
 // RegisterRoutes autentifikasiya marşrutlarını qeydə alır
-func RegisterRoutes(router *mux.Router, db *sqlx.DB, tmpl *template.Template, sessionManager *session.Manager) {
-	repo := NewPostgresRepository(db)
-	service := NewAuthService(repo)
-	handler := NewHandler(service, tmpl, sessionManager)
+func RegisterRoutes(router *mux.Router, db *sqlx.DB, tmpl *template.Template, sessionManager *session.Manager, cfg *config.Config, mailer mail.Mailer) {
+	handler := newHandler(db, tmpl, sessionManager, cfg, mailer)
 
 	// Login səhifəsi
 	router.HandleFunc("/login", handler.LoginPage).Methods("GET")
 	router.HandleFunc("/login", handler.Login).Methods("POST")
 
+	// İki faktorlu autentifikasiyanın ikinci addımı
+	router.HandleFunc("/login/2fa", handler.TwoFactorPage).Methods("GET")
+	router.HandleFunc("/login/2fa", handler.TwoFactorVerify).Methods("POST")
+
+	// Şifrənin bərpası
+	router.HandleFunc("/password/forgot", handler.ForgotPasswordPage).Methods("GET")
+	router.HandleFunc("/password/forgot", handler.ForgotPassword).Methods("POST")
+	router.HandleFunc("/password/reset", handler.ResetPasswordPage).Methods("GET")
+	router.HandleFunc("/password/reset", handler.ResetPassword).Methods("POST")
+
 	// Logout
-	router.HandleFunc("/logout", handler.Logout).Methods("GET")
+	router.HandleFunc("/logout", handler.Logout).Methods("POST")
+	router.HandleFunc("/logout/everywhere", handler.LogoutEverywhere).Methods("POST")
 
 	// Root path-i login-ə yönləndirir
 	router.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, "/login", http.StatusSeeOther)
 	}).Methods("GET")
 }
+
+// RegisterAdminRoutes giriş bloklamalarının idarəsi marşrutlarını autentifikasiya tələb edən router-də qeydə alır
+func RegisterAdminRoutes(router *mux.Router, db *sqlx.DB, tmpl *template.Template, sessionManager *session.Manager, cfg *config.Config, mailer mail.Mailer) {
+	handler := newHandler(db, tmpl, sessionManager, cfg, mailer)
+	canManage := middleware.RequirePermission(rbac.UsersManage)
+
+	router.Handle("/admin/lockouts", canManage(http.HandlerFunc(handler.Lockouts))).Methods("GET")
+	router.Handle("/admin/lockouts/unlock", canManage(http.HandlerFunc(handler.Unlock))).Methods("POST")
+}
+
+// RegisterAccountRoutes istifadəçinin öz hesab ayarları marşrutlarını qeydə alır (xüsusi icazə tələb etmir)
+func RegisterAccountRoutes(router *mux.Router, db *sqlx.DB, tmpl *template.Template, sessionManager *session.Manager, cfg *config.Config, mailer mail.Mailer) {
+	handler := newHandler(db, tmpl, sessionManager, cfg, mailer)
+
+	// Şifrə və iki faktorlu autentifikasiya API tokeni ilə dəyişdirilə bilməz
+	sessionOnly := middleware.SessionOnly
+
+	router.Handle("/account/password", sessionOnly(http.HandlerFunc(handler.ChangePasswordPage))).Methods("GET")
+	router.Handle("/account/password", sessionOnly(http.HandlerFunc(handler.ChangePassword))).Methods("POST")
+
+	router.Handle("/account/2fa", sessionOnly(http.HandlerFunc(handler.TwoFactorSettings))).Methods("GET")
+	router.Handle("/account/2fa/confirm", sessionOnly(http.HandlerFunc(handler.TwoFactorConfirm))).Methods("POST")
+	router.Handle("/account/2fa/disable", sessionOnly(http.HandlerFunc(handler.TwoFactorDisable))).Methods("POST")
+	router.Handle("/account/2fa/recovery-codes", sessionOnly(http.HandlerFunc(handler.TwoFactorRecoveryCodes))).Methods("POST")
+}
+
+// newHandler repository, servis və işləyicini birlikdə qurur
+func newHandler(db *sqlx.DB, tmpl *template.Template, sessionManager *session.Manager, cfg *config.Config, mailer mail.Mailer) *Handler {
+	repo := NewPostgresRepository(db)
+	service := NewAuthService(repo, cfg.Auth, mailer, audit.NewRecorder(db), cfg.App.BaseURL)
+	return NewHandler(service, apitoken.NewService(db), tmpl, sessionManager, cfg.Auth.PasswordMinLength)
+}
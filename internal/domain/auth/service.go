@@ -3,48 +3,414 @@ package auth
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+	"github.com/Zam83-AZE/logistics_system/pkg/config"
+	"github.com/Zam83-AZE/logistics_system/pkg/logger"
+	"github.com/Zam83-AZE/logistics_system/pkg/mail"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// ErrInvalidCredentials istifadəçi adı və ya şifrə yanlış olduqda qaytarılır.
+// Mesaj istifadəçi adının mövcud olub-olmadığını açıqlamır.
+var ErrInvalidCredentials = errors.New("istifadəçi adı və ya şifrə yanlışdır")
+
+// ErrMissingCredentials istifadəçi adı və ya şifrə daxil edilmədikdə qaytarılır
+var ErrMissingCredentials = errors.New("istifadəçi adı və şifrə tələb olunur")
+
+// ValidationError istifadəçinin daxil etdiyi məlumat yanlış olduqda qaytarılır
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// ThrottledError çoxlu uğursuz cəhddən sonra girişin müvəqqəti dayandırıldığını bildirir
+type ThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("Çoxlu uğursuz giriş cəhdi. Zəhmət olmasa %s sonra yenidən cəhd edin", formatWait(e.RetryAfter))
+}
+
+// dummyHash mövcud olmayan istifadəçilər üçün də bcrypt müqayisəsi aparmağa imkan verir,
+// beləliklə cavab müddəti istifadəçi adının mövcudluğunu açıqlamır
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("logistics-system-dummy-password"), bcrypt.DefaultCost)
+
 // Service istifadəçi autentifikasiyası biznes məntiqini müəyyən edir
 type Service interface {
-	Login(ctx context.Context, username, password string) (*User, error)
+	Login(ctx context.Context, username, password, ip string) (*User, error)
+	Lockouts(ctx context.Context) ([]LoginFailure, error)
+	Unlock(ctx context.Context, scope, key string, actorUserID int, ip string) error
+
+	VerifySecondFactor(ctx context.Context, userID int, code, ip string) (*User, error)
+	TwoFactorRequired(ctx context.Context, userID int) (bool, error)
+	TwoFactorStatus(ctx context.Context, userID int) (*TwoFactorStatus, error)
+	BeginEnrollment(ctx context.Context, userID int) (*Enrollment, error)
+	ConfirmEnrollment(ctx context.Context, userID int, code, ip string) ([]string, error)
+	DisableTwoFactor(ctx context.Context, userID int, code, ip string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID int, code, ip string) ([]string, error)
+
+	RequestPasswordReset(ctx context.Context, login, ip string) error
+	CheckResetToken(ctx context.Context, token string) error
+	ResetPassword(ctx context.Context, token, password, confirm, ip string) (int, error)
+	ChangePassword(ctx context.Context, userID int, current, password, confirm, ip string) error
+	RecordLogin(ctx context.Context, userID int) error
+	RecordLogout(ctx context.Context, userID int, everywhere bool) error
 }
 
 // AuthService Service interfeysini həyata keçirir
 type AuthService struct {
-	repo Repository
+	repo    Repository
+	policy  config.AuthConfig
+	mailer  mail.Mailer
+	audit   audit.Recorder
+	baseURL string
+	now     func() time.Time
 }
 
-// NewAuthService yeni AuthService yaradır
-func NewAuthService(repo Repository) *AuthService {
-	return &AuthService{repo: repo}
+// NewAuthService yeni AuthService yaradır; baseURL e-poçtdakı keçidlərin əsas ünvanıdır
+func NewAuthService(repo Repository, policy config.AuthConfig, mailer mail.Mailer, recorder audit.Recorder, baseURL string) *AuthService {
+	return &AuthService{
+		repo:    repo,
+		policy:  policy,
+		mailer:  mailer,
+		audit:   recorder,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		now:     time.Now,
+	}
 }
 
-// Login istifadəçi adı və şifrəyə görə istifadəçini yoxlayır
-func (s *AuthService) Login(ctx context.Context, username, password string) (*User, error) {
+// Login istifadəçi adı və şifrəyə görə istifadəçini yoxlayır.
+// Uğursuz cəhdlər istifadəçi adı və IP üzrə sayılır, gözləmə müddəti hər dəfə ikiqat artır.
+func (s *AuthService) Login(ctx context.Context, username, password, ip string) (*User, error) {
 	if username == "" || password == "" {
-		return nil, errors.New("istifadəçi adı və şifrə tələb olunur")
+		return nil, ErrMissingCredentials
 	}
 
-	user, err := s.repo.GetByUsername(ctx, username)
+	// Cəhd şifrə yoxlanılmazdan əvvəl sayılır; gözləmə və ya bloklama müddətində şifrə yoxlanılmır
+	a, err := s.reserveAttempt(ctx, throttleKeys(username, ip))
 	if err != nil {
 		return nil, err
 	}
 
-	if user == nil {
-		return nil, errors.New("istifadəçi adı və ya şifrə yanlışdır")
+	user, err := s.repo.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, s.abortAttempt(ctx, a, err)
+	}
+
+	hash := dummyHash
+	if user != nil {
+		hash = []byte(user.Password)
 	}
 
 	// Şifrəni yoxla
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
-	if err != nil {
-		return nil, errors.New("istifadəçi adı və ya şifrə yanlışdır")
+	if err := bcrypt.CompareHashAndPassword(hash, []byte(password)); err != nil || user == nil {
+		if err := s.failAttempt(ctx, a); err != nil {
+			return nil, err
+		}
+		s.audit.Record(ctx, audit.Event{
+			Action:     "auth.login_failed",
+			EntityType: "user",
+			EntityID:   a.keys[0].key,
+		})
+		return nil, ErrInvalidCredentials
+	}
+
+	if err := s.releaseAttempt(ctx, a); err != nil {
+		return nil, err
 	}
 
-	// Şifrəni silmək (təhlükəsizlik üçün)
+	// Şifrəni və TOTP açarını silmək (təhlükəsizlik üçün)
 	user.Password = ""
+	user.TOTPSecret = ""
 
 	return user, nil
 }
+
+// RecordLogin uğurlu girişin vaxtını qeyd edir və audit jurnalına yazır
+func (s *AuthService) RecordLogin(ctx context.Context, userID int) error {
+	if err := s.repo.RecordLogin(ctx, userID); err != nil {
+		return err
+	}
+	loginAttempts.Inc(loginSuccess)
+
+	s.audit.Record(ctx, audit.Event{
+		ActorUserID: &userID,
+		Action:      "auth.login",
+		EntityType:  "user",
+		EntityID:    fmt.Sprint(userID),
+	})
+
+	return nil
+}
+
+// RecordLogout çıxışı audit jurnalına yazır; everywhere bütün sessiyaların ləğv edildiyini göstərir
+func (s *AuthService) RecordLogout(ctx context.Context, userID int, everywhere bool) error {
+	if userID == 0 {
+		return nil
+	}
+
+	action := "auth.logout"
+	if everywhere {
+		action = "auth.logout_everywhere"
+	}
+
+	s.audit.Record(ctx, audit.Event{
+		ActorUserID: &userID,
+		Action:      action,
+		EntityType:  "user",
+		EntityID:    fmt.Sprint(userID),
+	})
+
+	return nil
+}
+
+// ChangePassword cari şifrəni yoxlayır, yeni şifrəni siyasətə görə yoxlayır və təyin edir
+func (s *AuthService) ChangePassword(ctx context.Context, userID int, current, password, confirm, ip string) error {
+	user, err := s.activeUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// Yanlış cari şifrə giriş cəhdləri ilə eyni sayğac üzrə sayılır ki,
+	// açıq qalmış sessiya şifrəni təxmin etmək üçün istifadə edilə bilməsin
+	a, err := s.reserveAttempt(ctx, throttleKeys(user.Username, ip))
+	if err != nil {
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(current)); err != nil {
+		if err := s.failAttempt(ctx, a); err != nil {
+			return err
+		}
+		return &ValidationError{Message: "Cari şifrə yanlışdır"}
+	}
+	if err := s.releaseAttempt(ctx, a); err != nil {
+		return err
+	}
+
+	if password != confirm {
+		return &ValidationError{Message: "Şifrələr üst-üstə düşmür"}
+	}
+	if password == current {
+		return &ValidationError{Message: "Yeni şifrə cari şifrədən fərqli olmalıdır"}
+	}
+	if err := s.passwordPolicy().Validate(password, user.Username); err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.SetPassword(ctx, userID, string(hash)); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, audit.Event{
+		ActorUserID: &userID,
+		Action:      "auth.password_changed",
+		EntityType:  "user",
+		EntityID:    fmt.Sprint(userID),
+	})
+
+	return nil
+}
+
+// Lockouts hazırda bloklanmış istifadəçi adlarını və IP ünvanlarını qaytarır
+func (s *AuthService) Lockouts(ctx context.Context) ([]LoginFailure, error) {
+	return s.repo.ListLockouts(ctx)
+}
+
+// Unlock administratorun bloku açmasını icra edir və audit jurnalına yazır
+func (s *AuthService) Unlock(ctx context.Context, scope, key string, actorUserID int, ip string) error {
+	if scope != ScopeUsername && scope != ScopeIP {
+		return errors.New("naməlum bloklama növü")
+	}
+
+	if err := s.repo.ClearFailures(ctx, scope, key); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, audit.Event{
+		ActorUserID: &actorUserID,
+		Action:      "auth.unlock",
+		EntityType:  "login_" + scope,
+		EntityID:    key,
+	})
+
+	return nil
+}
+
+type throttleKey struct {
+	scope string
+	key   string
+}
+
+// throttleKeys cəhdin sayılacağı açarları qaytarır; birinci açar həmişə istifadəçi adıdır
+func throttleKeys(username, ip string) []throttleKey {
+	keys := []throttleKey{{scope: ScopeUsername, key: strings.ToLower(strings.TrimSpace(username))}}
+	if ip != "" {
+		keys = append(keys, throttleKey{scope: ScopeIP, key: ip})
+	}
+	return keys
+}
+
+// attempt şifrə və ya kod yoxlanılmazdan əvvəl uğursuz kimi sayılmış cəhddir.
+// previous hər açarın əvvəlki vəziyyətini, reserved isə yazılmış vəziyyəti saxlayır.
+type attempt struct {
+	keys     []throttleKey
+	previous []*LoginFailure
+	reserved []*LoginFailure
+}
+
+// reserveAttempt cəhdi yoxlamadan əvvəl hər açar üzrə uğursuz kimi qeydə alır və növbəti gözləmə müddətini təyin edir.
+// Qeyd şərti yazılır: paralel sorğu sayğacı artıq dəyişibsə cəhd rədd edilir, beləliklə eyni anda
+// göndərilmiş sorğular gözləmə müddətindən yan keçə bilmir.
+func (s *AuthService) reserveAttempt(ctx context.Context, keys []throttleKey) (*attempt, error) {
+	now := s.now().UTC().Truncate(time.Microsecond)
+	a := &attempt{keys: keys}
+
+	// Gözləmə və ya bloklama müddətində heç bir açar dəyişdirilmir
+	for _, k := range keys {
+		failure, err := s.repo.GetFailure(ctx, k.scope, k.key)
+		if err != nil {
+			return nil, err
+		}
+		if failure != nil {
+			if wait := failure.RetryAfter(now); wait > 0 {
+				loginAttempts.Inc(loginThrottled)
+				return nil, &ThrottledError{RetryAfter: wait}
+			}
+		}
+		a.previous = append(a.previous, failure)
+	}
+
+	for i, k := range keys {
+		next := s.nextFailure(k, a.previous[i], now)
+		ok, err := s.repo.SwapFailure(ctx, k.scope, k.key, a.previous[i], next)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			// Paralel cəhd bizdən əvvəl qeydə alındı: artıq sayılmış açarlar əvvəlki vəziyyətə qaytarılır
+			if err := s.restore(ctx, a); err != nil {
+				return nil, err
+			}
+			loginAttempts.Inc(loginThrottled)
+			return nil, &ThrottledError{RetryAfter: s.backoff(next.Failures)}
+		}
+		a.reserved = append(a.reserved, next)
+	}
+
+	return a, nil
+}
+
+// nextFailure növbəti uğursuz cəhddən sonrakı qeydi hesablayır; son cəhddən FailureWindow qədər vaxt keçibsə sayğac yenidən başlayır
+func (s *AuthService) nextFailure(k throttleKey, previous *LoginFailure, now time.Time) *LoginFailure {
+	failures := 1
+	if previous != nil && now.Sub(previous.LastFailedAt) <= s.policy.FailureWindow {
+		failures = previous.Failures + 1
+	}
+
+	threshold := s.policy.LockoutThreshold
+	if k.scope == ScopeIP {
+		threshold = s.policy.IPLockoutThreshold
+	}
+
+	blockedUntil := now.Add(s.backoff(failures))
+	next := &LoginFailure{
+		Scope:        k.scope,
+		Key:          k.key,
+		Failures:     failures,
+		LastFailedAt: now,
+		BlockedUntil: &blockedUntil,
+	}
+	if failures >= threshold {
+		lockedUntil := now.Add(s.policy.LockoutDuration)
+		next.LockedUntil = &lockedUntil
+	}
+
+	return next
+}
+
+// releaseAttempt uğurlu cəhddən sonra istifadəçi adı üzrə sayğacı sıfırlayır; IP sayğacı isə bir hesabla
+// digər hesabların yoxlanmasının qarşısını almaq üçün cəhddən əvvəlki vəziyyətinə qaytarılır
+func (s *AuthService) releaseAttempt(ctx context.Context, a *attempt) error {
+	if err := s.repo.ClearFailures(ctx, ScopeUsername, a.keys[0].key); err != nil {
+		return err
+	}
+	return s.restore(ctx, &attempt{keys: a.keys[1:], previous: a.previous[1:], reserved: a.reserved[1:]})
+}
+
+// restore qeydə alınmış açarları əvvəlki vəziyyətinə qaytarır
+func (s *AuthService) restore(ctx context.Context, a *attempt) error {
+	for i, reserved := range a.reserved {
+		k := a.keys[i]
+		if _, err := s.repo.SwapFailure(ctx, k.scope, k.key, reserved, a.previous[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// abortAttempt yoxlama xəta ilə bitdikdə qeydə alınmış cəhdi geri qaytarır və ilkin xətanı qaytarır.
+// Müvəqqəti verilənlər bazası xətası istifadəçini gözləmə və bloklamaya yaxınlaşdırmamalıdır.
+func (s *AuthService) abortAttempt(ctx context.Context, a *attempt, err error) error {
+	if restoreErr := s.restore(ctx, a); restoreErr != nil {
+		logger.FromContext(ctx).WithError(restoreErr).Error("Giriş cəhdi geri qaytarılmadı")
+	}
+	return err
+}
+
+// failAttempt uğursuz cəhdi sayır və qeydiyyat zamanı təyin edilmiş bloklamaları audit jurnalına yazır
+func (s *AuthService) failAttempt(ctx context.Context, a *attempt) error {
+	loginAttempts.Inc(loginFailure)
+
+	// Bloklama müddətində cəhdlər qeydə alınmır, ona görə hər təyin edilən bloklama yenidir
+	for i, failure := range a.reserved {
+		if failure.LockedUntil == nil {
+			continue
+		}
+
+		k := a.keys[i]
+		s.audit.Record(ctx, audit.Event{
+			Action:     "auth.lockout",
+			EntityType: "login_" + k.scope,
+			EntityID:   k.key,
+			Details: map[string]interface{}{
+				"failures":     failure.Failures,
+				"locked_until": failure.LockedUntil.UTC().Format(time.RFC3339),
+			},
+		})
+	}
+
+	return nil
+}
+
+// backoff n-ci uğursuz cəhddən sonrakı gözləmə müddətini qaytarır: base * 2^(n-1), ən çox BackoffMax
+func (s *AuthService) backoff(failures int) time.Duration {
+	wait := s.policy.BackoffBase
+	for i := 1; i < failures && wait < s.policy.BackoffMax; i++ {
+		wait *= 2
+	}
+	if wait > s.policy.BackoffMax {
+		wait = s.policy.BackoffMax
+	}
+	return wait
+}
+
+// formatWait gözləmə müddətini istifadəçi üçün oxunaqlı formada qaytarır
+func formatWait(d time.Duration) string {
+	if d >= time.Minute {
+		return fmt.Sprintf("%d dəqiqə", int((d+time.Minute-1)/time.Minute))
+	}
+	return fmt.Sprintf("%d saniyə", int((d+time.Second-1)/time.Second))
+}
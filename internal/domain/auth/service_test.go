@@ -0,0 +1,329 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+	"github.com/Zam83-AZE/logistics_system/pkg/config"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fakeRepository uğursuz cəhdləri yaddaşda saxlayır; testlərdə çağırılmayan metodlar daxili Repository-yə
+// ötürülür (nil olduğu üçün panic verir)
+type fakeRepository struct {
+	Repository
+
+	mu       sync.Mutex
+	users    map[string]*User
+	failures map[throttleKey]LoginFailure
+	// getErr təyin edildikdə GetByUsername bu xətanı qaytarır
+	getErr error
+}
+
+func newFakeRepository(users ...*User) *fakeRepository {
+	repo := &fakeRepository{users: map[string]*User{}, failures: map[throttleKey]LoginFailure{}}
+	for _, user := range users {
+		repo.users[user.Username] = user
+	}
+	return repo
+}
+
+func (r *fakeRepository) GetByUsername(ctx context.Context, username string) (*User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.getErr != nil {
+		return nil, r.getErr
+	}
+	user, ok := r.users[username]
+	if !ok {
+		return nil, nil
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (r *fakeRepository) GetByID(ctx context.Context, id int) (*User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, user := range r.users {
+		if user.ID == id {
+			copied := *user
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeRepository) GetFailure(ctx context.Context, scope, key string) (*LoginFailure, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	failure, ok := r.failures[throttleKey{scope: scope, key: key}]
+	if !ok {
+		return nil, nil
+	}
+	return &failure, nil
+}
+
+func (r *fakeRepository) SwapFailure(ctx context.Context, scope, key string, current, next *LoginFailure) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	k := throttleKey{scope: scope, key: key}
+	stored, ok := r.failures[k]
+	if current == nil && ok {
+		return false, nil
+	}
+	if current != nil && (!ok || stored.Failures != current.Failures || !stored.LastFailedAt.Equal(current.LastFailedAt)) {
+		return false, nil
+	}
+
+	if next == nil {
+		delete(r.failures, k)
+	} else {
+		r.failures[k] = *next
+	}
+	return true, nil
+}
+
+func (r *fakeRepository) ClearFailures(ctx context.Context, scope, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.failures, throttleKey{scope: scope, key: key})
+	return nil
+}
+
+// fakeRecorder audit hadisələrini yaddaşda saxlayır
+type fakeRecorder struct {
+	mu     sync.Mutex
+	events []audit.Event
+}
+
+func (r *fakeRecorder) Record(ctx context.Context, event audit.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, event)
+}
+
+func (r *fakeRecorder) count(action string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := 0
+	for _, event := range r.events {
+		if event.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+var testPolicy = config.AuthConfig{
+	LockoutThreshold:   5,
+	IPLockoutThreshold: 20,
+	LockoutDuration:    15 * time.Minute,
+	BackoffBase:        time.Second,
+	BackoffMax:         8 * time.Second,
+	FailureWindow:      time.Hour,
+}
+
+// newTestService saxta repository və idarə olunan saatla AuthService yaradır
+func newTestService(t *testing.T) (*AuthService, *fakeRepository, *fakeRecorder, *time.Time) {
+	t.Helper()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("düzgün-şifrə"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := newFakeRepository(&User{ID: 1, Username: "ali", Password: string(hash), IsActive: true})
+	recorder := &fakeRecorder{}
+	service := NewAuthService(repo, testPolicy, nil, recorder, "")
+
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	return service, repo, recorder, &now
+}
+
+// retryAfter xətanın ThrottledError olduğunu yoxlayır və gözləmə müddətini qaytarır
+func retryAfter(t *testing.T, err error) time.Duration {
+	t.Helper()
+
+	var throttled *ThrottledError
+	if !errors.As(err, &throttled) {
+		t.Fatalf("xəta = %v, gözlənilən ThrottledError", err)
+	}
+	return throttled.RetryAfter
+}
+
+// TestLoginBackoff gözləmə müddətinin hər uğursuz cəhddən sonra ikiqat artdığını və BackoffMax ilə məhdudlaşdığını yoxlayır
+func TestLoginBackoff(t *testing.T) {
+	service, _, _, now := newTestService(t)
+	ctx := context.Background()
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second}
+	for i, wait := range want {
+		if _, err := service.Login(ctx, "ali", "yanlış", "10.0.0.1"); !errors.Is(err, ErrInvalidCredentials) {
+			t.Fatalf("cəhd %d: xəta = %v, gözlənilən ErrInvalidCredentials", i+1, err)
+		}
+
+		// Gözləmə müddətində hətta düzgün şifrə də yoxlanılmır
+		_, err := service.Login(ctx, "ali", "düzgün-şifrə", "10.0.0.1")
+		if got := retryAfter(t, err); got != wait {
+			t.Errorf("cəhd %d: gözləmə = %s, gözlənilən %s", i+1, got, wait)
+		}
+
+		*now = now.Add(wait)
+	}
+}
+
+// TestLoginLockout həddə çatdıqda hesabın LockoutDuration qədər bloklandığını və bunun audit jurnalına yazıldığını yoxlayır
+func TestLoginLockout(t *testing.T) {
+	service, repo, recorder, now := newTestService(t)
+	ctx := context.Background()
+
+	for i := 0; i < testPolicy.LockoutThreshold; i++ {
+		if _, err := service.Login(ctx, "ali", "yanlış", "10.0.0.1"); !errors.Is(err, ErrInvalidCredentials) {
+			t.Fatalf("cəhd %d: xəta = %v, gözlənilən ErrInvalidCredentials", i+1, err)
+		}
+		*now = now.Add(testPolicy.BackoffMax)
+	}
+
+	if got := recorder.count("auth.lockout"); got != 1 {
+		t.Errorf("auth.lockout hadisələri = %d, gözlənilən 1", got)
+	}
+
+	// BackoffMax keçsə də bloklama davam edir
+	_, err := service.Login(ctx, "ali", "düzgün-şifrə", "10.0.0.1")
+	if got := retryAfter(t, err); got != testPolicy.LockoutDuration-testPolicy.BackoffMax {
+		t.Errorf("gözləmə = %s, gözlənilən %s", got, testPolicy.LockoutDuration-testPolicy.BackoffMax)
+	}
+
+	*now = now.Add(testPolicy.LockoutDuration)
+	if _, err := service.Login(ctx, "ali", "düzgün-şifrə", "10.0.0.1"); err != nil {
+		t.Fatalf("bloklamadan sonra giriş: %v", err)
+	}
+
+	if failure, _ := repo.GetFailure(ctx, ScopeUsername, "ali"); failure != nil {
+		t.Errorf("uğurlu girişdən sonra istifadəçi adı sayğacı qalıb: %+v", failure)
+	}
+	failure, _ := repo.GetFailure(ctx, ScopeIP, "10.0.0.1")
+	if failure == nil || failure.Failures != testPolicy.LockoutThreshold {
+		t.Errorf("IP sayğacı = %+v, gözlənilən %d uğursuz cəhd", failure, testPolicy.LockoutThreshold)
+	}
+}
+
+// TestLoginRepositoryError istifadəçinin oxunması xəta verdikdə qeydə alınmış cəhdin geri qaytarıldığını yoxlayır
+func TestLoginRepositoryError(t *testing.T) {
+	service, repo, _, now := newTestService(t)
+	ctx := context.Background()
+
+	// Əvvəlki uğursuz cəhd saxlanılmalı, yalnız xəta ilə bitən cəhd geri qaytarılmalıdır
+	service.Login(ctx, "ali", "yanlış", "10.0.0.1")
+	*now = now.Add(testPolicy.BackoffBase)
+
+	dbErr := errors.New("bağlantı kəsildi")
+	repo.getErr = dbErr
+	if _, err := service.Login(ctx, "ali", "düzgün-şifrə", "10.0.0.1"); !errors.Is(err, dbErr) {
+		t.Fatalf("xəta = %v, gözlənilən %v", err, dbErr)
+	}
+
+	for _, k := range throttleKeys("ali", "10.0.0.1") {
+		failure, _ := repo.GetFailure(ctx, k.scope, k.key)
+		if failure == nil || failure.Failures != 1 {
+			t.Errorf("%s sayğacı = %+v, gözlənilən 1 uğursuz cəhd", k.scope, failure)
+		}
+	}
+
+	// Xəta aradan qalxdıqdan sonra gözləmə tətbiq edilmir
+	repo.getErr = nil
+	if _, err := service.Login(ctx, "ali", "düzgün-şifrə", "10.0.0.1"); err != nil {
+		t.Errorf("xətadan sonra giriş: %v", err)
+	}
+}
+
+// TestLoginFailureWindow son cəhddən FailureWindow keçdikdə sayğacın yenidən başladığını yoxlayır
+func TestLoginFailureWindow(t *testing.T) {
+	service, repo, _, now := newTestService(t)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		service.Login(ctx, "ali", "yanlış", "")
+		*now = now.Add(testPolicy.BackoffMax)
+	}
+
+	*now = now.Add(testPolicy.FailureWindow)
+	service.Login(ctx, "ali", "yanlış", "")
+
+	failure, _ := repo.GetFailure(ctx, ScopeUsername, "ali")
+	if failure == nil || failure.Failures != 1 {
+		t.Errorf("sayğac = %+v, gözlənilən 1", failure)
+	}
+}
+
+// TestLoginConcurrentAttempts eyni anda göndərilmiş cəhdlərdən yalnız birinin şifrə yoxlamasına çatdığını yoxlayır
+func TestLoginConcurrentAttempts(t *testing.T) {
+	service, _, _, _ := newTestService(t)
+	ctx := context.Background()
+
+	const attempts = 20
+	errs := make(chan error, attempts)
+
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.Login(ctx, "ali", "yanlış", "10.0.0.1")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	checked := 0
+	for err := range errs {
+		var throttled *ThrottledError
+		switch {
+		case errors.Is(err, ErrInvalidCredentials):
+			checked++
+		case errors.As(err, &throttled):
+		default:
+			t.Errorf("gözlənilməz xəta: %v", err)
+		}
+	}
+
+	if checked != 1 {
+		t.Errorf("yoxlanılan cəhdlər = %d, gözlənilən 1", checked)
+	}
+}
+
+// TestChangePasswordThrottle yanlış cari şifrənin giriş cəhdləri ilə eyni sayğac üzrə məhdudlaşdırıldığını yoxlayır
+func TestChangePasswordThrottle(t *testing.T) {
+	service, _, _, _ := newTestService(t)
+	ctx := context.Background()
+
+	var validationErr *ValidationError
+	err := service.ChangePassword(ctx, 1, "yanlış", "Yeni-Şifrə-2024", "Yeni-Şifrə-2024", "10.0.0.1")
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("xəta = %v, gözlənilən ValidationError", err)
+	}
+
+	err = service.ChangePassword(ctx, 1, "düzgün-şifrə", "Yeni-Şifrə-2024", "Yeni-Şifrə-2024", "10.0.0.1")
+	if got := retryAfter(t, err); got != testPolicy.BackoffBase {
+		t.Errorf("gözləmə = %s, gözlənilən %s", got, testPolicy.BackoffBase)
+	}
+
+	// Giriş səhifəsi də eyni sayğacı görür
+	_, err = service.Login(ctx, "ali", "düzgün-şifrə", "10.0.0.1")
+	retryAfter(t, err)
+}
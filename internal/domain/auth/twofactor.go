@@ -0,0 +1,343 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+	"github.com/Zam83-AZE/logistics_system/pkg/securetoken"
+	"github.com/Zam83-AZE/logistics_system/pkg/totp"
+)
+
+// Issuer autentifikator tətbiqlərində göstərilən xidmət adıdır
+const Issuer = "Logistics System"
+
+// recoveryCodeCount bir dəfədə yaradılan bərpa kodlarının sayıdır
+const recoveryCodeCount = 10
+
+var (
+	// ErrInvalidCode TOTP və ya bərpa kodu yanlış olduqda qaytarılır
+	ErrInvalidCode = errors.New("kod yanlışdır və ya artıq istifadə edilib")
+	// ErrTwoFactorRequired rol tələb etdiyi halda iki faktorlu autentifikasiyanı söndürməyə cəhd edildikdə qaytarılır
+	ErrTwoFactorRequired = errors.New("rolunuz üçün iki faktorlu autentifikasiya məcburidir")
+	// ErrTwoFactorEnabled iki faktorlu autentifikasiya artıq aktiv olduqda qaytarılır
+	ErrTwoFactorEnabled = errors.New("iki faktorlu autentifikasiya artıq aktivdir")
+	// ErrTwoFactorDisabled iki faktorlu autentifikasiya aktiv olmadıqda qaytarılır
+	ErrTwoFactorDisabled = errors.New("iki faktorlu autentifikasiya aktiv deyil")
+	// ErrUserNotFound istifadəçi tapılmadıqda və ya aktiv olmadıqda qaytarılır
+	ErrUserNotFound = errors.New("istifadəçi tapılmadı")
+)
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TwoFactorStatus istifadəçinin iki faktorlu autentifikasiya vəziyyətini təmsil edir
+type TwoFactorStatus struct {
+	Enabled        bool
+	Required       bool
+	RemainingCodes int
+}
+
+// Enrollment təsdiq gözləyən TOTP açarını və autentifikator tətbiqi üçün URI-ni saxlayır
+type Enrollment struct {
+	Secret string
+	URI    string
+}
+
+// VerifySecondFactor şifrədən sonra ikinci addımı (TOTP və ya bərpa kodu) yoxlayır.
+// Uğursuz cəhdlər şifrə cəhdləri ilə eyni sayğaca yazılır.
+func (s *AuthService) VerifySecondFactor(ctx context.Context, userID int, code, ip string) (*User, error) {
+	user, err := s.activeUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TOTPEnabled {
+		return nil, ErrTwoFactorDisabled
+	}
+
+	a, err := s.reserveAttempt(ctx, throttleKeys(user.Username, ip))
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := s.checkTOTP(ctx, user, code)
+	if err != nil {
+		return nil, s.abortAttempt(ctx, a, err)
+	}
+
+	if !ok {
+		// TOTP uyğun gəlmədisə kod bərpa kodu ola bilər
+		ok, err = s.repo.UseRecoveryCode(ctx, user.ID, hashRecoveryCode(code))
+		if err != nil {
+			return nil, s.abortAttempt(ctx, a, err)
+		}
+		if ok {
+			s.audit.Record(ctx, audit.Event{
+				ActorUserID: &user.ID,
+				Action:      "auth.recovery_code_used",
+				EntityType:  "user",
+				EntityID:    user.Username,
+			})
+		}
+	}
+
+	if !ok {
+		if err := s.failAttempt(ctx, a); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidCode
+	}
+
+	if err := s.releaseAttempt(ctx, a); err != nil {
+		return nil, err
+	}
+
+	user.Password = ""
+	user.TOTPSecret = ""
+
+	return user, nil
+}
+
+// TwoFactorRequired istifadəçinin rollarından birinin iki faktorlu autentifikasiya tələb edib-etmədiyini qaytarır
+func (s *AuthService) TwoFactorRequired(ctx context.Context, userID int) (bool, error) {
+	return s.repo.TwoFactorRequired(ctx, userID)
+}
+
+// TwoFactorStatus istifadəçinin iki faktorlu autentifikasiya vəziyyətini qaytarır
+func (s *AuthService) TwoFactorStatus(ctx context.Context, userID int) (*TwoFactorStatus, error) {
+	user, err := s.activeUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	required, err := s.repo.TwoFactorRequired(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &TwoFactorStatus{Enabled: user.TOTPEnabled, Required: required}
+	if user.TOTPEnabled {
+		if status.RemainingCodes, err = s.repo.CountRecoveryCodes(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return status, nil
+}
+
+// BeginEnrollment təsdiq gözləyən TOTP açarını qaytarır; açar yoxdursa yenisini yaradır
+func (s *AuthService) BeginEnrollment(ctx context.Context, userID int) (*Enrollment, error) {
+	user, err := s.activeUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, ErrTwoFactorEnabled
+	}
+
+	// Səhifə yenilənəndə QR kodu dəyişməsin deyə mövcud təsdiqlənməmiş açar saxlanılır
+	secret := user.TOTPSecret
+	if secret == "" {
+		if secret, err = totp.GenerateSecret(); err != nil {
+			return nil, err
+		}
+		if err := s.repo.SetTOTPSecret(ctx, userID, secret); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Enrollment{
+		Secret: secret,
+		URI:    totp.URI(Issuer, user.Username, secret),
+	}, nil
+}
+
+// ConfirmEnrollment autentifikator tətbiqindən gələn kodu yoxlayır, iki faktorlu autentifikasiyanı
+// aktivləşdirir və bir dəfə göstəriləcək bərpa kodlarını qaytarır
+func (s *AuthService) ConfirmEnrollment(ctx context.Context, userID int, code, ip string) ([]string, error) {
+	user, err := s.activeUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, ErrTwoFactorEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, ErrTwoFactorDisabled
+	}
+
+	var step int64
+	err = s.throttledCheck(ctx, user.Username, ip, func() (bool, error) {
+		var ok bool
+		step, ok = totp.Validate(user.TOTPSecret, code, s.now())
+		return ok, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.EnableTOTP(ctx, userID, step, hashes); err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(ctx, audit.Event{
+		ActorUserID: &user.ID,
+		Action:      "auth.2fa_enabled",
+		EntityType:  "user",
+		EntityID:    user.Username,
+	})
+
+	return codes, nil
+}
+
+// DisableTwoFactor cari TOTP kodu ilə təsdiqlədikdən sonra iki faktorlu autentifikasiyanı söndürür
+func (s *AuthService) DisableTwoFactor(ctx context.Context, userID int, code, ip string) error {
+	user, err := s.activeUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.TOTPEnabled {
+		return ErrTwoFactorDisabled
+	}
+
+	required, err := s.repo.TwoFactorRequired(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if required {
+		return ErrTwoFactorRequired
+	}
+
+	err = s.throttledCheck(ctx, user.Username, ip, func() (bool, error) {
+		return s.checkTOTP(ctx, user, code)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DisableTOTP(ctx, userID); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, audit.Event{
+		ActorUserID: &user.ID,
+		Action:      "auth.2fa_disabled",
+		EntityType:  "user",
+		EntityID:    user.Username,
+	})
+
+	return nil
+}
+
+// RegenerateRecoveryCodes cari TOTP kodu ilə təsdiqlədikdən sonra köhnə bərpa kodlarını yeniləri ilə əvəz edir
+func (s *AuthService) RegenerateRecoveryCodes(ctx context.Context, userID int, code, ip string) ([]string, error) {
+	user, err := s.activeUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TOTPEnabled {
+		return nil, ErrTwoFactorDisabled
+	}
+
+	err = s.throttledCheck(ctx, user.Username, ip, func() (bool, error) {
+		return s.checkTOTP(ctx, user, code)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(ctx, audit.Event{
+		ActorUserID: &user.ID,
+		Action:      "auth.recovery_codes_regenerated",
+		EntityType:  "user",
+		EntityID:    user.Username,
+	})
+
+	return codes, nil
+}
+
+// activeUser aktiv istifadəçini ID-yə görə qaytarır
+func (s *AuthService) activeUser(ctx context.Context, userID int) (*User, error) {
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// checkTOTP kodu yoxlayır və eyni kodun təkrar istifadəsinin qarşısını alır
+func (s *AuthService) checkTOTP(ctx context.Context, user *User, code string) (bool, error) {
+	step, ok := totp.Validate(user.TOTPSecret, code, s.now())
+	if !ok {
+		return false, nil
+	}
+	return s.repo.AdvanceTOTPStep(ctx, user.ID, step)
+}
+
+// throttledCheck hesab ayarlarını dəyişməzdən əvvəl kodu girişlə eyni sayğac üzrə yoxlayır:
+// gözləmə müddətində kod yoxlanılmır, yanlış kod isə uğursuz cəhd kimi qeydə alınır
+func (s *AuthService) throttledCheck(ctx context.Context, username, ip string, check func() (bool, error)) error {
+	a, err := s.reserveAttempt(ctx, throttleKeys(username, ip))
+	if err != nil {
+		return err
+	}
+
+	ok, err := check()
+	if err != nil {
+		return s.abortAttempt(ctx, a, err)
+	}
+
+	if !ok {
+		if err := s.failAttempt(ctx, a); err != nil {
+			return err
+		}
+		return ErrInvalidCode
+	}
+
+	return s.releaseAttempt(ctx, a)
+}
+
+// generateRecoveryCodes istifadəçiyə göstəriləcək kodları və verilənlər bazasında saxlanılacaq heşləri qaytarır
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw := make([]byte, 7)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, err
+		}
+		encoded := strings.ToLower(recoveryEncoding.EncodeToString(raw))[:10]
+		code := encoded[:5] + "-" + encoded[5:]
+
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode bərpa kodunu normallaşdırıb heşini qaytarır
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+	return securetoken.Hash(normalized)
+}
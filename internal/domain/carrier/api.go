@@ -0,0 +1,244 @@
+package carrier
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/api"
+)
+
+// VesselRequest API vasitəsilə gəmi yaratma və yeniləmə sorğusunun gövdəsidir
+type VesselRequest struct {
+	Name      string `json:"name"`
+	IMO       string `json:"imo"`
+	CarrierID *int   `json:"carrierId"`
+	Flag      string `json:"flag"`
+	IsActive  bool   `json:"isActive"`
+}
+
+// form sorğunu HTML formu ilə eyni yoxlamalardan keçən forma çevirir
+func (req VesselRequest) form() VesselForm {
+	form := VesselForm{
+		Name:     req.Name,
+		IMO:      req.IMO,
+		Flag:     req.Flag,
+		IsActive: req.IsActive,
+	}
+	if req.CarrierID != nil {
+		form.CarrierID = strconv.Itoa(*req.CarrierID)
+	}
+	return form
+}
+
+// APIHandler daşıyıcı və gəmi JSON API sorğularını işləyir
+type APIHandler struct {
+	service Service
+}
+
+// NewAPIHandler yeni daşıyıcı API işləyicisi yaradır
+func NewAPIHandler(service Service) *APIHandler {
+	return &APIHandler{service: service}
+}
+
+// List daşıyıcı siyahısını qaytarır: ?q=, ?mode=, ?inactive=true, ?sort=, ?cursor=, ?limit=
+func (h *APIHandler) List(w http.ResponseWriter, r *http.Request) {
+	params, err := api.ParseList(r, carrierSortColumns)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	inactive, err := api.QueryBool(r, "inactive")
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	mode := r.URL.Query().Get("mode")
+	if _, ok := ModeLabels[mode]; mode != "" && !ok {
+		api.WriteError(w, api.NewError(http.StatusBadRequest, api.CodeBadRequest, "naməlum nəqliyyat növü: "+mode))
+		return
+	}
+
+	carriers, err := h.service.ListCarriers(r.Context(), ListFilter{
+		Query:           r.URL.Query().Get("q"),
+		Mode:            mode,
+		IncludeInactive: inactive,
+		Sort:            params.Sort,
+		Page:            params.Page,
+		PerPage:         params.PerPage,
+	})
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteList(w, carriers.Items, carriers.Total, params)
+}
+
+// Get daşıyıcını qaytarır
+func (h *APIHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	carrier, err := h.service.GetCarrier(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, carrier)
+}
+
+// Create yeni daşıyıcı yaradır
+func (h *APIHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req CarrierForm
+	if err := api.Decode(w, r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	carrier, err := h.service.CreateCarrier(r.Context(), req)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusCreated, carrier)
+}
+
+// Update daşıyıcının məlumatlarını tam əvəz edir
+func (h *APIHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	var req CarrierForm
+	if err := api.Decode(w, r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	carrier, err := h.service.UpdateCarrier(r.Context(), id, req)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, carrier)
+}
+
+// VesselList gəmi siyahısını qaytarır: ?q=, ?carrierId=, ?inactive=true, ?sort=, ?cursor=, ?limit=
+func (h *APIHandler) VesselList(w http.ResponseWriter, r *http.Request) {
+	params, err := api.ParseList(r, vesselSortColumns)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	inactive, err := api.QueryBool(r, "inactive")
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	var carrierID int
+	if value := r.URL.Query().Get("carrierId"); value != "" {
+		carrierID, err = strconv.Atoi(value)
+		if err != nil || carrierID <= 0 {
+			api.WriteError(w, api.NewError(http.StatusBadRequest, api.CodeBadRequest, "carrierId müsbət tam ədəd olmalıdır"))
+			return
+		}
+	}
+
+	vessels, err := h.service.ListVessels(r.Context(), ListFilter{
+		Query:           r.URL.Query().Get("q"),
+		CarrierID:       carrierID,
+		IncludeInactive: inactive,
+		Sort:            params.Sort,
+		Page:            params.Page,
+		PerPage:         params.PerPage,
+	})
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteList(w, vessels.Items, vessels.Total, params)
+}
+
+// VesselGet gəmini qaytarır
+func (h *APIHandler) VesselGet(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	vessel, err := h.service.GetVessel(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, vessel)
+}
+
+// VesselCreate yeni gəmi yaradır
+func (h *APIHandler) VesselCreate(w http.ResponseWriter, r *http.Request) {
+	var req VesselRequest
+	if err := api.Decode(w, r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	vessel, err := h.service.CreateVessel(r.Context(), req.form())
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusCreated, vessel)
+}
+
+// VesselUpdate gəminin məlumatlarını tam əvəz edir
+func (h *APIHandler) VesselUpdate(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	var req VesselRequest
+	if err := api.Decode(w, r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	vessel, err := h.service.UpdateVessel(r.Context(), id, req.form())
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, vessel)
+}
+
+// writeAPIError servis xətasını uyğun HTTP statusu ilə yazır
+func writeAPIError(w http.ResponseWriter, err error) {
+	var validationErr *ValidationError
+	switch {
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrVesselNotFound):
+		api.WriteError(w, api.NewError(http.StatusNotFound, api.CodeNotFound, err.Error()))
+	case errors.As(err, &validationErr):
+		api.WriteError(w, api.NewError(http.StatusUnprocessableEntity, api.CodeValidation, validationErr.Message))
+	default:
+		api.WriteError(w, err)
+	}
+}
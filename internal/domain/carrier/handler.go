@@ -0,0 +1,345 @@
+package carrier
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/Zam83-AZE/logistics_system/pkg/view"
+	"github.com/gorilla/mux"
+)
+
+// Handler daşıyıcı və gəmi HTTP sorğularını işləyir
+type Handler struct {
+	service        Service
+	tmpl           *template.Template
+	sessionManager *session.Manager
+}
+
+// NewHandler yeni daşıyıcı işləyicisi yaradır
+func NewHandler(service Service, tmpl *template.Template, sessionManager *session.Manager) *Handler {
+	return &Handler{
+		service:        service,
+		tmpl:           tmpl,
+		sessionManager: sessionManager,
+	}
+}
+
+// List daşıyıcı siyahısını axtarış, filtr və səhifələmə ilə göstərir
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	filter := ListFilter{
+		Query:           r.URL.Query().Get("q"),
+		Mode:            r.URL.Query().Get("mode"),
+		IncludeInactive: r.URL.Query().Get("inactive") == "1",
+		Page:            page,
+	}
+
+	carriers, err := h.service.ListCarriers(ctx, filter)
+	if err != nil {
+		http.Error(w, "Daşıyıcı siyahısı əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	data := CarrierListPage{
+		Carriers:    *carriers,
+		Filter:      filter,
+		Modes:       ModeOptions(),
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "carriers",
+	}
+
+	view.Render(w, r, h.tmpl, "carrier/list.html", data)
+}
+
+// New yeni daşıyıcı formunu göstərir
+func (h *Handler) New(w http.ResponseWriter, r *http.Request) {
+	h.renderCarrierForm(w, r, CarrierForm{Mode: ModeSea, IsActive: true}, 0, http.StatusOK, "")
+}
+
+// Create yeni daşıyıcı yaradır
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	form := parseCarrierForm(r)
+
+	carrier, err := h.service.CreateCarrier(r.Context(), form)
+	if err != nil {
+		h.renderCarrierFormError(w, r, form, 0, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/carriers/%d", carrier.ID), http.StatusSeeOther)
+}
+
+// Detail daşıyıcının detallarını və ona məxsus gəmiləri göstərir
+func (h *Handler) Detail(w http.ResponseWriter, r *http.Request) {
+	carrier, ok := h.loadCarrier(w, r)
+	if !ok {
+		return
+	}
+
+	vessels, err := h.service.ListVessels(r.Context(), ListFilter{
+		CarrierID:       carrier.ID,
+		IncludeInactive: true,
+		PerPage:         100,
+	})
+	if err != nil {
+		http.Error(w, "Gəmi siyahısı əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	data := CarrierDetailPage{
+		Carrier:     *carrier,
+		Vessels:     vessels.Items,
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "carriers",
+	}
+
+	view.Render(w, r, h.tmpl, "carrier/detail.html", data)
+}
+
+// Edit mövcud daşıyıcının redaktə formunu göstərir
+func (h *Handler) Edit(w http.ResponseWriter, r *http.Request) {
+	carrier, ok := h.loadCarrier(w, r)
+	if !ok {
+		return
+	}
+
+	h.renderCarrierForm(w, r, FormFromCarrier(carrier), carrier.ID, http.StatusOK, "")
+}
+
+// Update mövcud daşıyıcının məlumatlarını yeniləyir
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	carrier, ok := h.loadCarrier(w, r)
+	if !ok {
+		return
+	}
+
+	form := parseCarrierForm(r)
+
+	if _, err := h.service.UpdateCarrier(r.Context(), carrier.ID, form); err != nil {
+		h.renderCarrierFormError(w, r, form, carrier.ID, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/carriers/%d", carrier.ID), http.StatusSeeOther)
+}
+
+// VesselList gəmi siyahısını axtarış, daşıyıcı filtri və səhifələmə ilə göstərir
+func (h *Handler) VesselList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	carrierID, _ := strconv.Atoi(r.URL.Query().Get("carrier_id"))
+	filter := ListFilter{
+		Query:           r.URL.Query().Get("q"),
+		CarrierID:       carrierID,
+		IncludeInactive: r.URL.Query().Get("inactive") == "1",
+		Page:            page,
+	}
+
+	vessels, err := h.service.ListVessels(ctx, filter)
+	if err != nil {
+		http.Error(w, "Gəmi siyahısı əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	carriers, err := h.service.CarrierOptions(ctx)
+	if err != nil {
+		http.Error(w, "Daşıyıcı siyahısı əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	data := VesselListPage{
+		Vessels:     *vessels,
+		Filter:      filter,
+		Carriers:    carriers,
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "vessels",
+	}
+
+	view.Render(w, r, h.tmpl, "carrier/vessel_list.html", data)
+}
+
+// VesselNew yeni gəmi formunu göstərir; carrier_id parametri daşıyıcını əvvəlcədən seçir
+func (h *Handler) VesselNew(w http.ResponseWriter, r *http.Request) {
+	form := VesselForm{CarrierID: r.URL.Query().Get("carrier_id"), IsActive: true}
+	h.renderVesselForm(w, r, form, 0, http.StatusOK, "")
+}
+
+// VesselCreate yeni gəmi yaradır
+func (h *Handler) VesselCreate(w http.ResponseWriter, r *http.Request) {
+	form := parseVesselForm(r)
+
+	if _, err := h.service.CreateVessel(r.Context(), form); err != nil {
+		h.renderVesselFormError(w, r, form, 0, err)
+		return
+	}
+
+	http.Redirect(w, r, "/vessels", http.StatusSeeOther)
+}
+
+// VesselEdit mövcud gəminin redaktə formunu göstərir
+func (h *Handler) VesselEdit(w http.ResponseWriter, r *http.Request) {
+	vessel, ok := h.loadVessel(w, r)
+	if !ok {
+		return
+	}
+
+	h.renderVesselForm(w, r, FormFromVessel(vessel), vessel.ID, http.StatusOK, "")
+}
+
+// VesselUpdate mövcud gəminin məlumatlarını yeniləyir
+func (h *Handler) VesselUpdate(w http.ResponseWriter, r *http.Request) {
+	vessel, ok := h.loadVessel(w, r)
+	if !ok {
+		return
+	}
+
+	form := parseVesselForm(r)
+
+	if _, err := h.service.UpdateVessel(r.Context(), vessel.ID, form); err != nil {
+		h.renderVesselFormError(w, r, form, vessel.ID, err)
+		return
+	}
+
+	http.Redirect(w, r, "/vessels", http.StatusSeeOther)
+}
+
+// loadCarrier URL-dəki ID-yə görə daşıyıcını əldə edir, tapılmadıqda cavabı özü yazır
+func (h *Handler) loadCarrier(w http.ResponseWriter, r *http.Request) (*Carrier, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return nil, false
+	}
+
+	carrier, err := h.service.GetCarrier(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			http.NotFound(w, r)
+			return nil, false
+		}
+		http.Error(w, "Daşıyıcı məlumatlarını əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	return carrier, true
+}
+
+// loadVessel URL-dəki ID-yə görə gəmini əldə edir, tapılmadıqda cavabı özü yazır
+func (h *Handler) loadVessel(w http.ResponseWriter, r *http.Request) (*Vessel, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return nil, false
+	}
+
+	vessel, err := h.service.GetVessel(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrVesselNotFound) {
+			http.NotFound(w, r)
+			return nil, false
+		}
+		http.Error(w, "Gəmi məlumatlarını əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	return vessel, true
+}
+
+// renderCarrierFormError daşıyıcı formunu xəta mesajı ilə yenidən göstərir
+func (h *Handler) renderCarrierFormError(w http.ResponseWriter, r *http.Request, form CarrierForm, id int, err error) {
+	if errors.Is(err, ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+
+	var validationErr *ValidationError
+	message := "Daşıyıcı məlumatlarını saxlayarkən xəta baş verdi"
+	if errors.As(err, &validationErr) {
+		message = validationErr.Message
+	}
+
+	h.renderCarrierForm(w, r, form, id, http.StatusUnprocessableEntity, message)
+}
+
+// renderCarrierForm daşıyıcı formunu göstərir; id sıfır olduqda yeni daşıyıcı formudur
+func (h *Handler) renderCarrierForm(w http.ResponseWriter, r *http.Request, form CarrierForm, id int, status int, message string) {
+	data := CarrierFormPage{
+		Form:        form,
+		CarrierID:   id,
+		IsEdit:      id > 0,
+		Modes:       ModeOptions(),
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "carriers",
+		Error:       message,
+	}
+
+	w.WriteHeader(status)
+	view.Render(w, r, h.tmpl, "carrier/form.html", data)
+}
+
+// renderVesselFormError gəmi formunu xəta mesajı ilə yenidən göstərir
+func (h *Handler) renderVesselFormError(w http.ResponseWriter, r *http.Request, form VesselForm, id int, err error) {
+	if errors.Is(err, ErrVesselNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+
+	var validationErr *ValidationError
+	message := "Gəmi məlumatlarını saxlayarkən xəta baş verdi"
+	if errors.As(err, &validationErr) {
+		message = validationErr.Message
+	}
+
+	h.renderVesselForm(w, r, form, id, http.StatusUnprocessableEntity, message)
+}
+
+// renderVesselForm gəmi formunu daşıyıcı siyahısı ilə göstərir; id sıfır olduqda yeni gəmi formudur
+func (h *Handler) renderVesselForm(w http.ResponseWriter, r *http.Request, form VesselForm, id int, status int, message string) {
+	carriers, err := h.service.CarrierOptions(r.Context())
+	if err != nil {
+		http.Error(w, "Daşıyıcı siyahısı əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	data := VesselFormPage{
+		Form:        form,
+		VesselID:    id,
+		IsEdit:      id > 0,
+		Carriers:    carriers,
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "vessels",
+		Error:       message,
+	}
+
+	w.WriteHeader(status)
+	view.Render(w, r, h.tmpl, "carrier/vessel_form.html", data)
+}
+
+// parseCarrierForm sorğudan daşıyıcı formunun dəyərlərini oxuyur
+func parseCarrierForm(r *http.Request) CarrierForm {
+	return CarrierForm{
+		Name:     r.FormValue("name"),
+		Mode:     r.FormValue("mode"),
+		SCAC:     r.FormValue("scac"),
+		IATA:     r.FormValue("iata"),
+		IsActive: r.FormValue("is_active") == "1",
+	}
+}
+
+// parseVesselForm sorğudan gəmi formunun dəyərlərini oxuyur
+func parseVesselForm(r *http.Request) VesselForm {
+	return VesselForm{
+		Name:      r.FormValue("name"),
+		IMO:       r.FormValue("imo"),
+		CarrierID: r.FormValue("carrier_id"),
+		Flag:      r.FormValue("flag"),
+		IsActive:  r.FormValue("is_active") == "1",
+	}
+}
@@ -0,0 +1,34 @@
+package carrier
+
+import (
+	"strings"
+)
+
+// NormalizeIMO IMO nömrəsindən "IMO" prefiksini və boşluqları silir
+func NormalizeIMO(value string) string {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	value = strings.TrimPrefix(value, "IMO")
+	return strings.ReplaceAll(value, " ", "")
+}
+
+// ValidIMO 7 rəqəmli IMO nömrəsinin yoxlama rəqəmini yoxlayır: ilk altı rəqəm uyğun olaraq
+// 7, 6, 5, 4, 3, 2 çəkilərinə vurulur və cəmin son rəqəmi yeddinci rəqəmə bərabər olmalıdır
+// (məs. 9074729: 9×7 + 0×6 + 7×5 + 4×4 + 7×3 + 2×2 = 139).
+func ValidIMO(imo string) bool {
+	if len(imo) != 7 {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < 7; i++ {
+		c := imo[i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		if i < 6 {
+			sum += int(c-'0') * (7 - i)
+		}
+	}
+
+	return sum%10 == int(imo[6]-'0')
+}
@@ -0,0 +1,51 @@
+package carrier
+
+import "testing"
+
+// TestValidIMO IMO gəmi nömrəsinin yoxlama rəqəmini məlum nömrələr üzrə yoxlayır
+func TestValidIMO(t *testing.T) {
+	tests := []struct {
+		imo  string
+		want bool
+	}{
+		{imo: "9074729", want: true},
+		{imo: "9321483", want: true},
+		// Yoxlama rəqəmi 0 (cəm 120)
+		{imo: "9811000", want: true},
+		{imo: "9074728", want: false},
+		{imo: "9321484", want: false},
+		{imo: "907472", want: false},
+		{imo: "90747290", want: false},
+		{imo: "907472A", want: false},
+		{imo: "IMO9074729", want: false},
+		{imo: "", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidIMO(tt.imo); got != tt.want {
+			t.Errorf("ValidIMO(%q) = %v, gözlənilən %v", tt.imo, got, tt.want)
+		}
+	}
+}
+
+// TestNormalizeIMO prefiksin və boşluqların silinməsini yoxlayır
+func TestNormalizeIMO(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "9074729", want: "9074729"},
+		{value: " imo 9074729 ", want: "9074729"},
+		{value: "IMO9 074 729", want: "9074729"},
+	}
+
+	for _, tt := range tests {
+		got := NormalizeIMO(tt.value)
+		if got != tt.want {
+			t.Errorf("NormalizeIMO(%q) = %q, gözlənilən %q", tt.value, got, tt.want)
+		}
+		if !ValidIMO(got) {
+			t.Errorf("ValidIMO(NormalizeIMO(%q)) = false", tt.value)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package carrier
+
+import (
+	"time"
+)
+
+// Nəqliyyat növləri
+const (
+	ModeSea  = "sea"
+	ModeRail = "rail"
+	ModeRoad = "road"
+	ModeAir  = "air"
+)
+
+// ModeLabels nəqliyyat növlərinin istifadəçi üçün adlarını saxlayır
+var ModeLabels = map[string]string{
+	ModeSea:  "Dəniz",
+	ModeRail: "Dəmir yolu",
+	ModeRoad: "Avtomobil",
+	ModeAir:  "Hava",
+}
+
+// ModeOption formda göstəriləcək nəqliyyat növü seçimini təmsil edir
+type ModeOption struct {
+	Value string
+	Label string
+}
+
+// ModeOptions bütün nəqliyyat növlərini formda göstəriləcək sıra ilə qaytarır
+func ModeOptions() []ModeOption {
+	modes := []string{ModeSea, ModeRail, ModeRoad, ModeAir}
+
+	options := make([]ModeOption, 0, len(modes))
+	for _, mode := range modes {
+		options = append(options, ModeOption{Value: mode, Label: ModeLabels[mode]})
+	}
+
+	return options
+}
+
+// ModeLabel nəqliyyat növünün istifadəçi üçün adını qaytarır
+func ModeLabel(mode string) string {
+	if label, ok := ModeLabels[mode]; ok {
+		return label
+	}
+	return mode
+}
+
+// Carrier verilənlər bazasından gələn daşıyıcı məlumatlarını təmsil edir.
+// SCAC dəniz və avtomobil daşıyıcılarının, IATA isə aviaşirkətlərin kodudur.
+type Carrier struct {
+	ID        int       `db:"id" json:"id"`
+	Name      string    `db:"name" json:"name"`
+	Mode      string    `db:"mode" json:"mode"`
+	SCAC      string    `db:"scac" json:"scac"`
+	IATA      string    `db:"iata" json:"iata"`
+	IsActive  bool      `db:"is_active" json:"isActive"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// ModeLabel daşıyıcının nəqliyyat növünün adını qaytarır
+func (c Carrier) ModeLabel() string {
+	return ModeLabel(c.Mode)
+}
+
+// Vessel verilənlər bazasından gələn gəmi məlumatlarını təmsil edir
+type Vessel struct {
+	ID          int       `db:"id" json:"id"`
+	Name        string    `db:"name" json:"name"`
+	IMO         string    `db:"imo" json:"imo"`
+	CarrierID   *int      `db:"carrier_id" json:"carrierId"`
+	CarrierName *string   `db:"carrier_name" json:"carrierName"`
+	Flag        string    `db:"flag" json:"flag"`
+	IsActive    bool      `db:"is_active" json:"isActive"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// ListFilter daşıyıcı və gəmi siyahıları üçün axtarış və səhifələmə parametrlərini saxlayır.
+// Mode yalnız daşıyıcılara, CarrierID yalnız gəmilərə tətbiq edilir.
+type ListFilter struct {
+	Query           string
+	Mode            string
+	CarrierID       int
+	IncludeInactive bool
+	Sort            string
+	Page            int
+	PerPage         int
+}
+
+// CarrierList səhifələnmiş daşıyıcı siyahısını təmsil edir
+type CarrierList struct {
+	Items   []Carrier
+	Total   int
+	Page    int
+	PerPage int
+}
+
+// HasPrev əvvəlki səhifənin olub-olmadığını göstərir
+func (l CarrierList) HasPrev() bool {
+	return l.Page > 1
+}
+
+// HasNext növbəti səhifənin olub-olmadığını göstərir
+func (l CarrierList) HasNext() bool {
+	return l.Page*l.PerPage < l.Total
+}
+
+// PrevPage əvvəlki səhifənin nömrəsini qaytarır
+func (l CarrierList) PrevPage() int {
+	return l.Page - 1
+}
+
+// NextPage növbəti səhifənin nömrəsini qaytarır
+func (l CarrierList) NextPage() int {
+	return l.Page + 1
+}
+
+// VesselList səhifələnmiş gəmi siyahısını təmsil edir
+type VesselList struct {
+	Items   []Vessel
+	Total   int
+	Page    int
+	PerPage int
+}
+
+// HasPrev əvvəlki səhifənin olub-olmadığını göstərir
+func (l VesselList) HasPrev() bool {
+	return l.Page > 1
+}
+
+// HasNext növbəti səhifənin olub-olmadığını göstərir
+func (l VesselList) HasNext() bool {
+	return l.Page*l.PerPage < l.Total
+}
+
+// PrevPage əvvəlki səhifənin nömrəsini qaytarır
+func (l VesselList) PrevPage() int {
+	return l.Page - 1
+}
+
+// NextPage növbəti səhifənin nömrəsini qaytarır
+func (l VesselList) NextPage() int {
+	return l.Page + 1
+}
+
+// CarrierForm daşıyıcı yaratma və redaktə formunu təmsil edir; API sorğularının gövdəsi də eyni formadadır
+type CarrierForm struct {
+	Name     string `json:"name"`
+	Mode     string `json:"mode"`
+	SCAC     string `json:"scac"`
+	IATA     string `json:"iata"`
+	IsActive bool   `json:"isActive"`
+}
+
+// VesselForm gəmi yaratma və redaktə formunu təmsil edir
+type VesselForm struct {
+	Name      string
+	IMO       string
+	CarrierID string
+	Flag      string
+	IsActive  bool
+}
+
+// CarrierOption formda seçilə bilən daşıyıcını təmsil edir
+type CarrierOption struct {
+	ID   int    `db:"id"`
+	Name string `db:"name"`
+	Mode string `db:"mode"`
+}
+
+// CarrierListPage daşıyıcı siyahısı səhifəsi üçün məlumatları təmsil edir
+type CarrierListPage struct {
+	Carriers    CarrierList
+	Filter      ListFilter
+	Modes       []ModeOption
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// CarrierFormPage daşıyıcı formu səhifəsi üçün məlumatları təmsil edir
+type CarrierFormPage struct {
+	Form        CarrierForm
+	CarrierID   int
+	IsEdit      bool
+	Modes       []ModeOption
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// CarrierDetailPage daşıyıcı detalları səhifəsi üçün məlumatları təmsil edir
+type CarrierDetailPage struct {
+	Carrier     Carrier
+	Vessels     []Vessel
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// VesselListPage gəmi siyahısı səhifəsi üçün məlumatları təmsil edir
+type VesselListPage struct {
+	Vessels     VesselList
+	Filter      ListFilter
+	Carriers    []CarrierOption
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// VesselFormPage gəmi formu səhifəsi üçün məlumatları təmsil edir
+type VesselFormPage struct {
+	Form        VesselForm
+	VesselID    int
+	IsEdit      bool
+	Carriers    []CarrierOption
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// FormFromCarrier mövcud daşıyıcıdan redaktə formu yaradır
+func FormFromCarrier(c *Carrier) CarrierForm {
+	return CarrierForm{
+		Name:     c.Name,
+		Mode:     c.Mode,
+		SCAC:     c.SCAC,
+		IATA:     c.IATA,
+		IsActive: c.IsActive,
+	}
+}
+
+// FormFromVessel mövcud gəmidən redaktə formu yaradır
+func FormFromVessel(v *Vessel) VesselForm {
+	form := VesselForm{
+		Name:     v.Name,
+		IMO:      v.IMO,
+		Flag:     v.Flag,
+		IsActive: v.IsActive,
+	}
+	if v.CarrierID != nil {
+		form.CarrierID = itoa(*v.CarrierID)
+	}
+	return form
+}
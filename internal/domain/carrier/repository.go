@@ -0,0 +1,290 @@
+package carrier
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/db"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// PostgreSQL məhdudiyyət pozulması kodları
+const (
+	uniqueViolation     = "23505"
+	foreignKeyViolation = "23503"
+)
+
+// Repository daşıyıcı və gəmi məlumatları əməliyyatlarını müəyyən edir
+type Repository interface {
+	ListCarriers(ctx context.Context, filter ListFilter) ([]Carrier, int, error)
+	GetCarrier(ctx context.Context, id int) (*Carrier, error)
+	CreateCarrier(ctx context.Context, carrier *Carrier) error
+	UpdateCarrier(ctx context.Context, carrier *Carrier) error
+	CarrierOptions(ctx context.Context) ([]CarrierOption, error)
+
+	ListVessels(ctx context.Context, filter ListFilter) ([]Vessel, int, error)
+	GetVessel(ctx context.Context, id int) (*Vessel, error)
+	CreateVessel(ctx context.Context, vessel *Vessel) error
+	UpdateVessel(ctx context.Context, vessel *Vessel) error
+}
+
+// carrierSortColumns daşıyıcı siyahısının sıralana biləcəyi sahələri saxlayır
+var carrierSortColumns = map[string]string{
+	"name":      "name",
+	"mode":      "mode",
+	"scac":      "scac",
+	"createdAt": "created_at",
+	"updatedAt": "updated_at",
+}
+
+// vesselSortColumns gəmi siyahısının sıralana biləcəyi sahələri saxlayır
+var vesselSortColumns = map[string]string{
+	"name":      "v.name",
+	"imo":       "v.imo",
+	"createdAt": "v.created_at",
+	"updatedAt": "v.updated_at",
+}
+
+const selectVessel = `
+	SELECT v.id, v.name, v.imo, v.carrier_id, c.name AS carrier_name, v.flag, v.is_active,
+		v.created_at, v.updated_at
+	FROM vessels v
+	LEFT JOIN carriers c ON c.id = v.carrier_id
+`
+
+// PostgresRepository Repository interfeysini həyata keçirir
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRepository yeni PostgresRepository yaradır
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+// ListCarriers filtrə uyğun daşıyıcıları və ümumi sayı əldə edir
+func (r *PostgresRepository) ListCarriers(ctx context.Context, filter ListFilter) ([]Carrier, int, error) {
+	var conditions []string
+	var args []interface{}
+
+	if !filter.IncludeInactive {
+		conditions = append(conditions, "is_active = true")
+	}
+
+	if filter.Mode != "" {
+		args = append(args, filter.Mode)
+		conditions = append(conditions, "mode = $"+strconv.Itoa(len(args)))
+	}
+
+	if q := strings.TrimSpace(filter.Query); q != "" {
+		args = append(args, "%"+q+"%")
+		n := strconv.Itoa(len(args))
+		conditions = append(conditions, "(name ILIKE $"+n+" OR scac ILIKE $"+n+" OR iata ILIKE $"+n+")")
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM carriers"+where, args...); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT id, name, mode, scac, iata, is_active, created_at, updated_at
+		FROM carriers` + where + `
+		ORDER BY ` + db.OrderBy(filter.Sort, carrierSortColumns, "name, id", "id") + `
+		LIMIT $` + strconv.Itoa(len(args)+1) + ` OFFSET $` + strconv.Itoa(len(args)+2)
+
+	args = append(args, filter.PerPage, (filter.Page-1)*filter.PerPage)
+
+	carriers := []Carrier{}
+	if err := r.db.SelectContext(ctx, &carriers, query, args...); err != nil {
+		return nil, 0, err
+	}
+
+	return carriers, total, nil
+}
+
+// GetCarrier daşıyıcını ID-yə görə əldə edir
+func (r *PostgresRepository) GetCarrier(ctx context.Context, id int) (*Carrier, error) {
+	query := `
+		SELECT id, name, mode, scac, iata, is_active, created_at, updated_at
+		FROM carriers
+		WHERE id = $1
+	`
+
+	carrier := &Carrier{}
+	err := r.db.GetContext(ctx, carrier, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Daşıyıcı tapılmadı
+		}
+		return nil, err
+	}
+
+	return carrier, nil
+}
+
+// CreateCarrier yeni daşıyıcı əlavə edir
+func (r *PostgresRepository) CreateCarrier(ctx context.Context, carrier *Carrier) error {
+	query := `
+		INSERT INTO carriers (name, mode, scac, iata, is_active)
+		VALUES (:name, :mode, :scac, :iata, :is_active)
+		RETURNING id, created_at, updated_at
+	`
+
+	rows, err := r.db.NamedQueryContext(ctx, query, carrier)
+	if err != nil {
+		return mapError(err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		return rows.Scan(&carrier.ID, &carrier.CreatedAt, &carrier.UpdatedAt)
+	}
+
+	return rows.Err()
+}
+
+// UpdateCarrier mövcud daşıyıcının məlumatlarını yeniləyir
+func (r *PostgresRepository) UpdateCarrier(ctx context.Context, carrier *Carrier) error {
+	query := `
+		UPDATE carriers
+		SET name = :name, mode = :mode, scac = :scac, iata = :iata, is_active = :is_active,
+			updated_at = NOW()
+		WHERE id = :id
+	`
+
+	_, err := r.db.NamedExecContext(ctx, query, carrier)
+	return mapError(err)
+}
+
+// CarrierOptions formlarda seçilə bilən aktiv daşıyıcıları əldə edir
+func (r *PostgresRepository) CarrierOptions(ctx context.Context) ([]CarrierOption, error) {
+	options := []CarrierOption{}
+	err := r.db.SelectContext(ctx, &options, `SELECT id, name, mode FROM carriers WHERE is_active = true ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
+// ListVessels filtrə uyğun gəmiləri və ümumi sayı əldə edir
+func (r *PostgresRepository) ListVessels(ctx context.Context, filter ListFilter) ([]Vessel, int, error) {
+	var conditions []string
+	var args []interface{}
+
+	if !filter.IncludeInactive {
+		conditions = append(conditions, "v.is_active = true")
+	}
+
+	if filter.CarrierID > 0 {
+		args = append(args, filter.CarrierID)
+		conditions = append(conditions, "v.carrier_id = $"+strconv.Itoa(len(args)))
+	}
+
+	if q := strings.TrimSpace(filter.Query); q != "" {
+		args = append(args, "%"+q+"%")
+		n := strconv.Itoa(len(args))
+		conditions = append(conditions, "(v.name ILIKE $"+n+" OR v.imo ILIKE $"+n+")")
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM vessels v"+where, args...); err != nil {
+		return nil, 0, err
+	}
+
+	query := selectVessel + where + `
+		ORDER BY ` + db.OrderBy(filter.Sort, vesselSortColumns, "v.name, v.id", "v.id") + `
+		LIMIT $` + strconv.Itoa(len(args)+1) + ` OFFSET $` + strconv.Itoa(len(args)+2)
+
+	args = append(args, filter.PerPage, (filter.Page-1)*filter.PerPage)
+
+	vessels := []Vessel{}
+	if err := r.db.SelectContext(ctx, &vessels, query, args...); err != nil {
+		return nil, 0, err
+	}
+
+	return vessels, total, nil
+}
+
+// GetVessel gəmini ID-yə görə əldə edir
+func (r *PostgresRepository) GetVessel(ctx context.Context, id int) (*Vessel, error) {
+	vessel := &Vessel{}
+	err := r.db.GetContext(ctx, vessel, selectVessel+" WHERE v.id = $1", id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Gəmi tapılmadı
+		}
+		return nil, err
+	}
+
+	return vessel, nil
+}
+
+// CreateVessel yeni gəmi əlavə edir
+func (r *PostgresRepository) CreateVessel(ctx context.Context, vessel *Vessel) error {
+	query := `
+		INSERT INTO vessels (name, imo, carrier_id, flag, is_active)
+		VALUES (:name, :imo, :carrier_id, :flag, :is_active)
+		RETURNING id, created_at, updated_at
+	`
+
+	rows, err := r.db.NamedQueryContext(ctx, query, vessel)
+	if err != nil {
+		return mapError(err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		return rows.Scan(&vessel.ID, &vessel.CreatedAt, &vessel.UpdatedAt)
+	}
+
+	return rows.Err()
+}
+
+// UpdateVessel mövcud gəminin məlumatlarını yeniləyir
+func (r *PostgresRepository) UpdateVessel(ctx context.Context, vessel *Vessel) error {
+	query := `
+		UPDATE vessels
+		SET name = :name, imo = :imo, carrier_id = :carrier_id, flag = :flag, is_active = :is_active,
+			updated_at = NOW()
+		WHERE id = :id
+	`
+
+	_, err := r.db.NamedExecContext(ctx, query, vessel)
+	return mapError(err)
+}
+
+// mapError verilənlər bazası xətalarını domen xətalarına çevirir
+func mapError(err error) error {
+	pqErr, ok := err.(*pq.Error)
+	if !ok {
+		return err
+	}
+
+	switch {
+	case pqErr.Code == uniqueViolation && pqErr.Constraint == "idx_carriers_scac":
+		return &ValidationError{Message: "bu SCAC kodu ilə daşıyıcı artıq mövcuddur"}
+	case pqErr.Code == uniqueViolation && pqErr.Constraint == "idx_carriers_iata":
+		return &ValidationError{Message: "bu IATA kodu ilə daşıyıcı artıq mövcuddur"}
+	case pqErr.Code == uniqueViolation && pqErr.Constraint == "vessels_imo_key":
+		return &ValidationError{Message: "bu IMO nömrəsi ilə gəmi artıq mövcuddur"}
+	case pqErr.Code == foreignKeyViolation:
+		return &ValidationError{Message: "seçilmiş daşıyıcı mövcud deyil"}
+	}
+
+	return err
+}
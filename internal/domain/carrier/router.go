@@ -0,0 +1,111 @@
+package carrier
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/rbac"
+	"github.com/Zam83-AZE/logistics_system/internal/middleware"
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+	"github.com/Zam83-AZE/logistics_system/pkg/openapi"
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/gorilla/mux"
+	"github.com/jmoiron/sqlx"
+)
+
+// RegisterRoutes daşıyıcı və gəmi marşrutlarını qeydə alır
+func RegisterRoutes(router *mux.Router, db *sqlx.DB, tmpl *template.Template, sessionManager *session.Manager) {
+	repo := NewPostgresRepository(db)
+	service := NewCarrierService(repo, audit.NewRecorder(db))
+	handler := NewHandler(service, tmpl, sessionManager)
+
+	// İcazə yoxlamaları
+	canView := middleware.RequirePermission(rbac.SchedulesView)
+	canManage := middleware.RequirePermission(rbac.SchedulesManage)
+
+	// Daşıyıcılar
+	router.Handle("/carriers", canView(http.HandlerFunc(handler.List))).Methods("GET")
+	router.Handle("/carriers/new", canManage(http.HandlerFunc(handler.New))).Methods("GET")
+	router.Handle("/carriers", canManage(http.HandlerFunc(handler.Create))).Methods("POST")
+	router.Handle("/carriers/{id:[0-9]+}", canView(http.HandlerFunc(handler.Detail))).Methods("GET")
+	router.Handle("/carriers/{id:[0-9]+}/edit", canManage(http.HandlerFunc(handler.Edit))).Methods("GET")
+	router.Handle("/carriers/{id:[0-9]+}", canManage(http.HandlerFunc(handler.Update))).Methods("POST")
+
+	// Gəmilər
+	router.Handle("/vessels", canView(http.HandlerFunc(handler.VesselList))).Methods("GET")
+	router.Handle("/vessels/new", canManage(http.HandlerFunc(handler.VesselNew))).Methods("GET")
+	router.Handle("/vessels", canManage(http.HandlerFunc(handler.VesselCreate))).Methods("POST")
+	router.Handle("/vessels/{id:[0-9]+}/edit", canManage(http.HandlerFunc(handler.VesselEdit))).Methods("GET")
+	router.Handle("/vessels/{id:[0-9]+}", canManage(http.HandlerFunc(handler.VesselUpdate))).Methods("POST")
+}
+
+// RegisterAPIRoutes daşıyıcı və gəmi JSON API marşrutlarını qeydə alır
+func RegisterAPIRoutes(router *mux.Router, db *sqlx.DB) {
+	handler := NewAPIHandler(NewCarrierService(NewPostgresRepository(db), audit.NewRecorder(db)))
+
+	canView := middleware.RequirePermission(rbac.SchedulesView)
+	canManage := middleware.RequirePermission(rbac.SchedulesManage)
+
+	router.Handle("/carriers", canView(http.HandlerFunc(handler.List))).Methods("GET")
+	router.Handle("/carriers", canManage(http.HandlerFunc(handler.Create))).Methods("POST")
+	router.Handle("/carriers/{id:[0-9]+}", canView(http.HandlerFunc(handler.Get))).Methods("GET")
+	router.Handle("/carriers/{id:[0-9]+}", canManage(http.HandlerFunc(handler.Update))).Methods("PUT")
+
+	router.Handle("/vessels", canView(http.HandlerFunc(handler.VesselList))).Methods("GET")
+	router.Handle("/vessels", canManage(http.HandlerFunc(handler.VesselCreate))).Methods("POST")
+	router.Handle("/vessels/{id:[0-9]+}", canView(http.HandlerFunc(handler.VesselGet))).Methods("GET")
+	router.Handle("/vessels/{id:[0-9]+}", canManage(http.HandlerFunc(handler.VesselUpdate))).Methods("PUT")
+}
+
+// DescribeAPI daşıyıcı və gəmi API marşrutlarını OpenAPI sənədinə əlavə edir
+func DescribeAPI(spec *openapi.Spec) {
+	view := string(rbac.SchedulesView)
+	manage := string(rbac.SchedulesManage)
+
+	modes := make([]string, 0, len(ModeLabels))
+	for _, option := range ModeOptions() {
+		modes = append(modes, option.Value)
+	}
+
+	spec.Add("GET", "/carriers", openapi.Operation{
+		Summary: "Daşıyıcı siyahısı", Tag: "carriers", Permission: view,
+		Query: []openapi.Param{
+			{Name: "q", Description: "Ad, SCAC və ya IATA kodu üzrə axtarış"},
+			{Name: "mode", Description: "Nəqliyyat növü", Enum: modes},
+			{Name: "inactive", Type: "boolean", Description: "Deaktiv daşıyıcıları da göstər"},
+		},
+		List: true, Sort: carrierSortColumns, Response: Carrier{},
+	})
+	spec.Add("POST", "/carriers", openapi.Operation{
+		Summary: "Daşıyıcı yarat", Tag: "carriers", Permission: manage,
+		Request: CarrierForm{}, Response: Carrier{}, Status: http.StatusCreated,
+	})
+	spec.Add("GET", "/carriers/{id}", openapi.Operation{
+		Summary: "Daşıyıcı", Tag: "carriers", Permission: view, Response: Carrier{},
+	})
+	spec.Add("PUT", "/carriers/{id}", openapi.Operation{
+		Summary: "Daşıyıcını yenilə", Tag: "carriers", Permission: manage,
+		Request: CarrierForm{}, Response: Carrier{},
+	})
+
+	spec.Add("GET", "/vessels", openapi.Operation{
+		Summary: "Gəmi siyahısı", Tag: "vessels", Permission: view,
+		Query: []openapi.Param{
+			{Name: "q", Description: "Ad və ya IMO nömrəsi üzrə axtarış"},
+			{Name: "carrierId", Type: "integer", Description: "Daşıyıcının ID-si"},
+			{Name: "inactive", Type: "boolean", Description: "Deaktiv gəmiləri də göstər"},
+		},
+		List: true, Sort: vesselSortColumns, Response: Vessel{},
+	})
+	spec.Add("POST", "/vessels", openapi.Operation{
+		Summary: "Gəmi yarat", Tag: "vessels", Permission: manage,
+		Request: VesselRequest{}, Response: Vessel{}, Status: http.StatusCreated,
+	})
+	spec.Add("GET", "/vessels/{id}", openapi.Operation{
+		Summary: "Gəmi", Tag: "vessels", Permission: view, Response: Vessel{},
+	})
+	spec.Add("PUT", "/vessels/{id}", openapi.Operation{
+		Summary: "Gəmini yenilə", Tag: "vessels", Permission: manage,
+		Request: VesselRequest{}, Response: Vessel{},
+	})
+}
@@ -0,0 +1,318 @@
+package carrier
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+)
+
+const defaultPerPage = 20
+
+// Xətalar
+var (
+	ErrNotFound       = errors.New("daşıyıcı tapılmadı")
+	ErrVesselNotFound = errors.New("gəmi tapılmadı")
+)
+
+// ValidationError istifadəçiyə göstəriləcək form xətasını təmsil edir
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// Service daşıyıcı və gəmi biznes məntiqini müəyyən edir
+type Service interface {
+	ListCarriers(ctx context.Context, filter ListFilter) (*CarrierList, error)
+	GetCarrier(ctx context.Context, id int) (*Carrier, error)
+	CreateCarrier(ctx context.Context, form CarrierForm) (*Carrier, error)
+	UpdateCarrier(ctx context.Context, id int, form CarrierForm) (*Carrier, error)
+	CarrierOptions(ctx context.Context) ([]CarrierOption, error)
+
+	ListVessels(ctx context.Context, filter ListFilter) (*VesselList, error)
+	GetVessel(ctx context.Context, id int) (*Vessel, error)
+	CreateVessel(ctx context.Context, form VesselForm) (*Vessel, error)
+	UpdateVessel(ctx context.Context, id int, form VesselForm) (*Vessel, error)
+}
+
+// CarrierService Service interfeysini həyata keçirir
+type CarrierService struct {
+	repo  Repository
+	audit audit.Recorder
+}
+
+// NewCarrierService yeni CarrierService yaradır
+func NewCarrierService(repo Repository, recorder audit.Recorder) *CarrierService {
+	return &CarrierService{repo: repo, audit: recorder}
+}
+
+// ListCarriers filtrə uyğun səhifələnmiş daşıyıcı siyahısını qaytarır
+func (s *CarrierService) ListCarriers(ctx context.Context, filter ListFilter) (*CarrierList, error) {
+	filter = normalizeFilter(filter)
+	if _, ok := ModeLabels[filter.Mode]; !ok {
+		filter.Mode = ""
+	}
+
+	carriers, total, err := s.repo.ListCarriers(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CarrierList{
+		Items:   carriers,
+		Total:   total,
+		Page:    filter.Page,
+		PerPage: filter.PerPage,
+	}, nil
+}
+
+// GetCarrier daşıyıcını ID-yə görə qaytarır
+func (s *CarrierService) GetCarrier(ctx context.Context, id int) (*Carrier, error) {
+	carrier, err := s.repo.GetCarrier(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if carrier == nil {
+		return nil, ErrNotFound
+	}
+
+	return carrier, nil
+}
+
+// CreateCarrier formdakı məlumatlarla yeni daşıyıcı yaradır
+func (s *CarrierService) CreateCarrier(ctx context.Context, form CarrierForm) (*Carrier, error) {
+	carrier := &Carrier{}
+	if err := applyCarrierForm(carrier, form); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateCarrier(ctx, carrier); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "carrier.created", "carrier", carrier.ID, nil, carrier)
+
+	return carrier, nil
+}
+
+// UpdateCarrier mövcud daşıyıcının məlumatlarını formdakı dəyərlərlə yeniləyir
+func (s *CarrierService) UpdateCarrier(ctx context.Context, id int, form CarrierForm) (*Carrier, error) {
+	carrier, err := s.GetCarrier(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *carrier
+	if err := applyCarrierForm(carrier, form); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateCarrier(ctx, carrier); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "carrier.updated", "carrier", carrier.ID, &before, carrier)
+
+	return carrier, nil
+}
+
+// CarrierOptions formlarda seçilə bilən aktiv daşıyıcıları qaytarır
+func (s *CarrierService) CarrierOptions(ctx context.Context) ([]CarrierOption, error) {
+	return s.repo.CarrierOptions(ctx)
+}
+
+// ListVessels filtrə uyğun səhifələnmiş gəmi siyahısını qaytarır
+func (s *CarrierService) ListVessels(ctx context.Context, filter ListFilter) (*VesselList, error) {
+	filter = normalizeFilter(filter)
+
+	vessels, total, err := s.repo.ListVessels(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VesselList{
+		Items:   vessels,
+		Total:   total,
+		Page:    filter.Page,
+		PerPage: filter.PerPage,
+	}, nil
+}
+
+// GetVessel gəmini ID-yə görə qaytarır
+func (s *CarrierService) GetVessel(ctx context.Context, id int) (*Vessel, error) {
+	vessel, err := s.repo.GetVessel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if vessel == nil {
+		return nil, ErrVesselNotFound
+	}
+
+	return vessel, nil
+}
+
+// CreateVessel formdakı məlumatlarla yeni gəmi yaradır
+func (s *CarrierService) CreateVessel(ctx context.Context, form VesselForm) (*Vessel, error) {
+	vessel := &Vessel{}
+	if err := applyVesselForm(vessel, form); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateVessel(ctx, vessel); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "vessel.created", "vessel", vessel.ID, nil, vessel)
+
+	return vessel, nil
+}
+
+// UpdateVessel mövcud gəminin məlumatlarını formdakı dəyərlərlə yeniləyir
+func (s *CarrierService) UpdateVessel(ctx context.Context, id int, form VesselForm) (*Vessel, error) {
+	vessel, err := s.GetVessel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *vessel
+	if err := applyVesselForm(vessel, form); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateVessel(ctx, vessel); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "vessel.updated", "vessel", vessel.ID, &before, vessel)
+
+	return vessel, nil
+}
+
+// record daşıyıcı və ya gəmi üzərində əməliyyatı audit jurnalına yazır
+func (s *CarrierService) record(ctx context.Context, action, entityType string, id int, before, after interface{}) {
+	s.audit.Record(ctx, audit.Event{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   strconv.Itoa(id),
+		Before:     before,
+		After:      after,
+	})
+}
+
+// normalizeFilter səhifələmə parametrlərini məqbul aralığa gətirir
+func normalizeFilter(filter ListFilter) ListFilter {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PerPage < 1 || filter.PerPage > 100 {
+		filter.PerPage = defaultPerPage
+	}
+	return filter
+}
+
+// applyCarrierForm formu yoxlayır və dəyərləri daşıyıcı obyektinə köçürür
+func applyCarrierForm(carrier *Carrier, form CarrierForm) error {
+	name := strings.TrimSpace(form.Name)
+	if name == "" {
+		return &ValidationError{Message: "daşıyıcının adı tələb olunur"}
+	}
+
+	mode := strings.TrimSpace(form.Mode)
+	if _, ok := ModeLabels[mode]; !ok {
+		return &ValidationError{Message: "nəqliyyat növü seçilməlidir"}
+	}
+
+	scac := strings.ToUpper(strings.TrimSpace(form.SCAC))
+	if scac != "" && !isLetters(scac, 2, 4) {
+		return &ValidationError{Message: "SCAC kodu 2-4 latın hərfindən ibarət olmalıdır"}
+	}
+
+	iata := strings.ToUpper(strings.TrimSpace(form.IATA))
+	if iata != "" && !validIATA(iata) {
+		return &ValidationError{Message: "IATA kodu iki simvoldan (hərf və ya rəqəm) ibarət olmalıdır"}
+	}
+
+	carrier.Name = name
+	carrier.Mode = mode
+	carrier.SCAC = scac
+	carrier.IATA = iata
+	carrier.IsActive = form.IsActive
+
+	return nil
+}
+
+// applyVesselForm formu yoxlayır və dəyərləri gəmi obyektinə köçürür
+func applyVesselForm(vessel *Vessel, form VesselForm) error {
+	name := strings.TrimSpace(form.Name)
+	if name == "" {
+		return &ValidationError{Message: "gəminin adı tələb olunur"}
+	}
+
+	imo := NormalizeIMO(form.IMO)
+	if !ValidIMO(imo) {
+		return &ValidationError{Message: "IMO nömrəsi yanlışdır (7 rəqəm, sonuncusu yoxlama rəqəmidir)"}
+	}
+
+	flag := strings.ToUpper(strings.TrimSpace(form.Flag))
+	if flag != "" && !isLetters(flag, 2, 2) {
+		return &ValidationError{Message: "bayraq ISO 3166 iki hərfli ölkə kodu ilə göstərilməlidir"}
+	}
+
+	var carrierID *int
+	if value := strings.TrimSpace(form.CarrierID); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil || id <= 0 {
+			return &ValidationError{Message: "daşıyıcı yanlış seçilib"}
+		}
+		carrierID = &id
+	}
+
+	vessel.Name = name
+	vessel.IMO = imo
+	vessel.CarrierID = carrierID
+	vessel.Flag = flag
+	vessel.IsActive = form.IsActive
+
+	return nil
+}
+
+// isLetters sətrin min-max uzunluqda böyük latın hərflərindən ibarət olduğunu yoxlayır
+func isLetters(s string, min, max int) bool {
+	if len(s) < min || len(s) > max {
+		return false
+	}
+
+	for _, c := range s {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validIATA iki simvollu IATA aviaşirkət kodunu yoxlayır (hərf və rəqəmlər)
+func validIATA(s string) bool {
+	if len(s) != 2 {
+		return false
+	}
+
+	for _, c := range s {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+
+	return true
+}
+
+func itoa(n int) string {
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,142 @@
+package container
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/api"
+)
+
+// ContainerRequest API vasitəsilə konteyner yaratma və yeniləmə sorğusunun gövdəsidir
+type ContainerRequest struct {
+	Number     string `json:"number"`
+	SizeType   string `json:"sizeType"`
+	TareKg     int    `json:"tareKg"`
+	MaxGrossKg int    `json:"maxGrossKg"`
+	Owner      string `json:"owner"`
+	Status     string `json:"status"`
+	Notes      string `json:"notes"`
+}
+
+// form sorğunu HTML formu ilə eyni yoxlamalardan keçməsi üçün ContainerForm-a çevirir
+func (req ContainerRequest) form() ContainerForm {
+	return ContainerForm{
+		Number:     req.Number,
+		SizeType:   req.SizeType,
+		TareKg:     strconv.Itoa(req.TareKg),
+		MaxGrossKg: strconv.Itoa(req.MaxGrossKg),
+		Owner:      req.Owner,
+		Status:     req.Status,
+		Notes:      req.Notes,
+	}
+}
+
+// APIHandler konteyner JSON API sorğularını işləyir
+type APIHandler struct {
+	service Service
+}
+
+// NewAPIHandler yeni konteyner API işləyicisi yaradır
+func NewAPIHandler(service Service) *APIHandler {
+	return &APIHandler{service: service}
+}
+
+// List konteyner siyahısını qaytarır: ?q=, ?status=, ?sort=, ?cursor=, ?limit=
+func (h *APIHandler) List(w http.ResponseWriter, r *http.Request) {
+	params, err := api.ParseList(r, sortColumns)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	if _, ok := StatusLabels[status]; status != "" && !ok {
+		api.WriteError(w, api.NewError(http.StatusBadRequest, api.CodeBadRequest, "konteyner statusu yanlışdır"))
+		return
+	}
+
+	containers, err := h.service.List(r.Context(), ListFilter{
+		Query:   r.URL.Query().Get("q"),
+		Status:  status,
+		Sort:    params.Sort,
+		Page:    params.Page,
+		PerPage: params.PerPage,
+	})
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteList(w, containers.Items, containers.Total, params)
+}
+
+// Get konteyneri qaytarır
+func (h *APIHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	container, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, container)
+}
+
+// Create yeni konteyneri reyestrə əlavə edir
+func (h *APIHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req ContainerRequest
+	if err := api.Decode(w, r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	container, err := h.service.Create(r.Context(), req.form())
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusCreated, container)
+}
+
+// Update konteynerin məlumatlarını tam əvəz edir
+func (h *APIHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	var req ContainerRequest
+	if err := api.Decode(w, r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	container, err := h.service.Update(r.Context(), id, req.form())
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, container)
+}
+
+// writeAPIError servis xətasını uyğun HTTP statusu ilə yazır
+func writeAPIError(w http.ResponseWriter, err error) {
+	var validationErr *ValidationError
+	switch {
+	case errors.Is(err, ErrNotFound):
+		api.WriteError(w, api.NewError(http.StatusNotFound, api.CodeNotFound, err.Error()))
+	case errors.As(err, &validationErr):
+		api.WriteError(w, api.NewError(http.StatusUnprocessableEntity, api.CodeValidation, validationErr.Message))
+	default:
+		api.WriteError(w, err)
+	}
+}
@@ -0,0 +1,201 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"html/template"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/Zam83-AZE/logistics_system/pkg/view"
+	"github.com/gorilla/mux"
+)
+
+// Handler konteyner reyestri HTTP sorğularını işləyir
+type Handler struct {
+	service        Service
+	tmpl           *template.Template
+	sessionManager *session.Manager
+}
+
+// NewHandler yeni konteyner işləyicisi yaradır
+func NewHandler(service Service, tmpl *template.Template, sessionManager *session.Manager) *Handler {
+	return &Handler{
+		service:        service,
+		tmpl:           tmpl,
+		sessionManager: sessionManager,
+	}
+}
+
+// List konteyner siyahısını axtarış və status filtri ilə göstərir
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	filter := ListFilter{
+		Query:  r.URL.Query().Get("q"),
+		Status: r.URL.Query().Get("status"),
+		Page:   page,
+	}
+
+	containers, err := h.service.List(ctx, filter)
+	if err != nil {
+		http.Error(w, "Konteyner siyahısı əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	data := ListPage{
+		Containers:  *containers,
+		Filter:      filter,
+		Statuses:    StatusOptions(),
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "containers",
+	}
+
+	view.Render(w, r, h.tmpl, "container/list.html", data)
+}
+
+// New yeni konteyner formunu göstərir
+func (h *Handler) New(w http.ResponseWriter, r *http.Request) {
+	data := FormPage{
+		Form:        ContainerForm{SizeType: "22G1", Status: StatusAvailable},
+		Statuses:    StatusOptions(),
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "containers",
+	}
+
+	view.Render(w, r, h.tmpl, "container/form.html", data)
+}
+
+// Create yeni konteyneri reyestrə əlavə edir
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	form := parseForm(r)
+
+	container, err := h.service.Create(ctx, form)
+	if err != nil {
+		h.renderFormError(w, r, form, 0, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/containers/%d", container.ID), http.StatusSeeOther)
+}
+
+// Detail konteynerin detallarını göstərir
+func (h *Handler) Detail(w http.ResponseWriter, r *http.Request) {
+	container, ok := h.loadContainer(w, r)
+	if !ok {
+		return
+	}
+
+	data := DetailPage{
+		Container:   *container,
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "containers",
+	}
+
+	view.Render(w, r, h.tmpl, "container/detail.html", data)
+}
+
+// Edit mövcud konteynerin redaktə formunu göstərir
+func (h *Handler) Edit(w http.ResponseWriter, r *http.Request) {
+	container, ok := h.loadContainer(w, r)
+	if !ok {
+		return
+	}
+
+	data := FormPage{
+		Form:        FormFromContainer(container),
+		ContainerID: container.ID,
+		IsEdit:      true,
+		Statuses:    StatusOptions(),
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "containers",
+	}
+
+	view.Render(w, r, h.tmpl, "container/form.html", data)
+}
+
+// Update mövcud konteynerin məlumatlarını yeniləyir
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	form := parseForm(r)
+
+	_, err = h.service.Update(ctx, id, form)
+	if err != nil {
+		h.renderFormError(w, r, form, id, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/containers/%d", id), http.StatusSeeOther)
+}
+
+// loadContainer URL-dəki ID-yə görə konteyneri əldə edir, tapılmadıqda cavabı özü yazır
+func (h *Handler) loadContainer(w http.ResponseWriter, r *http.Request) (*Container, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return nil, false
+	}
+
+	container, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			http.NotFound(w, r)
+			return nil, false
+		}
+		http.Error(w, "Konteyner məlumatlarını əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	return container, true
+}
+
+// renderFormError formu xəta mesajı ilə yenidən göstərir
+func (h *Handler) renderFormError(w http.ResponseWriter, r *http.Request, form ContainerForm, id int, err error) {
+	if errors.Is(err, ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+
+	var validationErr *ValidationError
+	message := "Konteyner məlumatlarını saxlayarkən xəta baş verdi"
+	if errors.As(err, &validationErr) {
+		message = validationErr.Message
+	}
+
+	data := FormPage{
+		Form:        form,
+		ContainerID: id,
+		IsEdit:      id != 0,
+		Statuses:    StatusOptions(),
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "containers",
+		Error:       message,
+	}
+
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	view.Render(w, r, h.tmpl, "container/form.html", data)
+}
+
+// parseForm sorğudan konteyner formunun dəyərlərini oxuyur
+func parseForm(r *http.Request) ContainerForm {
+	return ContainerForm{
+		Number:     r.FormValue("number"),
+		SizeType:   r.FormValue("size_type"),
+		TareKg:     r.FormValue("tare_kg"),
+		MaxGrossKg: r.FormValue("max_gross_kg"),
+		Owner:      r.FormValue("owner"),
+		Status:     r.FormValue("status"),
+		Notes:      r.FormValue("notes"),
+	}
+}
@@ -0,0 +1,154 @@
+package container
+
+import (
+	"fmt"
+	"strings"
+)
+
+// letterValues ISO 6346 üzrə hərflərin ədədi dəyərləridir (11-in misilləri buraxılır)
+var letterValues = map[byte]int{
+	'A': 10, 'B': 12, 'C': 13, 'D': 14, 'E': 15, 'F': 16, 'G': 17, 'H': 18, 'I': 19,
+	'J': 20, 'K': 21, 'L': 23, 'M': 24, 'N': 25, 'O': 26, 'P': 27, 'Q': 28, 'R': 29,
+	'S': 30, 'T': 31, 'U': 32, 'V': 34, 'W': 35, 'X': 36, 'Y': 37, 'Z': 38,
+}
+
+// equipmentCategories ISO 6346 avadanlıq kateqoriyası identifikatorlarıdır
+var equipmentCategories = map[byte]string{
+	'U': "yük konteyneri",
+	'J': "ayrıla bilən avadanlıq",
+	'Z': "qoşqu və ya şassi",
+}
+
+// lengthCodes ölçü kodunun birinci simvoluna uyğun uzunluqlardır
+var lengthCodes = map[byte]string{
+	'1': "10 fut", '2': "20 fut", '3': "30 fut", '4': "40 fut",
+	'B': "24 fut", 'C': "24 fut 6 düym", 'G': "41 fut", 'H': "43 fut",
+	'L': "45 fut", 'M': "48 fut", 'N': "49 fut",
+}
+
+// typeGroups tip kodunun birinci simvoluna uyğun konteyner qruplarıdır
+var typeGroups = map[byte]string{
+	'G': "ümumi təyinatlı",
+	'V': "ventilyasiyalı",
+	'B': "toplu yük",
+	'S': "xüsusi yük",
+	'R': "soyuducu",
+	'H': "izotermik",
+	'U': "açıq üstlü",
+	'P': "platforma",
+	'T': "çən",
+	'A': "hava/yer",
+}
+
+// ParsedNumber ISO 6346 konteyner nömrəsinin hissələrini saxlayır
+type ParsedNumber struct {
+	OwnerCode  string
+	Serial     string
+	CheckDigit int
+}
+
+// String nömrəni standart formatda qaytarır
+func (p ParsedNumber) String() string {
+	return fmt.Sprintf("%s%s%d", p.OwnerCode, p.Serial, p.CheckDigit)
+}
+
+// CheckDigit sahib kodu və seriya nömrəsinə görə yoxlama rəqəmini hesablayır
+func CheckDigit(ownerCode, serial string) (int, error) {
+	code := ownerCode + serial
+	if len(code) != 10 {
+		return 0, fmt.Errorf("sahib kodu 4 hərf, seriya nömrəsi 6 rəqəm olmalıdır")
+	}
+
+	sum := 0
+	for i := 0; i < len(code); i++ {
+		c := code[i]
+
+		var value int
+		switch {
+		case i < 4:
+			v, ok := letterValues[c]
+			if !ok {
+				return 0, fmt.Errorf("sahib kodunda yanlış simvol: %q", c)
+			}
+			value = v
+		case c >= '0' && c <= '9':
+			value = int(c - '0')
+		default:
+			return 0, fmt.Errorf("seriya nömrəsində yanlış simvol: %q", c)
+		}
+
+		sum += value << uint(i)
+	}
+
+	return sum % 11 % 10, nil
+}
+
+// ParseNumber konteyner nömrəsini təhlil edir və yoxlama rəqəmini təsdiqləyir
+func ParseNumber(number string) (ParsedNumber, error) {
+	n := strings.ToUpper(strings.Join(strings.FieldsFunc(number, func(r rune) bool {
+		return r == ' ' || r == '-'
+	}), ""))
+
+	if len(n) != 11 {
+		return ParsedNumber{}, fmt.Errorf("konteyner nömrəsi 11 simvoldan ibarət olmalıdır")
+	}
+
+	ownerCode, serial := n[:4], n[4:10]
+	if _, ok := equipmentCategories[ownerCode[3]]; !ok {
+		return ParsedNumber{}, fmt.Errorf("avadanlıq kateqoriyası U, J və ya Z olmalıdır")
+	}
+
+	expected, err := CheckDigit(ownerCode, serial)
+	if err != nil {
+		return ParsedNumber{}, err
+	}
+
+	last := n[10]
+	if last < '0' || last > '9' {
+		return ParsedNumber{}, fmt.Errorf("yoxlama rəqəmi rəqəm olmalıdır")
+	}
+
+	if int(last-'0') != expected {
+		return ParsedNumber{}, fmt.Errorf("yoxlama rəqəmi yanlışdır: %c, gözlənilən %d", last, expected)
+	}
+
+	return ParsedNumber{OwnerCode: ownerCode, Serial: serial, CheckDigit: expected}, nil
+}
+
+// ValidateSizeType ISO 6346 ölçü və tip kodunu (məs. 22G1, 45R1) yoxlayır
+func ValidateSizeType(code string) error {
+	if len(code) != 4 {
+		return fmt.Errorf("ölçü/tip kodu 4 simvoldan ibarət olmalıdır")
+	}
+
+	if _, ok := lengthCodes[code[0]]; !ok {
+		return fmt.Errorf("naməlum uzunluq kodu: %c", code[0])
+	}
+
+	if !isAlnum(code[1]) {
+		return fmt.Errorf("naməlum hündürlük kodu: %c", code[1])
+	}
+
+	if _, ok := typeGroups[code[2]]; !ok {
+		return fmt.Errorf("naməlum tip qrupu: %c", code[2])
+	}
+
+	if !isAlnum(code[3]) {
+		return fmt.Errorf("naməlum tip kodu: %c", code[3])
+	}
+
+	return nil
+}
+
+// DescribeSizeType ölçü/tip kodunun oxunaqlı təsvirini qaytarır
+func DescribeSizeType(code string) string {
+	if ValidateSizeType(code) != nil {
+		return code
+	}
+
+	return lengthCodes[code[0]] + ", " + typeGroups[code[2]]
+}
+
+func isAlnum(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,103 @@
+package container
+
+import "testing"
+
+// TestCheckDigit ISO 6346 yoxlama rəqəmini məlum nömrələr üzrə yoxlayır
+func TestCheckDigit(t *testing.T) {
+	tests := []struct {
+		owner  string
+		serial string
+		want   int
+	}{
+		{owner: "CSQU", serial: "305438", want: 3},
+		{owner: "MSKU", serial: "907032", want: 3},
+		{owner: "TCLU", serial: "000000", want: 9},
+		{owner: "APZU", serial: "321098", want: 8},
+		// Qalıq 10 olduqda yoxlama rəqəmi 0 olur
+		{owner: "CSQU", serial: "000007", want: 0},
+		{owner: "MSKU", serial: "000008", want: 0},
+	}
+
+	for _, tt := range tests {
+		got, err := CheckDigit(tt.owner, tt.serial)
+		if err != nil {
+			t.Errorf("CheckDigit(%q, %q): %v", tt.owner, tt.serial, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("CheckDigit(%q, %q) = %d, gözlənilən %d", tt.owner, tt.serial, got, tt.want)
+		}
+	}
+}
+
+// TestCheckDigitInvalid yanlış sahib kodu və seriya nömrələrinin rədd edildiyini yoxlayır
+func TestCheckDigitInvalid(t *testing.T) {
+	tests := []struct {
+		owner  string
+		serial string
+	}{
+		{owner: "CSQ", serial: "305438"},
+		{owner: "CSQU", serial: "30543"},
+		{owner: "CS1U", serial: "305438"},
+		{owner: "CSQU", serial: "30543A"},
+	}
+
+	for _, tt := range tests {
+		if _, err := CheckDigit(tt.owner, tt.serial); err == nil {
+			t.Errorf("CheckDigit(%q, %q) xəta qaytarmadı", tt.owner, tt.serial)
+		}
+	}
+}
+
+// TestParseNumber nömrənin normallaşdırılmasını, kateqoriyanı və yoxlama rəqəmini yoxlayır
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		number  string
+		want    string
+		wantErr bool
+	}{
+		{number: "CSQU3054383", want: "CSQU3054383"},
+		{number: "csqu 305438 3", want: "CSQU3054383"},
+		{number: "MSKU-907032-3", want: "MSKU9070323"},
+		{number: "CSQU0000070", want: "CSQU0000070"},
+		{number: "CSQU3054384", wantErr: true},
+		{number: "CSQU000007A", wantErr: true},
+		{number: "CSQA3054383", wantErr: true},
+		{number: "CSQU305438", wantErr: true},
+		{number: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseNumber(tt.number)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseNumber(%q) xəta qaytarmadı", tt.number)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseNumber(%q): %v", tt.number, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("ParseNumber(%q) = %s, gözlənilən %s", tt.number, got, tt.want)
+		}
+	}
+}
+
+// TestValidateSizeType ölçü/tip kodlarının yoxlanmasını yoxlayır
+func TestValidateSizeType(t *testing.T) {
+	valid := []string{"22G1", "45R1", "L5G1", "42U1"}
+	for _, code := range valid {
+		if err := ValidateSizeType(code); err != nil {
+			t.Errorf("ValidateSizeType(%q): %v", code, err)
+		}
+	}
+
+	invalid := []string{"", "22G", "92G1", "22X1", "22g1"}
+	for _, code := range invalid {
+		if err := ValidateSizeType(code); err == nil {
+			t.Errorf("ValidateSizeType(%q) xəta qaytarmadı", code)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package container
+
+import (
+	"fmt"
+	"time"
+)
+
+// Konteyner statusları
+const (
+	StatusAvailable = "available"
+	StatusInUse     = "in_use"
+	StatusInRepair  = "in_repair"
+	StatusRetired   = "retired"
+)
+
+// StatusLabels statusların istifadəçi üçün adlarını saxlayır
+var StatusLabels = map[string]string{
+	StatusAvailable: "Boş",
+	StatusInUse:     "İstifadədə",
+	StatusInRepair:  "Təmirdə",
+	StatusRetired:   "İstismardan çıxarılıb",
+}
+
+// StatusOption formda göstəriləcək status seçimini təmsil edir
+type StatusOption struct {
+	Value string
+	Label string
+}
+
+// StatusOptions bütün statusları formda göstəriləcək sıra ilə qaytarır
+func StatusOptions() []StatusOption {
+	statuses := []string{StatusAvailable, StatusInUse, StatusInRepair, StatusRetired}
+
+	options := make([]StatusOption, 0, len(statuses))
+	for _, status := range statuses {
+		options = append(options, StatusOption{Value: status, Label: StatusLabels[status]})
+	}
+
+	return options
+}
+
+// Container verilənlər bazasından gələn konteyner məlumatlarını təmsil edir
+type Container struct {
+	ID         int       `db:"id" json:"id"`
+	OwnerCode  string    `db:"owner_code" json:"ownerCode" validate:"required,len=4"`
+	Serial     string    `db:"serial" json:"serial" validate:"required,len=6"`
+	CheckDigit int       `db:"check_digit" json:"checkDigit"`
+	SizeType   string    `db:"size_type" json:"sizeType" validate:"required,len=4"`
+	TareKg     int       `db:"tare_kg" json:"tareKg"`
+	MaxGrossKg int       `db:"max_gross_kg" json:"maxGrossKg"`
+	Owner      string    `db:"owner" json:"owner"`
+	Status     string    `db:"status" json:"status"`
+	Notes      string    `db:"notes" json:"notes"`
+	CreatedAt  time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt  time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// Number konteynerin tam ISO 6346 nömrəsini qaytarır
+func (c Container) Number() string {
+	return fmt.Sprintf("%s%s%d", c.OwnerCode, c.Serial, c.CheckDigit)
+}
+
+// StatusLabel statusun istifadəçi üçün adını qaytarır
+func (c Container) StatusLabel() string {
+	if label, ok := StatusLabels[c.Status]; ok {
+		return label
+	}
+	return c.Status
+}
+
+// SizeTypeDescription ölçü/tip kodunun təsvirini qaytarır
+func (c Container) SizeTypeDescription() string {
+	return DescribeSizeType(c.SizeType)
+}
+
+// PayloadKg konteynerin maksimal yük tutumunu qaytarır
+func (c Container) PayloadKg() int {
+	return c.MaxGrossKg - c.TareKg
+}
+
+// ListFilter konteyner siyahısı üçün axtarış və səhifələmə parametrlərini saxlayır
+type ListFilter struct {
+	Query   string
+	Status  string
+	Sort    string
+	Page    int
+	PerPage int
+}
+
+// ContainerList səhifələnmiş konteyner siyahısını təmsil edir
+type ContainerList struct {
+	Items   []Container
+	Total   int
+	Page    int
+	PerPage int
+}
+
+// HasPrev əvvəlki səhifənin olub-olmadığını göstərir
+func (l ContainerList) HasPrev() bool {
+	return l.Page > 1
+}
+
+// HasNext növbəti səhifənin olub-olmadığını göstərir
+func (l ContainerList) HasNext() bool {
+	return l.Page*l.PerPage < l.Total
+}
+
+// PrevPage əvvəlki səhifənin nömrəsini qaytarır
+func (l ContainerList) PrevPage() int {
+	return l.Page - 1
+}
+
+// NextPage növbəti səhifənin nömrəsini qaytarır
+func (l ContainerList) NextPage() int {
+	return l.Page + 1
+}
+
+// ContainerForm konteyner yaratma və redaktə formunu təmsil edir
+type ContainerForm struct {
+	Number     string
+	SizeType   string
+	TareKg     string
+	MaxGrossKg string
+	Owner      string
+	Status     string
+	Notes      string
+}
+
+// ListPage konteyner siyahısı səhifəsi üçün məlumatları təmsil edir
+type ListPage struct {
+	Containers  ContainerList
+	Filter      ListFilter
+	Statuses    []StatusOption
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// FormPage konteyner formu səhifəsi üçün məlumatları təmsil edir
+type FormPage struct {
+	Form        ContainerForm
+	ContainerID int
+	IsEdit      bool
+	Statuses    []StatusOption
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// DetailPage konteyner detalları səhifəsi üçün məlumatları təmsil edir
+type DetailPage struct {
+	Container   Container
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// FormFromContainer mövcud konteynerdən redaktə formu yaradır
+func FormFromContainer(c *Container) ContainerForm {
+	return ContainerForm{
+		Number:     c.Number(),
+		SizeType:   c.SizeType,
+		TareKg:     fmt.Sprint(c.TareKg),
+		MaxGrossKg: fmt.Sprint(c.MaxGrossKg),
+		Owner:      c.Owner,
+		Status:     c.Status,
+		Notes:      c.Notes,
+	}
+}
@@ -0,0 +1,152 @@
+package container
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/db"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// uniqueViolation PostgreSQL-in unikal məhdudiyyət pozulması kodudur
+const uniqueViolation = "23505"
+
+// Repository konteyner məlumatları əməliyyatlarını müəyyən edir
+type Repository interface {
+	List(ctx context.Context, filter ListFilter) ([]Container, int, error)
+	GetByID(ctx context.Context, id int) (*Container, error)
+	Create(ctx context.Context, container *Container) error
+	Update(ctx context.Context, container *Container) error
+}
+
+// sortColumns siyahının sıralana biləcəyi sahələri və onlara uyğun sütunları saxlayır
+var sortColumns = map[string]string{
+	"number":    "owner_code || serial",
+	"sizeType":  "size_type",
+	"status":    "status",
+	"createdAt": "created_at",
+	"updatedAt": "updated_at",
+}
+
+// PostgresRepository Repository interfeysini həyata keçirir
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRepository yeni PostgresRepository yaradır
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+// List filtrə uyğun konteynerləri və ümumi sayı əldə edir
+func (r *PostgresRepository) List(ctx context.Context, filter ListFilter) ([]Container, int, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, "status = $"+strconv.Itoa(len(args)))
+	}
+
+	if q := strings.TrimSpace(filter.Query); q != "" {
+		args = append(args, "%"+strings.ToUpper(strings.ReplaceAll(q, " ", ""))+"%")
+		n := strconv.Itoa(len(args))
+		conditions = append(conditions, "(owner_code || serial || check_digit::text LIKE $"+n+
+			" OR UPPER(owner) LIKE $"+n+" OR size_type LIKE $"+n+")")
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM containers "+where, args...); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT id, owner_code, serial, check_digit, size_type, tare_kg, max_gross_kg, owner,
+			status, notes, created_at, updated_at
+		FROM containers
+		` + where + `
+		ORDER BY ` + db.OrderBy(filter.Sort, sortColumns, "owner_code, serial, id", "id") + `
+		LIMIT $` + strconv.Itoa(len(args)+1) + ` OFFSET $` + strconv.Itoa(len(args)+2)
+
+	args = append(args, filter.PerPage, (filter.Page-1)*filter.PerPage)
+
+	containers := []Container{}
+	if err := r.db.SelectContext(ctx, &containers, query, args...); err != nil {
+		return nil, 0, err
+	}
+
+	return containers, total, nil
+}
+
+// GetByID konteyneri ID-yə görə əldə edir
+func (r *PostgresRepository) GetByID(ctx context.Context, id int) (*Container, error) {
+	query := `
+		SELECT id, owner_code, serial, check_digit, size_type, tare_kg, max_gross_kg, owner,
+			status, notes, created_at, updated_at
+		FROM containers
+		WHERE id = $1
+	`
+
+	container := &Container{}
+	err := r.db.GetContext(ctx, container, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Konteyner tapılmadı
+		}
+		return nil, err
+	}
+
+	return container, nil
+}
+
+// Create yeni konteyner əlavə edir
+func (r *PostgresRepository) Create(ctx context.Context, container *Container) error {
+	query := `
+		INSERT INTO containers (owner_code, serial, check_digit, size_type, tare_kg, max_gross_kg, owner, status, notes)
+		VALUES (:owner_code, :serial, :check_digit, :size_type, :tare_kg, :max_gross_kg, :owner, :status, :notes)
+		RETURNING id, created_at, updated_at
+	`
+
+	rows, err := r.db.NamedQueryContext(ctx, query, container)
+	if err != nil {
+		return mapError(err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		return rows.Scan(&container.ID, &container.CreatedAt, &container.UpdatedAt)
+	}
+
+	return rows.Err()
+}
+
+// Update mövcud konteynerin məlumatlarını yeniləyir
+func (r *PostgresRepository) Update(ctx context.Context, container *Container) error {
+	query := `
+		UPDATE containers
+		SET owner_code = :owner_code, serial = :serial, check_digit = :check_digit,
+			size_type = :size_type, tare_kg = :tare_kg, max_gross_kg = :max_gross_kg,
+			owner = :owner, status = :status, notes = :notes, updated_at = NOW()
+		WHERE id = :id
+	`
+
+	_, err := r.db.NamedExecContext(ctx, query, container)
+	return mapError(err)
+}
+
+// mapError verilənlər bazası xətalarını domen xətalarına çevirir
+func mapError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+		return &ValidationError{Message: "bu nömrə ilə konteyner artıq qeydiyyatdadır"}
+	}
+
+	return err
+}
@@ -0,0 +1,79 @@
+package container
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/rbac"
+	"github.com/Zam83-AZE/logistics_system/internal/middleware"
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+	"github.com/Zam83-AZE/logistics_system/pkg/openapi"
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/gorilla/mux"
+	"github.com/jmoiron/sqlx"
+)
+
+// RegisterRoutes konteyner reyestri marşrutlarını qeydə alır
+func RegisterRoutes(router *mux.Router, db *sqlx.DB, tmpl *template.Template, sessionManager *session.Manager) {
+	repo := NewPostgresRepository(db)
+	service := NewContainerService(repo, audit.NewRecorder(db))
+	handler := NewHandler(service, tmpl, sessionManager)
+
+	// İcazə yoxlamaları
+	canView := middleware.RequirePermission(rbac.ContainersView)
+	canManage := middleware.RequirePermission(rbac.ContainersManage)
+
+	// Siyahı və yaratma
+	router.Handle("/containers", canView(http.HandlerFunc(handler.List))).Methods("GET")
+	router.Handle("/containers/new", canManage(http.HandlerFunc(handler.New))).Methods("GET")
+	router.Handle("/containers", canManage(http.HandlerFunc(handler.Create))).Methods("POST")
+
+	// Detallar və redaktə
+	router.Handle("/containers/{id:[0-9]+}", canView(http.HandlerFunc(handler.Detail))).Methods("GET")
+	router.Handle("/containers/{id:[0-9]+}/edit", canManage(http.HandlerFunc(handler.Edit))).Methods("GET")
+	router.Handle("/containers/{id:[0-9]+}", canManage(http.HandlerFunc(handler.Update))).Methods("POST")
+}
+
+// RegisterAPIRoutes konteyner JSON API marşrutlarını qeydə alır
+func RegisterAPIRoutes(router *mux.Router, db *sqlx.DB) {
+	handler := NewAPIHandler(NewContainerService(NewPostgresRepository(db), audit.NewRecorder(db)))
+
+	canView := middleware.RequirePermission(rbac.ContainersView)
+	canManage := middleware.RequirePermission(rbac.ContainersManage)
+
+	router.Handle("/containers", canView(http.HandlerFunc(handler.List))).Methods("GET")
+	router.Handle("/containers", canManage(http.HandlerFunc(handler.Create))).Methods("POST")
+	router.Handle("/containers/{id:[0-9]+}", canView(http.HandlerFunc(handler.Get))).Methods("GET")
+	router.Handle("/containers/{id:[0-9]+}", canManage(http.HandlerFunc(handler.Update))).Methods("PUT")
+}
+
+// DescribeAPI konteyner API marşrutlarını OpenAPI sənədinə əlavə edir
+func DescribeAPI(spec *openapi.Spec) {
+	view := string(rbac.ContainersView)
+	manage := string(rbac.ContainersManage)
+
+	var statuses []string
+	for _, option := range StatusOptions() {
+		statuses = append(statuses, option.Value)
+	}
+
+	spec.Add("GET", "/containers", openapi.Operation{
+		Summary: "Konteyner siyahısı", Tag: "containers", Permission: view,
+		Query: []openapi.Param{
+			{Name: "q", Description: "Konteyner nömrəsi, sahibi və ya ölçü/tip kodu üzrə axtarış"},
+			{Name: "status", Enum: statuses},
+		},
+		List: true, Sort: sortColumns, Response: Container{},
+	})
+	spec.Add("POST", "/containers", openapi.Operation{
+		Summary: "Konteyneri reyestrə əlavə et", Tag: "containers", Permission: manage,
+		Request: ContainerRequest{}, Response: Container{}, Status: http.StatusCreated,
+	})
+	spec.Add("GET", "/containers/{id}", openapi.Operation{
+		Summary: "Konteyner", Tag: "containers", Permission: view, Response: Container{},
+	})
+	spec.Add("PUT", "/containers/{id}", openapi.Operation{
+		Summary: "Konteyneri yenilə", Tag: "containers", Permission: manage,
+		Request: ContainerRequest{}, Response: Container{},
+	})
+}
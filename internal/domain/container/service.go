@@ -0,0 +1,192 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+)
+
+const defaultPerPage = 20
+
+// ErrNotFound konteyner tapılmadıqda qaytarılır
+var ErrNotFound = errors.New("konteyner tapılmadı")
+
+// ValidationError istifadəçiyə göstəriləcək form xətasını təmsil edir
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// Service konteyner reyestri biznes məntiqini müəyyən edir
+type Service interface {
+	List(ctx context.Context, filter ListFilter) (*ContainerList, error)
+	Get(ctx context.Context, id int) (*Container, error)
+	Create(ctx context.Context, form ContainerForm) (*Container, error)
+	Update(ctx context.Context, id int, form ContainerForm) (*Container, error)
+}
+
+// ContainerService Service interfeysini həyata keçirir
+type ContainerService struct {
+	repo  Repository
+	audit audit.Recorder
+}
+
+// NewContainerService yeni ContainerService yaradır
+func NewContainerService(repo Repository, recorder audit.Recorder) *ContainerService {
+	return &ContainerService{repo: repo, audit: recorder}
+}
+
+// List filtrə uyğun səhifələnmiş konteyner siyahısını qaytarır
+func (s *ContainerService) List(ctx context.Context, filter ListFilter) (*ContainerList, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PerPage < 1 || filter.PerPage > 100 {
+		filter.PerPage = defaultPerPage
+	}
+	if _, ok := StatusLabels[filter.Status]; !ok {
+		filter.Status = ""
+	}
+
+	containers, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ContainerList{
+		Items:   containers,
+		Total:   total,
+		Page:    filter.Page,
+		PerPage: filter.PerPage,
+	}, nil
+}
+
+// Get konteyneri ID-yə görə qaytarır
+func (s *ContainerService) Get(ctx context.Context, id int) (*Container, error) {
+	container, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if container == nil {
+		return nil, ErrNotFound
+	}
+
+	return container, nil
+}
+
+// Create formdakı məlumatlarla yeni konteyneri reyestrə əlavə edir
+func (s *ContainerService) Create(ctx context.Context, form ContainerForm) (*Container, error) {
+	container := &Container{Status: StatusAvailable}
+	if err := applyForm(container, form); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, container); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "container.created", nil, container)
+
+	return container, nil
+}
+
+// Update mövcud konteynerin məlumatlarını formdakı dəyərlərlə yeniləyir
+func (s *ContainerService) Update(ctx context.Context, id int, form ContainerForm) (*Container, error) {
+	container, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *container
+	if err := applyForm(container, form); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, container); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "container.updated", &before, container)
+
+	return container, nil
+}
+
+// record konteyner üzərində əməliyyatı audit jurnalına yazır
+func (s *ContainerService) record(ctx context.Context, action string, before, after *Container) {
+	s.audit.Record(ctx, audit.Event{
+		Action:     action,
+		EntityType: "container",
+		EntityID:   strconv.Itoa(after.ID),
+		Before:     before,
+		After:      after,
+	})
+}
+
+// applyForm formu yoxlayır və dəyərləri konteyner obyektinə köçürür
+func applyForm(container *Container, form ContainerForm) error {
+	number, err := ParseNumber(form.Number)
+	if err != nil {
+		return &ValidationError{Message: err.Error()}
+	}
+
+	sizeType := strings.ToUpper(strings.TrimSpace(form.SizeType))
+	if err := ValidateSizeType(sizeType); err != nil {
+		return &ValidationError{Message: err.Error()}
+	}
+
+	tare, err := parseWeight(form.TareKg)
+	if err != nil {
+		return &ValidationError{Message: "tara çəkisi müsbət tam ədəd olmalıdır"}
+	}
+
+	maxGross, err := parseWeight(form.MaxGrossKg)
+	if err != nil {
+		return &ValidationError{Message: "maksimal brutto çəki müsbət tam ədəd olmalıdır"}
+	}
+
+	if maxGross > 0 && maxGross <= tare {
+		return &ValidationError{Message: "maksimal brutto çəki tara çəkisindən böyük olmalıdır"}
+	}
+
+	status := form.Status
+	if status == "" {
+		status = container.Status
+	}
+	if _, ok := StatusLabels[status]; !ok {
+		return &ValidationError{Message: "konteyner statusu yanlışdır"}
+	}
+
+	container.OwnerCode = number.OwnerCode
+	container.Serial = number.Serial
+	container.CheckDigit = number.CheckDigit
+	container.SizeType = sizeType
+	container.TareKg = tare
+	container.MaxGrossKg = maxGross
+	container.Owner = strings.TrimSpace(form.Owner)
+	container.Status = status
+	container.Notes = strings.TrimSpace(form.Notes)
+
+	return nil
+}
+
+// parseWeight kiloqramla çəkini oxuyur, boş dəyəri sıfır kimi qəbul edir
+func parseWeight(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	weight, err := strconv.Atoi(value)
+	if err != nil || weight < 0 {
+		return 0, errors.New("yanlış çəki")
+	}
+
+	return weight, nil
+}
@@ -0,0 +1,156 @@
+package customer
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/api"
+)
+
+// APIHandler müştəri JSON API sorğularını işləyir
+type APIHandler struct {
+	service Service
+}
+
+// NewAPIHandler yeni müştəri API işləyicisi yaradır
+func NewAPIHandler(service Service) *APIHandler {
+	return &APIHandler{service: service}
+}
+
+// List müştəri siyahısını qaytarır: ?q=, ?inactive=true, ?sort=, ?cursor=, ?limit=
+func (h *APIHandler) List(w http.ResponseWriter, r *http.Request) {
+	params, err := api.ParseList(r, sortColumns)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	inactive, err := api.QueryBool(r, "inactive")
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	customers, err := h.service.List(r.Context(), ListFilter{
+		Query:           r.URL.Query().Get("q"),
+		IncludeInactive: inactive,
+		Sort:            params.Sort,
+		Page:            params.Page,
+		PerPage:         params.PerPage,
+	})
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteList(w, customers.Items, customers.Total, params)
+}
+
+// Get müştərini qaytarır
+func (h *APIHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	customer, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, customer)
+}
+
+// Create yeni müştəri yaradır
+func (h *APIHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var form CustomerForm
+	if err := api.Decode(w, r, &form); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	customer, err := h.service.Create(r.Context(), form)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusCreated, customer)
+}
+
+// Update müştərinin məlumatlarını tam əvəz edir
+func (h *APIHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	var form CustomerForm
+	if err := api.Decode(w, r, &form); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	customer, err := h.service.Update(r.Context(), id, form)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, customer)
+}
+
+// Deactivate müştərini deaktiv edir
+func (h *APIHandler) Deactivate(w http.ResponseWriter, r *http.Request) {
+	h.setActive(w, r, false)
+}
+
+// Activate müştərini yenidən aktiv edir
+func (h *APIHandler) Activate(w http.ResponseWriter, r *http.Request) {
+	h.setActive(w, r, true)
+}
+
+// setActive müştərinin statusunu dəyişir və yenilənmiş müştərini qaytarır
+func (h *APIHandler) setActive(w http.ResponseWriter, r *http.Request, active bool) {
+	ctx := r.Context()
+
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	if active {
+		err = h.service.Activate(ctx, id)
+	} else {
+		err = h.service.Deactivate(ctx, id)
+	}
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	customer, err := h.service.Get(ctx, id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, customer)
+}
+
+// writeAPIError servis xətasını uyğun HTTP statusu ilə yazır
+func writeAPIError(w http.ResponseWriter, err error) {
+	var validationErr *ValidationError
+	switch {
+	case errors.Is(err, ErrNotFound):
+		api.WriteError(w, api.NewError(http.StatusNotFound, api.CodeNotFound, err.Error()))
+	case errors.As(err, &validationErr):
+		api.WriteError(w, api.NewError(http.StatusUnprocessableEntity, api.CodeValidation, validationErr.Message))
+	default:
+		api.WriteError(w, err)
+	}
+}
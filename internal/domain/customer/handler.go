@@ -0,0 +1,236 @@
+package customer
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"html/template"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/Zam83-AZE/logistics_system/pkg/view"
+	"github.com/gorilla/mux"
+)
+
+// Handler müştəri HTTP sorğularını işləyir
+type Handler struct {
+	service        Service
+	tmpl           *template.Template
+	sessionManager *session.Manager
+}
+
+// NewHandler yeni müştəri işləyicisi yaradır
+func NewHandler(service Service, tmpl *template.Template, sessionManager *session.Manager) *Handler {
+	return &Handler{
+		service:        service,
+		tmpl:           tmpl,
+		sessionManager: sessionManager,
+	}
+}
+
+// List müştəri siyahısını axtarış və səhifələmə ilə göstərir
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	filter := ListFilter{
+		Query:           r.URL.Query().Get("q"),
+		IncludeInactive: r.URL.Query().Get("inactive") == "1",
+		Page:            page,
+	}
+
+	customers, err := h.service.List(ctx, filter)
+	if err != nil {
+		http.Error(w, "Müştəri siyahısı əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	data := ListPage{
+		Customers:   *customers,
+		Filter:      filter,
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "customers",
+	}
+
+	view.Render(w, r, h.tmpl, "customer/list.html", data)
+}
+
+// New yeni müştəri formunu göstərir
+func (h *Handler) New(w http.ResponseWriter, r *http.Request) {
+	data := FormPage{
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "customers",
+	}
+
+	view.Render(w, r, h.tmpl, "customer/form.html", data)
+}
+
+// Create yeni müştəri yaradır
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	form := parseForm(r)
+
+	customer, err := h.service.Create(ctx, form)
+	if err != nil {
+		h.renderFormError(w, r, form, 0, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/customers/%d", customer.ID), http.StatusSeeOther)
+}
+
+// Detail müştərinin detallarını göstərir
+func (h *Handler) Detail(w http.ResponseWriter, r *http.Request) {
+	customer, ok := h.loadCustomer(w, r)
+	if !ok {
+		return
+	}
+
+	data := DetailPage{
+		Customer:    *customer,
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "customers",
+	}
+
+	view.Render(w, r, h.tmpl, "customer/detail.html", data)
+}
+
+// Edit mövcud müştərinin redaktə formunu göstərir
+func (h *Handler) Edit(w http.ResponseWriter, r *http.Request) {
+	customer, ok := h.loadCustomer(w, r)
+	if !ok {
+		return
+	}
+
+	data := FormPage{
+		Form:        FormFromCustomer(customer),
+		CustomerID:  customer.ID,
+		IsEdit:      true,
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "customers",
+	}
+
+	view.Render(w, r, h.tmpl, "customer/form.html", data)
+}
+
+// Update mövcud müştərinin məlumatlarını yeniləyir
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	form := parseForm(r)
+
+	_, err = h.service.Update(ctx, id, form)
+	if err != nil {
+		h.renderFormError(w, r, form, id, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/customers/%d", id), http.StatusSeeOther)
+}
+
+// Deactivate müştərini deaktiv edir
+func (h *Handler) Deactivate(w http.ResponseWriter, r *http.Request) {
+	h.setActive(w, r, false)
+}
+
+// Activate müştərini yenidən aktiv edir
+func (h *Handler) Activate(w http.ResponseWriter, r *http.Request) {
+	h.setActive(w, r, true)
+}
+
+// setActive müştərinin aktivlik statusunu dəyişir və detallar səhifəsinə yönləndirir
+func (h *Handler) setActive(w http.ResponseWriter, r *http.Request, active bool) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if active {
+		err = h.service.Activate(ctx, id)
+	} else {
+		err = h.service.Deactivate(ctx, id)
+	}
+
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		http.Error(w, "Müştəri statusunu dəyişərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/customers/%d", id), http.StatusSeeOther)
+}
+
+// loadCustomer URL-dəki ID-yə görə müştərini əldə edir, tapılmadıqda cavabı özü yazır
+func (h *Handler) loadCustomer(w http.ResponseWriter, r *http.Request) (*Customer, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return nil, false
+	}
+
+	customer, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			http.NotFound(w, r)
+			return nil, false
+		}
+		http.Error(w, "Müştəri məlumatlarını əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	return customer, true
+}
+
+// renderFormError formu xəta mesajı ilə yenidən göstərir
+func (h *Handler) renderFormError(w http.ResponseWriter, r *http.Request, form CustomerForm, id int, err error) {
+	if errors.Is(err, ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+
+	var validationErr *ValidationError
+	message := "Müştəri məlumatlarını saxlayarkən xəta baş verdi"
+	if errors.As(err, &validationErr) {
+		message = validationErr.Message
+	}
+
+	data := FormPage{
+		Form:        form,
+		CustomerID:  id,
+		IsEdit:      id != 0,
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "customers",
+		Error:       message,
+	}
+
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	view.Render(w, r, h.tmpl, "customer/form.html", data)
+}
+
+// parseForm sorğudan müştəri formunun dəyərlərini oxuyur
+func parseForm(r *http.Request) CustomerForm {
+	return CustomerForm{
+		Name:        r.FormValue("name"),
+		TaxID:       r.FormValue("tax_id"),
+		ContactName: r.FormValue("contact_name"),
+		Email:       r.FormValue("email"),
+		Phone:       r.FormValue("phone"),
+		Address:     r.FormValue("address"),
+		City:        r.FormValue("city"),
+		Country:     r.FormValue("country"),
+		Notes:       r.FormValue("notes"),
+	}
+}
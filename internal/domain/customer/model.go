@@ -0,0 +1,114 @@
+package customer
+
+import (
+	"time"
+)
+
+// Customer verilənlər bazasından gələn müştəri məlumatlarını təmsil edir
+type Customer struct {
+	ID          int       `db:"id" json:"id"`
+	Name        string    `db:"name" json:"name" validate:"required"`
+	TaxID       string    `db:"tax_id" json:"taxId"`
+	ContactName string    `db:"contact_name" json:"contactName"`
+	Email       string    `db:"email" json:"email" validate:"omitempty,email"`
+	Phone       string    `db:"phone" json:"phone"`
+	Address     string    `db:"address" json:"address"`
+	City        string    `db:"city" json:"city"`
+	Country     string    `db:"country" json:"country"`
+	Notes       string    `db:"notes" json:"notes"`
+	IsActive    bool      `db:"is_active" json:"isActive"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// ListFilter müştəri siyahısı üçün axtarış və səhifələmə parametrlərini saxlayır
+type ListFilter struct {
+	Query           string
+	IncludeInactive bool
+	Sort            string
+	Page            int
+	PerPage         int
+}
+
+// CustomerList səhifələnmiş müştəri siyahısını təmsil edir
+type CustomerList struct {
+	Items   []Customer
+	Total   int
+	Page    int
+	PerPage int
+}
+
+// HasPrev əvvəlki səhifənin olub-olmadığını göstərir
+func (l CustomerList) HasPrev() bool {
+	return l.Page > 1
+}
+
+// HasNext növbəti səhifənin olub-olmadığını göstərir
+func (l CustomerList) HasNext() bool {
+	return l.Page*l.PerPage < l.Total
+}
+
+// PrevPage əvvəlki səhifənin nömrəsini qaytarır
+func (l CustomerList) PrevPage() int {
+	return l.Page - 1
+}
+
+// NextPage növbəti səhifənin nömrəsini qaytarır
+func (l CustomerList) NextPage() int {
+	return l.Page + 1
+}
+
+// CustomerForm müştəri yaratma və redaktə formunu təmsil edir; API sorğularının gövdəsi də eyni formadadır
+type CustomerForm struct {
+	Name        string `json:"name"`
+	TaxID       string `json:"taxId"`
+	ContactName string `json:"contactName"`
+	Email       string `json:"email"`
+	Phone       string `json:"phone"`
+	Address     string `json:"address"`
+	City        string `json:"city"`
+	Country     string `json:"country"`
+	Notes       string `json:"notes"`
+}
+
+// ListPage müştəri siyahısı səhifəsi üçün məlumatları təmsil edir
+type ListPage struct {
+	Customers   CustomerList
+	Filter      ListFilter
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// FormPage müştəri formu səhifəsi üçün məlumatları təmsil edir
+type FormPage struct {
+	Form        CustomerForm
+	CustomerID  int
+	IsEdit      bool
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// DetailPage müştəri detalları səhifəsi üçün məlumatları təmsil edir
+type DetailPage struct {
+	Customer    Customer
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// FormFromCustomer mövcud müştəridən redaktə formu yaradır
+func FormFromCustomer(c *Customer) CustomerForm {
+	return CustomerForm{
+		Name:        c.Name,
+		TaxID:       c.TaxID,
+		ContactName: c.ContactName,
+		Email:       c.Email,
+		Phone:       c.Phone,
+		Address:     c.Address,
+		City:        c.City,
+		Country:     c.Country,
+		Notes:       c.Notes,
+	}
+}
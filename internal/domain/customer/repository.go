@@ -0,0 +1,158 @@
+package customer
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/db"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// uniqueViolation PostgreSQL-in unikal məhdudiyyət pozulması kodudur
+const uniqueViolation = "23505"
+
+// Repository müştəri məlumatları əməliyyatlarını müəyyən edir
+type Repository interface {
+	List(ctx context.Context, filter ListFilter) ([]Customer, int, error)
+	GetByID(ctx context.Context, id int) (*Customer, error)
+	Create(ctx context.Context, customer *Customer) error
+	Update(ctx context.Context, customer *Customer) error
+	SetActive(ctx context.Context, id int, active bool) error
+}
+
+// sortColumns siyahının sıralana biləcəyi sahələri və onlara uyğun sütunları saxlayır
+var sortColumns = map[string]string{
+	"name":      "name",
+	"city":      "city",
+	"createdAt": "created_at",
+	"updatedAt": "updated_at",
+}
+
+// PostgresRepository Repository interfeysini həyata keçirir
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRepository yeni PostgresRepository yaradır
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+// List filtrə uyğun müştəriləri və ümumi sayı əldə edir
+func (r *PostgresRepository) List(ctx context.Context, filter ListFilter) ([]Customer, int, error) {
+	var conditions []string
+	var args []interface{}
+
+	if !filter.IncludeInactive {
+		conditions = append(conditions, "is_active = true")
+	}
+
+	if q := strings.TrimSpace(filter.Query); q != "" {
+		args = append(args, "%"+q+"%")
+		conditions = append(conditions, `(name ILIKE $1 OR tax_id ILIKE $1 OR contact_name ILIKE $1
+			OR email ILIKE $1 OR phone ILIKE $1 OR city ILIKE $1)`)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM customers "+where, args...); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT id, name, tax_id, contact_name, email, phone, address, city, country, notes,
+			is_active, created_at, updated_at
+		FROM customers
+		` + where + `
+		ORDER BY ` + db.OrderBy(filter.Sort, sortColumns, "name, id", "id") + `
+		LIMIT $` + strconv.Itoa(len(args)+1) + ` OFFSET $` + strconv.Itoa(len(args)+2)
+
+	args = append(args, filter.PerPage, (filter.Page-1)*filter.PerPage)
+
+	customers := []Customer{}
+	if err := r.db.SelectContext(ctx, &customers, query, args...); err != nil {
+		return nil, 0, err
+	}
+
+	return customers, total, nil
+}
+
+// GetByID müştərini ID-yə görə əldə edir
+func (r *PostgresRepository) GetByID(ctx context.Context, id int) (*Customer, error) {
+	query := `
+		SELECT id, name, tax_id, contact_name, email, phone, address, city, country, notes,
+			is_active, created_at, updated_at
+		FROM customers
+		WHERE id = $1
+	`
+
+	customer := &Customer{}
+	err := r.db.GetContext(ctx, customer, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Müştəri tapılmadı
+		}
+		return nil, err
+	}
+
+	return customer, nil
+}
+
+// Create yeni müştəri əlavə edir
+func (r *PostgresRepository) Create(ctx context.Context, customer *Customer) error {
+	query := `
+		INSERT INTO customers (name, tax_id, contact_name, email, phone, address, city, country, notes, is_active)
+		VALUES (:name, :tax_id, :contact_name, :email, :phone, :address, :city, :country, :notes, :is_active)
+		RETURNING id, created_at, updated_at
+	`
+
+	rows, err := r.db.NamedQueryContext(ctx, query, customer)
+	if err != nil {
+		return mapError(err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		return rows.Scan(&customer.ID, &customer.CreatedAt, &customer.UpdatedAt)
+	}
+
+	return rows.Err()
+}
+
+// Update mövcud müştərinin məlumatlarını yeniləyir
+func (r *PostgresRepository) Update(ctx context.Context, customer *Customer) error {
+	query := `
+		UPDATE customers
+		SET name = :name, tax_id = :tax_id, contact_name = :contact_name, email = :email,
+			phone = :phone, address = :address, city = :city, country = :country,
+			notes = :notes, updated_at = NOW()
+		WHERE id = :id
+	`
+
+	_, err := r.db.NamedExecContext(ctx, query, customer)
+	return mapError(err)
+}
+
+// SetActive müştərinin aktivlik statusunu dəyişir
+func (r *PostgresRepository) SetActive(ctx context.Context, id int, active bool) error {
+	query := `UPDATE customers SET is_active = $1, updated_at = NOW() WHERE id = $2`
+
+	_, err := r.db.ExecContext(ctx, query, active, id)
+	return err
+}
+
+// mapError verilənlər bazası xətalarını domen xətalarına çevirir
+func mapError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+		return &ValidationError{Message: "bu VÖEN ilə müştəri artıq mövcuddur"}
+	}
+
+	return err
+}
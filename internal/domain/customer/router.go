@@ -0,0 +1,84 @@
+package customer
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/rbac"
+	"github.com/Zam83-AZE/logistics_system/internal/middleware"
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+	"github.com/Zam83-AZE/logistics_system/pkg/openapi"
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/gorilla/mux"
+	"github.com/jmoiron/sqlx"
+)
+
+// RegisterRoutes müştəri marşrutlarını qeydə alır
+func RegisterRoutes(router *mux.Router, db *sqlx.DB, tmpl *template.Template, sessionManager *session.Manager) {
+	repo := NewPostgresRepository(db)
+	service := NewCustomerService(repo, audit.NewRecorder(db))
+	handler := NewHandler(service, tmpl, sessionManager)
+
+	// İcazə yoxlamaları
+	canView := middleware.RequirePermission(rbac.CustomersView)
+	canManage := middleware.RequirePermission(rbac.CustomersManage)
+
+	// Siyahı və yaratma
+	router.Handle("/customers", canView(http.HandlerFunc(handler.List))).Methods("GET")
+	router.Handle("/customers/new", canManage(http.HandlerFunc(handler.New))).Methods("GET")
+	router.Handle("/customers", canManage(http.HandlerFunc(handler.Create))).Methods("POST")
+
+	// Detallar, redaktə və status dəyişikliyi
+	router.Handle("/customers/{id:[0-9]+}", canView(http.HandlerFunc(handler.Detail))).Methods("GET")
+	router.Handle("/customers/{id:[0-9]+}/edit", canManage(http.HandlerFunc(handler.Edit))).Methods("GET")
+	router.Handle("/customers/{id:[0-9]+}", canManage(http.HandlerFunc(handler.Update))).Methods("POST")
+	router.Handle("/customers/{id:[0-9]+}/deactivate", canManage(http.HandlerFunc(handler.Deactivate))).Methods("POST")
+	router.Handle("/customers/{id:[0-9]+}/activate", canManage(http.HandlerFunc(handler.Activate))).Methods("POST")
+}
+
+// RegisterAPIRoutes müştəri JSON API marşrutlarını qeydə alır
+func RegisterAPIRoutes(router *mux.Router, db *sqlx.DB) {
+	handler := NewAPIHandler(NewCustomerService(NewPostgresRepository(db), audit.NewRecorder(db)))
+
+	canView := middleware.RequirePermission(rbac.CustomersView)
+	canManage := middleware.RequirePermission(rbac.CustomersManage)
+
+	router.Handle("/customers", canView(http.HandlerFunc(handler.List))).Methods("GET")
+	router.Handle("/customers", canManage(http.HandlerFunc(handler.Create))).Methods("POST")
+	router.Handle("/customers/{id:[0-9]+}", canView(http.HandlerFunc(handler.Get))).Methods("GET")
+	router.Handle("/customers/{id:[0-9]+}", canManage(http.HandlerFunc(handler.Update))).Methods("PUT")
+	router.Handle("/customers/{id:[0-9]+}/deactivate", canManage(http.HandlerFunc(handler.Deactivate))).Methods("POST")
+	router.Handle("/customers/{id:[0-9]+}/activate", canManage(http.HandlerFunc(handler.Activate))).Methods("POST")
+}
+
+// DescribeAPI müştəri API marşrutlarını OpenAPI sənədinə əlavə edir
+func DescribeAPI(spec *openapi.Spec) {
+	view := string(rbac.CustomersView)
+	manage := string(rbac.CustomersManage)
+
+	spec.Add("GET", "/customers", openapi.Operation{
+		Summary: "Müştəri siyahısı", Tag: "customers", Permission: view,
+		Query: []openapi.Param{
+			{Name: "q", Description: "Ad, VÖEN, əlaqə şəxsi, e-poçt, telefon və ya şəhər üzrə axtarış"},
+			{Name: "inactive", Type: "boolean", Description: "Deaktiv müştəriləri də göstər"},
+		},
+		List: true, Sort: sortColumns, Response: Customer{},
+	})
+	spec.Add("POST", "/customers", openapi.Operation{
+		Summary: "Müştəri yarat", Tag: "customers", Permission: manage,
+		Request: CustomerForm{}, Response: Customer{}, Status: http.StatusCreated,
+	})
+	spec.Add("GET", "/customers/{id}", openapi.Operation{
+		Summary: "Müştəri", Tag: "customers", Permission: view, Response: Customer{},
+	})
+	spec.Add("PUT", "/customers/{id}", openapi.Operation{
+		Summary: "Müştərini yenilə", Tag: "customers", Permission: manage,
+		Request: CustomerForm{}, Response: Customer{},
+	})
+	spec.Add("POST", "/customers/{id}/deactivate", openapi.Operation{
+		Summary: "Müştərini deaktiv et", Tag: "customers", Permission: manage, Response: Customer{},
+	})
+	spec.Add("POST", "/customers/{id}/activate", openapi.Operation{
+		Summary: "Müştərini aktiv et", Tag: "customers", Permission: manage, Response: Customer{},
+	})
+}
@@ -0,0 +1,225 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"strconv"
+	"strings"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+)
+
+const defaultPerPage = 20
+
+// ErrNotFound müştəri tapılmadıqda qaytarılır
+var ErrNotFound = errors.New("müştəri tapılmadı")
+
+// ValidationError istifadəçiyə göstəriləcək form xətasını təmsil edir
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// Service müştəri biznes məntiqini müəyyən edir
+type Service interface {
+	List(ctx context.Context, filter ListFilter) (*CustomerList, error)
+	Get(ctx context.Context, id int) (*Customer, error)
+	Create(ctx context.Context, form CustomerForm) (*Customer, error)
+	Update(ctx context.Context, id int, form CustomerForm) (*Customer, error)
+	Deactivate(ctx context.Context, id int) error
+	Activate(ctx context.Context, id int) error
+}
+
+// CustomerService Service interfeysini həyata keçirir
+type CustomerService struct {
+	repo  Repository
+	audit audit.Recorder
+}
+
+// NewCustomerService yeni CustomerService yaradır
+func NewCustomerService(repo Repository, recorder audit.Recorder) *CustomerService {
+	return &CustomerService{repo: repo, audit: recorder}
+}
+
+// List filtrə uyğun səhifələnmiş müştəri siyahısını qaytarır
+func (s *CustomerService) List(ctx context.Context, filter ListFilter) (*CustomerList, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PerPage < 1 || filter.PerPage > 100 {
+		filter.PerPage = defaultPerPage
+	}
+
+	customers, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CustomerList{
+		Items:   customers,
+		Total:   total,
+		Page:    filter.Page,
+		PerPage: filter.PerPage,
+	}, nil
+}
+
+// Get müştərini ID-yə görə qaytarır
+func (s *CustomerService) Get(ctx context.Context, id int) (*Customer, error) {
+	customer, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if customer == nil {
+		return nil, ErrNotFound
+	}
+
+	return customer, nil
+}
+
+// Create formdakı məlumatlarla yeni müştəri yaradır
+func (s *CustomerService) Create(ctx context.Context, form CustomerForm) (*Customer, error) {
+	form = normalizeForm(form)
+	if err := validateForm(form); err != nil {
+		return nil, err
+	}
+
+	customer := &Customer{IsActive: true}
+	applyForm(customer, form)
+
+	if err := s.repo.Create(ctx, customer); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "customer.created", nil, customer)
+
+	return customer, nil
+}
+
+// Update mövcud müştərinin məlumatlarını formdakı dəyərlərlə yeniləyir
+func (s *CustomerService) Update(ctx context.Context, id int, form CustomerForm) (*Customer, error) {
+	customer, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	form = normalizeForm(form)
+	if err := validateForm(form); err != nil {
+		return nil, err
+	}
+
+	before := *customer
+	applyForm(customer, form)
+
+	if err := s.repo.Update(ctx, customer); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "customer.updated", &before, customer)
+
+	return customer, nil
+}
+
+// Deactivate müştərini deaktiv edir
+func (s *CustomerService) Deactivate(ctx context.Context, id int) error {
+	return s.setActive(ctx, id, false, "customer.deactivated")
+}
+
+// Activate deaktiv edilmiş müştərini yenidən aktiv edir
+func (s *CustomerService) Activate(ctx context.Context, id int) error {
+	return s.setActive(ctx, id, true, "customer.activated")
+}
+
+// setActive müştərinin statusunu dəyişir və dəyişikliyi audit jurnalına yazır
+func (s *CustomerService) setActive(ctx context.Context, id int, active bool, action string) error {
+	customer, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.SetActive(ctx, id, active); err != nil {
+		return err
+	}
+
+	before := *customer
+	customer.IsActive = active
+	s.record(ctx, action, &before, customer)
+
+	return nil
+}
+
+// record müştəri üzərində əməliyyatı audit jurnalına yazır
+func (s *CustomerService) record(ctx context.Context, action string, before, after *Customer) {
+	s.audit.Record(ctx, audit.Event{
+		Action:     action,
+		EntityType: "customer",
+		EntityID:   strconv.Itoa(after.ID),
+		Before:     before,
+		After:      after,
+	})
+}
+
+// normalizeForm form dəyərlərindəki artıq boşluqları təmizləyir
+func normalizeForm(form CustomerForm) CustomerForm {
+	form.Name = strings.TrimSpace(form.Name)
+	form.TaxID = strings.TrimSpace(form.TaxID)
+	form.ContactName = strings.TrimSpace(form.ContactName)
+	form.Email = strings.TrimSpace(form.Email)
+	form.Phone = strings.TrimSpace(form.Phone)
+	form.Address = strings.TrimSpace(form.Address)
+	form.City = strings.TrimSpace(form.City)
+	form.Country = strings.TrimSpace(form.Country)
+	form.Notes = strings.TrimSpace(form.Notes)
+	return form
+}
+
+// validateForm müştəri formunun dəyərlərini yoxlayır
+func validateForm(form CustomerForm) error {
+	if form.Name == "" {
+		return &ValidationError{Message: "müştərinin adı tələb olunur"}
+	}
+
+	if form.TaxID != "" && !isDigits(form.TaxID, 10) {
+		return &ValidationError{Message: "VÖEN 10 rəqəmdən ibarət olmalıdır"}
+	}
+
+	if form.Email != "" {
+		if _, err := mail.ParseAddress(form.Email); err != nil {
+			return &ValidationError{Message: "e-poçt ünvanı yanlışdır"}
+		}
+	}
+
+	return nil
+}
+
+// applyForm form dəyərlərini müştəri obyektinə köçürür
+func applyForm(customer *Customer, form CustomerForm) {
+	customer.Name = form.Name
+	customer.TaxID = form.TaxID
+	customer.ContactName = form.ContactName
+	customer.Email = form.Email
+	customer.Phone = form.Phone
+	customer.Address = form.Address
+	customer.City = form.City
+	customer.Country = form.Country
+	customer.Notes = form.Notes
+}
+
+// isDigits sətrin tam olaraq n rəqəmdən ibarət olduğunu yoxlayır
+func isDigits(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,30 @@
+package dashboard
+
+import (
+	"net/http"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/api"
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+)
+
+// APIHandler dashboard JSON API sorğularını işləyir
+type APIHandler struct {
+	service        Service
+	sessionManager *session.Manager
+}
+
+// NewAPIHandler yeni dashboard API işləyicisi yaradır
+func NewAPIHandler(service Service, sessionManager *session.Manager) *APIHandler {
+	return &APIHandler{service: service, sessionManager: sessionManager}
+}
+
+// Summary dashboard-un əsas statistikasını qaytarır
+func (h *APIHandler) Summary(w http.ResponseWriter, r *http.Request) {
+	data, err := h.service.GetDashboardData(r.Context(), h.sessionManager.GetUsername(r))
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, data.Summary)
+}
@@ -6,6 +6,7 @@ import (
 	"html/template"
 
 	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/Zam83-AZE/logistics_system/pkg/view"
 )
 
 // Handler dashboard HTTP sorğularını işləyir
@@ -37,5 +38,5 @@ func (h *Handler) Index(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.tmpl.ExecuteTemplate(w, "dashboard/index.html", data)
+	view.Render(w, r, h.tmpl, "dashboard/index.html", data)
 }
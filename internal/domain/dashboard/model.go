@@ -1,17 +1,40 @@
 package dashboard
 
+import (
+	"time"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/shipment"
+)
+
 // Summary dashboard üçün əsas statistika məlumatlarını təmsil edir
 type Summary struct {
-	TotalCustomers  int `db:"total_customers"`
-	TotalContainers int `db:"total_containers"`
-	ActiveShipments int `db:"active_shipments"`
-	PendingInvoices int `db:"pending_invoices"`
+	TotalCustomers  int `db:"total_customers" json:"totalCustomers"`
+	TotalContainers int `db:"total_containers" json:"totalContainers"`
+	ActiveShipments int `db:"active_shipments" json:"activeShipments"`
+	PendingInvoices int `db:"pending_invoices" json:"pendingInvoices"`
+}
+
+// StatusCount statusa görə qruplaşdırılmış say
+type StatusCount struct {
+	Status string `db:"status"`
+	Count  int    `db:"count"`
+}
+
+// RecentEvent "Son fəaliyyətlər" panelində göstərilən izləmə hadisəsidir
+type RecentEvent struct {
+	ShipmentID        int                `db:"shipment_id"`
+	ShipmentReference string             `db:"shipment_reference"`
+	Code              shipment.EventCode `db:"code"`
+	Location          string             `db:"location"`
+	ContainerNumber   *string            `db:"container_number"`
+	OccurredAt        time.Time          `db:"occurred_at"`
 }
 
 // DashboardData dashboard üçün bütün lazımi məlumatları təmsil edir
 type DashboardData struct {
-	Summary     Summary
-	UserName    string
-	CurrentPage string
-	Error       string
+	Summary      Summary
+	RecentEvents []RecentEvent
+	UserName     string
+	CurrentPage  string
+	Error        string
 }
@@ -3,12 +3,17 @@ package dashboard
 import (
 	"context"
 
+	"github.com/Zam83-AZE/logistics_system/internal/domain/shipment"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 // Repository dashboard məlumatları əməliyyatlarını müəyyən edir
 type Repository interface {
 	GetSummary(ctx context.Context) (*Summary, error)
+	RecentEvents(ctx context.Context, limit int) ([]RecentEvent, error)
+	ActiveShipmentsByStatus(ctx context.Context) ([]StatusCount, error)
+	OverdueInvoices(ctx context.Context) (int, error)
 }
 
 // PostgresRepository Repository interfeysini həyata keçirir
@@ -25,17 +30,81 @@ func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
 func (r *PostgresRepository) GetSummary(ctx context.Context) (*Summary, error) {
 	query := `
 		SELECT
-			(SELECT COUNT(*) FROM users) AS total_customers,
-			0 AS total_containers,
-			0 AS active_shipments,
-			0 AS pending_invoices
+			(SELECT COUNT(*) FROM customers WHERE is_active = true) AS total_customers,
+			(SELECT COUNT(*) FROM containers WHERE status <> 'retired') AS total_containers,
+			(SELECT COUNT(*) FROM shipments WHERE status = ANY($1)) AS active_shipments,
+			(SELECT COUNT(*) FROM invoices WHERE status IN ('issued', 'overdue')) AS pending_invoices
 	`
 
 	summary := &Summary{}
-	err := r.db.GetContext(ctx, summary, query)
+	err := r.db.GetContext(ctx, summary, query, activeStatuses())
 	if err != nil {
 		return nil, err
 	}
 
 	return summary, nil
 }
+
+// RecentEvents ən son baş vermiş izləmə hadisələrini əldə edir
+func (r *PostgresRepository) RecentEvents(ctx context.Context, limit int) ([]RecentEvent, error) {
+	query := `
+		SELECT e.shipment_id, s.reference AS shipment_reference, e.code, e.location,
+			c.owner_code || c.serial || c.check_digit::text AS container_number, e.occurred_at
+		FROM tracking_events e
+		JOIN shipments s ON s.id = e.shipment_id
+		LEFT JOIN containers c ON c.id = e.container_id
+		ORDER BY e.occurred_at DESC, e.id DESC
+		LIMIT $1
+	`
+
+	events := []RecentEvent{}
+	if err := r.db.SelectContext(ctx, &events, query, limit); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// ActiveShipmentsByStatus tamamlanmamış daşınmaların statuslar üzrə sayını əldə edir
+func (r *PostgresRepository) ActiveShipmentsByStatus(ctx context.Context) ([]StatusCount, error) {
+	query := `
+		SELECT status, COUNT(*) AS count
+		FROM shipments
+		WHERE status = ANY($1)
+		GROUP BY status
+		ORDER BY status
+	`
+
+	var counts []StatusCount
+	if err := r.db.SelectContext(ctx, &counts, query, activeStatuses()); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// OverdueInvoices ödəniş tarixi keçmiş fakturaların sayını əldə edir.
+// Statusu hələ "overdue" kimi yenilənməmiş təqdim edilmiş fakturalar da sayılır.
+func (r *PostgresRepository) OverdueInvoices(ctx context.Context) (int, error) {
+	query := `
+		SELECT COUNT(*) FROM invoices
+		WHERE status = 'overdue' OR (status = 'issued' AND due_date < CURRENT_DATE)
+	`
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// activeStatuses tamamlanmamış daşınma statuslarını sorğu parametri kimi qaytarır;
+// siyahı statusların keçid cədvəlindən alınır ki, yeni status əlavə edildikdə dashboard ondan geri qalmasın
+func activeStatuses() pq.StringArray {
+	var statuses pq.StringArray
+	for _, s := range shipment.ActiveStatuses() {
+		statuses = append(statuses, string(s))
+	}
+	return statuses
+}
@@ -1,8 +1,14 @@
 package dashboard
 
 import (
+	"context"
 	"html/template"
+	"net/http"
 
+	"github.com/Zam83-AZE/logistics_system/internal/domain/rbac"
+	"github.com/Zam83-AZE/logistics_system/internal/middleware"
+	"github.com/Zam83-AZE/logistics_system/pkg/metrics"
+	"github.com/Zam83-AZE/logistics_system/pkg/openapi"
 	"github.com/Zam83-AZE/logistics_system/pkg/session"
 	"github.com/gorilla/mux"
 	"github.com/jmoiron/sqlx"
@@ -17,5 +23,47 @@ func RegisterRoutes(router *mux.Router, db *sqlx.DB, tmpl *template.Template) {
 	handler := NewHandler(service, tmpl, sessionManager)
 
 	// Dashboard ana səhifəsi
-	router.HandleFunc("/dashboard", handler.Index).Methods("GET")
+	router.Handle("/dashboard", middleware.RequirePermission(rbac.DashboardView)(http.HandlerFunc(handler.Index))).Methods("GET")
+}
+
+// RegisterAPIRoutes dashboard JSON API marşrutlarını qeydə alır
+func RegisterAPIRoutes(router *mux.Router, db *sqlx.DB, sessionManager *session.Manager) {
+	handler := NewAPIHandler(NewDashboardService(NewPostgresRepository(db)), sessionManager)
+
+	router.Handle("/dashboard/summary", middleware.RequirePermission(rbac.DashboardView)(http.HandlerFunc(handler.Summary))).Methods("GET")
+}
+
+// DescribeAPI dashboard API marşrutlarını OpenAPI sənədinə əlavə edir
+func DescribeAPI(spec *openapi.Spec) {
+	spec.Add("GET", "/dashboard/summary", openapi.Operation{
+		Summary: "Əsas statistika", Tag: "dashboard", Permission: string(rbac.DashboardView), Response: Summary{},
+	})
+}
+
+// RegisterMetrics biznes göstəricilərini (aktiv daşınmalar, vaxtı keçmiş fakturalar) reyestrdə qeydə alır.
+// Dəyərlər hər /metrics sorğusunda verilənlər bazasından hesablanır.
+func RegisterMetrics(registry *metrics.Registry, db *sqlx.DB) {
+	service := NewDashboardService(NewPostgresRepository(db))
+
+	registry.NewGaugeFunc("logistics_shipments_active", "Tamamlanmamış daşınmaların statuslar üzrə sayı",
+		func(ctx context.Context) ([]metrics.Sample, error) {
+			counts, err := service.ActiveShipmentsByStatus(ctx)
+			if err != nil {
+				return nil, err
+			}
+			samples := make([]metrics.Sample, 0, len(counts))
+			for _, c := range counts {
+				samples = append(samples, metrics.Sample{Labels: []string{c.Status}, Value: float64(c.Count)})
+			}
+			return samples, nil
+		}, "status")
+
+	registry.NewGaugeFunc("logistics_invoices_overdue", "Ödəniş tarixi keçmiş fakturaların sayı",
+		func(ctx context.Context) ([]metrics.Sample, error) {
+			count, err := service.OverdueInvoices(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return metrics.Value(float64(count)), nil
+		})
 }
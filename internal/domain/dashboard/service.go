@@ -4,9 +4,14 @@ import (
 	"context"
 )
 
+// recentEventsLimit "Son fəaliyyətlər" panelində göstərilən hadisələrin sayıdır
+const recentEventsLimit = 10
+
 // Service dashboard biznes məntiqini müəyyən edir
 type Service interface {
 	GetDashboardData(ctx context.Context, username string) (*DashboardData, error)
+	ActiveShipmentsByStatus(ctx context.Context) ([]StatusCount, error)
+	OverdueInvoices(ctx context.Context) (int, error)
 }
 
 // DashboardService Service interfeysini həyata keçirir
@@ -26,11 +31,27 @@ func (s *DashboardService) GetDashboardData(ctx context.Context, username string
 		return nil, err
 	}
 
+	events, err := s.repo.RecentEvents(ctx, recentEventsLimit)
+	if err != nil {
+		return nil, err
+	}
+
 	dashboardData := &DashboardData{
-		Summary:     *summary,
-		UserName:    username,
-		CurrentPage: "dashboard",
+		Summary:      *summary,
+		RecentEvents: events,
+		UserName:     username,
+		CurrentPage:  "dashboard",
 	}
 
 	return dashboardData, nil
 }
+
+// ActiveShipmentsByStatus tamamlanmamış daşınmaların statuslar üzrə sayını qaytarır
+func (s *DashboardService) ActiveShipmentsByStatus(ctx context.Context) ([]StatusCount, error) {
+	return s.repo.ActiveShipmentsByStatus(ctx)
+}
+
+// OverdueInvoices vaxtı keçmiş fakturaların sayını qaytarır
+func (s *DashboardService) OverdueInvoices(ctx context.Context) (int, error) {
+	return s.repo.OverdueInvoices(ctx)
+}
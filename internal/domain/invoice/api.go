@@ -0,0 +1,207 @@
+package invoice
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/api"
+)
+
+// InvoiceRequest API vasitəsilə qaralama faktura yaratma və yeniləmə sorğusunun gövdəsidir.
+// Məbləğlər cavablarda olduğu kimi valyutanın xırda vahidləri ilə (qəpik) göndərilir.
+type InvoiceRequest struct {
+	CustomerID       int           `json:"customerId"`
+	ShipmentID       *int          `json:"shipmentId"`
+	Currency         string        `json:"currency"`
+	PaymentTermsDays *int          `json:"paymentTermsDays"`
+	VATRate          *float64      `json:"vatRate"`
+	Notes            string        `json:"notes"`
+	Lines            []LineRequest `json:"lines"`
+}
+
+// LineRequest sorğudakı faktura sətridir; vatRate verilmədikdə fakturanın dərəcəsi tətbiq edilir
+type LineRequest struct {
+	Description     string   `json:"description"`
+	Quantity        float64  `json:"quantity"`
+	UnitPrice       Money    `json:"unitPrice"`
+	DiscountPercent float64  `json:"discountPercent"`
+	VATRate         *float64 `json:"vatRate"`
+}
+
+// form sorğunu HTML formu ilə eyni yoxlamalardan keçməsi üçün InvoiceForm-a çevirir
+func (req InvoiceRequest) form() InvoiceForm {
+	form := InvoiceForm{
+		CustomerID: strconv.Itoa(req.CustomerID),
+		Currency:   req.Currency,
+		Notes:      req.Notes,
+	}
+	if req.ShipmentID != nil {
+		form.ShipmentID = strconv.Itoa(*req.ShipmentID)
+	}
+	if req.PaymentTermsDays != nil {
+		form.PaymentTermsDays = strconv.Itoa(*req.PaymentTermsDays)
+	}
+	if req.VATRate != nil {
+		form.VATRate = formatFloat(*req.VATRate)
+	}
+
+	for _, line := range req.Lines {
+		lineForm := LineForm{
+			Description:     line.Description,
+			Quantity:        formatFloat(line.Quantity),
+			UnitPrice:       line.UnitPrice.String(),
+			DiscountPercent: formatFloat(line.DiscountPercent),
+		}
+		if line.VATRate != nil {
+			lineForm.VATRate = formatFloat(*line.VATRate)
+		}
+		form.Lines = append(form.Lines, lineForm)
+	}
+
+	return form
+}
+
+// APIHandler faktura JSON API sorğularını işləyir
+type APIHandler struct {
+	service Service
+}
+
+// NewAPIHandler yeni faktura API işləyicisi yaradır
+func NewAPIHandler(service Service) *APIHandler {
+	return &APIHandler{service: service}
+}
+
+// List faktura siyahısını qaytarır: ?q=, ?status=, ?sort=, ?cursor=, ?limit=
+func (h *APIHandler) List(w http.ResponseWriter, r *http.Request) {
+	params, err := api.ParseList(r, sortColumns)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	status := Status(r.URL.Query().Get("status"))
+	if status != "" && !status.Valid() {
+		api.WriteError(w, api.NewError(http.StatusBadRequest, api.CodeBadRequest, "faktura statusu yanlışdır"))
+		return
+	}
+
+	invoices, err := h.service.List(r.Context(), ListFilter{
+		Query:   r.URL.Query().Get("q"),
+		Status:  status,
+		Sort:    params.Sort,
+		Page:    params.Page,
+		PerPage: params.PerPage,
+	})
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteList(w, invoices.Items, invoices.Total, params)
+}
+
+// Get fakturanı sətirləri ilə qaytarır
+func (h *APIHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	invoice, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, invoice)
+}
+
+// Create qaralama faktura yaradır
+func (h *APIHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req InvoiceRequest
+	if err := api.Decode(w, r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	invoice, err := h.service.Create(r.Context(), req.form())
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusCreated, invoice)
+}
+
+// Update qaralama fakturanın məlumatlarını tam əvəz edir
+func (h *APIHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	var req InvoiceRequest
+	if err := api.Decode(w, r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	invoice, err := h.service.Update(r.Context(), id, req.form())
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, invoice)
+}
+
+// Issue qaralama fakturanı nömrələyib təqdim edir
+func (h *APIHandler) Issue(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.service.Issue)
+}
+
+// MarkPaid fakturanı ödənilmiş kimi qeyd edir
+func (h *APIHandler) MarkPaid(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.service.MarkPaid)
+}
+
+// Void fakturanı ləğv edir
+func (h *APIHandler) Void(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.service.Void)
+}
+
+// changeStatus status əməliyyatını icra edir və yenilənmiş fakturanı qaytarır
+func (h *APIHandler) changeStatus(w http.ResponseWriter, r *http.Request, action func(ctx context.Context, id int) (*Invoice, error)) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	invoice, err := action(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, invoice)
+}
+
+// writeAPIError servis xətasını uyğun HTTP statusu ilə yazır
+func writeAPIError(w http.ResponseWriter, err error) {
+	var validationErr *ValidationError
+	switch {
+	case errors.Is(err, ErrNotFound):
+		api.WriteError(w, api.NewError(http.StatusNotFound, api.CodeNotFound, err.Error()))
+	case errors.As(err, &validationErr):
+		api.WriteError(w, api.NewError(http.StatusUnprocessableEntity, api.CodeValidation, validationErr.Message))
+	case errors.Is(err, ErrNotEditable), errors.Is(err, ErrInvalidTransition), errors.Is(err, ErrConcurrentUpdate):
+		api.WriteError(w, api.NewError(http.StatusConflict, api.CodeConflict, err.Error()))
+	default:
+		api.WriteError(w, err)
+	}
+}
@@ -0,0 +1,294 @@
+package invoice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"html/template"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/Zam83-AZE/logistics_system/pkg/view"
+	"github.com/gorilla/mux"
+)
+
+// Handler faktura HTTP sorğularını işləyir
+type Handler struct {
+	service        Service
+	tmpl           *template.Template
+	sessionManager *session.Manager
+}
+
+// NewHandler yeni faktura işləyicisi yaradır
+func NewHandler(service Service, tmpl *template.Template, sessionManager *session.Manager) *Handler {
+	return &Handler{
+		service:        service,
+		tmpl:           tmpl,
+		sessionManager: sessionManager,
+	}
+}
+
+// List faktura siyahısını axtarış və status filtri ilə göstərir
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	filter := ListFilter{
+		Query:  r.URL.Query().Get("q"),
+		Status: Status(r.URL.Query().Get("status")),
+		Page:   page,
+	}
+
+	invoices, err := h.service.List(ctx, filter)
+	if err != nil {
+		http.Error(w, "Faktura siyahısı əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	data := ListPage{
+		Invoices:    *invoices,
+		Filter:      filter,
+		Statuses:    AllStatuses(),
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "invoices",
+	}
+
+	view.Render(w, r, h.tmpl, "invoice/list.html", data)
+}
+
+// New yeni qaralama faktura formunu göstərir
+func (h *Handler) New(w http.ResponseWriter, r *http.Request) {
+	form := h.service.NewForm()
+	form.CustomerID = r.URL.Query().Get("customer_id")
+	form.ShipmentID = r.URL.Query().Get("shipment_id")
+
+	h.renderForm(w, r, form, 0, "", http.StatusOK)
+}
+
+// Create yeni qaralama faktura yaradır
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	form := parseForm(r)
+
+	invoice, err := h.service.Create(ctx, form)
+	if err != nil {
+		h.renderFormError(w, r, form, 0, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/invoices/%d", invoice.ID), http.StatusSeeOther)
+}
+
+// Detail fakturanın detallarını göstərir
+func (h *Handler) Detail(w http.ResponseWriter, r *http.Request) {
+	invoice, ok := h.loadInvoice(w, r)
+	if !ok {
+		return
+	}
+
+	h.renderDetail(w, r, invoice, "", http.StatusOK)
+}
+
+// Edit qaralama fakturanın redaktə formunu göstərir
+func (h *Handler) Edit(w http.ResponseWriter, r *http.Request) {
+	invoice, ok := h.loadInvoice(w, r)
+	if !ok {
+		return
+	}
+
+	if !invoice.IsEditable() {
+		h.renderDetail(w, r, invoice, ErrNotEditable.Error(), http.StatusConflict)
+		return
+	}
+
+	h.renderForm(w, r, FormFromInvoice(invoice), invoice.ID, "", http.StatusOK)
+}
+
+// Update qaralama fakturanın məlumatlarını yeniləyir
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	form := parseForm(r)
+
+	_, err = h.service.Update(ctx, id, form)
+	if err != nil {
+		h.renderFormError(w, r, form, id, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/invoices/%d", id), http.StatusSeeOther)
+}
+
+// Issue qaralama fakturanı nömrələyib təqdim edir
+func (h *Handler) Issue(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.service.Issue)
+}
+
+// MarkPaid fakturanı ödənilmiş kimi qeyd edir
+func (h *Handler) MarkPaid(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.service.MarkPaid)
+}
+
+// Void fakturanı ləğv edir
+func (h *Handler) Void(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.service.Void)
+}
+
+// changeStatus status əməliyyatını icra edir və nəticəyə görə cavab verir
+func (h *Handler) changeStatus(w http.ResponseWriter, r *http.Request, action func(ctx context.Context, id int) (*Invoice, error)) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if _, err := action(ctx, id); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+
+		var validationErr *ValidationError
+		if errors.Is(err, ErrInvalidTransition) || errors.Is(err, ErrConcurrentUpdate) || errors.As(err, &validationErr) {
+			invoice, loadErr := h.service.Get(ctx, id)
+			if loadErr != nil {
+				http.Error(w, "Faktura məlumatlarını əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+				return
+			}
+			h.renderDetail(w, r, invoice, err.Error(), http.StatusConflict)
+			return
+		}
+
+		http.Error(w, "Faktura statusunu dəyişərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/invoices/%d", id), http.StatusSeeOther)
+}
+
+// loadInvoice URL-dəki ID-yə görə fakturanı əldə edir, tapılmadıqda cavabı özü yazır
+func (h *Handler) loadInvoice(w http.ResponseWriter, r *http.Request) (*Invoice, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return nil, false
+	}
+
+	invoice, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			http.NotFound(w, r)
+			return nil, false
+		}
+		http.Error(w, "Faktura məlumatlarını əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	return invoice, true
+}
+
+// renderDetail detallar səhifəsini göstərir
+func (h *Handler) renderDetail(w http.ResponseWriter, r *http.Request, invoice *Invoice, message string, status int) {
+	data := DetailPage{
+		Invoice:     *invoice,
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "invoices",
+		Error:       message,
+	}
+
+	w.WriteHeader(status)
+	view.Render(w, r, h.tmpl, "invoice/detail.html", data)
+}
+
+// renderForm faktura formunu müştəri və daşınma siyahıları ilə birlikdə göstərir
+func (h *Handler) renderForm(w http.ResponseWriter, r *http.Request, form InvoiceForm, id int, message string, status int) {
+	ctx := r.Context()
+
+	customers, err := h.service.Customers(ctx)
+	if err != nil {
+		http.Error(w, "Müştəri siyahısı əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	shipments, err := h.service.Shipments(ctx)
+	if err != nil {
+		http.Error(w, "Daşınma siyahısı əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	data := FormPage{
+		Form:        form,
+		InvoiceID:   id,
+		IsEdit:      id != 0,
+		Customers:   customers,
+		Shipments:   shipments,
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "invoices",
+		Error:       message,
+	}
+
+	w.WriteHeader(status)
+	view.Render(w, r, h.tmpl, "invoice/form.html", data)
+}
+
+// renderFormError formu xəta mesajı ilə yenidən göstərir
+func (h *Handler) renderFormError(w http.ResponseWriter, r *http.Request, form InvoiceForm, id int, err error) {
+	if errors.Is(err, ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+
+	var validationErr *ValidationError
+	message := "Faktura məlumatlarını saxlayarkən xəta baş verdi"
+	if errors.As(err, &validationErr) || errors.Is(err, ErrNotEditable) {
+		message = err.Error()
+	}
+
+	h.renderForm(w, r, form.WithEmptyLines(), id, message, http.StatusUnprocessableEntity)
+}
+
+// parseForm sorğudan faktura formunun dəyərlərini oxuyur
+func parseForm(r *http.Request) InvoiceForm {
+	r.ParseForm()
+
+	form := InvoiceForm{
+		CustomerID:       r.FormValue("customer_id"),
+		ShipmentID:       r.FormValue("shipment_id"),
+		Currency:         r.FormValue("currency"),
+		PaymentTermsDays: r.FormValue("payment_terms_days"),
+		VATRate:          r.FormValue("vat_rate"),
+		Notes:            r.FormValue("notes"),
+	}
+
+	descriptions := r.PostForm["line_description"]
+	for i := range descriptions {
+		form.Lines = append(form.Lines, LineForm{
+			Description:     descriptions[i],
+			Quantity:        formIndex(r, "line_quantity", i),
+			UnitPrice:       formIndex(r, "line_unit_price", i),
+			DiscountPercent: formIndex(r, "line_discount_percent", i),
+			VATRate:         formIndex(r, "line_vat_rate", i),
+		})
+	}
+
+	return form
+}
+
+// formIndex təkrarlanan form sahəsinin i-ci dəyərini qaytarır
+func formIndex(r *http.Request, key string, i int) string {
+	values := r.PostForm[key]
+	if i < len(values) {
+		return values[i]
+	}
+	return ""
+}
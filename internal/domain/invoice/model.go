@@ -0,0 +1,314 @@
+package invoice
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// DefaultVATRate Azərbaycanda tətbiq olunan standart ƏDV dərəcəsidir (faizlə)
+const DefaultVATRate = 18.0
+
+// DefaultPaymentTermsDays fakturanın standart ödəniş müddətidir (günlə)
+const DefaultPaymentTermsDays = 30
+
+// Status fakturanın vəziyyətini təmsil edir
+type Status string
+
+// Faktura statusları
+const (
+	StatusDraft   Status = "draft"
+	StatusIssued  Status = "issued"
+	StatusPaid    Status = "paid"
+	StatusOverdue Status = "overdue"
+	StatusVoid    Status = "void"
+)
+
+// statusLabels statusların istifadəçi üçün adlarını saxlayır
+var statusLabels = map[Status]string{
+	StatusDraft:   "Qaralama",
+	StatusIssued:  "Təqdim edilib",
+	StatusPaid:    "Ödənilib",
+	StatusOverdue: "Vaxtı keçib",
+	StatusVoid:    "Ləğv edilib",
+}
+
+// transitions hər statusdan icazə verilən keçidləri müəyyən edir
+var transitions = map[Status][]Status{
+	StatusDraft:   {StatusIssued, StatusVoid},
+	StatusIssued:  {StatusPaid, StatusOverdue, StatusVoid},
+	StatusOverdue: {StatusPaid, StatusVoid},
+	StatusPaid:    {},
+	StatusVoid:    {},
+}
+
+// Valid statusun məlum olub-olmadığını yoxlayır
+func (s Status) Valid() bool {
+	_, ok := transitions[s]
+	return ok
+}
+
+// Label statusun istifadəçi üçün adını qaytarır
+func (s Status) Label() string {
+	if label, ok := statusLabels[s]; ok {
+		return label
+	}
+	return string(s)
+}
+
+// CanTransition bu statusdan to statusuna keçidin mümkünlüyünü yoxlayır
+func (s Status) CanTransition(to Status) bool {
+	for _, next := range transitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// AllStatuses bütün statusları qaytarır
+func AllStatuses() []Status {
+	return []Status{StatusDraft, StatusIssued, StatusOverdue, StatusPaid, StatusVoid}
+}
+
+// Invoice verilənlər bazasından gələn faktura məlumatlarını təmsil edir
+type Invoice struct {
+	ID                int        `db:"id" json:"id"`
+	Number            string     `db:"number" json:"number"`
+	CustomerID        int        `db:"customer_id" json:"customerId" validate:"required"`
+	CustomerName      string     `db:"customer_name" json:"customerName"`
+	ShipmentID        *int       `db:"shipment_id" json:"shipmentId,omitempty"`
+	ShipmentReference string     `db:"shipment_reference" json:"shipmentReference,omitempty"`
+	Currency          string     `db:"currency" json:"currency"`
+	Status            Status     `db:"status" json:"status"`
+	IssueDate         *time.Time `db:"issue_date" json:"issueDate,omitempty"`
+	DueDate           *time.Time `db:"due_date" json:"dueDate,omitempty"`
+	PaymentTermsDays  int        `db:"payment_terms_days" json:"paymentTermsDays"`
+	VATRate           float64    `db:"vat_rate" json:"vatRate"`
+	Subtotal          Money      `db:"subtotal" json:"subtotal"`
+	DiscountTotal     Money      `db:"discount_total" json:"discountTotal"`
+	VATTotal          Money      `db:"vat_total" json:"vatTotal"`
+	Total             Money      `db:"total" json:"total"`
+	Notes             string     `db:"notes" json:"notes"`
+	PaidAt            *time.Time `db:"paid_at" json:"paidAt,omitempty"`
+	CreatedAt         time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt         time.Time  `db:"updated_at" json:"updatedAt"`
+	Lines             []Line     `db:"-" json:"lines"`
+}
+
+// Line faktura sətrini təmsil edir
+type Line struct {
+	ID              int     `db:"id" json:"id"`
+	InvoiceID       int     `db:"invoice_id" json:"-"`
+	LineNo          int     `db:"line_no" json:"lineNo"`
+	Description     string  `db:"description" json:"description"`
+	Quantity        float64 `db:"quantity" json:"quantity"`
+	UnitPrice       Money   `db:"unit_price" json:"unitPrice"`
+	DiscountPercent float64 `db:"discount_percent" json:"discountPercent"`
+	VATRate         float64 `db:"vat_rate" json:"vatRate"`
+	NetAmount       Money   `db:"net_amount" json:"netAmount"`
+	DiscountAmount  Money   `db:"discount_amount" json:"discountAmount"`
+	VATAmount       Money   `db:"vat_amount" json:"vatAmount"`
+	TotalAmount     Money   `db:"total_amount" json:"totalAmount"`
+}
+
+// Calculate sətrin endirim, xalis, ƏDV və yekun məbləğlərini hesablayır
+func (l *Line) Calculate() {
+	gross := Money(math.Round(l.Quantity * float64(l.UnitPrice)))
+	l.DiscountAmount = percentOf(gross, l.DiscountPercent)
+	l.NetAmount = gross - l.DiscountAmount
+	l.VATAmount = percentOf(l.NetAmount, l.VATRate)
+	l.TotalAmount = l.NetAmount + l.VATAmount
+}
+
+// Recalculate bütün sətirləri və faktura yekunlarını yenidən hesablayır
+func (inv *Invoice) Recalculate() {
+	inv.Subtotal, inv.DiscountTotal, inv.VATTotal, inv.Total = 0, 0, 0, 0
+
+	for i := range inv.Lines {
+		line := &inv.Lines[i]
+		line.LineNo = i + 1
+		line.Calculate()
+
+		inv.Subtotal += line.NetAmount + line.DiscountAmount
+		inv.DiscountTotal += line.DiscountAmount
+		inv.VATTotal += line.VATAmount
+		inv.Total += line.TotalAmount
+	}
+}
+
+// IsEditable fakturanın redaktə oluna bilməsini göstərir (yalnız qaralama)
+func (inv Invoice) IsEditable() bool {
+	return inv.Status == StatusDraft
+}
+
+// NextStatuses fakturanın keçə biləcəyi statusları qaytarır (vaxtı keçmə avtomatikdir)
+func (inv Invoice) NextStatuses() []Status {
+	var next []Status
+	for _, s := range transitions[inv.Status] {
+		if s != StatusOverdue {
+			next = append(next, s)
+		}
+	}
+	return next
+}
+
+// FormatNumber il və sıra nömrəsinə görə faktura nömrəsini formatlayır
+func FormatNumber(year, seq int) string {
+	return fmt.Sprintf("INV-%d-%06d", year, seq)
+}
+
+// ListFilter faktura siyahısı üçün axtarış və səhifələmə parametrlərini saxlayır
+type ListFilter struct {
+	Query   string
+	Status  Status
+	Sort    string
+	Page    int
+	PerPage int
+}
+
+// InvoiceList səhifələnmiş faktura siyahısını təmsil edir
+type InvoiceList struct {
+	Items   []Invoice
+	Total   int
+	Page    int
+	PerPage int
+}
+
+// HasPrev əvvəlki səhifənin olub-olmadığını göstərir
+func (l InvoiceList) HasPrev() bool {
+	return l.Page > 1
+}
+
+// HasNext növbəti səhifənin olub-olmadığını göstərir
+func (l InvoiceList) HasNext() bool {
+	return l.Page*l.PerPage < l.Total
+}
+
+// PrevPage əvvəlki səhifənin nömrəsini qaytarır
+func (l InvoiceList) PrevPage() int {
+	return l.Page - 1
+}
+
+// NextPage növbəti səhifənin nömrəsini qaytarır
+func (l InvoiceList) NextPage() int {
+	return l.Page + 1
+}
+
+// CustomerOption formda seçilə bilən müştərini təmsil edir
+type CustomerOption struct {
+	ID   int    `db:"id"`
+	Name string `db:"name"`
+}
+
+// ShipmentOption formda seçilə bilən daşınmanı təmsil edir
+type ShipmentOption struct {
+	ID           int    `db:"id"`
+	Reference    string `db:"reference"`
+	CustomerName string `db:"customer_name"`
+}
+
+// LineForm formdakı faktura sətrini təmsil edir
+type LineForm struct {
+	Description     string
+	Quantity        string
+	UnitPrice       string
+	DiscountPercent string
+	VATRate         string
+}
+
+// IsEmpty sətrin heç bir sahəsinin doldurulmadığını göstərir
+func (l LineForm) IsEmpty() bool {
+	return l.Description == "" && l.Quantity == "" && l.UnitPrice == "" && l.DiscountPercent == ""
+}
+
+// InvoiceForm faktura yaratma və redaktə formunu təmsil edir
+type InvoiceForm struct {
+	CustomerID       string
+	ShipmentID       string
+	Currency         string
+	PaymentTermsDays string
+	VATRate          string
+	Notes            string
+	Lines            []LineForm
+}
+
+// emptyLines formda əlavə olaraq göstərilən boş sətirlərin sayıdır
+const emptyLines = 3
+
+// WithEmptyLines formun sonuna yeni sətirlər üçün boş sətirlər əlavə edir
+func (f InvoiceForm) WithEmptyLines() InvoiceForm {
+	lines := make([]LineForm, 0, len(f.Lines)+emptyLines)
+	for _, line := range f.Lines {
+		if !line.IsEmpty() {
+			lines = append(lines, line)
+		}
+	}
+	for i := 0; i < emptyLines; i++ {
+		lines = append(lines, LineForm{})
+	}
+	f.Lines = lines
+	return f
+}
+
+// FormFromInvoice mövcud fakturadan redaktə formu yaradır
+func FormFromInvoice(inv *Invoice) InvoiceForm {
+	form := InvoiceForm{
+		CustomerID:       strconv.Itoa(inv.CustomerID),
+		Currency:         inv.Currency,
+		PaymentTermsDays: strconv.Itoa(inv.PaymentTermsDays),
+		VATRate:          formatFloat(inv.VATRate),
+		Notes:            inv.Notes,
+	}
+
+	if inv.ShipmentID != nil {
+		form.ShipmentID = strconv.Itoa(*inv.ShipmentID)
+	}
+
+	for _, line := range inv.Lines {
+		form.Lines = append(form.Lines, LineForm{
+			Description:     line.Description,
+			Quantity:        formatFloat(line.Quantity),
+			UnitPrice:       line.UnitPrice.String(),
+			DiscountPercent: formatFloat(line.DiscountPercent),
+			VATRate:         formatFloat(line.VATRate),
+		})
+	}
+
+	return form.WithEmptyLines()
+}
+
+// ListPage faktura siyahısı səhifəsi üçün məlumatları təmsil edir
+type ListPage struct {
+	Invoices    InvoiceList
+	Filter      ListFilter
+	Statuses    []Status
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// FormPage faktura formu səhifəsi üçün məlumatları təmsil edir
+type FormPage struct {
+	Form        InvoiceForm
+	InvoiceID   int
+	IsEdit      bool
+	Customers   []CustomerOption
+	Shipments   []ShipmentOption
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// DetailPage faktura detalları səhifəsi üçün məlumatları təmsil edir
+type DetailPage struct {
+	Invoice     Invoice
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
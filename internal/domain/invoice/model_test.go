@@ -0,0 +1,141 @@
+package invoice
+
+import "testing"
+
+// TestLineCalculate sətir məbləğlərinin, endirimin və ƏDV-nin yuvarlaqlaşdırılmasını yoxlayır
+func TestLineCalculate(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     Line
+		discount Money
+		net      Money
+		vat      Money
+		total    Money
+	}{
+		{
+			name:     "endirim və ƏDV",
+			line:     Line{Quantity: 2, UnitPrice: 10000, DiscountPercent: 10, VATRate: 18},
+			discount: 2000, net: 18000, vat: 3240, total: 21240,
+		},
+		{
+			// 125 * 18% = 22.5 qəpik, yarım sıfırdan uzağa yuvarlaqlaşdırılır
+			name: "ƏDV yarım qəpik",
+			line: Line{Quantity: 1, UnitPrice: 125, VATRate: 18},
+			net:  125, vat: 23, total: 148,
+		},
+		{
+			name: "ƏDV yuxarı yuvarlaqlaşdırılır",
+			line: Line{Quantity: 3, UnitPrice: 33, VATRate: 20},
+			net:  99, vat: 20, total: 119,
+		},
+		{
+			name: "kəsr miqdar",
+			line: Line{Quantity: 1.5, UnitPrice: 1001},
+			net:  1502, total: 1502,
+		},
+		{
+			// 999 * 12.5% = 124.875 -> 125; 874 * 18% = 157.32 -> 157
+			name:     "kəsr endirim",
+			line:     Line{Quantity: 1, UnitPrice: 999, DiscountPercent: 12.5, VATRate: 18},
+			discount: 125, net: 874, vat: 157, total: 1031,
+		},
+		{
+			name:     "tam endirim",
+			line:     Line{Quantity: 4, UnitPrice: 2500, DiscountPercent: 100, VATRate: 18},
+			discount: 10000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line := tt.line
+			line.Calculate()
+
+			if line.DiscountAmount != tt.discount || line.NetAmount != tt.net ||
+				line.VATAmount != tt.vat || line.TotalAmount != tt.total {
+				t.Errorf("endirim/xalis/ƏDV/yekun = %s/%s/%s/%s, gözlənilən %s/%s/%s/%s",
+					line.DiscountAmount, line.NetAmount, line.VATAmount, line.TotalAmount,
+					tt.discount, tt.net, tt.vat, tt.total)
+			}
+		})
+	}
+}
+
+// TestInvoiceRecalculate faktura yekunlarının sətirlərin cəmi olduğunu və sətirlərin yenidən nömrələndiyini yoxlayır
+func TestInvoiceRecalculate(t *testing.T) {
+	inv := Invoice{
+		Subtotal: 1,
+		Lines: []Line{
+			{LineNo: 5, Quantity: 2, UnitPrice: 10000, DiscountPercent: 10, VATRate: 18},
+			{LineNo: 9, Quantity: 1, UnitPrice: 125, VATRate: 18},
+		},
+	}
+	inv.Recalculate()
+
+	if inv.Subtotal != 20125 || inv.DiscountTotal != 2000 || inv.VATTotal != 3263 || inv.Total != 21388 {
+		t.Errorf("ara cəm/endirim/ƏDV/yekun = %s/%s/%s/%s, gözlənilən 201.25/20.00/32.63/213.88",
+			inv.Subtotal, inv.DiscountTotal, inv.VATTotal, inv.Total)
+	}
+
+	for i, line := range inv.Lines {
+		if line.LineNo != i+1 {
+			t.Errorf("sətir %d: nömrə = %d", i, line.LineNo)
+		}
+	}
+}
+
+// TestFormatNumber faktura nömrəsinin formatını yoxlayır
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		year int
+		seq  int
+		want string
+	}{
+		{year: 2024, seq: 1, want: "INV-2024-000001"},
+		{year: 2024, seq: 4321, want: "INV-2024-004321"},
+		{year: 2025, seq: 999999, want: "INV-2025-999999"},
+		{year: 2025, seq: 1000000, want: "INV-2025-1000000"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatNumber(tt.year, tt.seq); got != tt.want {
+			t.Errorf("FormatNumber(%d, %d) = %s, gözlənilən %s", tt.year, tt.seq, got, tt.want)
+		}
+	}
+}
+
+// TestParseMoney məbləğin oxunmasını və formatlanmasını yoxlayır
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Money
+		text    string
+		wantErr bool
+	}{
+		{value: "1234.56", want: 123456, text: "1234.56"},
+		{value: " 1234,56 ", want: 123456, text: "1234.56"},
+		{value: "0.1", want: 10, text: "0.10"},
+		{value: "19.999", want: 2000, text: "20.00"},
+		{value: "-3.1", want: -310, text: "-3.10"},
+		{value: "", wantErr: true},
+		{value: "abc", wantErr: true},
+		{value: "NaN", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseMoney(%q) xəta qaytarmadı", tt.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseMoney(%q): %v", tt.value, err)
+			continue
+		}
+		if got != tt.want || got.String() != tt.text {
+			t.Errorf("ParseMoney(%q) = %d (%s), gözlənilən %d (%s)", tt.value, got, got, tt.want, tt.text)
+		}
+	}
+}
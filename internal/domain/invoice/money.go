@@ -0,0 +1,43 @@
+package invoice
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money məbləği valyutanın xırda vahidlərində (qəpik, sent) saxlayır
+type Money int64
+
+// String məbləği iki onluq rəqəmlə formatlayır
+func (m Money) String() string {
+	sign := ""
+	v := int64(m)
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, v/100, v%100)
+}
+
+// ParseMoney "1234.56" və ya "1234,56" formatındakı məbləği oxuyur
+func ParseMoney(value string) (Money, error) {
+	value = strings.ReplaceAll(strings.TrimSpace(value), ",", ".")
+	if value == "" {
+		return 0, errors.New("məbləğ boşdur")
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, errors.New("məbləğ yanlışdır")
+	}
+
+	return Money(math.Round(f * 100)), nil
+}
+
+// percentOf məbləğin verilmiş faizini riyazi yuvarlaqlaşdırma ilə hesablayır
+func percentOf(amount Money, percent float64) Money {
+	return Money(math.Round(float64(amount) * percent / 100))
+}
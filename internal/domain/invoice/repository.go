@@ -0,0 +1,355 @@
+package invoice
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/db"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// foreignKeyViolation PostgreSQL-in xarici açar məhdudiyyəti pozulması kodudur
+const foreignKeyViolation = "23503"
+
+// Repository faktura məlumatları əməliyyatlarını müəyyən edir
+type Repository interface {
+	List(ctx context.Context, filter ListFilter) ([]Invoice, int, error)
+	GetByID(ctx context.Context, id int) (*Invoice, error)
+	Create(ctx context.Context, invoice *Invoice) error
+	Update(ctx context.Context, invoice *Invoice) (bool, error)
+	Issue(ctx context.Context, invoice *Invoice) (bool, error)
+	UpdateStatus(ctx context.Context, id int, from, to Status, paidAt *time.Time) (bool, error)
+	MarkOverdue(ctx context.Context, today time.Time) (int64, error)
+	CustomerOptions(ctx context.Context) ([]CustomerOption, error)
+	ShipmentOptions(ctx context.Context) ([]ShipmentOption, error)
+	ShipmentCustomerID(ctx context.Context, shipmentID int) (int, error)
+}
+
+// sortColumns siyahının sıralana biləcəyi sahələri və onlara uyğun sütunları saxlayır
+var sortColumns = map[string]string{
+	"number":       "i.number",
+	"customerName": "c.name",
+	"issueDate":    "i.issue_date",
+	"dueDate":      "i.due_date",
+	"status":       "i.status",
+	"total":        "i.total",
+	"createdAt":    "i.created_at",
+}
+
+// PostgresRepository Repository interfeysini həyata keçirir
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRepository yeni PostgresRepository yaradır
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+// selectInvoice faktura sorğularının ortaq SELECT hissəsidir
+const selectInvoice = `
+	SELECT i.id, i.number, i.customer_id, c.name AS customer_name, i.shipment_id,
+		COALESCE(s.reference, '') AS shipment_reference, i.currency, i.status, i.issue_date,
+		i.due_date, i.payment_terms_days, i.vat_rate, i.subtotal, i.discount_total, i.vat_total,
+		i.total, i.notes, i.paid_at, i.created_at, i.updated_at
+	FROM invoices i
+	JOIN customers c ON c.id = i.customer_id
+	LEFT JOIN shipments s ON s.id = i.shipment_id
+`
+
+// List filtrə uyğun fakturaları və ümumi sayı əldə edir
+func (r *PostgresRepository) List(ctx context.Context, filter ListFilter) ([]Invoice, int, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, "i.status = $"+strconv.Itoa(len(args)))
+	}
+
+	if q := strings.TrimSpace(filter.Query); q != "" {
+		args = append(args, "%"+q+"%")
+		n := strconv.Itoa(len(args))
+		conditions = append(conditions, "(i.number ILIKE $"+n+" OR c.name ILIKE $"+n+
+			" OR COALESCE(s.reference, '') ILIKE $"+n+")")
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM invoices i JOIN customers c ON c.id = i.customer_id
+		LEFT JOIN shipments s ON s.id = i.shipment_id ` + where
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, 0, err
+	}
+
+	query := selectInvoice + where + `
+		ORDER BY ` + db.OrderBy(filter.Sort, sortColumns, "i.created_at DESC, i.id DESC", "i.id") + `
+		LIMIT $` + strconv.Itoa(len(args)+1) + ` OFFSET $` + strconv.Itoa(len(args)+2)
+
+	args = append(args, filter.PerPage, (filter.Page-1)*filter.PerPage)
+
+	invoices := []Invoice{}
+	if err := r.db.SelectContext(ctx, &invoices, query, args...); err != nil {
+		return nil, 0, err
+	}
+
+	return invoices, total, nil
+}
+
+// GetByID fakturanı sətirləri ilə birlikdə əldə edir
+func (r *PostgresRepository) GetByID(ctx context.Context, id int) (*Invoice, error) {
+	invoice := &Invoice{}
+	err := r.db.GetContext(ctx, invoice, selectInvoice+" WHERE i.id = $1", id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Faktura tapılmadı
+		}
+		return nil, err
+	}
+
+	linesQuery := `
+		SELECT id, invoice_id, line_no, description, quantity, unit_price, discount_percent,
+			vat_rate, net_amount, discount_amount, vat_amount, total_amount
+		FROM invoice_lines
+		WHERE invoice_id = $1
+		ORDER BY line_no
+	`
+	if err := r.db.SelectContext(ctx, &invoice.Lines, linesQuery, id); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// Create yeni qaralama fakturanı sətirləri ilə birlikdə əlavə edir
+func (r *PostgresRepository) Create(ctx context.Context, invoice *Invoice) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO invoices (customer_id, shipment_id, currency, status, payment_terms_days, vat_rate,
+			subtotal, discount_total, vat_total, total, notes)
+		VALUES (:customer_id, :shipment_id, :currency, :status, :payment_terms_days, :vat_rate,
+			:subtotal, :discount_total, :vat_total, :total, :notes)
+		RETURNING id, created_at, updated_at
+	`
+	query, args, err := tx.BindNamed(query, invoice)
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRowxContext(ctx, query, args...).Scan(&invoice.ID, &invoice.CreatedAt, &invoice.UpdatedAt)
+	if err != nil {
+		return mapError(err)
+	}
+
+	if err := saveLines(ctx, tx, invoice); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Update qaralama fakturanı yeniləyir və sətirlərini əvəz edir
+func (r *PostgresRepository) Update(ctx context.Context, invoice *Invoice) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE invoices
+		SET customer_id = :customer_id, shipment_id = :shipment_id, currency = :currency,
+			payment_terms_days = :payment_terms_days, vat_rate = :vat_rate, subtotal = :subtotal,
+			discount_total = :discount_total, vat_total = :vat_total, total = :total, notes = :notes,
+			updated_at = NOW()
+		WHERE id = :id AND status = 'draft'
+	`
+	result, err := tx.NamedExecContext(ctx, query, invoice)
+	if err != nil {
+		return false, mapError(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM invoice_lines WHERE invoice_id = $1`, invoice.ID); err != nil {
+		return false, err
+	}
+
+	if err := saveLines(ctx, tx, invoice); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// Issue qaralama fakturaya ilin növbəti nömrəsini verir və onu təqdim edilmiş statusa keçirir.
+// Nömrə sayğacı eyni tranzaksiyada artırıldığı üçün uğursuz cəhd nömrə boşluğu yaratmır.
+func (r *PostgresRepository) Issue(ctx context.Context, invoice *Invoice) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var status Status
+	err = tx.GetContext(ctx, &status, `SELECT status FROM invoices WHERE id = $1 FOR UPDATE`, invoice.ID)
+	if err != nil {
+		return false, err
+	}
+	if status != StatusDraft {
+		return false, nil
+	}
+
+	year := invoice.IssueDate.Year()
+
+	var seq int
+	err = tx.GetContext(ctx, &seq, `
+		INSERT INTO invoice_sequences (year, last_number) VALUES ($1, 1)
+		ON CONFLICT (year) DO UPDATE SET last_number = invoice_sequences.last_number + 1
+		RETURNING last_number
+	`, year)
+	if err != nil {
+		return false, err
+	}
+
+	invoice.Number = FormatNumber(year, seq)
+	invoice.Status = StatusIssued
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE invoices
+		SET number = $1, status = $2, issue_date = $3, due_date = $4, updated_at = NOW()
+		WHERE id = $5
+	`, invoice.Number, invoice.Status, invoice.IssueDate, invoice.DueDate, invoice.ID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// UpdateStatus statusu yalnız hazırkı status from olduqda dəyişir
+func (r *PostgresRepository) UpdateStatus(ctx context.Context, id int, from, to Status, paidAt *time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE invoices SET status = $1, paid_at = COALESCE($2, paid_at), updated_at = NOW()
+		WHERE id = $3 AND status = $4
+	`, to, paidAt, id, from)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// MarkOverdue ödəniş tarixi keçmiş təqdim edilmiş fakturaları vaxtı keçmiş kimi işarələyir
+func (r *PostgresRepository) MarkOverdue(ctx context.Context, today time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE invoices SET status = 'overdue', updated_at = NOW()
+		WHERE status = 'issued' AND due_date < $1
+	`, today)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// CustomerOptions aktiv müştərilərin siyahısını əldə edir
+func (r *PostgresRepository) CustomerOptions(ctx context.Context) ([]CustomerOption, error) {
+	options := []CustomerOption{}
+	err := r.db.SelectContext(ctx, &options, `SELECT id, name FROM customers WHERE is_active = true ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
+// ShipmentOptions ləğv edilməmiş son daşınmaların siyahısını əldə edir
+func (r *PostgresRepository) ShipmentOptions(ctx context.Context) ([]ShipmentOption, error) {
+	query := `
+		SELECT s.id, s.reference, c.name AS customer_name
+		FROM shipments s
+		JOIN customers c ON c.id = s.customer_id
+		WHERE s.status <> 'cancelled'
+		ORDER BY s.created_at DESC
+		LIMIT 200
+	`
+
+	options := []ShipmentOption{}
+	if err := r.db.SelectContext(ctx, &options, query); err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
+// ShipmentCustomerID daşınmanın müştəri ID-sini qaytarır, daşınma tapılmadıqda 0
+func (r *PostgresRepository) ShipmentCustomerID(ctx context.Context, shipmentID int) (int, error) {
+	var customerID int
+	err := r.db.GetContext(ctx, &customerID, `SELECT customer_id FROM shipments WHERE id = $1`, shipmentID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return customerID, nil
+}
+
+// saveLines fakturanın sətirlərini yazır
+func saveLines(ctx context.Context, tx *sqlx.Tx, invoice *Invoice) error {
+	query := `
+		INSERT INTO invoice_lines (invoice_id, line_no, description, quantity, unit_price, discount_percent,
+			vat_rate, net_amount, discount_amount, vat_amount, total_amount)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id
+	`
+
+	for i := range invoice.Lines {
+		line := &invoice.Lines[i]
+		line.InvoiceID = invoice.ID
+
+		err := tx.QueryRowxContext(ctx, query, line.InvoiceID, line.LineNo, line.Description, line.Quantity,
+			line.UnitPrice, line.DiscountPercent, line.VATRate, line.NetAmount, line.DiscountAmount,
+			line.VATAmount, line.TotalAmount).Scan(&line.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mapError verilənlər bazası xətalarını domen xətalarına çevirir
+func mapError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == foreignKeyViolation {
+		return &ValidationError{Message: "seçilmiş müştəri və ya daşınma mövcud deyil"}
+	}
+
+	return err
+}
@@ -0,0 +1,96 @@
+package invoice
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/rbac"
+	"github.com/Zam83-AZE/logistics_system/internal/middleware"
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+	"github.com/Zam83-AZE/logistics_system/pkg/openapi"
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/gorilla/mux"
+	"github.com/jmoiron/sqlx"
+)
+
+// RegisterRoutes faktura marşrutlarını qeydə alır
+func RegisterRoutes(router *mux.Router, db *sqlx.DB, tmpl *template.Template, sessionManager *session.Manager) {
+	repo := NewPostgresRepository(db)
+	service := NewInvoiceService(repo, audit.NewRecorder(db), DefaultVATRate)
+	handler := NewHandler(service, tmpl, sessionManager)
+
+	// İcazə yoxlamaları
+	canView := middleware.RequirePermission(rbac.InvoicesView)
+	canManage := middleware.RequirePermission(rbac.InvoicesManage)
+
+	// Siyahı və qaralama yaratma
+	router.Handle("/invoices", canView(http.HandlerFunc(handler.List))).Methods("GET")
+	router.Handle("/invoices/new", canManage(http.HandlerFunc(handler.New))).Methods("GET")
+	router.Handle("/invoices", canManage(http.HandlerFunc(handler.Create))).Methods("POST")
+
+	// Detallar və qaralamanın redaktəsi
+	router.Handle("/invoices/{id:[0-9]+}", canView(http.HandlerFunc(handler.Detail))).Methods("GET")
+	router.Handle("/invoices/{id:[0-9]+}/edit", canManage(http.HandlerFunc(handler.Edit))).Methods("GET")
+	router.Handle("/invoices/{id:[0-9]+}", canManage(http.HandlerFunc(handler.Update))).Methods("POST")
+
+	// Status əməliyyatları
+	router.Handle("/invoices/{id:[0-9]+}/issue", canManage(http.HandlerFunc(handler.Issue))).Methods("POST")
+	router.Handle("/invoices/{id:[0-9]+}/pay", canManage(http.HandlerFunc(handler.MarkPaid))).Methods("POST")
+	router.Handle("/invoices/{id:[0-9]+}/void", canManage(http.HandlerFunc(handler.Void))).Methods("POST")
+}
+
+// RegisterAPIRoutes faktura JSON API marşrutlarını qeydə alır
+func RegisterAPIRoutes(router *mux.Router, db *sqlx.DB) {
+	handler := NewAPIHandler(NewInvoiceService(NewPostgresRepository(db), audit.NewRecorder(db), DefaultVATRate))
+
+	canView := middleware.RequirePermission(rbac.InvoicesView)
+	canManage := middleware.RequirePermission(rbac.InvoicesManage)
+
+	router.Handle("/invoices", canView(http.HandlerFunc(handler.List))).Methods("GET")
+	router.Handle("/invoices", canManage(http.HandlerFunc(handler.Create))).Methods("POST")
+	router.Handle("/invoices/{id:[0-9]+}", canView(http.HandlerFunc(handler.Get))).Methods("GET")
+	router.Handle("/invoices/{id:[0-9]+}", canManage(http.HandlerFunc(handler.Update))).Methods("PUT")
+	router.Handle("/invoices/{id:[0-9]+}/issue", canManage(http.HandlerFunc(handler.Issue))).Methods("POST")
+	router.Handle("/invoices/{id:[0-9]+}/pay", canManage(http.HandlerFunc(handler.MarkPaid))).Methods("POST")
+	router.Handle("/invoices/{id:[0-9]+}/void", canManage(http.HandlerFunc(handler.Void))).Methods("POST")
+}
+
+// DescribeAPI faktura API marşrutlarını OpenAPI sənədinə əlavə edir
+func DescribeAPI(spec *openapi.Spec) {
+	view := string(rbac.InvoicesView)
+	manage := string(rbac.InvoicesManage)
+
+	var statuses []string
+	for _, status := range AllStatuses() {
+		statuses = append(statuses, string(status))
+	}
+
+	spec.Add("GET", "/invoices", openapi.Operation{
+		Summary: "Faktura siyahısı", Tag: "invoices", Permission: view,
+		Query: []openapi.Param{
+			{Name: "q", Description: "Faktura nömrəsi, müştəri və ya daşınma istinadı üzrə axtarış"},
+			{Name: "status", Enum: statuses},
+		},
+		List: true, Sort: sortColumns, Response: Invoice{},
+	})
+	spec.Add("POST", "/invoices", openapi.Operation{
+		Summary: "Qaralama faktura yarat", Tag: "invoices", Permission: manage,
+		Request: InvoiceRequest{}, Response: Invoice{}, Status: http.StatusCreated,
+	})
+	spec.Add("GET", "/invoices/{id}", openapi.Operation{
+		Summary: "Faktura", Tag: "invoices", Permission: view, Response: Invoice{},
+	})
+	spec.Add("PUT", "/invoices/{id}", openapi.Operation{
+		Summary: "Qaralama fakturanı yenilə", Tag: "invoices", Permission: manage,
+		Request: InvoiceRequest{}, Response: Invoice{}, Conflict: true,
+	})
+	spec.Add("POST", "/invoices/{id}/issue", openapi.Operation{
+		Summary: "Fakturanı təqdim et", Tag: "invoices", Permission: manage, Response: Invoice{}, Conflict: true,
+	})
+	spec.Add("POST", "/invoices/{id}/pay", openapi.Operation{
+		Summary: "Ödənilmiş kimi qeyd et", Tag: "invoices", Permission: manage, Response: Invoice{}, Conflict: true,
+	})
+	spec.Add("POST", "/invoices/{id}/void", openapi.Operation{
+		Summary: "Fakturanı ləğv et", Tag: "invoices", Permission: manage, Response: Invoice{}, Conflict: true,
+	})
+}
@@ -0,0 +1,411 @@
+package invoice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+)
+
+const defaultPerPage = 20
+
+// ErrNotFound faktura tapılmadıqda qaytarılır
+var ErrNotFound = errors.New("faktura tapılmadı")
+
+// ErrNotEditable qaralama olmayan fakturanı redaktə etməyə cəhd edildikdə qaytarılır
+var ErrNotEditable = errors.New("yalnız qaralama fakturalar redaktə edilə bilər")
+
+// ErrInvalidTransition icazə verilməyən status keçidi üçün qaytarılır
+var ErrInvalidTransition = errors.New("faktura statusunun bu keçidinə icazə verilmir")
+
+// ErrConcurrentUpdate faktura eyni anda başqa sorğu ilə dəyişdirildikdə qaytarılır
+var ErrConcurrentUpdate = errors.New("faktura artıq dəyişdirilib, səhifəni yeniləyin")
+
+// ValidationError istifadəçiyə göstəriləcək form xətasını təmsil edir
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// Service faktura biznes məntiqini müəyyən edir
+type Service interface {
+	List(ctx context.Context, filter ListFilter) (*InvoiceList, error)
+	Get(ctx context.Context, id int) (*Invoice, error)
+	Customers(ctx context.Context) ([]CustomerOption, error)
+	Shipments(ctx context.Context) ([]ShipmentOption, error)
+	NewForm() InvoiceForm
+	Create(ctx context.Context, form InvoiceForm) (*Invoice, error)
+	Update(ctx context.Context, id int, form InvoiceForm) (*Invoice, error)
+	Issue(ctx context.Context, id int) (*Invoice, error)
+	MarkPaid(ctx context.Context, id int) (*Invoice, error)
+	Void(ctx context.Context, id int) (*Invoice, error)
+	RefreshOverdue(ctx context.Context) error
+}
+
+// InvoiceService Service interfeysini həyata keçirir
+type InvoiceService struct {
+	repo    Repository
+	audit   audit.Recorder
+	vatRate float64
+	now     func() time.Time
+}
+
+// NewInvoiceService yeni InvoiceService yaradır; vatRate yeni fakturalar üçün standart ƏDV dərəcəsidir
+func NewInvoiceService(repo Repository, recorder audit.Recorder, vatRate float64) *InvoiceService {
+	return &InvoiceService{repo: repo, audit: recorder, vatRate: vatRate, now: time.Now}
+}
+
+// List ödəniş müddəti keçmiş fakturaları yeniləyir və filtrə uyğun siyahını qaytarır
+func (s *InvoiceService) List(ctx context.Context, filter ListFilter) (*InvoiceList, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PerPage < 1 || filter.PerPage > 100 {
+		filter.PerPage = defaultPerPage
+	}
+	if !filter.Status.Valid() {
+		filter.Status = ""
+	}
+
+	if err := s.RefreshOverdue(ctx); err != nil {
+		return nil, err
+	}
+
+	invoices, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InvoiceList{
+		Items:   invoices,
+		Total:   total,
+		Page:    filter.Page,
+		PerPage: filter.PerPage,
+	}, nil
+}
+
+// Get fakturanı sətirləri ilə birlikdə qaytarır
+func (s *InvoiceService) Get(ctx context.Context, id int) (*Invoice, error) {
+	invoice, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if invoice == nil {
+		return nil, ErrNotFound
+	}
+
+	return invoice, nil
+}
+
+// Customers faktura üçün seçilə bilən aktiv müştəriləri qaytarır
+func (s *InvoiceService) Customers(ctx context.Context) ([]CustomerOption, error) {
+	return s.repo.CustomerOptions(ctx)
+}
+
+// Shipments faktura üçün seçilə bilən daşınmaları qaytarır
+func (s *InvoiceService) Shipments(ctx context.Context) ([]ShipmentOption, error) {
+	return s.repo.ShipmentOptions(ctx)
+}
+
+// NewForm standart dəyərlərlə doldurulmuş yeni faktura formunu qaytarır
+func (s *InvoiceService) NewForm() InvoiceForm {
+	return InvoiceForm{
+		Currency:         "AZN",
+		PaymentTermsDays: strconv.Itoa(DefaultPaymentTermsDays),
+		VATRate:          formatFloat(s.vatRate),
+	}.WithEmptyLines()
+}
+
+// Create formdakı məlumatlarla qaralama faktura yaradır
+func (s *InvoiceService) Create(ctx context.Context, form InvoiceForm) (*Invoice, error) {
+	invoice := &Invoice{Status: StatusDraft}
+	if err := s.applyForm(ctx, invoice, form); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, invoice); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "invoice.created", nil, invoice)
+
+	return invoice, nil
+}
+
+// Update qaralama fakturanın məlumatlarını formdakı dəyərlərlə yeniləyir
+func (s *InvoiceService) Update(ctx context.Context, id int, form InvoiceForm) (*Invoice, error) {
+	invoice, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !invoice.IsEditable() {
+		return nil, ErrNotEditable
+	}
+
+	before := *invoice
+	if err := s.applyForm(ctx, invoice, form); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.Update(ctx, invoice)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrNotEditable
+	}
+
+	s.record(ctx, "invoice.updated", &before, invoice)
+
+	return invoice, nil
+}
+
+// Issue qaralama fakturaya nömrə verir, təqdim tarixini və ödəniş müddətini təyin edir
+func (s *InvoiceService) Issue(ctx context.Context, id int) (*Invoice, error) {
+	invoice, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !invoice.Status.CanTransition(StatusIssued) {
+		return nil, ErrInvalidTransition
+	}
+
+	if len(invoice.Lines) == 0 {
+		return nil, &ValidationError{Message: "sətri olmayan faktura təqdim edilə bilməz"}
+	}
+
+	before := *invoice
+	issueDate := truncateDay(s.now())
+	dueDate := issueDate.AddDate(0, 0, invoice.PaymentTermsDays)
+	invoice.IssueDate = &issueDate
+	invoice.DueDate = &dueDate
+
+	issued, err := s.repo.Issue(ctx, invoice)
+	if err != nil {
+		return nil, err
+	}
+	if !issued {
+		return nil, ErrConcurrentUpdate
+	}
+
+	s.record(ctx, "invoice.issued", &before, invoice)
+
+	return invoice, nil
+}
+
+// MarkPaid təqdim edilmiş və ya vaxtı keçmiş fakturanı ödənilmiş kimi qeyd edir
+func (s *InvoiceService) MarkPaid(ctx context.Context, id int) (*Invoice, error) {
+	paidAt := s.now()
+	return s.transition(ctx, id, StatusPaid, &paidAt)
+}
+
+// Void fakturanı ləğv edir
+func (s *InvoiceService) Void(ctx context.Context, id int) (*Invoice, error) {
+	return s.transition(ctx, id, StatusVoid, nil)
+}
+
+// RefreshOverdue ödəniş tarixi keçmiş fakturaları vaxtı keçmiş statusuna keçirir
+func (s *InvoiceService) RefreshOverdue(ctx context.Context) error {
+	marked, err := s.repo.MarkOverdue(ctx, truncateDay(s.now()))
+	if err != nil || marked == 0 {
+		return err
+	}
+
+	s.audit.Record(ctx, audit.Event{
+		Action:     "invoice.overdue_marked",
+		EntityType: "invoice",
+		Details:    map[string]interface{}{"count": marked},
+	})
+
+	return nil
+}
+
+// transition fakturanı icazə verilən statusa keçirir
+func (s *InvoiceService) transition(ctx context.Context, id int, to Status, paidAt *time.Time) (*Invoice, error) {
+	invoice, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !invoice.Status.CanTransition(to) {
+		return nil, ErrInvalidTransition
+	}
+
+	updated, err := s.repo.UpdateStatus(ctx, id, invoice.Status, to, paidAt)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrConcurrentUpdate
+	}
+
+	before := *invoice
+	invoice.Status = to
+	invoice.PaidAt = paidAt
+
+	s.record(ctx, "invoice."+string(to), &before, invoice)
+
+	return invoice, nil
+}
+
+// record faktura üzərində əməliyyatı audit jurnalına yazır
+func (s *InvoiceService) record(ctx context.Context, action string, before, after *Invoice) {
+	s.audit.Record(ctx, audit.Event{
+		Action:     action,
+		EntityType: "invoice",
+		EntityID:   strconv.Itoa(after.ID),
+		Before:     before,
+		After:      after,
+	})
+}
+
+// applyForm formu yoxlayır, dəyərləri fakturaya köçürür və yekunları hesablayır
+func (s *InvoiceService) applyForm(ctx context.Context, invoice *Invoice, form InvoiceForm) error {
+	customerID, err := strconv.Atoi(strings.TrimSpace(form.CustomerID))
+	if err != nil || customerID <= 0 {
+		return &ValidationError{Message: "müştəri seçilməlidir"}
+	}
+
+	var shipmentID *int
+	if value := strings.TrimSpace(form.ShipmentID); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return &ValidationError{Message: "daşınma yanlış seçilib"}
+		}
+
+		owner, err := s.repo.ShipmentCustomerID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if owner == 0 {
+			return &ValidationError{Message: "seçilmiş daşınma mövcud deyil"}
+		}
+		if owner != customerID {
+			return &ValidationError{Message: "seçilmiş daşınma bu müştəriyə aid deyil"}
+		}
+		shipmentID = &id
+	}
+
+	currency := strings.ToUpper(strings.TrimSpace(form.Currency))
+	if currency == "" {
+		currency = "AZN"
+	}
+	if len(currency) != 3 {
+		return &ValidationError{Message: "valyuta kodu 3 hərfdən ibarət olmalıdır"}
+	}
+
+	terms := DefaultPaymentTermsDays
+	if value := strings.TrimSpace(form.PaymentTermsDays); value != "" {
+		terms, err = strconv.Atoi(value)
+		if err != nil || terms < 0 || terms > 365 {
+			return &ValidationError{Message: "ödəniş müddəti 0-365 gün aralığında olmalıdır"}
+		}
+	}
+
+	vatRate := s.vatRate
+	if value := strings.TrimSpace(form.VATRate); value != "" {
+		vatRate, err = parsePercent(value)
+		if err != nil {
+			return &ValidationError{Message: "ƏDV dərəcəsi 0-100 aralığında olmalıdır"}
+		}
+	}
+
+	lines, err := parseLines(form.Lines, vatRate)
+	if err != nil {
+		return err
+	}
+
+	invoice.CustomerID = customerID
+	invoice.ShipmentID = shipmentID
+	invoice.Currency = currency
+	invoice.PaymentTermsDays = terms
+	invoice.VATRate = vatRate
+	invoice.Notes = strings.TrimSpace(form.Notes)
+	invoice.Lines = lines
+	invoice.Recalculate()
+
+	return nil
+}
+
+// parseLines formdakı doldurulmuş sətirləri yoxlayır və çevirir
+func parseLines(forms []LineForm, defaultVATRate float64) ([]Line, error) {
+	var lines []Line
+
+	for _, f := range forms {
+		if f.IsEmpty() {
+			continue
+		}
+
+		lineNo := len(lines) + 1
+		description := strings.TrimSpace(f.Description)
+		if description == "" {
+			return nil, &ValidationError{Message: fmt.Sprintf("%d-ci sətirdə təsvir tələb olunur", lineNo)}
+		}
+
+		quantity, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(f.Quantity), ",", "."), 64)
+		if err != nil || quantity <= 0 {
+			return nil, &ValidationError{Message: fmt.Sprintf("%d-ci sətirdə miqdar müsbət olmalıdır", lineNo)}
+		}
+
+		price, err := ParseMoney(f.UnitPrice)
+		if err != nil || price < 0 {
+			return nil, &ValidationError{Message: fmt.Sprintf("%d-ci sətirdə vahid qiyməti yanlışdır", lineNo)}
+		}
+
+		var discount float64
+		if value := strings.TrimSpace(f.DiscountPercent); value != "" {
+			discount, err = parsePercent(value)
+			if err != nil {
+				return nil, &ValidationError{Message: fmt.Sprintf("%d-ci sətirdə endirim 0-100 aralığında olmalıdır", lineNo)}
+			}
+		}
+
+		vatRate := defaultVATRate
+		if value := strings.TrimSpace(f.VATRate); value != "" {
+			vatRate, err = parsePercent(value)
+			if err != nil {
+				return nil, &ValidationError{Message: fmt.Sprintf("%d-ci sətirdə ƏDV dərəcəsi yanlışdır", lineNo)}
+			}
+		}
+
+		lines = append(lines, Line{
+			LineNo:          lineNo,
+			Description:     description,
+			Quantity:        quantity,
+			UnitPrice:       price,
+			DiscountPercent: discount,
+			VATRate:         vatRate,
+		})
+	}
+
+	if len(lines) == 0 {
+		return nil, &ValidationError{Message: "ən azı bir faktura sətri tələb olunur"}
+	}
+
+	return lines, nil
+}
+
+// parsePercent 0-100 aralığında faiz dəyərini oxuyur
+func parsePercent(value string) (float64, error) {
+	p, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", "."), 64)
+	if err != nil || p < 0 || p > 100 {
+		return 0, errors.New("yanlış faiz")
+	}
+	return p, nil
+}
+
+// truncateDay vaxtı yerli günün başlanğıcına qədər kəsir
+func truncateDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,198 @@
+package location
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/api"
+)
+
+// LocationRequest API vasitəsilə məntəqə yaratma və yeniləmə sorğusunun gövdəsidir
+type LocationRequest struct {
+	UNLOCODE    string   `json:"unlocode"`
+	Name        string   `json:"name"`
+	Kind        string   `json:"kind"`
+	Country     string   `json:"country"`
+	Subdivision string   `json:"subdivision"`
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+	Timezone    string   `json:"timezone"`
+	Address     string   `json:"address"`
+	CustomerID  *int     `json:"customerId"`
+}
+
+// form sorğunu HTML formu ilə eyni yoxlamalardan keçən forma çevirir
+func (req LocationRequest) form() LocationForm {
+	form := LocationForm{
+		UNLOCODE:    req.UNLOCODE,
+		Name:        req.Name,
+		Kind:        req.Kind,
+		Country:     req.Country,
+		Subdivision: req.Subdivision,
+		Latitude:    formatCoordinate(req.Latitude),
+		Longitude:   formatCoordinate(req.Longitude),
+		Timezone:    req.Timezone,
+		Address:     req.Address,
+	}
+	if req.CustomerID != nil {
+		form.CustomerID = strconv.Itoa(*req.CustomerID)
+	}
+	return form
+}
+
+// APIHandler məntəqə JSON API sorğularını işləyir
+type APIHandler struct {
+	service Service
+}
+
+// NewAPIHandler yeni məntəqə API işləyicisi yaradır
+func NewAPIHandler(service Service) *APIHandler {
+	return &APIHandler{service: service}
+}
+
+// List məntəqə siyahısını qaytarır: ?q=, ?kind=, ?country=, ?inactive=true, ?sort=, ?cursor=, ?limit=
+func (h *APIHandler) List(w http.ResponseWriter, r *http.Request) {
+	params, err := api.ParseList(r, sortColumns)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	inactive, err := api.QueryBool(r, "inactive")
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	kind := r.URL.Query().Get("kind")
+	if _, ok := KindLabels[kind]; kind != "" && !ok {
+		api.WriteError(w, api.NewError(http.StatusBadRequest, api.CodeBadRequest, "naməlum məntəqə növü: "+kind))
+		return
+	}
+
+	locations, err := h.service.List(r.Context(), ListFilter{
+		Query:           r.URL.Query().Get("q"),
+		Kind:            kind,
+		Country:         r.URL.Query().Get("country"),
+		IncludeInactive: inactive,
+		Sort:            params.Sort,
+		Page:            params.Page,
+		PerPage:         params.PerPage,
+	})
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteList(w, locations.Items, locations.Total, params)
+}
+
+// Get məntəqəni qaytarır
+func (h *APIHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	location, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, location)
+}
+
+// Create yeni məntəqə yaradır
+func (h *APIHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req LocationRequest
+	if err := api.Decode(w, r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	location, err := h.service.Create(r.Context(), req.form())
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusCreated, location)
+}
+
+// Update məntəqənin məlumatlarını tam əvəz edir
+func (h *APIHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	var req LocationRequest
+	if err := api.Decode(w, r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	location, err := h.service.Update(r.Context(), id, req.form())
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, location)
+}
+
+// Deactivate məntəqəni deaktiv edir
+func (h *APIHandler) Deactivate(w http.ResponseWriter, r *http.Request) {
+	h.setActive(w, r, false)
+}
+
+// Activate məntəqəni yenidən aktiv edir
+func (h *APIHandler) Activate(w http.ResponseWriter, r *http.Request) {
+	h.setActive(w, r, true)
+}
+
+// setActive məntəqənin statusunu dəyişir və yenilənmiş məntəqəni qaytarır
+func (h *APIHandler) setActive(w http.ResponseWriter, r *http.Request, active bool) {
+	ctx := r.Context()
+
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	if active {
+		err = h.service.Activate(ctx, id)
+	} else {
+		err = h.service.Deactivate(ctx, id)
+	}
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	location, err := h.service.Get(ctx, id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, location)
+}
+
+// writeAPIError servis xətasını uyğun HTTP statusu ilə yazır
+func writeAPIError(w http.ResponseWriter, err error) {
+	var validationErr *ValidationError
+	switch {
+	case errors.Is(err, ErrNotFound):
+		api.WriteError(w, api.NewError(http.StatusNotFound, api.CodeNotFound, err.Error()))
+	case errors.As(err, &validationErr):
+		api.WriteError(w, api.NewError(http.StatusUnprocessableEntity, api.CodeValidation, validationErr.Message))
+	default:
+		api.WriteError(w, err)
+	}
+}
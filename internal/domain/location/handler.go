@@ -0,0 +1,237 @@
+package location
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/Zam83-AZE/logistics_system/pkg/view"
+	"github.com/gorilla/mux"
+)
+
+// Handler məntəqə HTTP sorğularını işləyir
+type Handler struct {
+	service        Service
+	tmpl           *template.Template
+	sessionManager *session.Manager
+}
+
+// NewHandler yeni məntəqə işləyicisi yaradır
+func NewHandler(service Service, tmpl *template.Template, sessionManager *session.Manager) *Handler {
+	return &Handler{
+		service:        service,
+		tmpl:           tmpl,
+		sessionManager: sessionManager,
+	}
+}
+
+// List məntəqə siyahısını axtarış, filtr və səhifələmə ilə göstərir
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	filter := ListFilter{
+		Query:           r.URL.Query().Get("q"),
+		Kind:            r.URL.Query().Get("kind"),
+		Country:         r.URL.Query().Get("country"),
+		IncludeInactive: r.URL.Query().Get("inactive") == "1",
+		Page:            page,
+	}
+
+	locations, err := h.service.List(ctx, filter)
+	if err != nil {
+		http.Error(w, "Məntəqə siyahısı əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	data := ListPage{
+		Locations:   *locations,
+		Filter:      filter,
+		Kinds:       KindOptions(),
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "locations",
+	}
+
+	view.Render(w, r, h.tmpl, "location/list.html", data)
+}
+
+// New yeni məntəqə formunu göstərir
+func (h *Handler) New(w http.ResponseWriter, r *http.Request) {
+	h.renderForm(w, r, LocationForm{Kind: KindPort}, nil, http.StatusOK, "")
+}
+
+// Create yeni məntəqə yaradır
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	form := parseForm(r)
+
+	location, err := h.service.Create(r.Context(), form)
+	if err != nil {
+		h.renderFormError(w, r, form, nil, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/locations/%d", location.ID), http.StatusSeeOther)
+}
+
+// Detail məntəqənin detallarını göstərir
+func (h *Handler) Detail(w http.ResponseWriter, r *http.Request) {
+	location, ok := h.loadLocation(w, r)
+	if !ok {
+		return
+	}
+
+	data := DetailPage{
+		Location:    *location,
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "locations",
+	}
+
+	view.Render(w, r, h.tmpl, "location/detail.html", data)
+}
+
+// Edit mövcud məntəqənin redaktə formunu göstərir
+func (h *Handler) Edit(w http.ResponseWriter, r *http.Request) {
+	location, ok := h.loadLocation(w, r)
+	if !ok {
+		return
+	}
+
+	h.renderForm(w, r, FormFromLocation(location), location, http.StatusOK, "")
+}
+
+// Update mövcud məntəqənin məlumatlarını yeniləyir
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	location, ok := h.loadLocation(w, r)
+	if !ok {
+		return
+	}
+
+	form := parseForm(r)
+
+	if _, err := h.service.Update(r.Context(), location.ID, form); err != nil {
+		h.renderFormError(w, r, form, location, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/locations/%d", location.ID), http.StatusSeeOther)
+}
+
+// Deactivate məntəqəni deaktiv edir
+func (h *Handler) Deactivate(w http.ResponseWriter, r *http.Request) {
+	h.setActive(w, r, false)
+}
+
+// Activate məntəqəni yenidən aktiv edir
+func (h *Handler) Activate(w http.ResponseWriter, r *http.Request) {
+	h.setActive(w, r, true)
+}
+
+// setActive məntəqənin aktivlik statusunu dəyişir və detallar səhifəsinə yönləndirir
+func (h *Handler) setActive(w http.ResponseWriter, r *http.Request, active bool) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if active {
+		err = h.service.Activate(ctx, id)
+	} else {
+		err = h.service.Deactivate(ctx, id)
+	}
+
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		http.Error(w, "Məntəqə statusunu dəyişərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/locations/%d", id), http.StatusSeeOther)
+}
+
+// loadLocation URL-dəki ID-yə görə məntəqəni əldə edir, tapılmadıqda cavabı özü yazır
+func (h *Handler) loadLocation(w http.ResponseWriter, r *http.Request) (*Location, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return nil, false
+	}
+
+	location, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			http.NotFound(w, r)
+			return nil, false
+		}
+		http.Error(w, "Məntəqə məlumatlarını əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	return location, true
+}
+
+// renderFormError formu xəta mesajı ilə yenidən göstərir
+func (h *Handler) renderFormError(w http.ResponseWriter, r *http.Request, form LocationForm, location *Location, err error) {
+	if errors.Is(err, ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+
+	var validationErr *ValidationError
+	message := "Məntəqə məlumatlarını saxlayarkən xəta baş verdi"
+	if errors.As(err, &validationErr) {
+		message = validationErr.Message
+	}
+
+	h.renderForm(w, r, form, location, http.StatusUnprocessableEntity, message)
+}
+
+// renderForm məntəqə formunu müştəri siyahısı ilə göstərir; location nil olduqda yeni məntəqə formudur
+func (h *Handler) renderForm(w http.ResponseWriter, r *http.Request, form LocationForm, location *Location, status int, message string) {
+	customers, err := h.service.Customers(r.Context())
+	if err != nil {
+		http.Error(w, "Müştəri siyahısı əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	data := FormPage{
+		Form:        form,
+		Kinds:       KindOptions(),
+		Customers:   customers,
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "locations",
+		Error:       message,
+	}
+	if location != nil {
+		data.LocationID = location.ID
+		data.IsEdit = true
+		data.IsImported = location.IsImported()
+	}
+
+	w.WriteHeader(status)
+	view.Render(w, r, h.tmpl, "location/form.html", data)
+}
+
+// parseForm sorğudan məntəqə formunun dəyərlərini oxuyur
+func parseForm(r *http.Request) LocationForm {
+	return LocationForm{
+		UNLOCODE:    r.FormValue("unlocode"),
+		Name:        r.FormValue("name"),
+		Kind:        r.FormValue("kind"),
+		Country:     r.FormValue("country"),
+		Subdivision: r.FormValue("subdivision"),
+		Latitude:    r.FormValue("latitude"),
+		Longitude:   r.FormValue("longitude"),
+		Timezone:    r.FormValue("timezone"),
+		Address:     r.FormValue("address"),
+		CustomerID:  r.FormValue("customer_id"),
+	}
+}
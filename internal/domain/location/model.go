@@ -0,0 +1,220 @@
+package location
+
+import (
+	"fmt"
+	"time"
+)
+
+// Məntəqə növləri
+const (
+	KindPort           = "port"
+	KindTerminal       = "terminal"
+	KindWarehouse      = "warehouse"
+	KindBorderCrossing = "border_crossing"
+	KindCustomerSite   = "customer_site"
+	KindPlace          = "place"
+)
+
+// KindLabels növlərin istifadəçi üçün adlarını saxlayır
+var KindLabels = map[string]string{
+	KindPort:           "Liman",
+	KindTerminal:       "Terminal",
+	KindWarehouse:      "Anbar",
+	KindBorderCrossing: "Sərhəd keçid məntəqəsi",
+	KindCustomerSite:   "Müştəri ünvanı",
+	KindPlace:          "Digər məntəqə",
+}
+
+// Məntəqənin mənbəyi: əl ilə əlavə edilib və ya UN/LOCODE siyahısından idxal edilib
+const (
+	SourceManual   = "manual"
+	SourceUNLOCODE = "unlocode"
+)
+
+// KindOption formda göstəriləcək növ seçimini təmsil edir
+type KindOption struct {
+	Value string
+	Label string
+}
+
+// KindOptions bütün növləri formda göstəriləcək sıra ilə qaytarır
+func KindOptions() []KindOption {
+	kinds := []string{KindPort, KindTerminal, KindWarehouse, KindBorderCrossing, KindCustomerSite, KindPlace}
+
+	options := make([]KindOption, 0, len(kinds))
+	for _, kind := range kinds {
+		options = append(options, KindOption{Value: kind, Label: KindLabels[kind]})
+	}
+
+	return options
+}
+
+// Location verilənlər bazasından gələn məntəqə məlumatlarını təmsil edir
+type Location struct {
+	ID           int       `db:"id" json:"id"`
+	UNLOCODE     string    `db:"unlocode" json:"unlocode"`
+	Name         string    `db:"name" json:"name"`
+	Kind         string    `db:"kind" json:"kind"`
+	Country      string    `db:"country" json:"country"`
+	Subdivision  string    `db:"subdivision" json:"subdivision"`
+	Latitude     *float64  `db:"latitude" json:"latitude"`
+	Longitude    *float64  `db:"longitude" json:"longitude"`
+	Timezone     string    `db:"timezone" json:"timezone"`
+	Address      string    `db:"address" json:"address"`
+	CustomerID   *int      `db:"customer_id" json:"customerId"`
+	CustomerName *string   `db:"customer_name" json:"customerName"`
+	Source       string    `db:"source" json:"source"`
+	IsActive     bool      `db:"is_active" json:"isActive"`
+	CreatedAt    time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt    time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// KindLabel növün istifadəçi üçün adını qaytarır
+func (l Location) KindLabel() string {
+	if label, ok := KindLabels[l.Kind]; ok {
+		return label
+	}
+	return l.Kind
+}
+
+// Coordinates koordinatları "enlik, uzunluq" formatında qaytarır; koordinat yoxdursa boş sətir
+func (l Location) Coordinates() string {
+	if l.Latitude == nil || l.Longitude == nil {
+		return ""
+	}
+	return fmt.Sprintf("%.5f, %.5f", *l.Latitude, *l.Longitude)
+}
+
+// IsImported məntəqənin UN/LOCODE siyahısından idxal edilib-edilmədiyini göstərir
+func (l Location) IsImported() bool {
+	return l.Source == SourceUNLOCODE
+}
+
+// ListFilter məntəqə siyahısı üçün axtarış və səhifələmə parametrlərini saxlayır
+type ListFilter struct {
+	Query           string
+	Kind            string
+	Country         string
+	IncludeInactive bool
+	Sort            string
+	Page            int
+	PerPage         int
+}
+
+// LocationList səhifələnmiş məntəqə siyahısını təmsil edir
+type LocationList struct {
+	Items   []Location
+	Total   int
+	Page    int
+	PerPage int
+}
+
+// HasPrev əvvəlki səhifənin olub-olmadığını göstərir
+func (l LocationList) HasPrev() bool {
+	return l.Page > 1
+}
+
+// HasNext növbəti səhifənin olub-olmadığını göstərir
+func (l LocationList) HasNext() bool {
+	return l.Page*l.PerPage < l.Total
+}
+
+// PrevPage əvvəlki səhifənin nömrəsini qaytarır
+func (l LocationList) PrevPage() int {
+	return l.Page - 1
+}
+
+// NextPage növbəti səhifənin nömrəsini qaytarır
+func (l LocationList) NextPage() int {
+	return l.Page + 1
+}
+
+// LocationForm məntəqə yaratma və redaktə formunu təmsil edir
+type LocationForm struct {
+	UNLOCODE    string
+	Name        string
+	Kind        string
+	Country     string
+	Subdivision string
+	Latitude    string
+	Longitude   string
+	Timezone    string
+	Address     string
+	CustomerID  string
+}
+
+// CustomerOption formda seçilə bilən müştərini təmsil edir
+type CustomerOption struct {
+	ID   int    `db:"id"`
+	Name string `db:"name"`
+}
+
+// ListPage məntəqə siyahısı səhifəsi üçün məlumatları təmsil edir
+type ListPage struct {
+	Locations   LocationList
+	Filter      ListFilter
+	Kinds       []KindOption
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// FormPage məntəqə formu səhifəsi üçün məlumatları təmsil edir
+type FormPage struct {
+	Form        LocationForm
+	LocationID  int
+	IsEdit      bool
+	IsImported  bool
+	Kinds       []KindOption
+	Customers   []CustomerOption
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// DetailPage məntəqə detalları səhifəsi üçün məlumatları təmsil edir
+type DetailPage struct {
+	Location    Location
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// FormFromLocation mövcud məntəqədən redaktə formu yaradır
+func FormFromLocation(l *Location) LocationForm {
+	form := LocationForm{
+		UNLOCODE:    l.UNLOCODE,
+		Name:        l.Name,
+		Kind:        l.Kind,
+		Country:     l.Country,
+		Subdivision: l.Subdivision,
+		Latitude:    formatCoordinate(l.Latitude),
+		Longitude:   formatCoordinate(l.Longitude),
+		Timezone:    l.Timezone,
+		Address:     l.Address,
+	}
+	if l.CustomerID != nil {
+		form.CustomerID = fmt.Sprint(*l.CustomerID)
+	}
+	return form
+}
+
+// ImportOptions UN/LOCODE idxalının parametrləridir.
+// All false olduqda yalnız liman və sərhəd keçidi funksiyası olan məntəqələr idxal edilir;
+// Countries boş deyilsə yalnız həmin ölkələrin məntəqələri idxal edilir.
+type ImportOptions struct {
+	All       bool
+	Countries []string
+}
+
+// ImportResult UN/LOCODE idxalının nəticəsidir
+type ImportResult struct {
+	Read        int `json:"read"`
+	Inserted    int `json:"inserted"`
+	Updated     int `json:"updated"`
+	Deactivated int `json:"deactivated"`
+	Skipped     int `json:"skipped"`
+
+	// Errors buraxılmış yanlış sətirlərdir (ən çox maxLineErrors); onlar Skipped-də də sayılır
+	Errors []LineError `json:"errors,omitempty"`
+}
@@ -0,0 +1,251 @@
+package location
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/db"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// foreignKeyViolation PostgreSQL-in xarici açar məhdudiyyəti pozulması kodudur
+const foreignKeyViolation = "23503"
+
+// Repository məntəqə məlumatları əməliyyatlarını müəyyən edir
+type Repository interface {
+	List(ctx context.Context, filter ListFilter) ([]Location, int, error)
+	GetByID(ctx context.Context, id int) (*Location, error)
+	Create(ctx context.Context, location *Location) error
+	Update(ctx context.Context, location *Location) error
+	SetActive(ctx context.Context, id int, active bool) error
+	CustomerOptions(ctx context.Context) ([]CustomerOption, error)
+	Import(ctx context.Context, entries []UNLOCODEEntry) (*ImportResult, error)
+}
+
+// sortColumns siyahının sıralana biləcəyi sahələri və onlara uyğun sütunları saxlayır
+var sortColumns = map[string]string{
+	"name":      "l.name",
+	"unlocode":  "l.unlocode",
+	"country":   "l.country",
+	"kind":      "l.kind",
+	"createdAt": "l.created_at",
+	"updatedAt": "l.updated_at",
+}
+
+const selectLocation = `
+	SELECT l.id, l.unlocode, l.name, l.kind, l.country, l.subdivision, l.latitude, l.longitude,
+		l.timezone, l.address, l.customer_id, c.name AS customer_name, l.source, l.is_active,
+		l.created_at, l.updated_at
+	FROM locations l
+	LEFT JOIN customers c ON c.id = l.customer_id
+`
+
+// PostgresRepository Repository interfeysini həyata keçirir
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRepository yeni PostgresRepository yaradır
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+// List filtrə uyğun məntəqələri və ümumi sayı əldə edir
+func (r *PostgresRepository) List(ctx context.Context, filter ListFilter) ([]Location, int, error) {
+	var conditions []string
+	var args []interface{}
+
+	if !filter.IncludeInactive {
+		conditions = append(conditions, "l.is_active = true")
+	}
+
+	if filter.Kind != "" {
+		args = append(args, filter.Kind)
+		conditions = append(conditions, "l.kind = $"+strconv.Itoa(len(args)))
+	}
+
+	if filter.Country != "" {
+		args = append(args, filter.Country)
+		conditions = append(conditions, "l.country = $"+strconv.Itoa(len(args)))
+	}
+
+	if q := strings.TrimSpace(filter.Query); q != "" {
+		args = append(args, "%"+q+"%")
+		n := strconv.Itoa(len(args))
+		conditions = append(conditions, "(l.name ILIKE $"+n+" OR l.unlocode ILIKE $"+n+" OR l.address ILIKE $"+n+")")
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM locations l"+where, args...); err != nil {
+		return nil, 0, err
+	}
+
+	query := selectLocation + where + `
+		ORDER BY ` + db.OrderBy(filter.Sort, sortColumns, "l.country, l.name, l.id", "l.id") + `
+		LIMIT $` + strconv.Itoa(len(args)+1) + ` OFFSET $` + strconv.Itoa(len(args)+2)
+
+	args = append(args, filter.PerPage, (filter.Page-1)*filter.PerPage)
+
+	locations := []Location{}
+	if err := r.db.SelectContext(ctx, &locations, query, args...); err != nil {
+		return nil, 0, err
+	}
+
+	return locations, total, nil
+}
+
+// GetByID məntəqəni ID-yə görə əldə edir
+func (r *PostgresRepository) GetByID(ctx context.Context, id int) (*Location, error) {
+	location := &Location{}
+	err := r.db.GetContext(ctx, location, selectLocation+" WHERE l.id = $1", id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Məntəqə tapılmadı
+		}
+		return nil, err
+	}
+
+	return location, nil
+}
+
+// Create yeni məntəqə əlavə edir
+func (r *PostgresRepository) Create(ctx context.Context, location *Location) error {
+	query := `
+		INSERT INTO locations (unlocode, name, kind, country, subdivision, latitude, longitude,
+			timezone, address, customer_id, source, is_active)
+		VALUES (:unlocode, :name, :kind, :country, :subdivision, :latitude, :longitude,
+			:timezone, :address, :customer_id, :source, :is_active)
+		RETURNING id, created_at, updated_at
+	`
+
+	rows, err := r.db.NamedQueryContext(ctx, query, location)
+	if err != nil {
+		return mapError(err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		return rows.Scan(&location.ID, &location.CreatedAt, &location.UpdatedAt)
+	}
+
+	return rows.Err()
+}
+
+// Update mövcud məntəqənin məlumatlarını yeniləyir
+func (r *PostgresRepository) Update(ctx context.Context, location *Location) error {
+	query := `
+		UPDATE locations
+		SET unlocode = :unlocode, name = :name, kind = :kind, country = :country,
+			subdivision = :subdivision, latitude = :latitude, longitude = :longitude,
+			timezone = :timezone, address = :address, customer_id = :customer_id, updated_at = NOW()
+		WHERE id = :id
+	`
+
+	_, err := r.db.NamedExecContext(ctx, query, location)
+	return mapError(err)
+}
+
+// SetActive məntəqənin aktivlik statusunu dəyişir
+func (r *PostgresRepository) SetActive(ctx context.Context, id int, active bool) error {
+	query := `UPDATE locations SET is_active = $1, updated_at = NOW() WHERE id = $2`
+
+	_, err := r.db.ExecContext(ctx, query, active, id)
+	return err
+}
+
+// CustomerOptions müştəri ünvanları üçün seçilə bilən aktiv müştəriləri əldə edir
+func (r *PostgresRepository) CustomerOptions(ctx context.Context) ([]CustomerOption, error) {
+	options := []CustomerOption{}
+	err := r.db.SelectContext(ctx, &options, `SELECT id, name FROM customers WHERE is_active = true ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
+// Import UN/LOCODE məntəqələrini bir tranzaksiyada əlavə edir və ya yeniləyir; idxal ya tam tətbiq edilir, ya da heç tətbiq edilmir.
+// Mövcud məntəqələrdə yalnız siyahıdan gələn sahələr yenilənir; növ, vaxt zonası, ünvan və
+// aktivlik istifadəçinin dəyişiklikləri kimi saxlanılır. Siyahıdan silinən məntəqələr deaktiv edilir.
+func (r *PostgresRepository) Import(ctx context.Context, entries []UNLOCODEEntry) (*ImportResult, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	upsert, err := tx.PreparexContext(ctx, `
+		INSERT INTO locations (unlocode, name, kind, country, subdivision, latitude, longitude, source)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, '`+SourceUNLOCODE+`')
+		ON CONFLICT (unlocode) WHERE source = '`+SourceUNLOCODE+`' DO UPDATE
+		SET name = EXCLUDED.name, country = EXCLUDED.country, subdivision = EXCLUDED.subdivision,
+			latitude = COALESCE(EXCLUDED.latitude, locations.latitude),
+			longitude = COALESCE(EXCLUDED.longitude, locations.longitude),
+			updated_at = NOW()
+		RETURNING (xmax = 0) AS inserted
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer upsert.Close()
+
+	deactivate, err := tx.PreparexContext(ctx, `
+		UPDATE locations SET is_active = false, updated_at = NOW()
+		WHERE source = '`+SourceUNLOCODE+`' AND unlocode = $1 AND is_active = true
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer deactivate.Close()
+
+	result := &ImportResult{}
+	for _, entry := range entries {
+		if entry.Removed {
+			res, err := deactivate.ExecContext(ctx, entry.Code)
+			if err != nil {
+				return nil, err
+			}
+			if n, _ := res.RowsAffected(); n > 0 {
+				result.Deactivated++
+			} else {
+				result.Skipped++
+			}
+			continue
+		}
+
+		var inserted bool
+		err := upsert.QueryRowxContext(ctx, entry.Code, entry.Name, entry.Kind(), entry.Country, entry.Subdivision,
+			entry.Latitude, entry.Longitude).Scan(&inserted)
+		if err != nil {
+			return nil, err
+		}
+		if inserted {
+			result.Inserted++
+		} else {
+			result.Updated++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// mapError verilənlər bazası xətalarını domen xətalarına çevirir
+func mapError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == foreignKeyViolation {
+		return &ValidationError{Message: "seçilmiş müştəri mövcud deyil"}
+	}
+
+	return err
+}
@@ -0,0 +1,91 @@
+package location
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/rbac"
+	"github.com/Zam83-AZE/logistics_system/internal/middleware"
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+	"github.com/Zam83-AZE/logistics_system/pkg/openapi"
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/gorilla/mux"
+	"github.com/jmoiron/sqlx"
+)
+
+// RegisterRoutes məntəqə marşrutlarını qeydə alır
+func RegisterRoutes(router *mux.Router, db *sqlx.DB, tmpl *template.Template, sessionManager *session.Manager) {
+	repo := NewPostgresRepository(db)
+	service := NewLocationService(repo, audit.NewRecorder(db))
+	handler := NewHandler(service, tmpl, sessionManager)
+
+	// İcazə yoxlamaları
+	canView := middleware.RequirePermission(rbac.LocationsView)
+	canManage := middleware.RequirePermission(rbac.LocationsManage)
+
+	// Siyahı və yaratma
+	router.Handle("/locations", canView(http.HandlerFunc(handler.List))).Methods("GET")
+	router.Handle("/locations/new", canManage(http.HandlerFunc(handler.New))).Methods("GET")
+	router.Handle("/locations", canManage(http.HandlerFunc(handler.Create))).Methods("POST")
+
+	// Detallar, redaktə və status dəyişikliyi
+	router.Handle("/locations/{id:[0-9]+}", canView(http.HandlerFunc(handler.Detail))).Methods("GET")
+	router.Handle("/locations/{id:[0-9]+}/edit", canManage(http.HandlerFunc(handler.Edit))).Methods("GET")
+	router.Handle("/locations/{id:[0-9]+}", canManage(http.HandlerFunc(handler.Update))).Methods("POST")
+	router.Handle("/locations/{id:[0-9]+}/deactivate", canManage(http.HandlerFunc(handler.Deactivate))).Methods("POST")
+	router.Handle("/locations/{id:[0-9]+}/activate", canManage(http.HandlerFunc(handler.Activate))).Methods("POST")
+}
+
+// RegisterAPIRoutes məntəqə JSON API marşrutlarını qeydə alır
+func RegisterAPIRoutes(router *mux.Router, db *sqlx.DB) {
+	handler := NewAPIHandler(NewLocationService(NewPostgresRepository(db), audit.NewRecorder(db)))
+
+	canView := middleware.RequirePermission(rbac.LocationsView)
+	canManage := middleware.RequirePermission(rbac.LocationsManage)
+
+	router.Handle("/locations", canView(http.HandlerFunc(handler.List))).Methods("GET")
+	router.Handle("/locations", canManage(http.HandlerFunc(handler.Create))).Methods("POST")
+	router.Handle("/locations/{id:[0-9]+}", canView(http.HandlerFunc(handler.Get))).Methods("GET")
+	router.Handle("/locations/{id:[0-9]+}", canManage(http.HandlerFunc(handler.Update))).Methods("PUT")
+	router.Handle("/locations/{id:[0-9]+}/deactivate", canManage(http.HandlerFunc(handler.Deactivate))).Methods("POST")
+	router.Handle("/locations/{id:[0-9]+}/activate", canManage(http.HandlerFunc(handler.Activate))).Methods("POST")
+}
+
+// DescribeAPI məntəqə API marşrutlarını OpenAPI sənədinə əlavə edir
+func DescribeAPI(spec *openapi.Spec) {
+	view := string(rbac.LocationsView)
+	manage := string(rbac.LocationsManage)
+
+	kinds := make([]string, 0, len(KindLabels))
+	for _, option := range KindOptions() {
+		kinds = append(kinds, option.Value)
+	}
+
+	spec.Add("GET", "/locations", openapi.Operation{
+		Summary: "Məntəqə siyahısı", Tag: "locations", Permission: view,
+		Query: []openapi.Param{
+			{Name: "q", Description: "Ad, UN/LOCODE və ya ünvan üzrə axtarış"},
+			{Name: "kind", Description: "Məntəqənin növü", Enum: kinds},
+			{Name: "country", Description: "ISO 3166 iki hərfli ölkə kodu"},
+			{Name: "inactive", Type: "boolean", Description: "Deaktiv məntəqələri də göstər"},
+		},
+		List: true, Sort: sortColumns, Response: Location{},
+	})
+	spec.Add("POST", "/locations", openapi.Operation{
+		Summary: "Məntəqə yarat", Tag: "locations", Permission: manage,
+		Request: LocationRequest{}, Response: Location{}, Status: http.StatusCreated,
+	})
+	spec.Add("GET", "/locations/{id}", openapi.Operation{
+		Summary: "Məntəqə", Tag: "locations", Permission: view, Response: Location{},
+	})
+	spec.Add("PUT", "/locations/{id}", openapi.Operation{
+		Summary: "Məntəqəni yenilə", Tag: "locations", Permission: manage,
+		Request: LocationRequest{}, Response: Location{},
+	})
+	spec.Add("POST", "/locations/{id}/deactivate", openapi.Operation{
+		Summary: "Məntəqəni deaktiv et", Tag: "locations", Permission: manage, Response: Location{},
+	})
+	spec.Add("POST", "/locations/{id}/activate", openapi.Operation{
+		Summary: "Məntəqəni aktiv et", Tag: "locations", Permission: manage, Response: Location{},
+	})
+}
@@ -0,0 +1,352 @@
+package location
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	// Vaxt zonalarının yoxlanılması serverdə zoneinfo bazasının olmasından asılı olmasın
+	_ "time/tzdata"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+)
+
+const defaultPerPage = 20
+
+// ErrNotFound məntəqə tapılmadıqda qaytarılır
+var ErrNotFound = errors.New("məntəqə tapılmadı")
+
+// ValidationError istifadəçiyə göstəriləcək form xətasını təmsil edir
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// Service məntəqə biznes məntiqini müəyyən edir
+type Service interface {
+	List(ctx context.Context, filter ListFilter) (*LocationList, error)
+	Get(ctx context.Context, id int) (*Location, error)
+	Customers(ctx context.Context) ([]CustomerOption, error)
+	Create(ctx context.Context, form LocationForm) (*Location, error)
+	Update(ctx context.Context, id int, form LocationForm) (*Location, error)
+	Deactivate(ctx context.Context, id int) error
+	Activate(ctx context.Context, id int) error
+	Import(ctx context.Context, r io.Reader, opts ImportOptions) (*ImportResult, error)
+}
+
+// LocationService Service interfeysini həyata keçirir
+type LocationService struct {
+	repo  Repository
+	audit audit.Recorder
+}
+
+// NewLocationService yeni LocationService yaradır
+func NewLocationService(repo Repository, recorder audit.Recorder) *LocationService {
+	return &LocationService{repo: repo, audit: recorder}
+}
+
+// List filtrə uyğun səhifələnmiş məntəqə siyahısını qaytarır
+func (s *LocationService) List(ctx context.Context, filter ListFilter) (*LocationList, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PerPage < 1 || filter.PerPage > 100 {
+		filter.PerPage = defaultPerPage
+	}
+	if _, ok := KindLabels[filter.Kind]; !ok {
+		filter.Kind = ""
+	}
+	filter.Country = strings.ToUpper(strings.TrimSpace(filter.Country))
+
+	locations, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LocationList{
+		Items:   locations,
+		Total:   total,
+		Page:    filter.Page,
+		PerPage: filter.PerPage,
+	}, nil
+}
+
+// Get məntəqəni ID-yə görə qaytarır
+func (s *LocationService) Get(ctx context.Context, id int) (*Location, error) {
+	location, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if location == nil {
+		return nil, ErrNotFound
+	}
+
+	return location, nil
+}
+
+// Customers müştəri ünvanı üçün seçilə bilən müştəriləri qaytarır
+func (s *LocationService) Customers(ctx context.Context) ([]CustomerOption, error) {
+	return s.repo.CustomerOptions(ctx)
+}
+
+// Create formdakı məlumatlarla yeni məntəqə yaradır
+func (s *LocationService) Create(ctx context.Context, form LocationForm) (*Location, error) {
+	location := &Location{Source: SourceManual, IsActive: true}
+	if err := applyForm(location, form); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, location); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "location.created", nil, location)
+
+	return location, nil
+}
+
+// Update mövcud məntəqənin məlumatlarını formdakı dəyərlərlə yeniləyir.
+// UN/LOCODE siyahısından idxal edilmiş məntəqənin kodu və ölkəsi dəyişdirilə bilməz,
+// çünki növbəti idxal məntəqəni kodla tapır.
+func (s *LocationService) Update(ctx context.Context, id int, form LocationForm) (*Location, error) {
+	location, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *location
+	if err := applyForm(location, form); err != nil {
+		return nil, err
+	}
+
+	if before.IsImported() && (location.UNLOCODE != before.UNLOCODE || location.Country != before.Country) {
+		return nil, &ValidationError{Message: "UN/LOCODE siyahısından idxal edilmiş məntəqənin kodu və ölkəsi dəyişdirilə bilməz"}
+	}
+
+	if err := s.repo.Update(ctx, location); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "location.updated", &before, location)
+
+	return location, nil
+}
+
+// Deactivate məntəqəni deaktiv edir
+func (s *LocationService) Deactivate(ctx context.Context, id int) error {
+	return s.setActive(ctx, id, false, "location.deactivated")
+}
+
+// Activate deaktiv edilmiş məntəqəni yenidən aktiv edir
+func (s *LocationService) Activate(ctx context.Context, id int) error {
+	return s.setActive(ctx, id, true, "location.activated")
+}
+
+// setActive məntəqənin statusunu dəyişir və dəyişikliyi audit jurnalına yazır
+func (s *LocationService) setActive(ctx context.Context, id int, active bool, action string) error {
+	location, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.SetActive(ctx, id, active); err != nil {
+		return err
+	}
+
+	before := *location
+	location.IsActive = active
+	s.record(ctx, action, &before, location)
+
+	return nil
+}
+
+// Import UN/LOCODE CSV faylını oxuyur və məntəqələri idxal edir.
+// Eyni faylın təkrar idxalı mövcud məntəqələri yeniləyir, dublikat yaratmır.
+func (s *LocationService) Import(ctx context.Context, r io.Reader, opts ImportOptions) (*ImportResult, error) {
+	entries, lineErrors, err := ParseUNLOCODE(r)
+	if err != nil {
+		return nil, err
+	}
+
+	countries := make(map[string]bool, len(opts.Countries))
+	for _, country := range opts.Countries {
+		countries[strings.ToUpper(strings.TrimSpace(country))] = true
+	}
+
+	selected := make([]UNLOCODEEntry, 0, len(entries))
+	for _, entry := range entries {
+		if len(countries) > 0 && !countries[entry.Country] {
+			continue
+		}
+		// Silinən məntəqələr növündən asılı olmayaraq ötürülür ki, əvvəl idxal edilmişsə deaktiv edilsin
+		if !opts.All && !entry.Removed && entry.Kind() == KindPlace {
+			continue
+		}
+		selected = append(selected, entry)
+	}
+
+	result, err := s.repo.Import(ctx, selected)
+	if err != nil {
+		return nil, err
+	}
+	result.Read = len(entries) + len(lineErrors)
+	result.Skipped += len(entries) - len(selected) + len(lineErrors)
+	result.Errors = lineErrors
+	if len(result.Errors) > maxLineErrors {
+		result.Errors = result.Errors[:maxLineErrors]
+	}
+
+	s.audit.Record(ctx, audit.Event{
+		Action:     "location.imported",
+		EntityType: "location",
+		Details: map[string]interface{}{
+			"source":      SourceUNLOCODE,
+			"all":         opts.All,
+			"countries":   opts.Countries,
+			"read":        result.Read,
+			"inserted":    result.Inserted,
+			"updated":     result.Updated,
+			"deactivated": result.Deactivated,
+			"skipped":     result.Skipped,
+			"errors":      len(lineErrors),
+		},
+	})
+
+	return result, nil
+}
+
+// record məntəqə üzərində əməliyyatı audit jurnalına yazır
+func (s *LocationService) record(ctx context.Context, action string, before, after *Location) {
+	s.audit.Record(ctx, audit.Event{
+		Action:     action,
+		EntityType: "location",
+		EntityID:   strconv.Itoa(after.ID),
+		Before:     before,
+		After:      after,
+	})
+}
+
+// applyForm formu yoxlayır və dəyərləri məntəqə obyektinə köçürür
+func applyForm(location *Location, form LocationForm) error {
+	name := strings.TrimSpace(form.Name)
+	if name == "" {
+		return &ValidationError{Message: "məntəqənin adı tələb olunur"}
+	}
+
+	kind := strings.TrimSpace(form.Kind)
+	if _, ok := KindLabels[kind]; !ok {
+		return &ValidationError{Message: "məntəqənin növü seçilməlidir"}
+	}
+
+	country := strings.ToUpper(strings.TrimSpace(form.Country))
+	if !isLetters(country, 2) {
+		return &ValidationError{Message: "ölkə ISO 3166 iki hərfli kodu ilə göstərilməlidir (məs. AZ)"}
+	}
+
+	code := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(form.UNLOCODE), " ", ""))
+	if code != "" {
+		if !validUNLOCODE(code) {
+			return &ValidationError{Message: "UN/LOCODE ölkə kodu və üç simvoldan ibarət olmalıdır (məs. AZBAK)"}
+		}
+		if code[:2] != country {
+			return &ValidationError{Message: "UN/LOCODE-un ilk iki hərfi ölkə kodu ilə eyni olmalıdır"}
+		}
+	}
+
+	subdivision := strings.ToUpper(strings.TrimSpace(form.Subdivision))
+	if len(subdivision) > 3 {
+		return &ValidationError{Message: "region kodu 3 simvoldan uzun ola bilməz"}
+	}
+
+	latitude, err := parseCoordinate(form.Latitude, 90)
+	if err != nil {
+		return &ValidationError{Message: "enlik -90 və 90 arasında olmalıdır"}
+	}
+	longitude, err := parseCoordinate(form.Longitude, 180)
+	if err != nil {
+		return &ValidationError{Message: "uzunluq -180 və 180 arasında olmalıdır"}
+	}
+	if (latitude == nil) != (longitude == nil) {
+		return &ValidationError{Message: "enlik və uzunluq birlikdə göstərilməlidir"}
+	}
+
+	timezone := strings.TrimSpace(form.Timezone)
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil || timezone == "Local" {
+			return &ValidationError{Message: "vaxt zonası IANA adı ilə göstərilməlidir (məs. Asia/Baku)"}
+		}
+	}
+
+	var customerID *int
+	if value := strings.TrimSpace(form.CustomerID); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil || id <= 0 {
+			return &ValidationError{Message: "müştəri yanlış seçilib"}
+		}
+		customerID = &id
+	}
+	if kind == KindCustomerSite && customerID == nil {
+		return &ValidationError{Message: "müştəri ünvanı üçün müştəri seçilməlidir"}
+	}
+
+	location.UNLOCODE = code
+	location.Name = name
+	location.Kind = kind
+	location.Country = country
+	location.Subdivision = subdivision
+	location.Latitude = latitude
+	location.Longitude = longitude
+	location.Timezone = timezone
+	location.Address = strings.TrimSpace(form.Address)
+	location.CustomerID = customerID
+
+	return nil
+}
+
+// parseCoordinate boş olmayan koordinatı oxuyur və [-limit, limit] aralığında olduğunu yoxlayır
+func parseCoordinate(value string, limit float64) (*float64, error) {
+	value = strings.ReplaceAll(strings.TrimSpace(value), ",", ".")
+	if value == "" {
+		return nil, nil
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, err
+	}
+	if f < -limit || f > limit {
+		return nil, errors.New("koordinat aralıqdan kənardadır")
+	}
+
+	return &f, nil
+}
+
+// formatCoordinate koordinatı formda göstərmək üçün sətrə çevirir
+func formatCoordinate(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*value, 'f', -1, 64)
+}
+
+// isLetters sətrin tam olaraq n böyük latın hərfindən ibarət olduğunu yoxlayır
+func isLetters(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+
+	for _, c := range s {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
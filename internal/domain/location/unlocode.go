@@ -0,0 +1,220 @@
+package location
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// UN/LOCODE CSV buraxılışındakı sütunların sırası (faylda başlıq sətri yoxdur)
+const (
+	colChange = iota
+	colCountry
+	colLocation
+	colName
+	colNameWoDiacritics
+	colSubdivision
+	colFunction
+	colStatus
+	colDate
+	colIATA
+	colCoordinates
+	colRemarks
+)
+
+// Dəyişiklik göstəricisinin (Ch sütunu) xüsusi dəyərləri
+const (
+	changeRemoved   = "X" // növbəti buraxılışda silinəcək
+	changeReference = "=" // başqa koda istinad (alternativ ad)
+)
+
+// maxLineErrors idxal nəticəsində saxlanılan sətir xətalarının ən çox sayıdır; qalanları yalnız sayılır
+const maxLineErrors = 100
+
+// LineError CSV faylının oxuna bilməyən və buraxılan sətrini təsvir edir
+type LineError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+func (e LineError) Error() string {
+	return fmt.Sprintf("%d-ci sətir: %s", e.Line, e.Message)
+}
+
+// UNLOCODEEntry UN/LOCODE siyahısının bir məntəqəsidir
+type UNLOCODEEntry struct {
+	Code        string
+	Name        string
+	Country     string
+	Subdivision string
+	Function    string
+	Latitude    *float64
+	Longitude   *float64
+	Removed     bool
+}
+
+// Kind məntəqənin funksiya kodundan növünü müəyyən edir:
+// 1-ci mövqe dəniz limanı, 8-ci mövqe (B) sərhəd keçididir
+func (e UNLOCODEEntry) Kind() string {
+	switch {
+	case strings.HasPrefix(e.Function, "1"):
+		return KindPort
+	case len(e.Function) >= 8 && e.Function[7] == 'B':
+		return KindBorderCrossing
+	default:
+		return KindPlace
+	}
+}
+
+// ParseUNLOCODE UNECE-nin rəsmi UN/LOCODE CSV faylını (CodeListPart1-3.csv) oxuyur.
+// Ölkə başlıqları və istinad sətirləri buraxılır. Köhnə buraxılışlar ISO 8859-1 kodlaşmasındadır;
+// UTF-8 olmayan sahələr Latin-1 kimi oxunur. Yanlış sətirlər idxalı dayandırmır: onlar buraxılır və
+// ikinci nəticədə qaytarılır. Xəta yalnız fayl CSV kimi oxuna bilmədikdə qaytarılır.
+func ParseUNLOCODE(r io.Reader) ([]UNLOCODEEntry, []LineError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.ReuseRecord = true
+
+	var (
+		entries []UNLOCODEEntry
+		skipped []LineError
+	)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(record) <= colCoordinates {
+			skipped = append(skipped, LineError{Line: line,
+				Message: fmt.Sprintf("%d sütun var, UN/LOCODE CSV formatı gözlənilirdi", len(record))})
+			continue
+		}
+
+		for i := range record {
+			record[i] = strings.TrimSpace(decodeLatin1(record[i]))
+		}
+
+		// Ölkə başlığı sətirlərində (",AZ,,.AZERBAIJAN,...") məntəqə kodu yoxdur
+		if record[colLocation] == "" || record[colChange] == changeReference {
+			continue
+		}
+
+		entry := UNLOCODEEntry{
+			Code:        strings.ToUpper(record[colCountry] + record[colLocation]),
+			Name:        record[colName],
+			Country:     strings.ToUpper(record[colCountry]),
+			Subdivision: strings.ToUpper(record[colSubdivision]),
+			Function:    record[colFunction],
+			Removed:     record[colChange] == changeRemoved,
+		}
+		if entry.Name == "" {
+			entry.Name = record[colNameWoDiacritics]
+		}
+		if !validUNLOCODE(entry.Code) || entry.Name == "" {
+			skipped = append(skipped, LineError{Line: line, Message: fmt.Sprintf("yanlış UN/LOCODE %q", entry.Code)})
+			continue
+		}
+
+		// Koordinatlar bəzi məntəqələrdə yoxdur və ya yanlış yazılıb; belə halda sadəcə buraxılır
+		entry.Latitude, entry.Longitude, _ = parseUNLOCODECoordinates(record[colCoordinates])
+
+		entries = append(entries, entry)
+	}
+
+	return entries, skipped, nil
+}
+
+// parseUNLOCODECoordinates "4023N 04951E" formatındakı (dərəcə və dəqiqə) koordinatları onluq dərəcəyə çevirir
+func parseUNLOCODECoordinates(value string) (*float64, *float64, error) {
+	if value == "" {
+		return nil, nil, nil
+	}
+
+	parts := strings.Fields(value)
+	if len(parts) != 2 {
+		return nil, nil, fmt.Errorf("yanlış koordinat: %q", value)
+	}
+
+	lat, err := parseDegreesMinutes(parts[0], 2, 90, 'N', 'S')
+	if err != nil {
+		return nil, nil, err
+	}
+	lon, err := parseDegreesMinutes(parts[1], 3, 180, 'E', 'W')
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &lat, &lon, nil
+}
+
+// parseDegreesMinutes "DDMMH" və ya "DDDMMH" formatını onluq dərəcəyə çevirir; cənub və qərb mənfi qiymətdir.
+// max enlik üçün 90, uzunluq üçün 180 dərəcədir.
+func parseDegreesMinutes(value string, degreeDigits int, max float64, positive, negative byte) (float64, error) {
+	if len(value) != degreeDigits+3 {
+		return 0, fmt.Errorf("yanlış koordinat: %q", value)
+	}
+
+	degrees, err := strconv.Atoi(value[:degreeDigits])
+	if err != nil {
+		return 0, fmt.Errorf("yanlış koordinat: %q", value)
+	}
+	minutes, err := strconv.Atoi(value[degreeDigits : degreeDigits+2])
+	if err != nil || minutes >= 60 {
+		return 0, fmt.Errorf("yanlış koordinat: %q", value)
+	}
+
+	result := float64(degrees) + float64(minutes)/60
+	if result > max {
+		return 0, fmt.Errorf("yanlış koordinat: %q", value)
+	}
+
+	switch value[len(value)-1] {
+	case positive:
+	case negative:
+		result = -result
+	default:
+		return 0, fmt.Errorf("yanlış koordinat: %q", value)
+	}
+
+	return result, nil
+}
+
+// decodeLatin1 UTF-8 olmayan sətri ISO 8859-1 kimi oxuyur (hər bayt eyni kodlu Unicode simvoludur)
+func decodeLatin1(value string) string {
+	if utf8.ValidString(value) {
+		return value
+	}
+
+	var b strings.Builder
+	b.Grow(len(value) * 2)
+	for i := 0; i < len(value); i++ {
+		b.WriteRune(rune(value[i]))
+	}
+	return b.String()
+}
+
+// validUNLOCODE kodun iki hərfli ölkə kodu və üç simvollu məntəqə kodundan ibarət olduğunu yoxlayır.
+// Məntəqə kodunda hərflər və 2-9 rəqəmləri istifadə olunur.
+func validUNLOCODE(code string) bool {
+	if len(code) != 5 {
+		return false
+	}
+	for i := 0; i < len(code); i++ {
+		c := code[i]
+		switch {
+		case c >= 'A' && c <= 'Z':
+		case i >= 2 && c >= '2' && c <= '9':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,124 @@
+package location
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// unlocodeSample UNECE CSV buraxılışının formatında kiçik nümunədir: ölkə başlığı, istinad sətri,
+// Latin-1 kodlaşmalı ad, silinən məntəqə, cənub/qərb koordinatları və yanlış sətirlər
+const unlocodeSample = ",\"AZ\",,\".AZERBAIJAN\",,,,,,,,\n" +
+	",\"AZ\",\"BAK\",\"Baku\",\"Baku\",\"BA\",\"1234----\",\"AI\",\"0101\",,\"4023N 04951E\",\"\"\n" +
+	"+,\"AZ\",\"SAM\",\"Sam\xfdr\",\"Samur\",\"\",\"--3----B\",\"RL\",\"0101\",,\"4147N 04833E\",\"\"\n" +
+	"X,\"AZ\",\"OLD\",\"Old\",\"Old\",\"\",\"--3-----\",\"RL\",\"0101\",,\"\",\"\"\n" +
+	"=,\"AZ\",\"BAK\",\"Bakou = Baku\",\"\",\"\",\"\",\"\",\"\",,\"\",\"\"\n" +
+	",\"BR\",\"RIO\",\"Rio de Janeiro\",\"Rio de Janeiro\",\"RJ\",\"1-------\",\"AI\",\"0101\",,\"2254S 04314W\",\"\"\n" +
+	",\"AZ\",\"B1K\",\"Bad\",\"Bad\",\"\",\"1-------\",\"AI\",\"0101\",,\"\",\"\"\n" +
+	",\"AZ\",\"SHT\"\n" +
+	",\"GE\",\"PTI\",\"Poti\",\"Poti\",\"\",\"1-------\",\"AI\",\"0101\",,\"9130N 04140E\",\"\"\n"
+
+// TestParseUNLOCODE sətirlərin oxunmasını, buraxılmasını və yanlış sətirlərin idxalı dayandırmadığını yoxlayır
+func TestParseUNLOCODE(t *testing.T) {
+	entries, lineErrors, err := ParseUNLOCODE(strings.NewReader(unlocodeSample))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byCode := map[string]UNLOCODEEntry{}
+	for _, entry := range entries {
+		byCode[entry.Code] = entry
+	}
+	if len(entries) != 5 {
+		t.Errorf("məntəqələr = %d, gözlənilən 5: %+v", len(entries), entries)
+	}
+
+	baku, ok := byCode["AZBAK"]
+	if !ok {
+		t.Fatal("AZBAK oxunmadı")
+	}
+	if baku.Name != "Baku" || baku.Subdivision != "BA" || baku.Kind() != KindPort {
+		t.Errorf("AZBAK = %+v", baku)
+	}
+	assertCoordinate(t, "AZBAK enlik", baku.Latitude, 40+23.0/60)
+	assertCoordinate(t, "AZBAK uzunluq", baku.Longitude, 49+51.0/60)
+
+	// İstinad sətri (=) əsas yazını əvəz etmir
+	if baku.Name == "Bakou = Baku" {
+		t.Error("istinad sətri buraxılmadı")
+	}
+
+	if samur := byCode["AZSAM"]; samur.Name != "Samýr" || samur.Kind() != KindBorderCrossing {
+		t.Errorf("AZSAM = %+v, Latin-1 adı və sərhəd keçidi gözlənilirdi", samur)
+	}
+
+	if old := byCode["AZOLD"]; !old.Removed || old.Latitude != nil {
+		t.Errorf("AZOLD = %+v, silinən və koordinatsız gözlənilirdi", old)
+	}
+
+	rio := byCode["BRRIO"]
+	assertCoordinate(t, "BRRIO enlik", rio.Latitude, -(22 + 54.0/60))
+	assertCoordinate(t, "BRRIO uzunluq", rio.Longitude, -(43 + 14.0/60))
+
+	// Hüdudlardan kənar koordinat atılır, məntəqə isə saxlanılır
+	if poti, ok := byCode["GEPTI"]; !ok || poti.Latitude != nil || poti.Longitude != nil {
+		t.Errorf("GEPTI = %+v, koordinatsız gözlənilirdi", poti)
+	}
+
+	if len(lineErrors) != 2 || lineErrors[0].Line != 7 || lineErrors[1].Line != 8 {
+		t.Errorf("sətir xətaları = %+v, gözlənilən 7 və 8-ci sətirlər", lineErrors)
+	}
+}
+
+// TestParseUNLOCODECoordinates dərəcə-dəqiqə formatının və hüdudların yoxlanmasını yoxlayır
+func TestParseUNLOCODECoordinates(t *testing.T) {
+	tests := []struct {
+		value   string
+		lat     float64
+		lon     float64
+		wantErr bool
+	}{
+		{value: "4023N 04951E", lat: 40 + 23.0/60, lon: 49 + 51.0/60},
+		{value: "2254S 04314W", lat: -(22 + 54.0/60), lon: -(43 + 14.0/60)},
+		{value: "9000N 18000W", lat: 90, lon: -180},
+		{value: "0000N 00000E", lat: 0, lon: 0},
+		{value: "9001N 04951E", wantErr: true},
+		{value: "9100S 04951E", wantErr: true},
+		{value: "4023N 18001E", wantErr: true},
+		{value: "4023N 19000W", wantErr: true},
+		{value: "4060N 04951E", wantErr: true},
+		{value: "4023E 04951N", wantErr: true},
+		{value: "4023N04951E", wantErr: true},
+		{value: "402N 04951E", wantErr: true},
+		{value: "40a3N 04951E", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		lat, lon, err := parseUNLOCODECoordinates(tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%q: xəta gözlənilirdi, alındı %v %v", tt.value, *lat, *lon)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", tt.value, err)
+			continue
+		}
+		assertCoordinate(t, tt.value+" enlik", lat, tt.lat)
+		assertCoordinate(t, tt.value+" uzunluq", lon, tt.lon)
+	}
+}
+
+// assertCoordinate koordinatın mövcud olduğunu və gözlənilən qiymətə bərabər olduğunu yoxlayır
+func assertCoordinate(t *testing.T, name string, got *float64, want float64) {
+	t.Helper()
+
+	if got == nil {
+		t.Errorf("%s yoxdur, gözlənilən %.5f", name, want)
+		return
+	}
+	if math.Abs(*got-want) > 1e-9 {
+		t.Errorf("%s = %.5f, gözlənilən %.5f", name, *got, want)
+	}
+}
@@ -0,0 +1,74 @@
+package rbac
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/Zam83-AZE/logistics_system/pkg/view"
+	"github.com/gorilla/mux"
+)
+
+// Handler rolların idarəsi HTTP sorğularını işləyir
+type Handler struct {
+	service        Service
+	tmpl           *template.Template
+	sessionManager *session.Manager
+}
+
+// NewHandler yeni rol işləyicisi yaradır
+func NewHandler(service Service, tmpl *template.Template, sessionManager *session.Manager) *Handler {
+	return &Handler{
+		service:        service,
+		tmpl:           tmpl,
+		sessionManager: sessionManager,
+	}
+}
+
+// List rolların siyahısını göstərir
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	h.renderList(w, r, "", http.StatusOK)
+}
+
+// SetTwoFactor rol üçün iki faktorlu autentifikasiya tələbini dəyişir
+func (h *Handler) SetTwoFactor(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Yanlış rol ID-si", http.StatusBadRequest)
+		return
+	}
+
+	required := r.FormValue("require_two_factor") == "true"
+
+	if err := h.service.SetRequireTwoFactor(r.Context(), id, required); err != nil {
+		if errors.Is(err, ErrRoleNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		h.renderList(w, r, "Rol yenilənmədi", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/roles", http.StatusSeeOther)
+}
+
+// renderList rollar səhifəsini verilmiş status və xəta mesajı ilə göstərir
+func (h *Handler) renderList(w http.ResponseWriter, r *http.Request, message string, status int) {
+	roles, err := h.service.ListRoles(r.Context())
+	if err != nil {
+		http.Error(w, "Rollar əldə edilərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	data := RolesPage{
+		Roles:       roles,
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "roles",
+		Error:       message,
+	}
+
+	w.WriteHeader(status)
+	view.Render(w, r, h.tmpl, "rbac/roles.html", data)
+}
@@ -0,0 +1,91 @@
+package rbac
+
+import "context"
+
+// Permission sistemdə bir əməliyyat növünə icazəni təmsil edir.
+// Dəyərlər permissions cədvəlindəki code sütunu ilə eynidir.
+type Permission string
+
+const (
+	DashboardView    Permission = "dashboard.view"
+	CustomersView    Permission = "customers.view"
+	CustomersManage  Permission = "customers.manage"
+	ContainersView   Permission = "containers.view"
+	ContainersManage Permission = "containers.manage"
+	ShipmentsView    Permission = "shipments.view"
+	ShipmentsManage  Permission = "shipments.manage"
+	ShipmentsStatus  Permission = "shipments.status"
+	InvoicesView     Permission = "invoices.view"
+	InvoicesManage   Permission = "invoices.manage"
+	LocationsView    Permission = "locations.view"
+	LocationsManage  Permission = "locations.manage"
+	SchedulesView    Permission = "schedules.view"
+	SchedulesManage  Permission = "schedules.manage"
+	UsersManage      Permission = "users.manage"
+	AuditView        Permission = "audit.view"
+)
+
+// Rol kodları (roles cədvəlindəki code sütunu)
+const (
+	RoleAdmin             = "admin"
+	RoleDispatcher        = "dispatcher"
+	RoleAccountant        = "accountant"
+	RoleCustomsBroker     = "customs_broker"
+	RoleWarehouseOperator = "warehouse_operator"
+	RoleCustomerPortal    = "customer_portal"
+)
+
+// Role istifadəçilərə təyin edilən rolu təmsil edir
+type Role struct {
+	ID               int    `db:"id"`
+	Code             string `db:"code"`
+	Name             string `db:"name"`
+	RequireTwoFactor bool   `db:"require_two_factor"`
+}
+
+// RolesPage rolların idarəsi səhifəsinin məlumatlarını saxlayır
+type RolesPage struct {
+	Roles       []Role
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// PermissionSet istifadəçinin malik olduğu icazələr çoxluğudur
+type PermissionSet map[Permission]bool
+
+// Has icazənin çoxluqda olub-olmadığını yoxlayır
+func (s PermissionSet) Has(permission Permission) bool {
+	return s[permission]
+}
+
+// Can şablonlarda istifadə üçün sətir qəbul edən Has variantıdır
+func (s PermissionSet) Can(permission string) bool {
+	return s[Permission(permission)]
+}
+
+// Restrict çoxluğu verilmiş icazələrlə məhdudlaşdırır (məs. API tokeninin əhatə dairəsi)
+func (s PermissionSet) Restrict(scopes []Permission) PermissionSet {
+	restricted := make(PermissionSet, len(scopes))
+	for _, scope := range scopes {
+		if s[scope] {
+			restricted[scope] = true
+		}
+	}
+	return restricted
+}
+
+type contextKey struct{}
+
+// WithPermissions icazələri sorğu kontekstinə əlavə edir
+func WithPermissions(ctx context.Context, permissions PermissionSet) context.Context {
+	return context.WithValue(ctx, contextKey{}, permissions)
+}
+
+// FromContext sorğu kontekstindəki icazələri qaytarır; yoxdursa boş çoxluq qaytarılır
+func FromContext(ctx context.Context) PermissionSet {
+	if permissions, ok := ctx.Value(contextKey{}).(PermissionSet); ok {
+		return permissions
+	}
+	return PermissionSet{}
+}
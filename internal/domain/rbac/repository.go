@@ -0,0 +1,91 @@
+package rbac
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Repository rol və icazə məlumatları əməliyyatlarını müəyyən edir
+type Repository interface {
+	PermissionsForUser(ctx context.Context, userID int) ([]Permission, error)
+	RolesForUser(ctx context.Context, userID int) ([]Role, error)
+	ListRoles(ctx context.Context) ([]Role, error)
+	SetRequireTwoFactor(ctx context.Context, roleID int, required bool) (bool, error)
+}
+
+// PostgresRepository Repository interfeysini həyata keçirir
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRepository yeni PostgresRepository yaradır
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+// PermissionsForUser istifadəçinin bütün rollarından gələn icazələri qaytarır
+func (r *PostgresRepository) PermissionsForUser(ctx context.Context, userID int) ([]Permission, error) {
+	query := `
+		SELECT DISTINCT rp.permission_code
+		FROM user_roles ur
+		JOIN role_permissions rp ON rp.role_id = ur.role_id
+		WHERE ur.user_id = $1
+	`
+
+	var permissions []Permission
+	if err := r.db.SelectContext(ctx, &permissions, query, userID); err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+// RolesForUser istifadəçiyə təyin edilmiş rolları qaytarır
+func (r *PostgresRepository) RolesForUser(ctx context.Context, userID int) ([]Role, error) {
+	query := `
+		SELECT r.id, r.code, r.name, r.require_two_factor
+		FROM roles r
+		JOIN user_roles ur ON ur.role_id = r.id
+		WHERE ur.user_id = $1
+		ORDER BY r.name
+	`
+
+	var roles []Role
+	if err := r.db.SelectContext(ctx, &roles, query, userID); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// ListRoles bütün rolları qaytarır
+func (r *PostgresRepository) ListRoles(ctx context.Context) ([]Role, error) {
+	query := `
+		SELECT id, code, name, require_two_factor
+		FROM roles
+		ORDER BY name
+	`
+
+	var roles []Role
+	if err := r.db.SelectContext(ctx, &roles, query); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// SetRequireTwoFactor rol üçün iki faktorlu autentifikasiya tələbini dəyişir; rol tapılmadıqda false qaytarır
+func (r *PostgresRepository) SetRequireTwoFactor(ctx context.Context, roleID int, required bool) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `UPDATE roles SET require_two_factor = $2 WHERE id = $1`, roleID, required)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
@@ -0,0 +1,22 @@
+package rbac
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/gorilla/mux"
+	"github.com/jmoiron/sqlx"
+)
+
+// RegisterRoutes rolların idarəsi marşrutlarını qeydə alır.
+// middleware paketi bu paketi idxal etdiyi üçün icazə yoxlaması (users.manage) çağıran tərəfindən verilir.
+func RegisterRoutes(router *mux.Router, db *sqlx.DB, tmpl *template.Template, sessionManager *session.Manager, canManage func(http.Handler) http.Handler) {
+	repo := NewPostgresRepository(db)
+	service := NewRBACService(repo, audit.NewRecorder(db))
+	handler := NewHandler(service, tmpl, sessionManager)
+
+	router.Handle("/admin/roles", canManage(http.HandlerFunc(handler.List))).Methods("GET")
+	router.Handle("/admin/roles/{id:[0-9]+}/two-factor", canManage(http.HandlerFunc(handler.SetTwoFactor))).Methods("POST")
+}
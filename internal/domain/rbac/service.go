@@ -0,0 +1,76 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+)
+
+// ErrRoleNotFound rol tapılmadıqda qaytarılır
+var ErrRoleNotFound = errors.New("rol tapılmadı")
+
+// Service rol və icazə biznes məntiqini müəyyən edir
+type Service interface {
+	Permissions(ctx context.Context, userID int) (PermissionSet, error)
+	Roles(ctx context.Context, userID int) ([]Role, error)
+	ListRoles(ctx context.Context) ([]Role, error)
+	SetRequireTwoFactor(ctx context.Context, roleID int, required bool) error
+}
+
+// RBACService Service interfeysini həyata keçirir
+type RBACService struct {
+	repo  Repository
+	audit audit.Recorder
+}
+
+// NewRBACService yeni RBACService yaradır
+func NewRBACService(repo Repository, recorder audit.Recorder) *RBACService {
+	return &RBACService{repo: repo, audit: recorder}
+}
+
+// Permissions istifadəçinin icazələr çoxluğunu qaytarır
+func (s *RBACService) Permissions(ctx context.Context, userID int) (PermissionSet, error) {
+	permissions, err := s.repo.PermissionsForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	set := make(PermissionSet, len(permissions))
+	for _, permission := range permissions {
+		set[permission] = true
+	}
+
+	return set, nil
+}
+
+// Roles istifadəçinin rollarını qaytarır
+func (s *RBACService) Roles(ctx context.Context, userID int) ([]Role, error) {
+	return s.repo.RolesForUser(ctx, userID)
+}
+
+// ListRoles bütün rolları qaytarır
+func (s *RBACService) ListRoles(ctx context.Context) ([]Role, error) {
+	return s.repo.ListRoles(ctx)
+}
+
+// SetRequireTwoFactor rolun üzvləri üçün iki faktorlu autentifikasiyanı məcburi edir və ya tələbi götürür
+func (s *RBACService) SetRequireTwoFactor(ctx context.Context, roleID int, required bool) error {
+	found, err := s.repo.SetRequireTwoFactor(ctx, roleID, required)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrRoleNotFound
+	}
+
+	s.audit.Record(ctx, audit.Event{
+		Action:     "role.two_factor_changed",
+		EntityType: "role",
+		EntityID:   strconv.Itoa(roleID),
+		After:      map[string]bool{"requireTwoFactor": required},
+	})
+
+	return nil
+}
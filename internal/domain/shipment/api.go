@@ -0,0 +1,394 @@
+package shipment
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/api"
+)
+
+// ShipmentRequest API vasitəsilə daşınma yaratma və yeniləmə sorğusunun gövdəsidir
+type ShipmentRequest struct {
+	CustomerID  int                `json:"customerId"`
+	BLNumber    string             `json:"blNumber"`
+	Origin      string             `json:"origin"`
+	Destination string             `json:"destination"`
+	ETD         *time.Time         `json:"etd"`
+	ETA         *time.Time         `json:"eta"`
+	Containers  []string           `json:"containers"`
+	Notes       string             `json:"notes"`
+	CargoLines  []CargoLineRequest `json:"cargoLines"`
+}
+
+// CargoLineRequest sorğudakı yük sətridir
+type CargoLineRequest struct {
+	Description   string  `json:"description"`
+	HSCode        string  `json:"hsCode"`
+	Packages      int     `json:"packages"`
+	PackageType   string  `json:"packageType"`
+	GrossWeightKg float64 `json:"grossWeightKg"`
+	VolumeM3      float64 `json:"volumeM3"`
+}
+
+// StatusRequest status keçidi sorğusunun gövdəsidir
+type StatusRequest struct {
+	Status Status `json:"status"`
+	Note   string `json:"note"`
+}
+
+// EventRequest izləmə hadisəsi əlavə etmə sorğusunun gövdəsidir.
+// Code: gate_in, loaded, departed, arrived, discharged, gate_out, delivered.
+// Source boş olduqda "api" qəbul edilir (məs. daşıyıcının adı və ya EDI kanalı göstərilə bilər).
+type EventRequest struct {
+	Code       EventCode  `json:"code"`
+	Container  string     `json:"container"`
+	Location   string     `json:"location"`
+	OccurredAt *time.Time `json:"occurredAt"`
+	Source     string     `json:"source"`
+	Note       string     `json:"note"`
+}
+
+// LegRequest marşrut hissəsinin sorğudakı təsviridir. From və To məntəqənin UN/LOCODE-udur;
+// kodu olmayan məntəqələr üçün fromLocationId və toLocationId göstərilir.
+// Mode: sea, rail, road, air. Containers boş olduqda hissə daşınmanın bütün konteynerlərinə aiddir.
+type LegRequest struct {
+	Mode             string     `json:"mode"`
+	CarrierID        int        `json:"carrierId"`
+	From             string     `json:"from"`
+	FromLocationID   int        `json:"fromLocationId"`
+	To               string     `json:"to"`
+	ToLocationID     int        `json:"toLocationId"`
+	PlannedDeparture *time.Time `json:"plannedDeparture"`
+	PlannedArrival   *time.Time `json:"plannedArrival"`
+	ActualDeparture  *time.Time `json:"actualDeparture"`
+	ActualArrival    *time.Time `json:"actualArrival"`
+	Containers       []string   `json:"containers"`
+}
+
+// form sorğunu HTML formu ilə eyni yoxlamalardan keçməsi üçün LegForm-a çevirir
+func (req LegRequest) form() LegForm {
+	form := LegForm{
+		Mode:             req.Mode,
+		CarrierID:        itoa(req.CarrierID),
+		From:             req.From,
+		To:               req.To,
+		PlannedDeparture: formatDateTime(req.PlannedDeparture),
+		PlannedArrival:   formatDateTime(req.PlannedArrival),
+		ActualDeparture:  formatDateTime(req.ActualDeparture),
+		ActualArrival:    formatDateTime(req.ActualArrival),
+		Containers:       strings.Join(req.Containers, ","),
+	}
+	if strings.TrimSpace(form.From) == "" {
+		form.From = itoa(req.FromLocationID)
+	}
+	if strings.TrimSpace(form.To) == "" {
+		form.To = itoa(req.ToLocationID)
+	}
+	return form
+}
+
+// form sorğunu HTML formu ilə eyni yoxlamalardan keçməsi üçün ShipmentForm-a çevirir
+func (req ShipmentRequest) form() ShipmentForm {
+	form := ShipmentForm{
+		CustomerID:  itoa(req.CustomerID),
+		BLNumber:    req.BLNumber,
+		Origin:      req.Origin,
+		Destination: req.Destination,
+		ETD:         formatDateTime(req.ETD),
+		ETA:         formatDateTime(req.ETA),
+		Containers:  strings.Join(req.Containers, ","),
+		Notes:       req.Notes,
+	}
+
+	for _, line := range req.CargoLines {
+		form.CargoLines = append(form.CargoLines, CargoLineForm{
+			Description:   line.Description,
+			HSCode:        line.HSCode,
+			Packages:      itoa(line.Packages),
+			PackageType:   line.PackageType,
+			GrossWeightKg: ftoa(line.GrossWeightKg),
+			VolumeM3:      ftoa(line.VolumeM3),
+		})
+	}
+
+	return form
+}
+
+// APIHandler daşınma JSON API sorğularını işləyir
+type APIHandler struct {
+	service Service
+}
+
+// NewAPIHandler yeni daşınma API işləyicisi yaradır
+func NewAPIHandler(service Service) *APIHandler {
+	return &APIHandler{service: service}
+}
+
+// List daşınma siyahısını qaytarır: ?q=, ?status=, ?sort=, ?cursor=, ?limit=
+func (h *APIHandler) List(w http.ResponseWriter, r *http.Request) {
+	params, err := api.ParseList(r, sortColumns)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	status := Status(r.URL.Query().Get("status"))
+	if status != "" && !status.Valid() {
+		api.WriteError(w, api.NewError(http.StatusBadRequest, api.CodeBadRequest, ErrUnknownStatus.Error()))
+		return
+	}
+
+	shipments, err := h.service.List(r.Context(), ListFilter{
+		Query:   r.URL.Query().Get("q"),
+		Status:  status,
+		Sort:    params.Sort,
+		Page:    params.Page,
+		PerPage: params.PerPage,
+	})
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteList(w, shipments.Items, shipments.Total, params)
+}
+
+// Get daşınmanı konteynerləri və yük sətirləri ilə qaytarır
+func (h *APIHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	shipment, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, shipment)
+}
+
+// History daşınmanın status tarixçəsini qaytarır
+func (h *APIHandler) History(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	if _, err := h.service.Get(ctx, id); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	history, err := h.service.History(ctx, id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, history)
+}
+
+// Create qaralama statusunda yeni daşınma yaradır
+func (h *APIHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req ShipmentRequest
+	if err := api.Decode(w, r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	shipment, err := h.service.Create(r.Context(), req.form())
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusCreated, shipment)
+}
+
+// Update daşınmanın məlumatlarını tam əvəz edir
+func (h *APIHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	var req ShipmentRequest
+	if err := api.Decode(w, r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	shipment, err := h.service.Update(r.Context(), id, req.form())
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, shipment)
+}
+
+// ChangeStatus daşınmanı növbəti statusa keçirir
+func (h *APIHandler) ChangeStatus(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	var req StatusRequest
+	if err := api.Decode(w, r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	to, err := ParseStatus(string(req.Status))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	shipment, err := h.service.ChangeStatus(r.Context(), id, to, req.Note)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, shipment)
+}
+
+// Events daşınmanın izləmə hadisələrini xronoloji sıra ilə qaytarır
+func (h *APIHandler) Events(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	if _, err := h.service.Get(ctx, id); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	events, err := h.service.Events(ctx, id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, events)
+}
+
+// AddEvent daşınmaya izləmə hadisəsi əlavə edir
+func (h *APIHandler) AddEvent(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	var req EventRequest
+	if err := api.Decode(w, r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	form := EventForm{
+		Code:      string(req.Code),
+		Container: req.Container,
+		Location:  req.Location,
+		Note:      req.Note,
+	}
+	if req.OccurredAt != nil {
+		form.OccurredAt = req.OccurredAt.Format(time.RFC3339Nano)
+	}
+
+	source := req.Source
+	if strings.TrimSpace(source) == "" {
+		source = SourceAPI
+	}
+
+	result, err := h.service.AddEvent(r.Context(), id, form, source)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusCreated, result)
+}
+
+// Route daşınmanın marşrutunu hissələrin vəziyyəti, cari hissə və hesablanmış ETA ilə qaytarır
+func (h *APIHandler) Route(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	route, err := h.service.Route(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, route)
+}
+
+// ReplaceLegs daşınmanın marşrut hissələrini tam əvəz edir; boş massiv marşrutu silir
+func (h *APIHandler) ReplaceLegs(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	var req []LegRequest
+	if err := api.Decode(w, r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	forms := make([]LegForm, 0, len(req))
+	for _, leg := range req {
+		form := leg.form()
+		if form.IsEmpty() {
+			api.WriteError(w, api.NewError(http.StatusUnprocessableEntity, api.CodeValidation, "marşrut hissəsi boş ola bilməz"))
+			return
+		}
+		forms = append(forms, form)
+	}
+
+	route, err := h.service.SaveLegs(r.Context(), id, forms)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, route)
+}
+
+// writeAPIError servis xətasını uyğun HTTP statusu ilə yazır
+func writeAPIError(w http.ResponseWriter, err error) {
+	var validationErr *ValidationError
+	switch {
+	case errors.Is(err, ErrNotFound):
+		api.WriteError(w, api.NewError(http.StatusNotFound, api.CodeNotFound, err.Error()))
+	case errors.As(err, &validationErr), errors.Is(err, ErrUnknownStatus):
+		api.WriteError(w, api.NewError(http.StatusUnprocessableEntity, api.CodeValidation, err.Error()))
+	case errors.Is(err, ErrNotEditable), errors.Is(err, ErrInvalidTransition), errors.Is(err, ErrConcurrentUpdate),
+		errors.Is(err, ErrEventsClosed):
+		api.WriteError(w, api.NewError(http.StatusConflict, api.CodeConflict, err.Error()))
+	default:
+		api.WriteError(w, err)
+	}
+}
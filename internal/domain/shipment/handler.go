@@ -0,0 +1,449 @@
+package shipment
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"html/template"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/carrier"
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/Zam83-AZE/logistics_system/pkg/view"
+	"github.com/gorilla/mux"
+)
+
+// Handler daşınma HTTP sorğularını işləyir
+type Handler struct {
+	service        Service
+	tmpl           *template.Template
+	sessionManager *session.Manager
+}
+
+// NewHandler yeni daşınma işləyicisi yaradır
+func NewHandler(service Service, tmpl *template.Template, sessionManager *session.Manager) *Handler {
+	return &Handler{
+		service:        service,
+		tmpl:           tmpl,
+		sessionManager: sessionManager,
+	}
+}
+
+// List daşınma siyahısını axtarış və status filtri ilə göstərir
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	filter := ListFilter{
+		Query:  r.URL.Query().Get("q"),
+		Status: Status(r.URL.Query().Get("status")),
+		Page:   page,
+	}
+
+	shipments, err := h.service.List(ctx, filter)
+	if err != nil {
+		http.Error(w, "Daşınma siyahısı əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	data := ListPage{
+		Shipments:   *shipments,
+		Filter:      filter,
+		Statuses:    AllStatuses(),
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "shipments",
+	}
+
+	view.Render(w, r, h.tmpl, "shipment/list.html", data)
+}
+
+// New yeni daşınma formunu göstərir
+func (h *Handler) New(w http.ResponseWriter, r *http.Request) {
+	form := ShipmentForm{CustomerID: r.URL.Query().Get("customer_id")}
+	h.renderForm(w, r, form.WithEmptyLines(), 0, "", http.StatusOK)
+}
+
+// Create yeni daşınma yaradır
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	form := parseForm(r)
+
+	shipment, err := h.service.Create(ctx, form)
+	if err != nil {
+		h.renderFormError(w, r, form, 0, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/shipments/%d", shipment.ID), http.StatusSeeOther)
+}
+
+// Detail daşınmanın detallarını və status tarixçəsini göstərir
+func (h *Handler) Detail(w http.ResponseWriter, r *http.Request) {
+	shipment, ok := h.loadShipment(w, r)
+	if !ok {
+		return
+	}
+
+	h.renderDetail(w, r, shipment, "", http.StatusOK)
+}
+
+// Edit mövcud daşınmanın redaktə formunu göstərir
+func (h *Handler) Edit(w http.ResponseWriter, r *http.Request) {
+	shipment, ok := h.loadShipment(w, r)
+	if !ok {
+		return
+	}
+
+	if !shipment.IsEditable() {
+		h.renderDetail(w, r, shipment, ErrNotEditable.Error(), http.StatusConflict)
+		return
+	}
+
+	h.renderForm(w, r, FormFromShipment(shipment), shipment.ID, "", http.StatusOK)
+}
+
+// Update mövcud daşınmanın məlumatlarını yeniləyir
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	form := parseForm(r)
+
+	_, err = h.service.Update(ctx, id, form)
+	if err != nil {
+		h.renderFormError(w, r, form, id, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/shipments/%d", id), http.StatusSeeOther)
+}
+
+// ChangeStatus daşınmanı növbəti statusa keçirir
+func (h *Handler) ChangeStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	to, err := ParseStatus(r.FormValue("status"))
+	if err == nil {
+		_, err = h.service.ChangeStatus(ctx, id, to, r.FormValue("note"))
+	}
+
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+
+		var validationErr *ValidationError
+		switch {
+		case errors.Is(err, ErrInvalidTransition), errors.Is(err, ErrUnknownStatus),
+			errors.Is(err, ErrConcurrentUpdate), errors.As(err, &validationErr):
+			shipment, loadErr := h.service.Get(ctx, id)
+			if loadErr != nil {
+				http.Error(w, "Daşınma məlumatlarını əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+				return
+			}
+			h.renderDetail(w, r, shipment, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, "Daşınma statusunu dəyişərkən xəta baş verdi", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/shipments/%d", id), http.StatusSeeOther)
+}
+
+// AddEvent daşınmaya izləmə hadisəsi əlavə edir
+func (h *Handler) AddEvent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	form := EventForm{
+		Code:       r.FormValue("code"),
+		Container:  r.FormValue("container"),
+		Location:   r.FormValue("location"),
+		OccurredAt: r.FormValue("occurred_at"),
+		Note:       r.FormValue("note"),
+	}
+
+	if _, err := h.service.AddEvent(ctx, id, form, SourceManual); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) && !errors.Is(err, ErrEventsClosed) {
+			http.Error(w, "İzləmə hadisəsini saxlayarkən xəta baş verdi", http.StatusInternalServerError)
+			return
+		}
+
+		shipment, loadErr := h.service.Get(ctx, id)
+		if loadErr != nil {
+			http.Error(w, "Daşınma məlumatlarını əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+			return
+		}
+		h.renderDetailWithEvent(w, r, shipment, form, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/shipments/%d#events", id), http.StatusSeeOther)
+}
+
+// Legs daşınmanın marşrut hissələrinin redaktə formunu göstərir
+func (h *Handler) Legs(w http.ResponseWriter, r *http.Request) {
+	shipment, ok := h.loadShipment(w, r)
+	if !ok {
+		return
+	}
+
+	if !shipment.IsEditable() {
+		h.renderDetail(w, r, shipment, ErrNotEditable.Error(), http.StatusConflict)
+		return
+	}
+
+	route, err := h.service.Route(r.Context(), shipment.ID)
+	if err != nil {
+		http.Error(w, "Marşrut məlumatlarını əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderLegs(w, r, shipment, FormsFromLegs(route.Legs), "", http.StatusOK)
+}
+
+// SaveLegs daşınmanın marşrut hissələrini formdakı hissələrlə əvəz edir
+func (h *Handler) SaveLegs(w http.ResponseWriter, r *http.Request) {
+	shipment, ok := h.loadShipment(w, r)
+	if !ok {
+		return
+	}
+
+	forms := parseLegForms(r)
+
+	if _, err := h.service.SaveLegs(r.Context(), shipment.ID, forms); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) && !errors.Is(err, ErrNotEditable) {
+			http.Error(w, "Marşrutu saxlayarkən xəta baş verdi", http.StatusInternalServerError)
+			return
+		}
+
+		h.renderLegs(w, r, shipment, forms, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/shipments/%d#route", shipment.ID), http.StatusSeeOther)
+}
+
+// loadShipment URL-dəki ID-yə görə daşınmanı əldə edir, tapılmadıqda cavabı özü yazır
+func (h *Handler) loadShipment(w http.ResponseWriter, r *http.Request) (*Shipment, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return nil, false
+	}
+
+	shipment, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			http.NotFound(w, r)
+			return nil, false
+		}
+		http.Error(w, "Daşınma məlumatlarını əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	return shipment, true
+}
+
+// renderDetail detallar səhifəsini status tarixçəsi və izləmə hadisələri ilə birlikdə göstərir
+func (h *Handler) renderDetail(w http.ResponseWriter, r *http.Request, shipment *Shipment, message string, status int) {
+	form := EventForm{OccurredAt: time.Now().Format(dateTimeLayout)}
+	h.renderDetailWithEvent(w, r, shipment, form, message, status)
+}
+
+// renderDetailWithEvent detallar səhifəsini verilmiş hadisə formu ilə göstərir
+func (h *Handler) renderDetailWithEvent(w http.ResponseWriter, r *http.Request, shipment *Shipment, form EventForm, message string, status int) {
+	history, err := h.service.History(r.Context(), shipment.ID)
+	if err != nil {
+		http.Error(w, "Status tarixçəsini əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	events, err := h.service.Events(r.Context(), shipment.ID)
+	if err != nil {
+		http.Error(w, "İzləmə hadisələrini əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	voyages, err := h.service.Voyages(r.Context(), shipment.ID)
+	if err != nil {
+		http.Error(w, "Reys məlumatlarını əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	route, err := h.service.Route(r.Context(), shipment.ID)
+	if err != nil {
+		http.Error(w, "Marşrut məlumatlarını əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	data := DetailPage{
+		Shipment:    *shipment,
+		History:     history,
+		Events:      events,
+		Voyages:     voyages,
+		Route:       *route,
+		EventForm:   form,
+		EventCodes:  AllEventCodes(),
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "shipments",
+		Error:       message,
+	}
+
+	w.WriteHeader(status)
+	view.Render(w, r, h.tmpl, "shipment/detail.html", data)
+}
+
+// renderForm daşınma formunu müştəri siyahısı ilə birlikdə göstərir
+func (h *Handler) renderForm(w http.ResponseWriter, r *http.Request, form ShipmentForm, id int, message string, status int) {
+	customers, err := h.service.Customers(r.Context())
+	if err != nil {
+		http.Error(w, "Müştəri siyahısı əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	data := FormPage{
+		Form:        form,
+		ShipmentID:  id,
+		IsEdit:      id != 0,
+		Customers:   customers,
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "shipments",
+		Error:       message,
+	}
+
+	w.WriteHeader(status)
+	view.Render(w, r, h.tmpl, "shipment/form.html", data)
+}
+
+// renderLegs marşrut formunu nəqliyyat növləri və daşıyıcılar siyahısı ilə birlikdə göstərir
+func (h *Handler) renderLegs(w http.ResponseWriter, r *http.Request, shipment *Shipment, forms []LegForm, message string, status int) {
+	carriers, err := h.service.Carriers(r.Context())
+	if err != nil {
+		http.Error(w, "Daşıyıcı siyahısı əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	data := LegsPage{
+		Shipment:    *shipment,
+		Legs:        WithEmptyLegs(forms),
+		Modes:       carrier.ModeOptions(),
+		Carriers:    carriers,
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "shipments",
+		Error:       message,
+	}
+
+	w.WriteHeader(status)
+	view.Render(w, r, h.tmpl, "shipment/legs.html", data)
+}
+
+// renderFormError formu xəta mesajı ilə yenidən göstərir
+func (h *Handler) renderFormError(w http.ResponseWriter, r *http.Request, form ShipmentForm, id int, err error) {
+	if errors.Is(err, ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+
+	var validationErr *ValidationError
+	message := "Daşınma məlumatlarını saxlayarkən xəta baş verdi"
+	if errors.As(err, &validationErr) || errors.Is(err, ErrNotEditable) {
+		message = err.Error()
+	}
+
+	h.renderForm(w, r, form.WithEmptyLines(), id, message, http.StatusUnprocessableEntity)
+}
+
+// parseForm sorğudan daşınma formunun dəyərlərini oxuyur
+func parseForm(r *http.Request) ShipmentForm {
+	r.ParseForm()
+
+	form := ShipmentForm{
+		CustomerID:  r.FormValue("customer_id"),
+		BLNumber:    r.FormValue("bl_number"),
+		Origin:      r.FormValue("origin"),
+		Destination: r.FormValue("destination"),
+		ETD:         r.FormValue("etd"),
+		ETA:         r.FormValue("eta"),
+		Containers:  r.FormValue("containers"),
+		Notes:       r.FormValue("notes"),
+	}
+
+	descriptions := r.PostForm["cargo_description"]
+	for i := range descriptions {
+		form.CargoLines = append(form.CargoLines, CargoLineForm{
+			Description:   descriptions[i],
+			HSCode:        formIndex(r, "cargo_hs_code", i),
+			Packages:      formIndex(r, "cargo_packages", i),
+			PackageType:   formIndex(r, "cargo_package_type", i),
+			GrossWeightKg: formIndex(r, "cargo_gross_weight_kg", i),
+			VolumeM3:      formIndex(r, "cargo_volume_m3", i),
+		})
+	}
+
+	return form
+}
+
+// parseLegForms sorğudan marşrut hissələrinin dəyərlərini oxuyur
+func parseLegForms(r *http.Request) []LegForm {
+	r.ParseForm()
+
+	var forms []LegForm
+	modes := r.PostForm["leg_mode"]
+	for i := range modes {
+		forms = append(forms, LegForm{
+			Mode:             modes[i],
+			CarrierID:        formIndex(r, "leg_carrier_id", i),
+			From:             formIndex(r, "leg_from", i),
+			To:               formIndex(r, "leg_to", i),
+			PlannedDeparture: formIndex(r, "leg_planned_departure", i),
+			PlannedArrival:   formIndex(r, "leg_planned_arrival", i),
+			ActualDeparture:  formIndex(r, "leg_actual_departure", i),
+			ActualArrival:    formIndex(r, "leg_actual_arrival", i),
+			Containers:       formIndex(r, "leg_containers", i),
+		})
+	}
+
+	return forms
+}
+
+// formIndex təkrarlanan form sahəsinin i-ci dəyərini qaytarır
+func formIndex(r *http.Request, key string, i int) string {
+	values := r.PostForm[key]
+	if i < len(values) {
+		return values[i]
+	}
+	return ""
+}
@@ -0,0 +1,349 @@
+package shipment
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/carrier"
+)
+
+// LegState marşrut hissəsinin izləmə hadisələrinə görə hesablanan vəziyyətidir
+type LegState string
+
+// Marşrut hissəsinin vəziyyətləri
+const (
+	LegPlanned    LegState = "planned"
+	LegInProgress LegState = "in_progress"
+	LegCompleted  LegState = "completed"
+)
+
+// legStateLabels vəziyyətlərin istifadəçi üçün adlarını saxlayır
+var legStateLabels = map[LegState]string{
+	LegPlanned:    "Planlaşdırılıb",
+	LegInProgress: "Yoldadır",
+	LegCompleted:  "Tamamlanıb",
+}
+
+// Label vəziyyətin istifadəçi üçün adını qaytarır
+func (s LegState) Label() string {
+	if label, ok := legStateLabels[s]; ok {
+		return label
+	}
+	return string(s)
+}
+
+// Leg daşınmanın bir nəqliyyat növü ilə iki məntəqə arasındakı marşrut hissəsini təmsil edir.
+// Containers boş olduqda hissə daşınmanın bütün konteynerlərinə aiddir.
+// ExpectedDeparture, ExpectedArrival və State saxlanılmır, PlanRoute tərəfindən hesablanır.
+type Leg struct {
+	ID               int         `db:"id" json:"id"`
+	ShipmentID       int         `db:"shipment_id" json:"-"`
+	Seq              int         `db:"seq" json:"seq"`
+	Mode             string      `db:"mode" json:"mode"`
+	CarrierID        *int        `db:"carrier_id" json:"carrierId"`
+	CarrierName      *string     `db:"carrier_name" json:"carrierName"`
+	FromLocationID   int         `db:"from_location_id" json:"fromLocationId"`
+	FromLocation     string      `db:"from_location" json:"fromLocation"`
+	FromUNLOCODE     string      `db:"from_unlocode" json:"fromUnlocode"`
+	ToLocationID     int         `db:"to_location_id" json:"toLocationId"`
+	ToLocation       string      `db:"to_location" json:"toLocation"`
+	ToUNLOCODE       string      `db:"to_unlocode" json:"toUnlocode"`
+	PlannedDeparture *time.Time  `db:"planned_departure" json:"plannedDeparture"`
+	PlannedArrival   *time.Time  `db:"planned_arrival" json:"plannedArrival"`
+	ActualDeparture  *time.Time  `db:"actual_departure" json:"actualDeparture"`
+	ActualArrival    *time.Time  `db:"actual_arrival" json:"actualArrival"`
+	Containers       []Container `db:"-" json:"containers"`
+
+	ExpectedDeparture *time.Time `db:"-" json:"expectedDeparture"`
+	ExpectedArrival   *time.Time `db:"-" json:"expectedArrival"`
+	State             LegState   `db:"-" json:"state"`
+}
+
+// ModeLabel hissənin nəqliyyat növünün adını qaytarır
+func (l Leg) ModeLabel() string {
+	return carrier.ModeLabel(l.Mode)
+}
+
+// Delayed gözlənilən çatma vaxtının planlaşdırılandan gec olduğunu göstərir
+func (l Leg) Delayed() bool {
+	return l.PlannedArrival != nil && l.ExpectedArrival != nil && l.ExpectedArrival.After(*l.PlannedArrival)
+}
+
+// carries hissənin verilmiş konteyneri daşıdığını göstərir
+func (l Leg) carries(containerID int) bool {
+	if len(l.Containers) == 0 {
+		return true
+	}
+	for _, c := range l.Containers {
+		if c.ID == containerID {
+			return true
+		}
+	}
+	return false
+}
+
+// Route daşınmanın marşrutu, cari hissəsi və bütün marşrut üzrə hesablanmış ETA-sıdır.
+// CurrentSeq tamamlanmamış ilk hissənin sıra nömrəsidir; bütün hissələr tamamlandıqda 0 olur.
+type Route struct {
+	Legs       []Leg      `json:"legs"`
+	CurrentSeq int        `json:"currentSeq"`
+	ETA        *time.Time `json:"eta"`
+}
+
+// Current cari hissəni qaytarır; belə hissə yoxdursa nil qaytarır
+func (r Route) Current() *Leg {
+	for i := range r.Legs {
+		if r.Legs[i].Seq == r.CurrentSeq {
+			return &r.Legs[i]
+		}
+	}
+	return nil
+}
+
+// LegForm marşrut hissəsinin form sahələridir. From və To məntəqənin UN/LOCODE-u və ya (kodu olmayan
+// məntəqələr, məs. müştərinin anbarı üçün) ID-sidir; Containers vergüllə ayrılmış konteyner nömrələridir.
+type LegForm struct {
+	Mode             string
+	CarrierID        string
+	From             string
+	To               string
+	PlannedDeparture string
+	PlannedArrival   string
+	ActualDeparture  string
+	ActualArrival    string
+	Containers       string
+}
+
+// IsEmpty hissənin heç bir sahəsinin doldurulmadığını göstərir
+func (f LegForm) IsEmpty() bool {
+	return f == LegForm{}
+}
+
+// LocationRef marşrut hissəsinin məntəqəsini təmsil edir
+type LocationRef struct {
+	ID       int    `db:"id"`
+	Name     string `db:"name"`
+	UNLOCODE string `db:"unlocode"`
+}
+
+// CarrierOption formda seçilə bilən daşıyıcını nəqliyyat növü ilə birlikdə təmsil edir
+type CarrierOption struct {
+	ID   int    `db:"id"`
+	Name string `db:"name"`
+	Mode string `db:"mode"`
+}
+
+// ModeLabel daşıyıcının nəqliyyat növünün adını qaytarır
+func (c CarrierOption) ModeLabel() string {
+	return carrier.ModeLabel(c.Mode)
+}
+
+// LegsPage marşrut redaktə səhifəsi üçün məlumatları təmsil edir
+type LegsPage struct {
+	Shipment    Shipment
+	Legs        []LegForm
+	Modes       []carrier.ModeOption
+	Carriers    []CarrierOption
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// emptyLegs marşrut formunda əlavə olaraq göstərilən boş hissələrin sayıdır
+const emptyLegs = 2
+
+// FormsFromLegs mövcud hissələrdən redaktə formunu yaradır
+func FormsFromLegs(legs []Leg) []LegForm {
+	forms := make([]LegForm, 0, len(legs))
+	for _, leg := range legs {
+		form := LegForm{
+			Mode:             leg.Mode,
+			From:             locationKey(leg.FromLocationID, leg.FromUNLOCODE),
+			To:               locationKey(leg.ToLocationID, leg.ToUNLOCODE),
+			PlannedDeparture: formatDateTime(leg.PlannedDeparture),
+			PlannedArrival:   formatDateTime(leg.PlannedArrival),
+			ActualDeparture:  formatDateTime(leg.ActualDeparture),
+			ActualArrival:    formatDateTime(leg.ActualArrival),
+		}
+		if leg.CarrierID != nil {
+			form.CarrierID = itoa(*leg.CarrierID)
+		}
+		for i, c := range leg.Containers {
+			if i > 0 {
+				form.Containers += ", "
+			}
+			form.Containers += c.Number
+		}
+		forms = append(forms, form)
+	}
+	return forms
+}
+
+// locationKey məntəqəni formda UN/LOCODE ilə, kodu olmadıqda isə ID ilə göstərir
+func locationKey(id int, unlocode string) string {
+	if unlocode != "" {
+		return unlocode
+	}
+	return itoa(id)
+}
+
+// WithEmptyLegs doldurulmamış hissələri çıxarır və sona yeni hissələr üçün boş sətirlər əlavə edir
+func WithEmptyLegs(forms []LegForm) []LegForm {
+	result := make([]LegForm, 0, len(forms)+emptyLegs)
+	for _, f := range forms {
+		if !f.IsEmpty() {
+			result = append(result, f)
+		}
+	}
+	for i := 0; i < emptyLegs; i++ {
+		result = append(result, LegForm{})
+	}
+	return result
+}
+
+// PlanRoute hissələrin faktiki vaxtlarını izləmə hadisələrindən tamamlayır, hər hissənin vəziyyətini
+// və gözlənilən vaxtlarını, eləcə də bütün marşrut üzrə ETA-nı hesablayır.
+// Hissədə əllə daxil edilmiş faktiki vaxtlar hadisələrdən üstündür. events xronoloji sırada olmalıdır.
+func PlanRoute(legs []Leg, events []TrackingEvent, now time.Time) Route {
+	route := Route{Legs: make([]Leg, len(legs))}
+	copy(route.Legs, legs)
+	if len(route.Legs) == 0 {
+		return route
+	}
+
+	for _, event := range events {
+		applyEvent(route.Legs, event)
+	}
+
+	completed := make([]bool, len(route.Legs))
+	started := false
+	for i := len(route.Legs) - 1; i >= 0; i-- {
+		leg := &route.Legs[i]
+		// Sonrakı hissə başlayıbsa (və ya çatıbsa) bu hissənin çatma hadisəsi qeydə alınmasa da o tamamlanıb
+		completed[i] = leg.ActualArrival != nil || started
+		started = started || leg.ActualDeparture != nil || leg.ActualArrival != nil
+	}
+
+	var ready *time.Time
+	for i := range route.Legs {
+		leg := &route.Legs[i]
+
+		switch {
+		case completed[i]:
+			leg.State = LegCompleted
+		case leg.ActualDeparture != nil:
+			leg.State = LegInProgress
+		default:
+			leg.State = LegPlanned
+		}
+		if leg.State != LegCompleted && route.CurrentSeq == 0 {
+			route.CurrentSeq = leg.Seq
+		}
+
+		leg.ExpectedDeparture = leg.ActualDeparture
+		if leg.ExpectedDeparture == nil {
+			// Yola düşmə planlaşdırılan vaxtdan, əvvəlki hissənin çatmasından və indidən tez ola bilməz
+			departure := latest(leg.PlannedDeparture, ready)
+			if departure != nil && !completed[i] {
+				departure = latest(departure, &now)
+			}
+			leg.ExpectedDeparture = departure
+		}
+
+		leg.ExpectedArrival = leg.ActualArrival
+		if leg.ExpectedArrival == nil && !completed[i] {
+			arrival := leg.PlannedArrival
+			// Planlaşdırılan tranzit müddəti saxlanılır, gecikmiş yola düşmə çatmanı da sürüşdürür
+			if arrival != nil && leg.PlannedDeparture != nil && leg.ExpectedDeparture != nil {
+				shifted := leg.ExpectedDeparture.Add(arrival.Sub(*leg.PlannedDeparture))
+				arrival = latest(arrival, &shifted)
+			}
+			if arrival != nil {
+				arrival = latest(arrival, &now)
+			}
+			leg.ExpectedArrival = arrival
+		}
+
+		if leg.ExpectedArrival != nil {
+			ready = leg.ExpectedArrival
+		}
+	}
+
+	last := route.Legs[len(route.Legs)-1]
+	route.ETA = last.ExpectedArrival
+
+	return route
+}
+
+// applyEvent yola düşmə və çatma hadisəsini uyğun hissənin faktiki vaxtı kimi qeyd edir.
+// Hissə əvvəlcə hadisənin məkanına görə, tapılmadıqda isə hissələrin ardıcıllığına görə seçilir.
+func applyEvent(legs []Leg, event TrackingEvent) {
+	at := event.OccurredAt
+	eligible := func(i int) bool {
+		return event.ContainerID == nil || legs[i].carries(*event.ContainerID)
+	}
+
+	switch event.Code {
+	case EventDeparted:
+		for i := range legs {
+			if eligible(i) && legs[i].ActualDeparture == nil && matchesLocation(event.Location, legs[i].FromLocation, legs[i].FromUNLOCODE) {
+				legs[i].ActualDeparture = &at
+				return
+			}
+		}
+		// Eyni hissənin digər konteyneri üçün təkrar hadisə növbəti hissəyə aid edilməməlidir:
+		// hissə yalnız əvvəlki hissə çatdıqdan sonra yola düşə bilər
+		for i := range legs {
+			if !eligible(i) || legs[i].ActualDeparture != nil {
+				continue
+			}
+			if i == 0 || legs[i-1].ActualArrival != nil {
+				legs[i].ActualDeparture = &at
+			}
+			return
+		}
+
+	case EventArrived, EventDelivered:
+		for i := range legs {
+			if eligible(i) && legs[i].ActualArrival == nil && matchesLocation(event.Location, legs[i].ToLocation, legs[i].ToUNLOCODE) {
+				legs[i].ActualArrival = &at
+				return
+			}
+		}
+		if event.Code == EventDelivered {
+			if last := len(legs) - 1; eligible(last) && legs[last].ActualArrival == nil {
+				legs[last].ActualArrival = &at
+			}
+			return
+		}
+		for i := range legs {
+			if eligible(i) && legs[i].ActualDeparture != nil && legs[i].ActualArrival == nil {
+				legs[i].ActualArrival = &at
+				return
+			}
+		}
+	}
+}
+
+// matchesLocation hadisənin sərbəst mətnli məkanının məntəqənin adı və ya UN/LOCODE-u ilə uyğun gəldiyini yoxlayır
+func matchesLocation(value, name, unlocode string) bool {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return false
+	}
+	if unlocode != "" && strings.Contains(value, strings.ToLower(unlocode)) {
+		return true
+	}
+	return name != "" && strings.Contains(value, strings.ToLower(name))
+}
+
+// latest iki vaxtdan gec olanını qaytarır; nil dəyərlər nəzərə alınmır
+func latest(a, b *time.Time) *time.Time {
+	if a == nil {
+		return b
+	}
+	if b == nil || a.After(*b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,207 @@
+package shipment
+
+import (
+	"testing"
+	"time"
+)
+
+// routeStart test marşrutlarının planlaşdırılan başlanğıc vaxtıdır
+var routeStart = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+// at routeStart-dan verilmiş saat sonrakı vaxtı qaytarır
+func at(hours int) *time.Time {
+	t := routeStart.Add(time.Duration(hours) * time.Hour)
+	return &t
+}
+
+// testLegs Bakı - Poti avtomobil və Poti - Konstansa dəniz hissələrindən ibarət marşrut qaytarır
+func testLegs() []Leg {
+	return []Leg{
+		{
+			Seq: 1, Mode: "road",
+			FromLocation: "Baku", FromUNLOCODE: "AZBAK",
+			ToLocation: "Poti", ToUNLOCODE: "GEPTI",
+			PlannedDeparture: at(0), PlannedArrival: at(48),
+		},
+		{
+			Seq: 2, Mode: "sea",
+			FromLocation: "Poti", FromUNLOCODE: "GEPTI",
+			ToLocation: "Constanta", ToUNLOCODE: "ROCND",
+			PlannedDeparture: at(72), PlannedArrival: at(144),
+		},
+	}
+}
+
+// event izləmə hadisəsi yaradır
+func event(code EventCode, location string, hours int) TrackingEvent {
+	return TrackingEvent{Code: code, Location: location, OccurredAt: *at(hours)}
+}
+
+// assertTime vaxtın gözlənilən qiymətə bərabər olduğunu yoxlayır
+func assertTime(t *testing.T, name string, got, want *time.Time) {
+	t.Helper()
+
+	switch {
+	case got == nil && want == nil:
+	case got == nil || want == nil:
+		t.Errorf("%s = %v, gözlənilən %v", name, got, want)
+	case !got.Equal(*want):
+		t.Errorf("%s = %s, gözlənilən %s", name, got.Format(time.RFC3339), want.Format(time.RFC3339))
+	}
+}
+
+// TestPlanRouteOnSchedule hadisə olmadıqda planlaşdırılan vaxtların saxlanıldığını yoxlayır
+func TestPlanRouteOnSchedule(t *testing.T) {
+	route := PlanRoute(testLegs(), nil, *at(-1))
+
+	if route.CurrentSeq != 1 {
+		t.Errorf("cari hissə = %d, gözlənilən 1", route.CurrentSeq)
+	}
+	for _, leg := range route.Legs {
+		if leg.State != LegPlanned || leg.Delayed() {
+			t.Errorf("hissə %d: vəziyyət = %s, gecikmə = %v", leg.Seq, leg.State, leg.Delayed())
+		}
+	}
+	assertTime(t, "ETA", route.ETA, at(144))
+}
+
+// TestPlanRouteDelayedDeparture gecikmiş yola düşmənin tranzit müddətini saxlayaraq çatmanı
+// və sonrakı hissələri sürüşdürdüyünü yoxlayır
+func TestPlanRouteDelayedDeparture(t *testing.T) {
+	events := []TrackingEvent{event(EventDeparted, "Baku terminal", 30)}
+	route := PlanRoute(testLegs(), events, *at(31))
+
+	first, second := route.Legs[0], route.Legs[1]
+	if first.State != LegInProgress || route.CurrentSeq != 1 {
+		t.Errorf("birinci hissə = %s, cari hissə = %d", first.State, route.CurrentSeq)
+	}
+	assertTime(t, "birinci hissənin faktiki yola düşməsi", first.ActualDeparture, at(30))
+	assertTime(t, "birinci hissənin gözlənilən çatması", first.ExpectedArrival, at(78))
+
+	// İkinci hissə birinci çatmadan yola düşə bilməz
+	assertTime(t, "ikinci hissənin gözlənilən yola düşməsi", second.ExpectedDeparture, at(78))
+	assertTime(t, "ikinci hissənin gözlənilən çatması", second.ExpectedArrival, at(150))
+	assertTime(t, "ETA", route.ETA, at(150))
+
+	if !first.Delayed() || !second.Delayed() {
+		t.Error("gecikmə göstərilmədi")
+	}
+}
+
+// TestPlanRouteNotDeparted planlaşdırılan vaxtda yola düşməyən hissənin indiki vaxtdan hesablandığını yoxlayır
+func TestPlanRouteNotDeparted(t *testing.T) {
+	route := PlanRoute(testLegs(), nil, *at(12))
+
+	assertTime(t, "gözlənilən yola düşmə", route.Legs[0].ExpectedDeparture, at(12))
+	assertTime(t, "gözlənilən çatma", route.Legs[0].ExpectedArrival, at(60))
+	assertTime(t, "ETA", route.ETA, at(144))
+}
+
+// TestPlanRouteImpliedCompletion çatma hadisəsi olmasa da növbəti hissə yola düşdükdə
+// əvvəlki hissənin tamamlanmış sayıldığını yoxlayır
+func TestPlanRouteImpliedCompletion(t *testing.T) {
+	events := []TrackingEvent{
+		event(EventDeparted, "AZBAK", 1),
+		event(EventDeparted, "Poti port", 70),
+	}
+	route := PlanRoute(testLegs(), events, *at(71))
+
+	first, second := route.Legs[0], route.Legs[1]
+	if first.State != LegCompleted || second.State != LegInProgress {
+		t.Errorf("vəziyyətlər = %s, %s, gözlənilən completed, in_progress", first.State, second.State)
+	}
+	if route.CurrentSeq != 2 {
+		t.Errorf("cari hissə = %d, gözlənilən 2", route.CurrentSeq)
+	}
+	if first.ActualArrival != nil {
+		t.Error("çatma vaxtı uydurulmamalıdır")
+	}
+	// Vaxtından tez yola düşmə planlaşdırılan çatmanı irəli çəkmir
+	assertTime(t, "ETA", route.ETA, at(144))
+}
+
+// TestPlanRouteDelivered bütün hissələr tamamlandıqda cari hissənin olmadığını və ETA-nın faktiki çatma olduğunu yoxlayır
+func TestPlanRouteDelivered(t *testing.T) {
+	events := []TrackingEvent{
+		event(EventDeparted, "Baku", 0),
+		event(EventArrived, "Poti", 46),
+		event(EventDeparted, "Poti", 72),
+		event(EventDelivered, "Receiver warehouse", 140),
+	}
+	route := PlanRoute(testLegs(), events, *at(150))
+
+	if route.CurrentSeq != 0 || route.Current() != nil {
+		t.Errorf("cari hissə = %d, gözlənilən 0", route.CurrentSeq)
+	}
+	for _, leg := range route.Legs {
+		if leg.State != LegCompleted {
+			t.Errorf("hissə %d: vəziyyət = %s", leg.Seq, leg.State)
+		}
+	}
+	assertTime(t, "ETA", route.ETA, at(140))
+}
+
+// TestPlanRouteManualActuals əllə daxil edilmiş faktiki vaxtların hadisələrdən üstün olduğunu yoxlayır
+func TestPlanRouteManualActuals(t *testing.T) {
+	legs := testLegs()
+	legs[0].ActualDeparture = at(2)
+
+	route := PlanRoute(legs, []TrackingEvent{event(EventDeparted, "Baku", 5)}, *at(6))
+
+	assertTime(t, "faktiki yola düşmə", route.Legs[0].ActualDeparture, at(2))
+	if route.Legs[1].ActualDeparture != nil {
+		t.Error("hadisə növbəti hissəyə aid edildi")
+	}
+}
+
+// TestApplyEventSequenceFallback məkanı uyğun gəlməyən hadisələrin hissələrin ardıcıllığına görə
+// aid edildiyini və əvvəlki hissə çatmadan növbəti hissənin başlamadığını yoxlayır
+func TestApplyEventSequenceFallback(t *testing.T) {
+	legs := testLegs()
+
+	applyEvent(legs, event(EventDeparted, "", 1))
+	assertTime(t, "birinci hissənin yola düşməsi", legs[0].ActualDeparture, at(1))
+
+	// Digər konteyner üçün təkrar yola düşmə hadisəsi
+	applyEvent(legs, event(EventDeparted, "", 2))
+	if legs[1].ActualDeparture != nil {
+		t.Error("təkrar hadisə növbəti hissəyə aid edildi")
+	}
+
+	applyEvent(legs, event(EventArrived, "", 47))
+	assertTime(t, "birinci hissənin çatması", legs[0].ActualArrival, at(47))
+
+	applyEvent(legs, event(EventDeparted, "", 73))
+	assertTime(t, "ikinci hissənin yola düşməsi", legs[1].ActualDeparture, at(73))
+}
+
+// TestApplyEventContainer konteynerə aid hadisələrin yalnız həmin konteyneri daşıyan hissələrə aid edildiyini yoxlayır
+func TestApplyEventContainer(t *testing.T) {
+	legs := []Leg{
+		{Seq: 1, FromLocation: "Baku", ToLocation: "Poti", ToUNLOCODE: "GEPTI"},
+		{Seq: 2, FromLocation: "Poti", ToLocation: "Constanta", Containers: []Container{{ID: 7}}},
+		{Seq: 3, FromLocation: "Poti", ToLocation: "Varna", Containers: []Container{{ID: 8}}},
+	}
+	container := func(id int, e TrackingEvent) TrackingEvent {
+		e.ContainerID = &id
+		return e
+	}
+
+	applyEvent(legs, container(8, event(EventArrived, "GEPTI", 40)))
+	assertTime(t, "bütün konteynerlərin hissəsinin çatması", legs[0].ActualArrival, at(40))
+
+	applyEvent(legs, container(8, event(EventDeparted, "Poti", 80)))
+	if legs[1].ActualDeparture != nil {
+		t.Error("8 nömrəli konteynerin hadisəsi 7 nömrəlinin hissəsinə aid edildi")
+	}
+	assertTime(t, "8 nömrəli konteynerin yola düşməsi", legs[2].ActualDeparture, at(80))
+
+	applyEvent(legs, container(7, event(EventDeparted, "Poti", 82)))
+	assertTime(t, "7 nömrəli konteynerin yola düşməsi", legs[1].ActualDeparture, at(82))
+
+	// 9 nömrəli konteyneri heç bir xüsusi hissə daşımır
+	applyEvent(legs, container(9, event(EventDelivered, "Varna", 120)))
+	if legs[2].ActualArrival != nil {
+		t.Error("başqa konteynerin təhvili 8 nömrəlinin hissəsinə aid edildi")
+	}
+}
@@ -0,0 +1,252 @@
+package shipment
+
+import (
+	"time"
+)
+
+// Shipment verilənlər bazasından gələn daşınma məlumatlarını təmsil edir
+type Shipment struct {
+	ID             int         `db:"id" json:"id"`
+	Reference      string      `db:"reference" json:"reference"`
+	TrackingNumber string      `db:"tracking_number" json:"trackingNumber"`
+	BLNumber       string      `db:"bl_number" json:"blNumber"`
+	CustomerID     int         `db:"customer_id" json:"customerId" validate:"required"`
+	CustomerName   string      `db:"customer_name" json:"customerName"`
+	Origin         string      `db:"origin" json:"origin" validate:"required"`
+	Destination    string      `db:"destination" json:"destination" validate:"required"`
+	ETD            *time.Time  `db:"etd" json:"etd,omitempty"`
+	ETA            *time.Time  `db:"eta" json:"eta,omitempty"`
+	Status         Status      `db:"status" json:"status"`
+	Notes          string      `db:"notes" json:"notes"`
+	CreatedAt      time.Time   `db:"created_at" json:"createdAt"`
+	UpdatedAt      time.Time   `db:"updated_at" json:"updatedAt"`
+	Containers     []Container `db:"-" json:"containers"`
+	CargoLines     []CargoLine `db:"-" json:"cargoLines"`
+}
+
+// Container daşınmaya bağlanmış konteyneri təmsil edir
+type Container struct {
+	ID       int    `db:"id" json:"id"`
+	Number   string `db:"number" json:"number"`
+	SizeType string `db:"size_type" json:"sizeType"`
+}
+
+// CargoLine daşınmadakı yük sətrini təmsil edir
+type CargoLine struct {
+	ID            int     `db:"id" json:"id"`
+	ShipmentID    int     `db:"shipment_id" json:"-"`
+	LineNo        int     `db:"line_no" json:"lineNo"`
+	Description   string  `db:"description" json:"description"`
+	HSCode        string  `db:"hs_code" json:"hsCode"`
+	Packages      int     `db:"packages" json:"packages"`
+	PackageType   string  `db:"package_type" json:"packageType"`
+	GrossWeightKg float64 `db:"gross_weight_kg" json:"grossWeightKg"`
+	VolumeM3      float64 `db:"volume_m3" json:"volumeM3"`
+}
+
+// StatusChange daşınma statusunun dəyişmə tarixçəsindəki qeydi təmsil edir
+type StatusChange struct {
+	ID         int       `db:"id" json:"id"`
+	ShipmentID int       `db:"shipment_id" json:"-"`
+	FromStatus Status    `db:"from_status" json:"fromStatus"`
+	ToStatus   Status    `db:"to_status" json:"toStatus"`
+	Note       string    `db:"note" json:"note"`
+	ChangedAt  time.Time `db:"changed_at" json:"changedAt"`
+}
+
+// VoyageLeg daşınmanın təyin edildiyi reysin yükləmə və boşaltma məntəqələri arasındakı hissəsidir
+type VoyageLeg struct {
+	VoyageID          int        `db:"voyage_id" json:"voyageId"`
+	VoyageNumber      string     `db:"voyage_number" json:"voyageNumber"`
+	CarrierName       string     `db:"carrier_name" json:"carrierName"`
+	VesselName        *string    `db:"vessel_name" json:"vesselName"`
+	LoadLocation      string     `db:"load_location" json:"loadLocation"`
+	LoadETD           *time.Time `db:"load_etd" json:"loadEtd"`
+	DischargeLocation string     `db:"discharge_location" json:"dischargeLocation"`
+	DischargeETA      *time.Time `db:"discharge_eta" json:"dischargeEta"`
+}
+
+// CustomerOption formda seçilə bilən müştərini təmsil edir
+type CustomerOption struct {
+	ID   int    `db:"id"`
+	Name string `db:"name"`
+}
+
+// TotalWeightKg bütün yük sətirlərinin ümumi brutto çəkisini qaytarır
+func (s Shipment) TotalWeightKg() float64 {
+	var total float64
+	for _, line := range s.CargoLines {
+		total += line.GrossWeightKg
+	}
+	return total
+}
+
+// TotalPackages bütün yük sətirlərinin ümumi yer sayını qaytarır
+func (s Shipment) TotalPackages() int {
+	var total int
+	for _, line := range s.CargoLines {
+		total += line.Packages
+	}
+	return total
+}
+
+// IsEditable daşınmanın redaktə oluna bilməsini göstərir
+func (s Shipment) IsEditable() bool {
+	return !s.Status.IsTerminal()
+}
+
+// AcceptsEvents daşınmaya izləmə hadisəsi əlavə oluna bilməsini göstərir
+func (s Shipment) AcceptsEvents() bool {
+	return s.Status != StatusDraft && s.Status != StatusCancelled
+}
+
+// ListFilter daşınma siyahısı üçün axtarış və səhifələmə parametrlərini saxlayır
+type ListFilter struct {
+	Query   string
+	Status  Status
+	Sort    string
+	Page    int
+	PerPage int
+}
+
+// ShipmentList səhifələnmiş daşınma siyahısını təmsil edir
+type ShipmentList struct {
+	Items   []Shipment
+	Total   int
+	Page    int
+	PerPage int
+}
+
+// HasPrev əvvəlki səhifənin olub-olmadığını göstərir
+func (l ShipmentList) HasPrev() bool {
+	return l.Page > 1
+}
+
+// HasNext növbəti səhifənin olub-olmadığını göstərir
+func (l ShipmentList) HasNext() bool {
+	return l.Page*l.PerPage < l.Total
+}
+
+// PrevPage əvvəlki səhifənin nömrəsini qaytarır
+func (l ShipmentList) PrevPage() int {
+	return l.Page - 1
+}
+
+// NextPage növbəti səhifənin nömrəsini qaytarır
+func (l ShipmentList) NextPage() int {
+	return l.Page + 1
+}
+
+// CargoLineForm formdakı yük sətrini təmsil edir
+type CargoLineForm struct {
+	Description   string
+	HSCode        string
+	Packages      string
+	PackageType   string
+	GrossWeightKg string
+	VolumeM3      string
+}
+
+// ShipmentForm daşınma yaratma və redaktə formunu təmsil edir
+type ShipmentForm struct {
+	CustomerID  string
+	BLNumber    string
+	Origin      string
+	Destination string
+	ETD         string
+	ETA         string
+	Containers  string
+	Notes       string
+	CargoLines  []CargoLineForm
+}
+
+// ListPage daşınma siyahısı səhifəsi üçün məlumatları təmsil edir
+type ListPage struct {
+	Shipments   ShipmentList
+	Filter      ListFilter
+	Statuses    []Status
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// FormPage daşınma formu səhifəsi üçün məlumatları təmsil edir
+type FormPage struct {
+	Form        ShipmentForm
+	ShipmentID  int
+	IsEdit      bool
+	Customers   []CustomerOption
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// DetailPage daşınma detalları səhifəsi üçün məlumatları təmsil edir
+type DetailPage struct {
+	Shipment    Shipment
+	History     []StatusChange
+	Events      []TrackingEvent
+	Voyages     []VoyageLeg
+	Route       Route
+	EventForm   EventForm
+	EventCodes  []EventCode
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// emptyCargoLines formda əlavə olaraq göstərilən boş yük sətirlərinin sayıdır
+const emptyCargoLines = 3
+
+// FormFromShipment mövcud daşınmadan redaktə formu yaradır
+func FormFromShipment(s *Shipment) ShipmentForm {
+	form := ShipmentForm{
+		CustomerID:  itoa(s.CustomerID),
+		BLNumber:    s.BLNumber,
+		Origin:      s.Origin,
+		Destination: s.Destination,
+		ETD:         formatDateTime(s.ETD),
+		ETA:         formatDateTime(s.ETA),
+		Notes:       s.Notes,
+	}
+
+	for i, c := range s.Containers {
+		if i > 0 {
+			form.Containers += ", "
+		}
+		form.Containers += c.Number
+	}
+
+	for _, line := range s.CargoLines {
+		form.CargoLines = append(form.CargoLines, CargoLineForm{
+			Description:   line.Description,
+			HSCode:        line.HSCode,
+			Packages:      itoa(line.Packages),
+			PackageType:   line.PackageType,
+			GrossWeightKg: ftoa(line.GrossWeightKg),
+			VolumeM3:      ftoa(line.VolumeM3),
+		})
+	}
+
+	return form.WithEmptyLines()
+}
+
+// WithEmptyLines formun sonuna yeni yük sətirləri üçün boş sətirlər əlavə edir
+func (f ShipmentForm) WithEmptyLines() ShipmentForm {
+	lines := make([]CargoLineForm, 0, len(f.CargoLines)+emptyCargoLines)
+	for _, line := range f.CargoLines {
+		if !line.IsEmpty() {
+			lines = append(lines, line)
+		}
+	}
+	for i := 0; i < emptyCargoLines; i++ {
+		lines = append(lines, CargoLineForm{})
+	}
+	f.CargoLines = lines
+	return f
+}
+
+// IsEmpty yük sətrinin heç bir sahəsinin doldurulmadığını göstərir
+func (l CargoLineForm) IsEmpty() bool {
+	return l == CargoLineForm{}
+}
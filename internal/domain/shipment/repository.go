@@ -0,0 +1,573 @@
+package shipment
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/db"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// foreignKeyViolation PostgreSQL-in xarici açar məhdudiyyəti pozulması kodudur
+const foreignKeyViolation = "23503"
+
+// Repository daşınma məlumatları əməliyyatlarını müəyyən edir
+type Repository interface {
+	List(ctx context.Context, filter ListFilter) ([]Shipment, int, error)
+	GetByID(ctx context.Context, id int) (*Shipment, error)
+	Create(ctx context.Context, shipment *Shipment) error
+	Update(ctx context.Context, shipment *Shipment) error
+	UpdateStatus(ctx context.Context, id int, from, to Status, note string) (bool, error)
+	StatusHistory(ctx context.Context, id int) ([]StatusChange, error)
+	CustomerOptions(ctx context.Context) ([]CustomerOption, error)
+	FindContainers(ctx context.Context, numbers []string) ([]Container, error)
+	Events(ctx context.Context, id int) ([]TrackingEvent, error)
+	LastEventAt(ctx context.Context, id int) (*time.Time, error)
+	AddEvent(ctx context.Context, event *TrackingEvent, from, to Status, note string) (bool, error)
+	Voyages(ctx context.Context, id int) ([]VoyageLeg, error)
+	Legs(ctx context.Context, id int) ([]Leg, error)
+	ReplaceLegs(ctx context.Context, id int, legs []Leg) error
+	FindLocation(ctx context.Context, id int, unlocode string) (*LocationRef, error)
+	CarrierOptions(ctx context.Context) ([]CarrierOption, error)
+}
+
+// sortColumns siyahının sıralana biləcəyi sahələri və onlara uyğun sütunları saxlayır
+var sortColumns = map[string]string{
+	"reference":    "s.reference",
+	"customerName": "c.name",
+	"etd":          "s.etd",
+	"eta":          "s.eta",
+	"status":       "s.status",
+	"createdAt":    "s.created_at",
+	"updatedAt":    "s.updated_at",
+}
+
+// PostgresRepository Repository interfeysini həyata keçirir
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRepository yeni PostgresRepository yaradır
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+// selectShipment daşınma sorğularının ortaq SELECT hissəsidir
+const selectShipment = `
+	SELECT s.id, s.reference, s.tracking_number, s.bl_number, s.customer_id, c.name AS customer_name, s.origin, s.destination,
+		s.etd, s.eta, s.status, s.notes, s.created_at, s.updated_at
+	FROM shipments s
+	JOIN customers c ON c.id = s.customer_id
+`
+
+// List filtrə uyğun daşınmaları və ümumi sayı əldə edir
+func (r *PostgresRepository) List(ctx context.Context, filter ListFilter) ([]Shipment, int, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, "s.status = $"+strconv.Itoa(len(args)))
+	}
+
+	if q := strings.TrimSpace(filter.Query); q != "" {
+		args = append(args, "%"+q+"%")
+		n := strconv.Itoa(len(args))
+		conditions = append(conditions, "(s.reference ILIKE $"+n+" OR s.tracking_number ILIKE $"+n+
+			" OR s.bl_number ILIKE $"+n+" OR c.name ILIKE $"+n+
+			" OR s.origin ILIKE $"+n+" OR s.destination ILIKE $"+n+")")
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	countQuery := "SELECT COUNT(*) FROM shipments s JOIN customers c ON c.id = s.customer_id " + where
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, 0, err
+	}
+
+	query := selectShipment + where + `
+		ORDER BY ` + db.OrderBy(filter.Sort, sortColumns, "s.created_at DESC, s.id DESC", "s.id") + `
+		LIMIT $` + strconv.Itoa(len(args)+1) + ` OFFSET $` + strconv.Itoa(len(args)+2)
+
+	args = append(args, filter.PerPage, (filter.Page-1)*filter.PerPage)
+
+	shipments := []Shipment{}
+	if err := r.db.SelectContext(ctx, &shipments, query, args...); err != nil {
+		return nil, 0, err
+	}
+
+	return shipments, total, nil
+}
+
+// GetByID daşınmanı konteynerləri və yük sətirləri ilə birlikdə əldə edir
+func (r *PostgresRepository) GetByID(ctx context.Context, id int) (*Shipment, error) {
+	shipment := &Shipment{}
+	err := r.db.GetContext(ctx, shipment, selectShipment+" WHERE s.id = $1", id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Daşınma tapılmadı
+		}
+		return nil, err
+	}
+
+	containersQuery := `
+		SELECT k.id, k.owner_code || k.serial || k.check_digit::text AS number, k.size_type
+		FROM shipment_containers sc
+		JOIN containers k ON k.id = sc.container_id
+		WHERE sc.shipment_id = $1
+		ORDER BY k.owner_code, k.serial
+	`
+	if err := r.db.SelectContext(ctx, &shipment.Containers, containersQuery, id); err != nil {
+		return nil, err
+	}
+
+	linesQuery := `
+		SELECT id, shipment_id, line_no, description, hs_code, packages, package_type,
+			gross_weight_kg, volume_m3
+		FROM shipment_cargo_lines
+		WHERE shipment_id = $1
+		ORDER BY line_no
+	`
+	if err := r.db.SelectContext(ctx, &shipment.CargoLines, linesQuery, id); err != nil {
+		return nil, err
+	}
+
+	return shipment, nil
+}
+
+// Create yeni daşınmanı konteynerləri və yük sətirləri ilə birlikdə əlavə edir
+func (r *PostgresRepository) Create(ctx context.Context, shipment *Shipment) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO shipments (tracking_number, bl_number, customer_id, origin, destination, etd, eta, status, notes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, reference, created_at, updated_at
+	`
+	err = tx.QueryRowxContext(ctx, query, shipment.TrackingNumber, shipment.BLNumber, shipment.CustomerID,
+		shipment.Origin, shipment.Destination, shipment.ETD, shipment.ETA, shipment.Status, shipment.Notes).
+		Scan(&shipment.ID, &shipment.Reference, &shipment.CreatedAt, &shipment.UpdatedAt)
+	if err != nil {
+		return mapError(err)
+	}
+
+	if err := saveChildren(ctx, tx, shipment); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Update mövcud daşınmanı yeniləyir, konteyner və yük sətirlərini əvəz edir
+func (r *PostgresRepository) Update(ctx context.Context, shipment *Shipment) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE shipments
+		SET customer_id = $1, bl_number = $2, origin = $3, destination = $4, etd = $5, eta = $6, notes = $7,
+			updated_at = NOW()
+		WHERE id = $8
+	`
+	_, err = tx.ExecContext(ctx, query, shipment.CustomerID, shipment.BLNumber, shipment.Origin,
+		shipment.Destination, shipment.ETD, shipment.ETA, shipment.Notes, shipment.ID)
+	if err != nil {
+		return mapError(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM shipment_containers WHERE shipment_id = $1`, shipment.ID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM shipment_cargo_lines WHERE shipment_id = $1`, shipment.ID); err != nil {
+		return err
+	}
+
+	if err := saveChildren(ctx, tx, shipment); err != nil {
+		return err
+	}
+
+	// Daşınmadan çıxarılmış konteynerlər marşrut hissələrindən də çıxarılır
+	query = `
+		DELETE FROM shipment_leg_containers lc
+		USING shipment_legs l
+		WHERE l.id = lc.leg_id AND l.shipment_id = $1
+			AND NOT EXISTS (
+				SELECT 1 FROM shipment_containers sc
+				WHERE sc.shipment_id = l.shipment_id AND sc.container_id = lc.container_id
+			)
+	`
+	if _, err := tx.ExecContext(ctx, query, shipment.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdateStatus statusu yalnız hazırkı status from olduqda dəyişir və tarixçəyə yazır
+func (r *PostgresRepository) UpdateStatus(ctx context.Context, id int, from, to Status, note string) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	updated, err := updateStatus(ctx, tx, id, from, to, note)
+	if err != nil || !updated {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+// updateStatus tranzaksiya daxilində statusu şərti dəyişir və tarixçəyə yazır
+func updateStatus(ctx context.Context, tx *sqlx.Tx, id int, from, to Status, note string) (bool, error) {
+	result, err := tx.ExecContext(ctx,
+		`UPDATE shipments SET status = $1, updated_at = NOW() WHERE id = $2 AND status = $3`,
+		to, id, from)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO shipment_status_history (shipment_id, from_status, to_status, note) VALUES ($1, $2, $3, $4)`,
+		id, from, to, note)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Events daşınmanın izləmə hadisələrini baş vermə vaxtına görə xronoloji sıra ilə əldə edir
+func (r *PostgresRepository) Events(ctx context.Context, id int) ([]TrackingEvent, error) {
+	query := `
+		SELECT e.id, e.shipment_id, e.container_id,
+			c.owner_code || c.serial || c.check_digit::text AS container_number,
+			e.code, e.location, e.occurred_at, e.source, e.note, e.recorded_at
+		FROM tracking_events e
+		LEFT JOIN containers c ON c.id = e.container_id
+		WHERE e.shipment_id = $1
+		ORDER BY e.occurred_at, e.id
+	`
+
+	events := []TrackingEvent{}
+	if err := r.db.SelectContext(ctx, &events, query, id); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// Voyages daşınmanın təyin edildiyi reys hissələrini yükləmə vaxtı sırası ilə əldə edir
+func (r *PostgresRepository) Voyages(ctx context.Context, id int) ([]VoyageLeg, error) {
+	query := `
+		SELECT v.id AS voyage_id, v.voyage_number, c.name AS carrier_name, vs.name AS vessel_name,
+			ll.name AS load_location, lc.etd AS load_etd,
+			dl.name AS discharge_location, dc.eta AS discharge_eta
+		FROM shipment_voyages sv
+		JOIN voyages v ON v.id = sv.voyage_id
+		JOIN carriers c ON c.id = v.carrier_id
+		LEFT JOIN vessels vs ON vs.id = v.vessel_id
+		JOIN voyage_calls lc ON lc.id = sv.load_call_id
+		JOIN locations ll ON ll.id = lc.location_id
+		JOIN voyage_calls dc ON dc.id = sv.discharge_call_id
+		JOIN locations dl ON dl.id = dc.location_id
+		WHERE sv.shipment_id = $1
+		ORDER BY lc.etd NULLS LAST, sv.id
+	`
+
+	legs := []VoyageLeg{}
+	if err := r.db.SelectContext(ctx, &legs, query, id); err != nil {
+		return nil, err
+	}
+
+	return legs, nil
+}
+
+// Legs daşınmanın marşrut hissələrini konteynerləri ilə birlikdə ardıcıllıq sırası ilə əldə edir
+func (r *PostgresRepository) Legs(ctx context.Context, id int) ([]Leg, error) {
+	query := `
+		SELECT sl.id, sl.shipment_id, sl.seq, sl.mode, sl.carrier_id, c.name AS carrier_name,
+			sl.from_location_id, fl.name AS from_location, fl.unlocode AS from_unlocode,
+			sl.to_location_id, tl.name AS to_location, tl.unlocode AS to_unlocode,
+			sl.planned_departure, sl.planned_arrival, sl.actual_departure, sl.actual_arrival
+		FROM shipment_legs sl
+		LEFT JOIN carriers c ON c.id = sl.carrier_id
+		JOIN locations fl ON fl.id = sl.from_location_id
+		JOIN locations tl ON tl.id = sl.to_location_id
+		WHERE sl.shipment_id = $1
+		ORDER BY sl.seq
+	`
+
+	legs := []Leg{}
+	if err := r.db.SelectContext(ctx, &legs, query, id); err != nil {
+		return nil, err
+	}
+
+	if len(legs) == 0 {
+		return legs, nil
+	}
+
+	containersQuery := `
+		SELECT lc.leg_id, k.id, k.owner_code || k.serial || k.check_digit::text AS number, k.size_type
+		FROM shipment_leg_containers lc
+		JOIN shipment_legs sl ON sl.id = lc.leg_id
+		JOIN containers k ON k.id = lc.container_id
+		WHERE sl.shipment_id = $1
+		ORDER BY k.owner_code, k.serial
+	`
+
+	var rows []struct {
+		LegID int `db:"leg_id"`
+		Container
+	}
+	if err := r.db.SelectContext(ctx, &rows, containersQuery, id); err != nil {
+		return nil, err
+	}
+
+	byLeg := make(map[int]*Leg, len(legs))
+	for i := range legs {
+		byLeg[legs[i].ID] = &legs[i]
+	}
+	for _, row := range rows {
+		if leg, ok := byLeg[row.LegID]; ok {
+			leg.Containers = append(leg.Containers, row.Container)
+		}
+	}
+
+	return legs, nil
+}
+
+// ReplaceLegs daşınmanın bütün marşrut hissələrini tranzaksiya daxilində verilmiş hissələrlə əvəz edir
+func (r *PostgresRepository) ReplaceLegs(ctx context.Context, id int, legs []Leg) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM shipment_legs WHERE shipment_id = $1`, id); err != nil {
+		return err
+	}
+
+	for i := range legs {
+		leg := &legs[i]
+		leg.ShipmentID = id
+
+		query := `
+			INSERT INTO shipment_legs (shipment_id, seq, mode, carrier_id, from_location_id, to_location_id,
+				planned_departure, planned_arrival, actual_departure, actual_arrival)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			RETURNING id
+		`
+		err := tx.QueryRowxContext(ctx, query, leg.ShipmentID, leg.Seq, leg.Mode, leg.CarrierID,
+			leg.FromLocationID, leg.ToLocationID, leg.PlannedDeparture, leg.PlannedArrival,
+			leg.ActualDeparture, leg.ActualArrival).Scan(&leg.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range leg.Containers {
+			_, err := tx.ExecContext(ctx,
+				`INSERT INTO shipment_leg_containers (leg_id, container_id) VALUES ($1, $2)`,
+				leg.ID, c.ID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Hissələrin dəyişməsi daşınmanın yenilənmə vaxtına da təsir edir
+	if _, err := tx.ExecContext(ctx, `UPDATE shipments SET updated_at = NOW() WHERE id = $1`, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// FindLocation aktiv məntəqəni ID-yə və ya UN/LOCODE-a görə tapır.
+// Eyni kodlu bir neçə məntəqə olduqda UN/LOCODE idxalından gələn məntəqəyə üstünlük verilir.
+func (r *PostgresRepository) FindLocation(ctx context.Context, id int, unlocode string) (*LocationRef, error) {
+	location := &LocationRef{}
+
+	var err error
+	if id > 0 {
+		err = r.db.GetContext(ctx, location,
+			`SELECT id, name, unlocode FROM locations WHERE id = $1 AND is_active = true`, id)
+	} else {
+		query := `
+			SELECT id, name, unlocode FROM locations
+			WHERE unlocode = $1 AND is_active = true
+			ORDER BY source = 'unlocode' DESC, id
+			LIMIT 1
+		`
+		err = r.db.GetContext(ctx, location, query, unlocode)
+	}
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Məntəqə tapılmadı
+		}
+		return nil, err
+	}
+
+	return location, nil
+}
+
+// CarrierOptions aktiv daşıyıcıların siyahısını nəqliyyat növü ilə birlikdə əldə edir
+func (r *PostgresRepository) CarrierOptions(ctx context.Context) ([]CarrierOption, error) {
+	options := []CarrierOption{}
+	err := r.db.SelectContext(ctx, &options, `SELECT id, name, mode FROM carriers WHERE is_active = true ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
+// LastEventAt daşınmanın ən son hadisəsinin baş vermə vaxtını əldə edir; hadisə yoxdursa nil qaytarır
+func (r *PostgresRepository) LastEventAt(ctx context.Context, id int) (*time.Time, error) {
+	var last *time.Time
+	err := r.db.GetContext(ctx, &last, `SELECT MAX(occurred_at) FROM tracking_events WHERE shipment_id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return last, nil
+}
+
+// AddEvent hadisəni yazır və to boş deyilsə eyni tranzaksiyada statusu from-dan to-ya keçirir.
+// Status bu arada başqa sorğu ilə dəyişdirilibsə hadisə yenə də yazılır, ikinci dəyər isə false olur.
+func (r *PostgresRepository) AddEvent(ctx context.Context, event *TrackingEvent, from, to Status, note string) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO tracking_events (shipment_id, container_id, code, location, occurred_at, source, note)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, recorded_at
+	`
+	err = tx.QueryRowxContext(ctx, query, event.ShipmentID, event.ContainerID, event.Code, event.Location,
+		event.OccurredAt, event.Source, event.Note).Scan(&event.ID, &event.RecordedAt)
+	if err != nil {
+		return false, err
+	}
+
+	advanced := false
+	if to != "" {
+		if advanced, err = updateStatus(ctx, tx, event.ShipmentID, from, to, note); err != nil {
+			return false, err
+		}
+	}
+
+	return advanced, tx.Commit()
+}
+
+// StatusHistory daşınmanın status dəyişikliklərini xronoloji sıra ilə əldə edir
+func (r *PostgresRepository) StatusHistory(ctx context.Context, id int) ([]StatusChange, error) {
+	query := `
+		SELECT id, shipment_id, from_status, to_status, note, changed_at
+		FROM shipment_status_history
+		WHERE shipment_id = $1
+		ORDER BY changed_at, id
+	`
+
+	history := []StatusChange{}
+	if err := r.db.SelectContext(ctx, &history, query, id); err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// CustomerOptions aktiv müştərilərin siyahısını əldə edir
+func (r *PostgresRepository) CustomerOptions(ctx context.Context) ([]CustomerOption, error) {
+	options := []CustomerOption{}
+	err := r.db.SelectContext(ctx, &options, `SELECT id, name FROM customers WHERE is_active = true ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
+// FindContainers tam nömrələrə görə reyestrdəki konteynerləri əldə edir
+func (r *PostgresRepository) FindContainers(ctx context.Context, numbers []string) ([]Container, error) {
+	query := `
+		SELECT id, owner_code || serial || check_digit::text AS number, size_type
+		FROM containers
+		WHERE owner_code || serial || check_digit::text = ANY($1)
+	`
+
+	containers := []Container{}
+	if err := r.db.SelectContext(ctx, &containers, query, pq.Array(numbers)); err != nil {
+		return nil, err
+	}
+
+	return containers, nil
+}
+
+// saveChildren daşınmanın konteyner bağlantılarını və yük sətirlərini yazır
+func saveChildren(ctx context.Context, tx *sqlx.Tx, shipment *Shipment) error {
+	for _, c := range shipment.Containers {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO shipment_containers (shipment_id, container_id) VALUES ($1, $2)`,
+			shipment.ID, c.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range shipment.CargoLines {
+		line := &shipment.CargoLines[i]
+		line.ShipmentID = shipment.ID
+
+		query := `
+			INSERT INTO shipment_cargo_lines (shipment_id, line_no, description, hs_code, packages,
+				package_type, gross_weight_kg, volume_m3)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING id
+		`
+		err := tx.QueryRowxContext(ctx, query, line.ShipmentID, line.LineNo, line.Description, line.HSCode,
+			line.Packages, line.PackageType, line.GrossWeightKg, line.VolumeM3).Scan(&line.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mapError verilənlər bazası xətalarını domen xətalarına çevirir
+func mapError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == foreignKeyViolation {
+		return &ValidationError{Message: "seçilmiş müştəri mövcud deyil"}
+	}
+
+	return err
+}
@@ -0,0 +1,120 @@
+package shipment
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/rbac"
+	"github.com/Zam83-AZE/logistics_system/internal/middleware"
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+	"github.com/Zam83-AZE/logistics_system/pkg/openapi"
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/gorilla/mux"
+	"github.com/jmoiron/sqlx"
+)
+
+// RegisterRoutes daşınma marşrutlarını qeydə alır
+func RegisterRoutes(router *mux.Router, db *sqlx.DB, tmpl *template.Template, sessionManager *session.Manager) {
+	repo := NewPostgresRepository(db)
+	service := NewShipmentService(repo, audit.NewRecorder(db))
+	handler := NewHandler(service, tmpl, sessionManager)
+
+	// İcazə yoxlamaları
+	canView := middleware.RequirePermission(rbac.ShipmentsView)
+	canManage := middleware.RequirePermission(rbac.ShipmentsManage)
+	canChangeStatus := middleware.RequirePermission(rbac.ShipmentsStatus)
+
+	// Siyahı və yaratma
+	router.Handle("/shipments", canView(http.HandlerFunc(handler.List))).Methods("GET")
+	router.Handle("/shipments/new", canManage(http.HandlerFunc(handler.New))).Methods("GET")
+	router.Handle("/shipments", canManage(http.HandlerFunc(handler.Create))).Methods("POST")
+
+	// Detallar, redaktə və status keçidləri
+	router.Handle("/shipments/{id:[0-9]+}", canView(http.HandlerFunc(handler.Detail))).Methods("GET")
+	router.Handle("/shipments/{id:[0-9]+}/edit", canManage(http.HandlerFunc(handler.Edit))).Methods("GET")
+	router.Handle("/shipments/{id:[0-9]+}", canManage(http.HandlerFunc(handler.Update))).Methods("POST")
+	router.Handle("/shipments/{id:[0-9]+}/status", canChangeStatus(http.HandlerFunc(handler.ChangeStatus))).Methods("POST")
+
+	// Çoxhissəli marşrut
+	router.Handle("/shipments/{id:[0-9]+}/legs", canManage(http.HandlerFunc(handler.Legs))).Methods("GET")
+	router.Handle("/shipments/{id:[0-9]+}/legs", canManage(http.HandlerFunc(handler.SaveLegs))).Methods("POST")
+
+	// İzləmə hadisələri (statusu da irəli apara bildiyi üçün status icazəsi tələb olunur)
+	router.Handle("/shipments/{id:[0-9]+}/events", canChangeStatus(http.HandlerFunc(handler.AddEvent))).Methods("POST")
+}
+
+// RegisterAPIRoutes daşınma JSON API marşrutlarını qeydə alır
+func RegisterAPIRoutes(router *mux.Router, db *sqlx.DB) {
+	handler := NewAPIHandler(NewShipmentService(NewPostgresRepository(db), audit.NewRecorder(db)))
+
+	canView := middleware.RequirePermission(rbac.ShipmentsView)
+	canManage := middleware.RequirePermission(rbac.ShipmentsManage)
+	canChangeStatus := middleware.RequirePermission(rbac.ShipmentsStatus)
+
+	router.Handle("/shipments", canView(http.HandlerFunc(handler.List))).Methods("GET")
+	router.Handle("/shipments", canManage(http.HandlerFunc(handler.Create))).Methods("POST")
+	router.Handle("/shipments/{id:[0-9]+}", canView(http.HandlerFunc(handler.Get))).Methods("GET")
+	router.Handle("/shipments/{id:[0-9]+}", canManage(http.HandlerFunc(handler.Update))).Methods("PUT")
+	router.Handle("/shipments/{id:[0-9]+}/history", canView(http.HandlerFunc(handler.History))).Methods("GET")
+	router.Handle("/shipments/{id:[0-9]+}/status", canChangeStatus(http.HandlerFunc(handler.ChangeStatus))).Methods("POST")
+	router.Handle("/shipments/{id:[0-9]+}/events", canView(http.HandlerFunc(handler.Events))).Methods("GET")
+	router.Handle("/shipments/{id:[0-9]+}/events", canChangeStatus(http.HandlerFunc(handler.AddEvent))).Methods("POST")
+	router.Handle("/shipments/{id:[0-9]+}/route", canView(http.HandlerFunc(handler.Route))).Methods("GET")
+	router.Handle("/shipments/{id:[0-9]+}/legs", canManage(http.HandlerFunc(handler.ReplaceLegs))).Methods("PUT")
+}
+
+// DescribeAPI daşınma API marşrutlarını OpenAPI sənədinə əlavə edir
+func DescribeAPI(spec *openapi.Spec) {
+	view := string(rbac.ShipmentsView)
+	manage := string(rbac.ShipmentsManage)
+
+	var statuses []string
+	for _, status := range AllStatuses() {
+		statuses = append(statuses, string(status))
+	}
+
+	spec.Add("GET", "/shipments", openapi.Operation{
+		Summary: "Daşınma siyahısı", Tag: "shipments", Permission: view,
+		Query: []openapi.Param{
+			{Name: "q", Description: "İstinad, müştəri, çıxış və ya təyinat məntəqəsi üzrə axtarış"},
+			{Name: "status", Enum: statuses},
+		},
+		List: true, Sort: sortColumns, Response: Shipment{},
+	})
+	spec.Add("POST", "/shipments", openapi.Operation{
+		Summary: "Qaralama daşınma yarat", Tag: "shipments", Permission: manage,
+		Request: ShipmentRequest{}, Response: Shipment{}, Status: http.StatusCreated,
+	})
+	spec.Add("GET", "/shipments/{id}", openapi.Operation{
+		Summary: "Daşınma", Tag: "shipments", Permission: view, Response: Shipment{},
+	})
+	spec.Add("PUT", "/shipments/{id}", openapi.Operation{
+		Summary: "Daşınmanı yenilə", Tag: "shipments", Permission: manage,
+		Request: ShipmentRequest{}, Response: Shipment{}, Conflict: true,
+	})
+	spec.Add("GET", "/shipments/{id}/history", openapi.Operation{
+		Summary: "Status tarixçəsi", Tag: "shipments", Permission: view, Response: []StatusChange{},
+	})
+	spec.Add("POST", "/shipments/{id}/status", openapi.Operation{
+		Summary: "Statusu dəyiş", Tag: "shipments", Permission: string(rbac.ShipmentsStatus),
+		Request: StatusRequest{}, Response: Shipment{}, Conflict: true,
+	})
+
+	spec.Add("GET", "/shipments/{id}/events", openapi.Operation{
+		Summary: "İzləmə hadisələri", Tag: "shipments", Permission: view, Response: []TrackingEvent{},
+	})
+	spec.Add("POST", "/shipments/{id}/events", openapi.Operation{
+		Summary: "İzləmə hadisəsi əlavə et (uyğun olduqda statusu irəli aparır)", Tag: "shipments",
+		Permission: string(rbac.ShipmentsStatus), Request: EventRequest{}, Response: EventResult{},
+		Status: http.StatusCreated, Conflict: true,
+	})
+
+	spec.Add("GET", "/shipments/{id}/route", openapi.Operation{
+		Summary: "Marşrut: hissələr, cari hissə və hadisələrə görə hesablanmış ETA", Tag: "shipments",
+		Permission: view, Response: Route{},
+	})
+	spec.Add("PUT", "/shipments/{id}/legs", openapi.Operation{
+		Summary: "Marşrut hissələrini əvəz et", Tag: "shipments", Permission: manage,
+		Request: []LegRequest{}, Response: Route{}, Conflict: true,
+	})
+}
@@ -0,0 +1,847 @@
+package shipment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/carrier"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/container"
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+)
+
+const (
+	defaultPerPage = 20
+
+	// dateTimeLayout HTML datetime-local sahəsinin formatıdır
+	dateTimeLayout = "2006-01-02T15:04"
+)
+
+// ErrNotFound daşınma tapılmadıqda qaytarılır
+var ErrNotFound = errors.New("daşınma tapılmadı")
+
+// ErrNotEditable son mərhələdəki daşınmanı redaktə etməyə cəhd edildikdə qaytarılır
+var ErrNotEditable = errors.New("tamamlanmış və ya ləğv edilmiş daşınma redaktə edilə bilməz")
+
+// ErrConcurrentUpdate status eyni anda başqa sorğu ilə dəyişdirildikdə qaytarılır
+var ErrConcurrentUpdate = errors.New("daşınmanın statusu artıq dəyişdirilib, səhifəni yeniləyin")
+
+// ErrEventsClosed qaralama və ya ləğv edilmiş daşınmaya hadisə əlavə etməyə cəhd edildikdə qaytarılır
+var ErrEventsClosed = errors.New("izləmə hadisələri yalnız sifariş edilmiş daşınmalara əlavə edilə bilər")
+
+// maxEventClockSkew hadisə vaxtının gələcəkdə ola biləcəyi maksimal fərqdir (mənbələrin saat fərqi üçün)
+const maxEventClockSkew = 10 * time.Minute
+
+// ValidationError istifadəçiyə göstəriləcək form xətasını təmsil edir
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// Service daşınma biznes məntiqini müəyyən edir
+type Service interface {
+	List(ctx context.Context, filter ListFilter) (*ShipmentList, error)
+	Get(ctx context.Context, id int) (*Shipment, error)
+	History(ctx context.Context, id int) ([]StatusChange, error)
+	Customers(ctx context.Context) ([]CustomerOption, error)
+	Create(ctx context.Context, form ShipmentForm) (*Shipment, error)
+	Update(ctx context.Context, id int, form ShipmentForm) (*Shipment, error)
+	ChangeStatus(ctx context.Context, id int, to Status, note string) (*Shipment, error)
+	Events(ctx context.Context, id int) ([]TrackingEvent, error)
+	AddEvent(ctx context.Context, id int, form EventForm, source string) (*EventResult, error)
+	Voyages(ctx context.Context, id int) ([]VoyageLeg, error)
+	Route(ctx context.Context, id int) (*Route, error)
+	SaveLegs(ctx context.Context, id int, forms []LegForm) (*Route, error)
+	Carriers(ctx context.Context) ([]CarrierOption, error)
+}
+
+// ShipmentService Service interfeysini həyata keçirir
+type ShipmentService struct {
+	repo  Repository
+	audit audit.Recorder
+}
+
+// NewShipmentService yeni ShipmentService yaradır
+func NewShipmentService(repo Repository, recorder audit.Recorder) *ShipmentService {
+	return &ShipmentService{repo: repo, audit: recorder}
+}
+
+// List filtrə uyğun səhifələnmiş daşınma siyahısını qaytarır
+func (s *ShipmentService) List(ctx context.Context, filter ListFilter) (*ShipmentList, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PerPage < 1 || filter.PerPage > 100 {
+		filter.PerPage = defaultPerPage
+	}
+	if !filter.Status.Valid() {
+		filter.Status = ""
+	}
+
+	shipments, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ShipmentList{
+		Items:   shipments,
+		Total:   total,
+		Page:    filter.Page,
+		PerPage: filter.PerPage,
+	}, nil
+}
+
+// Get daşınmanı konteynerləri və yük sətirləri ilə birlikdə qaytarır
+func (s *ShipmentService) Get(ctx context.Context, id int) (*Shipment, error) {
+	shipment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if shipment == nil {
+		return nil, ErrNotFound
+	}
+
+	return shipment, nil
+}
+
+// History daşınmanın status dəyişiklikləri tarixçəsini qaytarır
+func (s *ShipmentService) History(ctx context.Context, id int) ([]StatusChange, error) {
+	return s.repo.StatusHistory(ctx, id)
+}
+
+// Customers daşınma üçün seçilə bilən aktiv müştəriləri qaytarır
+func (s *ShipmentService) Customers(ctx context.Context) ([]CustomerOption, error) {
+	return s.repo.CustomerOptions(ctx)
+}
+
+// Create formdakı məlumatlarla qaralama statusunda yeni daşınma yaradır
+func (s *ShipmentService) Create(ctx context.Context, form ShipmentForm) (*Shipment, error) {
+	shipment := &Shipment{Status: StatusDraft}
+	if err := s.applyForm(ctx, shipment, form); err != nil {
+		return nil, err
+	}
+
+	number, err := NewTrackingNumber()
+	if err != nil {
+		return nil, err
+	}
+	shipment.TrackingNumber = number
+
+	if err := s.repo.Create(ctx, shipment); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "shipment.created", nil, shipment, nil)
+
+	return shipment, nil
+}
+
+// Update mövcud daşınmanın məlumatlarını formdakı dəyərlərlə yeniləyir
+func (s *ShipmentService) Update(ctx context.Context, id int, form ShipmentForm) (*Shipment, error) {
+	shipment, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !shipment.IsEditable() {
+		return nil, ErrNotEditable
+	}
+
+	before := *shipment
+	if err := s.applyForm(ctx, shipment, form); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, shipment); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "shipment.updated", &before, shipment, nil)
+
+	return shipment, nil
+}
+
+// ChangeStatus daşınmanı vəziyyət maşınının icazə verdiyi növbəti statusa keçirir
+func (s *ShipmentService) ChangeStatus(ctx context.Context, id int, to Status, note string) (*Shipment, error) {
+	shipment, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateTransition(shipment.Status, to); err != nil {
+		return nil, err
+	}
+
+	if to == StatusBooked && len(shipment.Containers) == 0 && len(shipment.CargoLines) == 0 {
+		return nil, &ValidationError{Message: "sifariş üçün ən azı bir konteyner və ya yük sətri tələb olunur"}
+	}
+
+	note = strings.TrimSpace(note)
+	updated, err := s.repo.UpdateStatus(ctx, id, shipment.Status, to, note)
+	if err != nil {
+		return nil, err
+	}
+
+	if !updated {
+		return nil, ErrConcurrentUpdate
+	}
+
+	before := *shipment
+	shipment.Status = to
+
+	var details map[string]interface{}
+	if note != "" {
+		details = map[string]interface{}{"note": note}
+	}
+	s.record(ctx, "shipment.status_changed", &before, shipment, details)
+
+	return shipment, nil
+}
+
+// Events daşınmanın izləmə hadisələrini xronoloji sıra ilə qaytarır
+func (s *ShipmentService) Events(ctx context.Context, id int) ([]TrackingEvent, error) {
+	return s.repo.Events(ctx, id)
+}
+
+// Voyages daşınmanın təyin edildiyi reys hissələrini qaytarır
+func (s *ShipmentService) Voyages(ctx context.Context, id int) ([]VoyageLeg, error) {
+	return s.repo.Voyages(ctx, id)
+}
+
+// Route daşınmanın marşrut hissələrini izləmə hadisələrinə görə hesablanmış vəziyyət və ETA ilə qaytarır
+func (s *ShipmentService) Route(ctx context.Context, id int) (*Route, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.route(ctx, id)
+}
+
+// SaveLegs daşınmanın marşrutunu formdakı hissələrlə tam əvəz edir. Boş forma siyahısı marşrutu silir.
+func (s *ShipmentService) SaveLegs(ctx context.Context, id int, forms []LegForm) (*Route, error) {
+	shipment, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !shipment.IsEditable() {
+		return nil, ErrNotEditable
+	}
+
+	legs, err := s.parseLegs(ctx, shipment, forms)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := s.repo.Legs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ReplaceLegs(ctx, id, legs); err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(ctx, audit.Event{
+		Action:     "shipment.legs_updated",
+		EntityType: "shipment",
+		EntityID:   strconv.Itoa(id),
+		Before:     map[string]interface{}{"legs": legSnapshots(previous)},
+		After:      map[string]interface{}{"legs": legSnapshots(legs)},
+	})
+
+	return s.route(ctx, id)
+}
+
+// Carriers marşrut hissəsi üçün seçilə bilən aktiv daşıyıcıları qaytarır
+func (s *ShipmentService) Carriers(ctx context.Context) ([]CarrierOption, error) {
+	return s.repo.CarrierOptions(ctx)
+}
+
+// route saxlanılmış hissələri və hadisələri oxuyur və marşrutu hesablayır
+func (s *ShipmentService) route(ctx context.Context, id int) (*Route, error) {
+	legs, err := s.repo.Legs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.repo.Events(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	route := PlanRoute(legs, events, time.Now())
+	return &route, nil
+}
+
+// AddEvent daşınmaya izləmə hadisəsi əlavə edir. Hadisə daşınmanın ən son hadisəsidirsə və
+// vəziyyət maşını icazə verirsə daşınma uyğun statusa keçirilir (məs. departed -> in_transit).
+func (s *ShipmentService) AddEvent(ctx context.Context, id int, form EventForm, source string) (*EventResult, error) {
+	shipment, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !shipment.AcceptsEvents() {
+		return nil, ErrEventsClosed
+	}
+
+	event, err := parseEvent(shipment, form, source, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Keçmişə aid hadisə (məs. gecikmiş EDI mesajı) statusu geri qaytarmamalıdır
+	to, advance := event.Code.StatusAfter(shipment.Status)
+	if advance {
+		last, err := s.repo.LastEventAt(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		advance = last == nil || !event.OccurredAt.Before(*last)
+	}
+
+	var note string
+	if !advance {
+		to = ""
+	} else {
+		note = "Hadisə: " + event.Code.Label()
+		if event.Location != "" {
+			note += " (" + event.Location + ")"
+		}
+	}
+
+	advanced, err := s.repo.AddEvent(ctx, event, shipment.Status, to, note)
+	if err != nil {
+		return nil, err
+	}
+
+	details := map[string]interface{}{
+		"code":       event.Code,
+		"occurredAt": event.OccurredAt,
+		"source":     event.Source,
+	}
+	if event.Location != "" {
+		details["location"] = event.Location
+	}
+	if event.ContainerNumber != nil {
+		details["container"] = *event.ContainerNumber
+	}
+	s.audit.Record(ctx, audit.Event{
+		Action:     "shipment.event_added",
+		EntityType: "shipment",
+		EntityID:   strconv.Itoa(shipment.ID),
+		Details:    details,
+	})
+
+	if advanced {
+		before := *shipment
+		shipment.Status = to
+		s.record(ctx, "shipment.status_changed", &before, shipment, map[string]interface{}{"note": note})
+	}
+
+	return &EventResult{Event: *event, Status: shipment.Status, Advanced: advanced}, nil
+}
+
+// parseEvent hadisə formunu yoxlayır və daşınmaya aid hadisəyə çevirir
+func parseEvent(shipment *Shipment, form EventForm, source string, now time.Time) (*TrackingEvent, error) {
+	code, err := ParseEventCode(strings.TrimSpace(form.Code))
+	if err != nil {
+		return nil, &ValidationError{Message: "hadisə növü seçilməlidir"}
+	}
+
+	occurredAt, err := parseEventTime(form.OccurredAt)
+	if err != nil || occurredAt == nil {
+		return nil, &ValidationError{Message: "hadisənin vaxtı tələb olunur (məs. 2024-05-01T14:30 və ya 2024-05-01T14:30:00+04:00)"}
+	}
+	if occurredAt.After(now.Add(maxEventClockSkew)) {
+		return nil, &ValidationError{Message: "hadisənin vaxtı gələcəkdə ola bilməz"}
+	}
+
+	location := strings.TrimSpace(form.Location)
+	if len([]rune(location)) > 255 {
+		return nil, &ValidationError{Message: "məkan 255 simvoldan uzun ola bilməz"}
+	}
+
+	source = strings.TrimSpace(source)
+	if source == "" {
+		source = SourceManual
+	}
+	if len([]rune(source)) > 50 {
+		return nil, &ValidationError{Message: "mənbə 50 simvoldan uzun ola bilməz"}
+	}
+
+	event := &TrackingEvent{
+		ShipmentID: shipment.ID,
+		Code:       code,
+		Location:   location,
+		OccurredAt: *occurredAt,
+		Source:     source,
+		Note:       strings.TrimSpace(form.Note),
+	}
+
+	if raw := strings.TrimSpace(form.Container); raw != "" {
+		number, err := container.ParseNumber(raw)
+		if err != nil {
+			return nil, &ValidationError{Message: fmt.Sprintf("%s: %s", raw, err)}
+		}
+
+		for _, c := range shipment.Containers {
+			if c.Number == number.String() {
+				c := c
+				event.ContainerID = &c.ID
+				event.ContainerNumber = &c.Number
+				break
+			}
+		}
+		if event.ContainerID == nil {
+			return nil, &ValidationError{Message: fmt.Sprintf("%s nömrəli konteyner bu daşınmaya bağlanmayıb", number)}
+		}
+	}
+
+	return event, nil
+}
+
+// parseEventTime hadisə vaxtını RFC 3339 (vaxt zonası ilə) və ya datetime-local formatında oxuyur
+func parseEventTime(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	return parseDateTime(value)
+}
+
+// record daşınma üzərində əməliyyatı audit jurnalına yazır
+func (s *ShipmentService) record(ctx context.Context, action string, before, after *Shipment, details map[string]interface{}) {
+	s.audit.Record(ctx, audit.Event{
+		Action:     action,
+		EntityType: "shipment",
+		EntityID:   strconv.Itoa(after.ID),
+		Before:     before,
+		After:      after,
+		Details:    details,
+	})
+}
+
+// applyForm formu yoxlayır və dəyərləri daşınma obyektinə köçürür
+func (s *ShipmentService) applyForm(ctx context.Context, shipment *Shipment, form ShipmentForm) error {
+	customerID, err := strconv.Atoi(strings.TrimSpace(form.CustomerID))
+	if err != nil || customerID <= 0 {
+		return &ValidationError{Message: "müştəri seçilməlidir"}
+	}
+
+	origin := strings.TrimSpace(form.Origin)
+	destination := strings.TrimSpace(form.Destination)
+	if origin == "" || destination == "" {
+		return &ValidationError{Message: "göndərmə və təyinat məntəqələri tələb olunur"}
+	}
+
+	etd, err := parseDateTime(form.ETD)
+	if err != nil {
+		return &ValidationError{Message: "göndərmə tarixi (ETD) yanlışdır"}
+	}
+
+	eta, err := parseDateTime(form.ETA)
+	if err != nil {
+		return &ValidationError{Message: "çatma tarixi (ETA) yanlışdır"}
+	}
+
+	if etd != nil && eta != nil && eta.Before(*etd) {
+		return &ValidationError{Message: "çatma tarixi göndərmə tarixindən əvvəl ola bilməz"}
+	}
+
+	blNumber := NormalizeNumber(form.BLNumber)
+	if !validBLNumber(blNumber) {
+		return &ValidationError{Message: "konosament (B/L) nömrəsi yalnız hərf və rəqəmlərdən ibarət olmalı, 35 simvoldan uzun olmamalıdır"}
+	}
+
+	containers, err := s.resolveContainers(ctx, form.Containers)
+	if err != nil {
+		return err
+	}
+
+	lines, err := parseCargoLines(form.CargoLines)
+	if err != nil {
+		return err
+	}
+
+	shipment.CustomerID = customerID
+	shipment.BLNumber = blNumber
+	shipment.Origin = origin
+	shipment.Destination = destination
+	shipment.ETD = etd
+	shipment.ETA = eta
+	shipment.Notes = strings.TrimSpace(form.Notes)
+	shipment.Containers = containers
+	shipment.CargoLines = lines
+
+	return nil
+}
+
+// resolveContainers vergüllə ayrılmış konteyner nömrələrini reyestrdəki konteynerlərə çevirir
+func (s *ShipmentService) resolveContainers(ctx context.Context, value string) ([]Container, error) {
+	var numbers []string
+	seen := map[string]bool{}
+
+	for _, raw := range strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ';' || r == '\n'
+	}) {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+
+		parsed, err := container.ParseNumber(raw)
+		if err != nil {
+			return nil, &ValidationError{Message: fmt.Sprintf("%s: %s", strings.TrimSpace(raw), err)}
+		}
+
+		number := parsed.String()
+		if !seen[number] {
+			seen[number] = true
+			numbers = append(numbers, number)
+		}
+	}
+
+	if len(numbers) == 0 {
+		return nil, nil
+	}
+
+	found, err := s.repo.FindContainers(ctx, numbers)
+	if err != nil {
+		return nil, err
+	}
+
+	byNumber := make(map[string]Container, len(found))
+	for _, c := range found {
+		byNumber[c.Number] = c
+	}
+
+	containers := make([]Container, 0, len(numbers))
+	for _, number := range numbers {
+		c, ok := byNumber[number]
+		if !ok {
+			return nil, &ValidationError{Message: fmt.Sprintf("%s nömrəli konteyner reyestrdə tapılmadı", number)}
+		}
+		containers = append(containers, c)
+	}
+
+	return containers, nil
+}
+
+// parseCargoLines formdakı doldurulmuş yük sətirlərini yoxlayır və çevirir
+func parseCargoLines(forms []CargoLineForm) ([]CargoLine, error) {
+	var lines []CargoLine
+
+	for _, f := range forms {
+		if f.IsEmpty() {
+			continue
+		}
+
+		lineNo := len(lines) + 1
+		description := strings.TrimSpace(f.Description)
+		if description == "" {
+			return nil, &ValidationError{Message: fmt.Sprintf("%d-ci yük sətrində təsvir tələb olunur", lineNo)}
+		}
+
+		packages, err := parseOptionalInt(f.Packages)
+		if err != nil {
+			return nil, &ValidationError{Message: fmt.Sprintf("%d-ci yük sətrində yer sayı yanlışdır", lineNo)}
+		}
+
+		weight, err := parseOptionalFloat(f.GrossWeightKg)
+		if err != nil {
+			return nil, &ValidationError{Message: fmt.Sprintf("%d-ci yük sətrində çəki yanlışdır", lineNo)}
+		}
+
+		volume, err := parseOptionalFloat(f.VolumeM3)
+		if err != nil {
+			return nil, &ValidationError{Message: fmt.Sprintf("%d-ci yük sətrində həcm yanlışdır", lineNo)}
+		}
+
+		lines = append(lines, CargoLine{
+			LineNo:        lineNo,
+			Description:   description,
+			HSCode:        strings.TrimSpace(f.HSCode),
+			Packages:      packages,
+			PackageType:   strings.TrimSpace(f.PackageType),
+			GrossWeightKg: weight,
+			VolumeM3:      volume,
+		})
+	}
+
+	return lines, nil
+}
+
+// parseLegs formdakı doldurulmuş hissələri yoxlayır və çevirir. Hissələr ardıcıl olmalıdır:
+// hər hissə əvvəlkinin çatdığı məntəqədən başlayır və əvvəlki planlaşdırılan çatmadan tez yola düşmür.
+func (s *ShipmentService) parseLegs(ctx context.Context, shipment *Shipment, forms []LegForm) ([]Leg, error) {
+	carriers, err := s.repo.CarrierOptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	carrierModes := make(map[int]string, len(carriers))
+	for _, c := range carriers {
+		carrierModes[c.ID] = c.Mode
+	}
+
+	shipmentContainers := make(map[string]Container, len(shipment.Containers))
+	for _, c := range shipment.Containers {
+		shipmentContainers[c.Number] = c
+	}
+
+	var legs []Leg
+	for _, f := range forms {
+		if f.IsEmpty() {
+			continue
+		}
+
+		seq := len(legs) + 1
+		fail := func(message string) error {
+			return &ValidationError{Message: fmt.Sprintf("%d-ci hissə: %s", seq, message)}
+		}
+
+		leg := Leg{Seq: seq, Mode: strings.TrimSpace(f.Mode)}
+		if _, ok := carrier.ModeLabels[leg.Mode]; !ok {
+			return nil, fail("nəqliyyat növü seçilməlidir")
+		}
+
+		if value := strings.TrimSpace(f.CarrierID); value != "" {
+			id, err := strconv.Atoi(value)
+			mode, ok := carrierModes[id]
+			if err != nil || !ok {
+				return nil, fail("daşıyıcı tapılmadı və ya deaktivdir")
+			}
+			if mode != leg.Mode {
+				return nil, fail(fmt.Sprintf("daşıyıcının nəqliyyat növü (%s) hissənin növü ilə uyğun gəlmir", carrier.ModeLabel(mode)))
+			}
+			leg.CarrierID = &id
+		}
+
+		from, err := s.findLocation(ctx, f.From)
+		if err != nil {
+			return nil, locationError(err, fail, "çıxış")
+		}
+		to, err := s.findLocation(ctx, f.To)
+		if err != nil {
+			return nil, locationError(err, fail, "təyinat")
+		}
+		if from.ID == to.ID {
+			return nil, fail("çıxış və təyinat məntəqələri eyni ola bilməz")
+		}
+		leg.FromLocationID, leg.FromLocation, leg.FromUNLOCODE = from.ID, from.Name, from.UNLOCODE
+		leg.ToLocationID, leg.ToLocation, leg.ToUNLOCODE = to.ID, to.Name, to.UNLOCODE
+
+		times := []struct {
+			value  string
+			target **time.Time
+			label  string
+		}{
+			{f.PlannedDeparture, &leg.PlannedDeparture, "planlaşdırılan yola düşmə"},
+			{f.PlannedArrival, &leg.PlannedArrival, "planlaşdırılan çatma"},
+			{f.ActualDeparture, &leg.ActualDeparture, "faktiki yola düşmə"},
+			{f.ActualArrival, &leg.ActualArrival, "faktiki çatma"},
+		}
+		for _, t := range times {
+			if *t.target, err = parseDateTime(t.value); err != nil {
+				return nil, fail(t.label + " vaxtı yanlışdır")
+			}
+		}
+		if before(leg.PlannedArrival, leg.PlannedDeparture) {
+			return nil, fail("planlaşdırılan çatma yola düşmədən əvvəl ola bilməz")
+		}
+		if before(leg.ActualArrival, leg.ActualDeparture) {
+			return nil, fail("faktiki çatma yola düşmədən əvvəl ola bilməz")
+		}
+		if leg.ActualArrival != nil && leg.ActualDeparture == nil {
+			return nil, fail("faktiki çatma üçün faktiki yola düşmə vaxtı da göstərilməlidir")
+		}
+
+		for _, raw := range strings.FieldsFunc(f.Containers, func(r rune) bool {
+			return r == ',' || r == ';' || r == '\n'
+		}) {
+			if strings.TrimSpace(raw) == "" {
+				continue
+			}
+			parsed, err := container.ParseNumber(raw)
+			if err != nil {
+				return nil, fail(fmt.Sprintf("%s: %s", strings.TrimSpace(raw), err))
+			}
+			c, ok := shipmentContainers[parsed.String()]
+			if !ok {
+				return nil, fail(fmt.Sprintf("%s nömrəli konteyner daşınmaya bağlı deyil", parsed.String()))
+			}
+			leg.Containers = append(leg.Containers, c)
+		}
+
+		if seq > 1 {
+			prev := legs[seq-2]
+			if prev.ToLocationID != leg.FromLocationID {
+				return nil, fail(fmt.Sprintf("hissə əvvəlki hissənin təyinat məntəqəsindən (%s) başlamalıdır", prev.ToLocation))
+			}
+			if before(leg.PlannedDeparture, prev.PlannedArrival) {
+				return nil, fail("planlaşdırılan yola düşmə əvvəlki hissənin çatmasından tez ola bilməz")
+			}
+			if before(leg.ActualDeparture, prev.ActualArrival) {
+				return nil, fail("faktiki yola düşmə əvvəlki hissənin faktiki çatmasından tez ola bilməz")
+			}
+		}
+
+		legs = append(legs, leg)
+	}
+
+	return legs, nil
+}
+
+// findLocation UN/LOCODE və ya ID ilə göstərilmiş aktiv məntəqəni tapır.
+// UN/LOCODE-da hərflər olduğu üçün yalnız rəqəmlərdən ibarət dəyər ID kimi qəbul edilir.
+func (s *ShipmentService) findLocation(ctx context.Context, value string) (*LocationRef, error) {
+	value = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(value), " ", ""))
+	if value == "" {
+		return nil, &ValidationError{Message: "(UN/LOCODE və ya ID) göstərilməlidir"}
+	}
+
+	var id int
+	unlocode := value
+	if parsed, err := strconv.Atoi(value); err == nil {
+		if parsed <= 0 {
+			return nil, &ValidationError{Message: "yanlış göstərilib"}
+		}
+		id, unlocode = parsed, ""
+	}
+
+	location, err := s.repo.FindLocation(ctx, id, unlocode)
+	if err != nil {
+		return nil, err
+	}
+
+	if location == nil {
+		return nil, &ValidationError{Message: "tapılmadı və ya deaktivdir"}
+	}
+
+	return location, nil
+}
+
+// locationError məntəqənin yoxlama xətasını hissənin nömrəsi və məntəqənin rolu ilə tamamlayır
+func locationError(err error, fail func(string) error, role string) error {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return fail(role + " məntəqəsi " + validationErr.Message)
+	}
+	return err
+}
+
+// legSnapshot hissənin audit jurnalında saxlanılan təsviridir (ID-lər hər saxlamada dəyişdiyi üçün daxil edilmir)
+type legSnapshot struct {
+	Seq              int        `json:"seq"`
+	Mode             string     `json:"mode"`
+	CarrierID        *int       `json:"carrierId,omitempty"`
+	FromLocationID   int        `json:"fromLocationId"`
+	ToLocationID     int        `json:"toLocationId"`
+	PlannedDeparture *time.Time `json:"plannedDeparture,omitempty"`
+	PlannedArrival   *time.Time `json:"plannedArrival,omitempty"`
+	ActualDeparture  *time.Time `json:"actualDeparture,omitempty"`
+	ActualArrival    *time.Time `json:"actualArrival,omitempty"`
+	Containers       []string   `json:"containers,omitempty"`
+}
+
+func legSnapshots(legs []Leg) []legSnapshot {
+	snapshots := make([]legSnapshot, 0, len(legs))
+	for _, leg := range legs {
+		snapshot := legSnapshot{
+			Seq:              leg.Seq,
+			Mode:             leg.Mode,
+			CarrierID:        leg.CarrierID,
+			FromLocationID:   leg.FromLocationID,
+			ToLocationID:     leg.ToLocationID,
+			PlannedDeparture: leg.PlannedDeparture,
+			PlannedArrival:   leg.PlannedArrival,
+			ActualDeparture:  leg.ActualDeparture,
+			ActualArrival:    leg.ActualArrival,
+		}
+		for _, c := range leg.Containers {
+			snapshot.Containers = append(snapshot.Containers, c.Number)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots
+}
+
+// before a-nın b-dən əvvəl olduğunu göstərir; vaxtlardan biri nil olduqda false qaytarır
+func before(a, b *time.Time) bool {
+	return a != nil && b != nil && a.Before(*b)
+}
+
+// parseDateTime datetime-local dəyərini yerli vaxt zonasında oxuyur, boş dəyər üçün nil qaytarır
+func parseDateTime(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.ParseInLocation(dateTimeLayout, value, time.Local)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// parseOptionalInt mənfi olmayan tam ədədi oxuyur, boş dəyəri sıfır kimi qəbul edir
+func parseOptionalInt(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, errors.New("yanlış ədəd")
+	}
+
+	return n, nil
+}
+
+// parseOptionalFloat mənfi olmayan kəsr ədədi oxuyur, boş dəyəri sıfır kimi qəbul edir
+func parseOptionalFloat(value string) (float64, error) {
+	value = strings.ReplaceAll(strings.TrimSpace(value), ",", ".")
+	if value == "" {
+		return 0, nil
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f < 0 {
+		return 0, errors.New("yanlış ədəd")
+	}
+
+	return f, nil
+}
+
+// formatDateTime vaxtı datetime-local sahəsi üçün formatlayır
+func formatDateTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.In(time.Local).Format(dateTimeLayout)
+}
+
+func itoa(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+func ftoa(f float64) string {
+	if f == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,141 @@
+package shipment
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Status daşınmanın həyat dövrü mərhələsini təmsil edir
+type Status string
+
+// Daşınma statusları
+const (
+	StatusDraft     Status = "draft"
+	StatusBooked    Status = "booked"
+	StatusPickedUp  Status = "picked_up"
+	StatusInTransit Status = "in_transit"
+	StatusAtPort    Status = "at_port"
+	StatusCustoms   Status = "customs"
+	StatusDelivered Status = "delivered"
+	StatusCancelled Status = "cancelled"
+)
+
+// statusLabels statusların istifadəçi üçün adlarını saxlayır
+var statusLabels = map[Status]string{
+	StatusDraft:     "Qaralama",
+	StatusBooked:    "Sifariş edilib",
+	StatusPickedUp:  "Götürülüb",
+	StatusInTransit: "Yoldadır",
+	StatusAtPort:    "Limandadır",
+	StatusCustoms:   "Gömrükdədir",
+	StatusDelivered: "Çatdırılıb",
+	StatusCancelled: "Ləğv edilib",
+}
+
+// transitions hər statusdan icazə verilən keçidləri müəyyən edir
+var transitions = map[Status][]Status{
+	StatusDraft:     {StatusBooked, StatusCancelled},
+	StatusBooked:    {StatusPickedUp, StatusCancelled},
+	StatusPickedUp:  {StatusInTransit},
+	StatusInTransit: {StatusAtPort},
+	StatusAtPort:    {StatusCustoms, StatusInTransit},
+	StatusCustoms:   {StatusDelivered},
+	StatusDelivered: {},
+	StatusCancelled: {},
+}
+
+// ErrInvalidTransition icazə verilməyən status keçidi üçün əsas xətadır
+var ErrInvalidTransition = errors.New("status keçidinə icazə verilmir")
+
+// ErrUnknownStatus naməlum status dəyəri üçün qaytarılır
+var ErrUnknownStatus = errors.New("naməlum daşınma statusu")
+
+// TransitionError icazə verilməyən konkret keçidi təsvir edir
+type TransitionError struct {
+	From Status
+	To   Status
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%q statusundan %q statusuna keçid mümkün deyil", e.From.Label(), e.To.Label())
+}
+
+// Is errors.Is ilə ErrInvalidTransition yoxlamasını dəstəkləyir
+func (e *TransitionError) Is(target error) bool {
+	return target == ErrInvalidTransition
+}
+
+// ParseStatus sətri statusa çevirir
+func ParseStatus(value string) (Status, error) {
+	status := Status(value)
+	if !status.Valid() {
+		return "", ErrUnknownStatus
+	}
+	return status, nil
+}
+
+// Valid statusun məlum olub-olmadığını yoxlayır
+func (s Status) Valid() bool {
+	_, ok := transitions[s]
+	return ok
+}
+
+// Label statusun istifadəçi üçün adını qaytarır
+func (s Status) Label() string {
+	if label, ok := statusLabels[s]; ok {
+		return label
+	}
+	return string(s)
+}
+
+// IsTerminal statusun son mərhələ olub-olmadığını göstərir
+func (s Status) IsTerminal() bool {
+	return len(transitions[s]) == 0
+}
+
+// Next bu statusdan keçid edilə bilən statusları qaytarır
+func (s Status) Next() []Status {
+	return transitions[s]
+}
+
+// CanTransition from statusundan to statusuna keçidin mümkünlüyünü yoxlayır
+func CanTransition(from, to Status) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTransition keçidi yoxlayır və icazə verilmədikdə tipli xəta qaytarır
+func ValidateTransition(from, to Status) error {
+	if !from.Valid() || !to.Valid() {
+		return ErrUnknownStatus
+	}
+
+	if !CanTransition(from, to) {
+		return &TransitionError{From: from, To: to}
+	}
+
+	return nil
+}
+
+// ActiveStatuses son mərhələ olmayan bütün statusları qaytarır
+func ActiveStatuses() []Status {
+	var active []Status
+	for _, s := range AllStatuses() {
+		if !s.IsTerminal() {
+			active = append(active, s)
+		}
+	}
+	return active
+}
+
+// AllStatuses bütün statusları həyat dövrü sırası ilə qaytarır
+func AllStatuses() []Status {
+	return []Status{
+		StatusDraft, StatusBooked, StatusPickedUp, StatusInTransit,
+		StatusAtPort, StatusCustoms, StatusDelivered, StatusCancelled,
+	}
+}
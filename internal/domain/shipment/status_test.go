@@ -0,0 +1,77 @@
+package shipment
+
+import (
+	"errors"
+	"testing"
+)
+
+// allowedEdges daşınma həyat dövrünün gözlənilən keçidləridir; transitions cədvəlindən asılı olmadan yazılıb
+var allowedEdges = map[[2]Status]bool{
+	{StatusDraft, StatusBooked}:       true,
+	{StatusDraft, StatusCancelled}:    true,
+	{StatusBooked, StatusPickedUp}:    true,
+	{StatusBooked, StatusCancelled}:   true,
+	{StatusPickedUp, StatusInTransit}: true,
+	{StatusInTransit, StatusAtPort}:   true,
+	{StatusAtPort, StatusCustoms}:     true,
+	{StatusAtPort, StatusInTransit}:   true,
+	{StatusCustoms, StatusDelivered}:  true,
+}
+
+// TestValidateTransition bütün status cütləri üzrə icazə verilən və qadağan olunan keçidləri yoxlayır
+func TestValidateTransition(t *testing.T) {
+	for _, from := range AllStatuses() {
+		for _, to := range AllStatuses() {
+			err := ValidateTransition(from, to)
+
+			if allowedEdges[[2]Status{from, to}] {
+				if err != nil {
+					t.Errorf("%s -> %s: %v, keçidə icazə verilməli idi", from, to, err)
+				}
+				continue
+			}
+
+			var transitionErr *TransitionError
+			if !errors.As(err, &transitionErr) || !errors.Is(err, ErrInvalidTransition) {
+				t.Errorf("%s -> %s: xəta = %v, gözlənilən TransitionError", from, to, err)
+				continue
+			}
+			if transitionErr.From != from || transitionErr.To != to {
+				t.Errorf("%s -> %s: xətada %s -> %s göstərilib", from, to, transitionErr.From, transitionErr.To)
+			}
+		}
+	}
+}
+
+// TestValidateTransitionUnknown naməlum statusların ErrUnknownStatus ilə rədd edildiyini yoxlayır
+func TestValidateTransitionUnknown(t *testing.T) {
+	tests := []struct {
+		from Status
+		to   Status
+	}{
+		{from: "lost", to: StatusBooked},
+		{from: StatusDraft, to: "lost"},
+		{from: "", to: ""},
+	}
+
+	for _, tt := range tests {
+		if err := ValidateTransition(tt.from, tt.to); !errors.Is(err, ErrUnknownStatus) {
+			t.Errorf("%q -> %q: xəta = %v, gözlənilən ErrUnknownStatus", tt.from, tt.to, err)
+		}
+	}
+}
+
+// TestActiveStatuses son mərhələlərin aktiv statuslara daxil edilmədiyini yoxlayır
+func TestActiveStatuses(t *testing.T) {
+	active := map[Status]bool{}
+	for _, s := range ActiveStatuses() {
+		active[s] = true
+	}
+
+	for _, s := range AllStatuses() {
+		terminal := s == StatusDelivered || s == StatusCancelled
+		if active[s] == terminal {
+			t.Errorf("%s: aktiv = %v, son mərhələ = %v", s, active[s], terminal)
+		}
+	}
+}
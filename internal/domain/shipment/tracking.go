@@ -0,0 +1,181 @@
+package shipment
+
+import (
+	"crypto/rand"
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// EventCode izləmə hadisəsinin növüdür
+type EventCode string
+
+// İzləmə hadisələrinin kodları (daşınmanın fiziki hərəkəti sırası ilə)
+const (
+	EventGateIn     EventCode = "gate_in"
+	EventLoaded     EventCode = "loaded"
+	EventDeparted   EventCode = "departed"
+	EventArrived    EventCode = "arrived"
+	EventDischarged EventCode = "discharged"
+	EventGateOut    EventCode = "gate_out"
+	EventDelivered  EventCode = "delivered"
+)
+
+// eventLabels hadisə kodlarının istifadəçi üçün adlarını saxlayır
+var eventLabels = map[EventCode]string{
+	EventGateIn:     "Terminala daxil olub",
+	EventLoaded:     "Yüklənib",
+	EventDeparted:   "Yola düşüb",
+	EventArrived:    "Çatıb",
+	EventDischarged: "Boşaldılıb",
+	EventGateOut:    "Terminaldan çıxıb",
+	EventDelivered:  "Təhvil verilib",
+}
+
+// eventStatuses hadisənin daşınmanı keçirdiyi statusdur. Keçid yalnız vəziyyət maşını
+// hazırkı statusdan ona icazə verdikdə edilir; digər hallarda hadisə statusu dəyişmir.
+var eventStatuses = map[EventCode]Status{
+	EventGateIn:    StatusPickedUp,
+	EventDeparted:  StatusInTransit,
+	EventArrived:   StatusAtPort,
+	EventDelivered: StatusDelivered,
+}
+
+// ErrUnknownEventCode naməlum hadisə kodu üçün qaytarılır
+var ErrUnknownEventCode = errors.New("naməlum izləmə hadisəsi")
+
+// Hadisə mənbələri: forma ilə daxil edilmiş və API vasitəsilə göndərilmiş hadisələr
+const (
+	SourceManual = "manual"
+	SourceAPI    = "api"
+)
+
+// ParseEventCode sətri hadisə koduna çevirir
+func ParseEventCode(value string) (EventCode, error) {
+	code := EventCode(value)
+	if !code.Valid() {
+		return "", ErrUnknownEventCode
+	}
+	return code, nil
+}
+
+// Valid hadisə kodunun məlum olub-olmadığını yoxlayır
+func (c EventCode) Valid() bool {
+	_, ok := eventLabels[c]
+	return ok
+}
+
+// Label hadisənin istifadəçi üçün adını qaytarır
+func (c EventCode) Label() string {
+	if label, ok := eventLabels[c]; ok {
+		return label
+	}
+	return string(c)
+}
+
+// StatusAfter hadisənin from statusundakı daşınmanı keçirəcəyi statusu qaytarır.
+// Keçid mümkün olmadıqda ikinci dəyər false olur.
+func (c EventCode) StatusAfter(from Status) (Status, bool) {
+	to, ok := eventStatuses[c]
+	if !ok || !CanTransition(from, to) {
+		return "", false
+	}
+	return to, true
+}
+
+// AllEventCodes bütün hadisə kodlarını fiziki hərəkət sırası ilə qaytarır
+func AllEventCodes() []EventCode {
+	return []EventCode{
+		EventGateIn, EventLoaded, EventDeparted, EventArrived,
+		EventDischarged, EventGateOut, EventDelivered,
+	}
+}
+
+// TrackingEvent daşınmanın (və ya onun konteynerinin) izləmə hadisəsini təmsil edir
+type TrackingEvent struct {
+	ID              int       `db:"id" json:"id"`
+	ShipmentID      int       `db:"shipment_id" json:"-"`
+	ContainerID     *int      `db:"container_id" json:"containerId"`
+	ContainerNumber *string   `db:"container_number" json:"containerNumber"`
+	Code            EventCode `db:"code" json:"code"`
+	Location        string    `db:"location" json:"location"`
+	OccurredAt      time.Time `db:"occurred_at" json:"occurredAt"`
+	Source          string    `db:"source" json:"source"`
+	Note            string    `db:"note" json:"note"`
+	RecordedAt      time.Time `db:"recorded_at" json:"recordedAt"`
+}
+
+// EventForm izləmə hadisəsi əlavə etmə formunu təmsil edir.
+// Container daşınmaya bağlı konteynerin nömrəsidir; boş olduqda hadisə bütün daşınmaya aiddir.
+// OccurredAt datetime-local (yerli vaxt) və ya RFC 3339 (vaxt zonası ilə) formatında ola bilər.
+type EventForm struct {
+	Code       string
+	Container  string
+	Location   string
+	OccurredAt string
+	Note       string
+}
+
+// EventResult hadisənin əlavə edilməsinin nəticəsidir; Status daşınmanın hadisədən sonrakı statusudur
+type EventResult struct {
+	Event    TrackingEvent `json:"event"`
+	Status   Status        `json:"status"`
+	Advanced bool          `json:"advanced"`
+}
+
+// trackingAlphabet izləmə nömrəsinin simvollarıdır (Crockford base32: I, L, O, U yoxdur ki,
+// nömrə telefonla deyildikdə və ya əllə yazıldıqda səhv oxunmasın)
+const trackingAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// İzləmə nömrəsinin prefiksi və təsadüfi hissəsinin uzunluğu
+const (
+	trackingPrefix = "LGS"
+	trackingLength = 10
+)
+
+// maxBLNumberLength konosament nömrəsinin maksimal uzunluğudur
+const maxBLNumberLength = 35
+
+// NewTrackingNumber yeni daşınma üçün təsadüfi izləmə nömrəsi yaradır.
+// Ardıcıl istinad nömrəsindən (reference) fərqli olaraq onu təxmin etmək mümkün deyil, ona görə də
+// müştəriyə verilən identifikator budur. Açıq izləmə səhifəsi konosament nömrəsini də qəbul edir;
+// həmin nömrələr daşıyıcının formatında olduğu üçün təxmin edilə bilər və onları yalnız /track
+// marşrutunun sorğu limiti qoruyur.
+func NewTrackingNumber() (string, error) {
+	buf := make([]byte, trackingLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	number := make([]byte, 0, len(trackingPrefix)+trackingLength)
+	number = append(number, trackingPrefix...)
+	for _, b := range buf {
+		number = append(number, trackingAlphabet[int(b)%len(trackingAlphabet)])
+	}
+	return string(number), nil
+}
+
+// NormalizeNumber izləmə və ya konosament nömrəsini axtarış üçün vahid formaya salır:
+// boşluqlar və defislər silinir, hərflər böyük hərfə çevrilir
+func NormalizeNumber(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == '-' {
+			return -1
+		}
+		return unicode.ToUpper(r)
+	}, value)
+}
+
+// validBLNumber normallaşdırılmış konosament nömrəsini yoxlayır; boş nömrə icazəlidir
+func validBLNumber(value string) bool {
+	if len(value) > maxBLNumberLength {
+		return false
+	}
+	for _, r := range value {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
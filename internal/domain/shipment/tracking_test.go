@@ -0,0 +1,34 @@
+package shipment
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestNewTrackingNumber nömrənin formatını yoxlayır; 0015 miqrasiyası mövcud daşınmalar üçün eyni formatı yaradır
+func TestNewTrackingNumber(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		number, err := NewTrackingNumber()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(number) != len(trackingPrefix)+trackingLength || !strings.HasPrefix(number, trackingPrefix) {
+			t.Fatalf("nömrə = %q, gözlənilən %s və %d simvol", number, trackingPrefix, trackingLength)
+		}
+		for _, r := range number[len(trackingPrefix):] {
+			if !strings.ContainsRune(trackingAlphabet, r) {
+				t.Fatalf("nömrə %q əlifbada olmayan %q simvolunu içərir", number, r)
+			}
+		}
+		if NormalizeNumber(number) != number {
+			t.Errorf("NormalizeNumber(%q) = %q", number, NormalizeNumber(number))
+		}
+
+		if seen[number] {
+			t.Fatalf("nömrə təkrarlandı: %q", number)
+		}
+		seen[number] = true
+	}
+}
@@ -0,0 +1,50 @@
+package tracking
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/logger"
+	"github.com/Zam83-AZE/logistics_system/pkg/view"
+)
+
+// Handler açıq izləmə səhifəsinin HTTP sorğularını işləyir
+type Handler struct {
+	service Service
+	tmpl    *template.Template
+}
+
+// NewHandler yeni izləmə işləyicisi yaradır
+func NewHandler(service Service, tmpl *template.Template) *Handler {
+	return &Handler{service: service, tmpl: tmpl}
+}
+
+// Index axtarış formunu və nömrə verildikdə daşınmanın statusunu göstərir
+func (h *Handler) Index(w http.ResponseWriter, r *http.Request) {
+	// Nəticə daşınmanın cari vəziyyətidir; ara proksilərdə saxlanılmamalı və indekslənməməlidir
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Robots-Tag", "noindex")
+
+	data := TrackPage{Number: strings.TrimSpace(r.URL.Query().Get("number"))}
+	if data.Number == "" {
+		view.Render(w, r, h.tmpl, "tracking/index.html", data)
+		return
+	}
+
+	found, err := h.service.Lookup(r.Context(), data.Number)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		data.Error = err.Error()
+		w.WriteHeader(http.StatusNotFound)
+	case err != nil:
+		logger.FromContext(r.Context()).WithError(err).Error("Daşınma izlənərkən xəta baş verdi")
+		http.Error(w, "Daşınma məlumatları əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	default:
+		data.Shipment = found
+	}
+
+	view.Render(w, r, h.tmpl, "tracking/index.html", data)
+}
@@ -0,0 +1,37 @@
+package tracking
+
+import (
+	"time"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/shipment"
+)
+
+// Shipment açıq izləmə səhifəsində göstərilən daşınma məlumatlarıdır.
+// Müştəri, qeydlər, yük və qiymət məlumatları bura qəsdən daxil edilmir.
+type Shipment struct {
+	ID             int             `db:"id"`
+	TrackingNumber string          `db:"tracking_number"`
+	Status         shipment.Status `db:"status"`
+	Origin         string          `db:"origin"`
+	Destination    string          `db:"destination"`
+	ETD            *time.Time      `db:"etd"`
+	ETA            *time.Time      `db:"eta"`
+	Milestones     []Milestone     `db:"-"`
+}
+
+// Milestone daşınmanın izləmə hadisəsinin açıq göstərilən hissəsidir (daxili qeyd və mənbə olmadan)
+type Milestone struct {
+	Code            shipment.EventCode `db:"code"`
+	Location        string             `db:"location"`
+	OccurredAt      time.Time          `db:"occurred_at"`
+	ContainerNumber *string            `db:"container_number"`
+}
+
+// TrackPage izləmə səhifəsinin məlumatlarıdır
+type TrackPage struct {
+	UserName    string
+	CurrentPage string
+	Number      string
+	Shipment    *Shipment
+	Error       string
+}
@@ -0,0 +1,67 @@
+package tracking
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/shipment"
+	"github.com/jmoiron/sqlx"
+)
+
+// Repository açıq izləmə üçün məlumat əməliyyatlarını müəyyən edir
+type Repository interface {
+	FindByNumber(ctx context.Context, number string) (*Shipment, error)
+	Milestones(ctx context.Context, shipmentID int) ([]Milestone, error)
+}
+
+// PostgresRepository Repository interfeysini həyata keçirir
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRepository yeni PostgresRepository yaradır
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+// FindByNumber daşınmanı izləmə və ya konosament nömrəsinə görə tapır.
+// Qaralamalar hələ rəsmiləşdirilmədiyi üçün açıq göstərilmir.
+func (r *PostgresRepository) FindByNumber(ctx context.Context, number string) (*Shipment, error) {
+	query := `
+		SELECT id, tracking_number, status, origin, destination, etd, eta
+		FROM shipments
+		WHERE (tracking_number = $1 OR (bl_number <> '' AND bl_number = $1))
+			AND status <> $2
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	s := &Shipment{}
+	if err := r.db.GetContext(ctx, s, query, number, shipment.StatusDraft); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Daşınma tapılmadı
+		}
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Milestones daşınmanın izləmə hadisələrini xronoloji sıra ilə əldə edir
+func (r *PostgresRepository) Milestones(ctx context.Context, shipmentID int) ([]Milestone, error) {
+	query := `
+		SELECT e.code, e.location, e.occurred_at,
+			c.owner_code || c.serial || c.check_digit::text AS container_number
+		FROM tracking_events e
+		LEFT JOIN containers c ON c.id = e.container_id
+		WHERE e.shipment_id = $1
+		ORDER BY e.occurred_at, e.id
+	`
+
+	milestones := []Milestone{}
+	if err := r.db.SelectContext(ctx, &milestones, query, shipmentID); err != nil {
+		return nil, err
+	}
+
+	return milestones, nil
+}
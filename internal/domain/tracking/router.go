@@ -0,0 +1,21 @@
+package tracking
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/Zam83-AZE/logistics_system/internal/middleware"
+	"github.com/Zam83-AZE/logistics_system/pkg/config"
+	"github.com/Zam83-AZE/logistics_system/pkg/ratelimit"
+	"github.com/gorilla/mux"
+	"github.com/jmoiron/sqlx"
+)
+
+// RegisterPublicRoutes autentifikasiya tələb etməyən izləmə səhifəsini qeydə alır.
+// Nömrələrin ardıcıl yoxlanılmasının qarşısını almaq üçün sorğular IP üzrə məhdudlaşdırılır.
+func RegisterPublicRoutes(router *mux.Router, db *sqlx.DB, tmpl *template.Template, cfg config.TrackingConfig) {
+	handler := NewHandler(NewTrackingService(NewPostgresRepository(db)), tmpl)
+	limit := middleware.RateLimit(ratelimit.New(cfg.RateLimit, cfg.RateWindow))
+
+	router.Handle("/track", limit(http.HandlerFunc(handler.Index))).Methods("GET")
+}
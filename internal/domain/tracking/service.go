@@ -0,0 +1,53 @@
+package tracking
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/shipment"
+)
+
+// maxNumberLength axtarış üçün qəbul edilən nömrənin maksimal uzunluğudur (konosament nömrəsi 35 simvola qədər)
+const maxNumberLength = 35
+
+// ErrNotFound nömrəyə uyğun daşınma tapılmadıqda qaytarılır.
+// Nömrənin yanlış formatda olması ilə mövcud olmaması fərqləndirilmir.
+var ErrNotFound = errors.New("bu nömrə ilə daşınma tapılmadı")
+
+// Service açıq izləmə biznes məntiqini müəyyən edir
+type Service interface {
+	Lookup(ctx context.Context, number string) (*Shipment, error)
+}
+
+// TrackingService Service interfeysini həyata keçirir
+type TrackingService struct {
+	repo Repository
+}
+
+// NewTrackingService yeni TrackingService yaradır
+func NewTrackingService(repo Repository) *TrackingService {
+	return &TrackingService{repo: repo}
+}
+
+// Lookup daşınmanı izləmə və ya konosament nömrəsinə görə tapır və onun hadisələrini yükləyir
+func (s *TrackingService) Lookup(ctx context.Context, number string) (*Shipment, error) {
+	number = shipment.NormalizeNumber(number)
+	if number == "" || len(number) > maxNumberLength {
+		return nil, ErrNotFound
+	}
+
+	found, err := s.repo.FindByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, ErrNotFound
+	}
+
+	found.Milestones, err = s.repo.Milestones(ctx, found.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return found, nil
+}
@@ -0,0 +1,205 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/auth"
+	"github.com/Zam83-AZE/logistics_system/pkg/api"
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+)
+
+// UserRequest API vasitəsilə istifadəçi yaratma və yeniləmə sorğusunun gövdəsidir.
+// Yeniləmədə istifadəçi adı və şifrə nəzərə alınmır; şifrəni yalnız istifadəçinin özü dəyişir.
+type UserRequest struct {
+	Username           string `json:"username"`
+	Email              string `json:"email"`
+	FullName           string `json:"fullName"`
+	Password           string `json:"password"`
+	MustChangePassword bool   `json:"mustChangePassword"`
+	RoleIDs            []int  `json:"roleIds"`
+}
+
+// form sorğunu HTML formu ilə eyni yoxlamalardan keçməsi üçün UserForm-a çevirir
+func (req UserRequest) form() UserForm {
+	return UserForm{
+		Username:           req.Username,
+		Email:              req.Email,
+		FullName:           req.FullName,
+		Password:           req.Password,
+		ConfirmPassword:    req.Password,
+		MustChangePassword: req.MustChangePassword,
+		RoleIDs:            req.RoleIDs,
+	}
+}
+
+// userResponse istifadəçini rollarının ID-ləri ilə birlikdə təmsil edir
+type userResponse struct {
+	*User
+	RoleIDs []int `json:"roleIds"`
+}
+
+// APIHandler istifadəçi JSON API sorğularını işləyir
+type APIHandler struct {
+	service        Service
+	sessionManager *session.Manager
+}
+
+// NewAPIHandler yeni istifadəçi API işləyicisi yaradır
+func NewAPIHandler(service Service, sessionManager *session.Manager) *APIHandler {
+	return &APIHandler{service: service, sessionManager: sessionManager}
+}
+
+// List istifadəçi siyahısını qaytarır: ?q=, ?inactive=true, ?sort=, ?cursor=, ?limit=
+func (h *APIHandler) List(w http.ResponseWriter, r *http.Request) {
+	params, err := api.ParseList(r, sortColumns)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	inactive, err := api.QueryBool(r, "inactive")
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	users, err := h.service.List(r.Context(), ListFilter{
+		Query:           r.URL.Query().Get("q"),
+		IncludeInactive: inactive,
+		Sort:            params.Sort,
+		Page:            params.Page,
+		PerPage:         params.PerPage,
+	})
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteList(w, users.Items, users.Total, params)
+}
+
+// Get istifadəçini rolları ilə qaytarır
+func (h *APIHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	h.writeUser(w, r, id, http.StatusOK)
+}
+
+// Create yeni istifadəçi yaradır
+func (h *APIHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req UserRequest
+	if err := api.Decode(w, r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	user, err := h.service.Create(r.Context(), req.form())
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	h.writeUser(w, r, user.ID, http.StatusCreated)
+}
+
+// Update istifadəçinin e-poçt ünvanını, tam adını və rollarını yeniləyir
+func (h *APIHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	var req UserRequest
+	if err := api.Decode(w, r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	if _, err := h.service.Update(r.Context(), id, req.form()); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	h.writeUser(w, r, id, http.StatusOK)
+}
+
+// Deactivate istifadəçini deaktiv edir və bütün sessiyalarını ləğv edir
+func (h *APIHandler) Deactivate(w http.ResponseWriter, r *http.Request) {
+	h.changeState(w, r, func(id int) error {
+		if err := h.service.Deactivate(r.Context(), id, h.sessionManager.GetUserID(r)); err != nil {
+			return err
+		}
+		return h.sessionManager.RevokeUser(r.Context(), id)
+	})
+}
+
+// Activate istifadəçini yenidən aktiv edir
+func (h *APIHandler) Activate(w http.ResponseWriter, r *http.Request) {
+	h.changeState(w, r, func(id int) error {
+		return h.service.Activate(r.Context(), id)
+	})
+}
+
+// ForcePasswordChange istifadəçidən növbəti girişdə şifrəsini dəyişməyi tələb edir
+func (h *APIHandler) ForcePasswordChange(w http.ResponseWriter, r *http.Request) {
+	h.changeState(w, r, func(id int) error {
+		return h.service.ForcePasswordChange(r.Context(), id)
+	})
+}
+
+// changeState istifadəçinin vəziyyətini dəyişən əməliyyatı icra edir və yenilənmiş istifadəçini qaytarır
+func (h *APIHandler) changeState(w http.ResponseWriter, r *http.Request, action func(id int) error) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	if err := action(id); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	h.writeUser(w, r, id, http.StatusOK)
+}
+
+// writeUser istifadəçini rollarının ID-ləri ilə yazır
+func (h *APIHandler) writeUser(w http.ResponseWriter, r *http.Request, id, status int) {
+	user, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	roleIDs, err := h.service.RoleIDs(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, status, userResponse{User: user, RoleIDs: roleIDs})
+}
+
+// writeAPIError servis xətasını uyğun HTTP statusu ilə yazır
+func writeAPIError(w http.ResponseWriter, err error) {
+	var validationErr *ValidationError
+	var policyErr *auth.ValidationError
+	switch {
+	case errors.Is(err, ErrNotFound):
+		api.WriteError(w, api.NewError(http.StatusNotFound, api.CodeNotFound, err.Error()))
+	case errors.Is(err, ErrSelfDeactivate):
+		api.WriteError(w, api.NewError(http.StatusConflict, api.CodeConflict, err.Error()))
+	case errors.As(err, &validationErr):
+		api.WriteError(w, api.NewError(http.StatusUnprocessableEntity, api.CodeValidation, validationErr.Message))
+	case errors.As(err, &policyErr):
+		api.WriteError(w, api.NewError(http.StatusUnprocessableEntity, api.CodeValidation, policyErr.Message))
+	default:
+		api.WriteError(w, err)
+	}
+}
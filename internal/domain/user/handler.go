@@ -0,0 +1,267 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"html/template"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/auth"
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/Zam83-AZE/logistics_system/pkg/view"
+	"github.com/gorilla/mux"
+)
+
+// Handler istifadəçilərin idarəsi HTTP sorğularını işləyir
+type Handler struct {
+	service           Service
+	tmpl              *template.Template
+	sessionManager    *session.Manager
+	passwordMinLength int
+}
+
+// NewHandler yeni istifadəçi işləyicisi yaradır
+func NewHandler(service Service, tmpl *template.Template, sessionManager *session.Manager, passwordMinLength int) *Handler {
+	return &Handler{
+		service:           service,
+		tmpl:              tmpl,
+		sessionManager:    sessionManager,
+		passwordMinLength: passwordMinLength,
+	}
+}
+
+// List istifadəçi siyahısını axtarış və səhifələmə ilə göstərir
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	filter := ListFilter{
+		Query:           r.URL.Query().Get("q"),
+		IncludeInactive: r.URL.Query().Get("inactive") == "1",
+		Page:            page,
+	}
+
+	users, err := h.service.List(ctx, filter)
+	if err != nil {
+		http.Error(w, "İstifadəçi siyahısı əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	data := ListPage{
+		Users:       *users,
+		Filter:      filter,
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "users",
+	}
+
+	view.Render(w, r, h.tmpl, "user/list.html", data)
+}
+
+// New yeni istifadəçi formunu göstərir
+func (h *Handler) New(w http.ResponseWriter, r *http.Request) {
+	h.renderForm(w, r, UserForm{MustChangePassword: true}, 0, "", http.StatusOK)
+}
+
+// Create yeni istifadəçi yaradır
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	form := parseForm(r)
+
+	user, err := h.service.Create(r.Context(), form)
+	if err != nil {
+		h.renderFormError(w, r, form, 0, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/admin/users/%d", user.ID), http.StatusSeeOther)
+}
+
+// Detail istifadəçinin detallarını göstərir
+func (h *Handler) Detail(w http.ResponseWriter, r *http.Request) {
+	user, ok := h.loadUser(w, r)
+	if !ok {
+		return
+	}
+
+	data := DetailPage{
+		User:        *user,
+		IsSelf:      user.ID == h.sessionManager.GetUserID(r),
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "users",
+	}
+
+	view.Render(w, r, h.tmpl, "user/detail.html", data)
+}
+
+// Edit mövcud istifadəçinin redaktə formunu göstərir
+func (h *Handler) Edit(w http.ResponseWriter, r *http.Request) {
+	user, ok := h.loadUser(w, r)
+	if !ok {
+		return
+	}
+
+	roleIDs, err := h.service.RoleIDs(r.Context(), user.ID)
+	if err != nil {
+		http.Error(w, "İstifadəçinin rolları əldə edilmədi", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderForm(w, r, FormFromUser(user, roleIDs), user.ID, "", http.StatusOK)
+}
+
+// Update mövcud istifadəçinin məlumatlarını yeniləyir
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	form := parseForm(r)
+
+	user, err := h.service.Update(r.Context(), id, form)
+	if err != nil {
+		h.renderFormError(w, r, form, id, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/admin/users/%d", user.ID), http.StatusSeeOther)
+}
+
+// Deactivate istifadəçini deaktiv edir və bütün sessiyalarını ləğv edir
+func (h *Handler) Deactivate(w http.ResponseWriter, r *http.Request) {
+	h.changeState(w, r, func(id int) error {
+		if err := h.service.Deactivate(r.Context(), id, h.sessionManager.GetUserID(r)); err != nil {
+			return err
+		}
+		return h.sessionManager.RevokeUser(r.Context(), id)
+	})
+}
+
+// Activate istifadəçini yenidən aktiv edir
+func (h *Handler) Activate(w http.ResponseWriter, r *http.Request) {
+	h.changeState(w, r, func(id int) error {
+		return h.service.Activate(r.Context(), id)
+	})
+}
+
+// ForcePasswordChange istifadəçidən növbəti girişdə şifrəsini dəyişməyi tələb edir
+func (h *Handler) ForcePasswordChange(w http.ResponseWriter, r *http.Request) {
+	h.changeState(w, r, func(id int) error {
+		return h.service.ForcePasswordChange(r.Context(), id)
+	})
+}
+
+// changeState istifadəçinin vəziyyətini dəyişən əməliyyatı icra edir və detallar səhifəsinə yönləndirir
+func (h *Handler) changeState(w http.ResponseWriter, r *http.Request, action func(id int) error) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := action(id); err != nil {
+		switch {
+		case errors.Is(err, ErrNotFound):
+			http.NotFound(w, r)
+		case errors.Is(err, ErrSelfDeactivate):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, "İstifadəçinin vəziyyətini dəyişərkən xəta baş verdi", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/admin/users/%d", id), http.StatusSeeOther)
+}
+
+// loadUser URL-dəki ID-yə görə istifadəçini əldə edir, tapılmadıqda cavabı özü yazır
+func (h *Handler) loadUser(w http.ResponseWriter, r *http.Request) (*User, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return nil, false
+	}
+
+	user, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			http.NotFound(w, r)
+			return nil, false
+		}
+		http.Error(w, "İstifadəçi məlumatlarını əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	return user, true
+}
+
+// renderFormError formu xəta mesajı ilə yenidən göstərir
+func (h *Handler) renderFormError(w http.ResponseWriter, r *http.Request, form UserForm, id int, err error) {
+	if errors.Is(err, ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+
+	var validationErr *ValidationError
+	var policyErr *auth.ValidationError
+	message := "İstifadəçi məlumatlarını saxlayarkən xəta baş verdi"
+	switch {
+	case errors.As(err, &validationErr):
+		message = validationErr.Message
+	case errors.As(err, &policyErr):
+		message = policyErr.Message
+	}
+
+	// Şifrə sahələri yenidən göstərilmir
+	form.Password = ""
+	form.ConfirmPassword = ""
+
+	h.renderForm(w, r, form, id, message, http.StatusUnprocessableEntity)
+}
+
+// renderForm istifadəçi formunu rolların siyahısı ilə göstərir
+func (h *Handler) renderForm(w http.ResponseWriter, r *http.Request, form UserForm, id int, message string, status int) {
+	roles, err := h.service.Roles(r.Context())
+	if err != nil {
+		http.Error(w, "Rollar əldə edilərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	data := FormPage{
+		Form:        form,
+		Roles:       roles,
+		UserID:      id,
+		IsEdit:      id != 0,
+		MinLength:   h.passwordMinLength,
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "users",
+		Error:       message,
+	}
+
+	w.WriteHeader(status)
+	view.Render(w, r, h.tmpl, "user/form.html", data)
+}
+
+// parseForm sorğudan istifadəçi formunun dəyərlərini oxuyur
+func parseForm(r *http.Request) UserForm {
+	r.ParseForm()
+
+	var roleIDs []int
+	for _, raw := range r.PostForm["role_ids"] {
+		if id, err := strconv.Atoi(raw); err == nil {
+			roleIDs = append(roleIDs, id)
+		}
+	}
+
+	return UserForm{
+		Username:           r.FormValue("username"),
+		Email:              r.FormValue("email"),
+		FullName:           r.FormValue("full_name"),
+		Password:           r.FormValue("password"),
+		ConfirmPassword:    r.FormValue("confirm_password"),
+		MustChangePassword: r.FormValue("must_change_password") == "1",
+		RoleIDs:            roleIDs,
+	}
+}
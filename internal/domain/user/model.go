@@ -0,0 +1,121 @@
+package user
+
+import (
+	"time"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/rbac"
+)
+
+// User administratorun idarə etdiyi istifadəçi hesabını təmsil edir
+type User struct {
+	ID                 int        `db:"id" json:"id"`
+	Username           string     `db:"username" json:"username"`
+	Email              string     `db:"email" json:"email"`
+	FullName           string     `db:"full_name" json:"fullName"`
+	IsActive           bool       `db:"is_active" json:"isActive"`
+	MustChangePassword bool       `db:"must_change_password" json:"mustChangePassword"`
+	TOTPEnabled        bool       `db:"totp_enabled" json:"totpEnabled"`
+	LastLoginAt        *time.Time `db:"last_login_at" json:"lastLoginAt"`
+	RoleNames          string     `db:"role_names" json:"roleNames"`
+	CreatedAt          time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt          time.Time  `db:"updated_at" json:"updatedAt"`
+}
+
+// ListFilter istifadəçi siyahısı üçün axtarış və səhifələmə parametrlərini saxlayır
+type ListFilter struct {
+	Query           string
+	IncludeInactive bool
+	Sort            string
+	Page            int
+	PerPage         int
+}
+
+// UserList səhifələnmiş istifadəçi siyahısını təmsil edir
+type UserList struct {
+	Items   []User
+	Total   int
+	Page    int
+	PerPage int
+}
+
+// HasPrev əvvəlki səhifənin olub-olmadığını göstərir
+func (l UserList) HasPrev() bool {
+	return l.Page > 1
+}
+
+// HasNext növbəti səhifənin olub-olmadığını göstərir
+func (l UserList) HasNext() bool {
+	return l.Page*l.PerPage < l.Total
+}
+
+// PrevPage əvvəlki səhifənin nömrəsini qaytarır
+func (l UserList) PrevPage() int {
+	return l.Page - 1
+}
+
+// NextPage növbəti səhifənin nömrəsini qaytarır
+func (l UserList) NextPage() int {
+	return l.Page + 1
+}
+
+// UserForm istifadəçi yaratma və redaktə formunu təmsil edir.
+// İstifadəçi adı və şifrə yalnız yaradılarkən istifadə olunur.
+type UserForm struct {
+	Username           string
+	Email              string
+	FullName           string
+	Password           string
+	ConfirmPassword    string
+	MustChangePassword bool
+	RoleIDs            []int
+}
+
+// HasRole rolun formda seçildiyini yoxlayır
+func (f UserForm) HasRole(id int) bool {
+	for _, roleID := range f.RoleIDs {
+		if roleID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// ListPage istifadəçi siyahısı səhifəsi üçün məlumatları təmsil edir
+type ListPage struct {
+	Users       UserList
+	Filter      ListFilter
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// FormPage istifadəçi formu səhifəsi üçün məlumatları təmsil edir
+type FormPage struct {
+	Form        UserForm
+	Roles       []rbac.Role
+	UserID      int
+	IsEdit      bool
+	MinLength   int
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// DetailPage istifadəçi detalları səhifəsi üçün məlumatları təmsil edir
+type DetailPage struct {
+	User        User
+	IsSelf      bool
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// FormFromUser mövcud istifadəçidən redaktə formu yaradır
+func FormFromUser(u *User, roleIDs []int) UserForm {
+	return UserForm{
+		Username: u.Username,
+		Email:    u.Email,
+		FullName: u.FullName,
+		RoleIDs:  roleIDs,
+	}
+}
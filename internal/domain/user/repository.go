@@ -0,0 +1,227 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/db"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// PostgreSQL xəta kodları
+const (
+	uniqueViolation     = "23505"
+	foreignKeyViolation = "23503"
+)
+
+// userColumns istifadəçi sorğularında seçilən sütunlardır; rolların adları vergüllə birləşdirilir
+const userColumns = `u.id, u.username, u.email, u.full_name, u.is_active, u.must_change_password,
+	u.totp_enabled, u.last_login_at, u.created_at, u.updated_at,
+	COALESCE((
+		SELECT string_agg(r.name, ', ' ORDER BY r.name)
+		FROM user_roles ur
+		JOIN roles r ON r.id = ur.role_id
+		WHERE ur.user_id = u.id
+	), '') AS role_names`
+
+// Repository istifadəçi məlumatları əməliyyatlarını müəyyən edir
+type Repository interface {
+	List(ctx context.Context, filter ListFilter) ([]User, int, error)
+	GetByID(ctx context.Context, id int) (*User, error)
+	RoleIDs(ctx context.Context, id int) ([]int, error)
+	EmailTaken(ctx context.Context, email string, exceptID int) (bool, error)
+	Create(ctx context.Context, user *User, passwordHash string, roleIDs []int) error
+	Update(ctx context.Context, user *User, roleIDs []int) error
+	SetActive(ctx context.Context, id int, active bool) error
+	SetMustChangePassword(ctx context.Context, id int, required bool) error
+}
+
+// sortColumns siyahının sıralana biləcəyi sahələri və onlara uyğun sütunları saxlayır
+var sortColumns = map[string]string{
+	"username":    "u.username",
+	"email":       "u.email",
+	"fullName":    "u.full_name",
+	"lastLoginAt": "u.last_login_at",
+	"createdAt":   "u.created_at",
+}
+
+// PostgresRepository Repository interfeysini həyata keçirir
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRepository yeni PostgresRepository yaradır
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+// List filtrə uyğun istifadəçiləri və ümumi sayı əldə edir
+func (r *PostgresRepository) List(ctx context.Context, filter ListFilter) ([]User, int, error) {
+	var conditions []string
+	var args []interface{}
+
+	if !filter.IncludeInactive {
+		conditions = append(conditions, "u.is_active = true")
+	}
+
+	if q := strings.TrimSpace(filter.Query); q != "" {
+		args = append(args, "%"+q+"%")
+		conditions = append(conditions, "(u.username ILIKE $1 OR u.email ILIKE $1 OR u.full_name ILIKE $1)")
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM users u "+where, args...); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT ` + userColumns + `
+		FROM users u
+		` + where + `
+		ORDER BY ` + db.OrderBy(filter.Sort, sortColumns, "u.username, u.id", "u.id") + `
+		LIMIT $` + strconv.Itoa(len(args)+1) + ` OFFSET $` + strconv.Itoa(len(args)+2)
+
+	args = append(args, filter.PerPage, (filter.Page-1)*filter.PerPage)
+
+	users := []User{}
+	if err := r.db.SelectContext(ctx, &users, query, args...); err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// GetByID istifadəçini ID-yə görə əldə edir
+func (r *PostgresRepository) GetByID(ctx context.Context, id int) (*User, error) {
+	query := `
+		SELECT ` + userColumns + `
+		FROM users u
+		WHERE u.id = $1
+	`
+
+	user := &User{}
+	err := r.db.GetContext(ctx, user, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // İstifadəçi tapılmadı
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// RoleIDs istifadəçiyə təyin edilmiş rolların ID-lərini qaytarır
+func (r *PostgresRepository) RoleIDs(ctx context.Context, id int) ([]int, error) {
+	roleIDs := []int{}
+	err := r.db.SelectContext(ctx, &roleIDs, `SELECT role_id FROM user_roles WHERE user_id = $1 ORDER BY role_id`, id)
+	return roleIDs, err
+}
+
+// EmailTaken e-poçt ünvanının başqa istifadəçidə olub-olmadığını yoxlayır
+func (r *PostgresRepository) EmailTaken(ctx context.Context, email string, exceptID int) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM users WHERE LOWER(email) = LOWER($1) AND id <> $2)`
+
+	var taken bool
+	err := r.db.GetContext(ctx, &taken, query, email, exceptID)
+	return taken, err
+}
+
+// Create yeni istifadəçi və onun rollarını əlavə edir
+func (r *PostgresRepository) Create(ctx context.Context, user *User, passwordHash string, roleIDs []int) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO users (username, password, email, full_name, is_active, must_change_password)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at
+	`
+	row := tx.QueryRowxContext(ctx, query, user.Username, passwordHash, user.Email, user.FullName,
+		user.IsActive, user.MustChangePassword)
+	if err := row.Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt); err != nil {
+		return mapError(err)
+	}
+
+	if err := replaceRoles(ctx, tx, user.ID, roleIDs); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Update istifadəçinin e-poçt ünvanını, tam adını və rollarını yeniləyir
+func (r *PostgresRepository) Update(ctx context.Context, user *User, roleIDs []int) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE users SET email = $2, full_name = $3, updated_at = NOW() WHERE id = $1`
+	if _, err := tx.ExecContext(ctx, query, user.ID, user.Email, user.FullName); err != nil {
+		return mapError(err)
+	}
+
+	if err := replaceRoles(ctx, tx, user.ID, roleIDs); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetActive istifadəçinin aktivlik statusunu dəyişir
+func (r *PostgresRepository) SetActive(ctx context.Context, id int, active bool) error {
+	query := `UPDATE users SET is_active = $1, updated_at = NOW() WHERE id = $2`
+
+	_, err := r.db.ExecContext(ctx, query, active, id)
+	return err
+}
+
+// SetMustChangePassword istifadəçinin növbəti girişdə şifrəsini dəyişməli olduğunu qeyd edir
+func (r *PostgresRepository) SetMustChangePassword(ctx context.Context, id int, required bool) error {
+	query := `UPDATE users SET must_change_password = $1, updated_at = NOW() WHERE id = $2`
+
+	_, err := r.db.ExecContext(ctx, query, required, id)
+	return err
+}
+
+// replaceRoles istifadəçinin rollarını tranzaksiya daxilində əvəz edir
+func replaceRoles(ctx context.Context, tx *sqlx.Tx, userID int, roleIDs []int) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_roles WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	for _, roleID := range roleIDs {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO user_roles (user_id, role_id) VALUES ($1, $2)`, userID, roleID); err != nil {
+			return mapError(err)
+		}
+	}
+
+	return nil
+}
+
+// mapError verilənlər bazası xətalarını domen xətalarına çevirir
+func mapError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok {
+		switch pqErr.Code {
+		case uniqueViolation:
+			return &ValidationError{Message: "bu istifadəçi adı artıq mövcuddur"}
+		case foreignKeyViolation:
+			return &ValidationError{Message: "seçilmiş rol tapılmadı"}
+		}
+	}
+
+	return err
+}
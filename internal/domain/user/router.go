@@ -0,0 +1,93 @@
+package user
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/auth"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/rbac"
+	"github.com/Zam83-AZE/logistics_system/internal/middleware"
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+	"github.com/Zam83-AZE/logistics_system/pkg/config"
+	"github.com/Zam83-AZE/logistics_system/pkg/openapi"
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/gorilla/mux"
+	"github.com/jmoiron/sqlx"
+)
+
+// RegisterRoutes istifadəçilərin idarəsi marşrutlarını qeydə alır
+func RegisterRoutes(router *mux.Router, db *sqlx.DB, tmpl *template.Template, sessionManager *session.Manager, policy config.AuthConfig) {
+	repo := NewPostgresRepository(db)
+	recorder := audit.NewRecorder(db)
+	roles := rbac.NewRBACService(rbac.NewPostgresRepository(db), recorder)
+	service := NewUserService(repo, roles, recorder, auth.PasswordPolicy{MinLength: policy.PasswordMinLength})
+	handler := NewHandler(service, tmpl, sessionManager, policy.PasswordMinLength)
+
+	// İcazə yoxlaması
+	canManage := middleware.RequirePermission(rbac.UsersManage)
+
+	// Siyahı və yaratma
+	router.Handle("/admin/users", canManage(http.HandlerFunc(handler.List))).Methods("GET")
+	router.Handle("/admin/users/new", canManage(http.HandlerFunc(handler.New))).Methods("GET")
+	router.Handle("/admin/users", canManage(http.HandlerFunc(handler.Create))).Methods("POST")
+
+	// Detallar, redaktə və vəziyyət dəyişiklikləri
+	router.Handle("/admin/users/{id:[0-9]+}", canManage(http.HandlerFunc(handler.Detail))).Methods("GET")
+	router.Handle("/admin/users/{id:[0-9]+}/edit", canManage(http.HandlerFunc(handler.Edit))).Methods("GET")
+	router.Handle("/admin/users/{id:[0-9]+}", canManage(http.HandlerFunc(handler.Update))).Methods("POST")
+	router.Handle("/admin/users/{id:[0-9]+}/deactivate", canManage(http.HandlerFunc(handler.Deactivate))).Methods("POST")
+	router.Handle("/admin/users/{id:[0-9]+}/activate", canManage(http.HandlerFunc(handler.Activate))).Methods("POST")
+	router.Handle("/admin/users/{id:[0-9]+}/force-password-change", canManage(http.HandlerFunc(handler.ForcePasswordChange))).Methods("POST")
+}
+
+// RegisterAPIRoutes istifadəçi JSON API marşrutlarını qeydə alır
+func RegisterAPIRoutes(router *mux.Router, db *sqlx.DB, sessionManager *session.Manager, policy config.AuthConfig) {
+	recorder := audit.NewRecorder(db)
+	roles := rbac.NewRBACService(rbac.NewPostgresRepository(db), recorder)
+	service := NewUserService(NewPostgresRepository(db), roles, recorder, auth.PasswordPolicy{MinLength: policy.PasswordMinLength})
+	handler := NewAPIHandler(service, sessionManager)
+
+	canManage := middleware.RequirePermission(rbac.UsersManage)
+
+	router.Handle("/users", canManage(http.HandlerFunc(handler.List))).Methods("GET")
+	router.Handle("/users", canManage(http.HandlerFunc(handler.Create))).Methods("POST")
+	router.Handle("/users/{id:[0-9]+}", canManage(http.HandlerFunc(handler.Get))).Methods("GET")
+	router.Handle("/users/{id:[0-9]+}", canManage(http.HandlerFunc(handler.Update))).Methods("PUT")
+	router.Handle("/users/{id:[0-9]+}/deactivate", canManage(http.HandlerFunc(handler.Deactivate))).Methods("POST")
+	router.Handle("/users/{id:[0-9]+}/activate", canManage(http.HandlerFunc(handler.Activate))).Methods("POST")
+	router.Handle("/users/{id:[0-9]+}/force-password-change", canManage(http.HandlerFunc(handler.ForcePasswordChange))).Methods("POST")
+}
+
+// DescribeAPI istifadəçi API marşrutlarını OpenAPI sənədinə əlavə edir
+func DescribeAPI(spec *openapi.Spec) {
+	manage := string(rbac.UsersManage)
+
+	spec.Add("GET", "/users", openapi.Operation{
+		Summary: "İstifadəçi siyahısı", Tag: "users", Permission: manage,
+		Query: []openapi.Param{
+			{Name: "q", Description: "İstifadəçi adı, e-poçt və ya tam ad üzrə axtarış"},
+			{Name: "inactive", Type: "boolean", Description: "Deaktiv istifadəçiləri də göstər"},
+		},
+		List: true, Sort: sortColumns, Response: User{},
+	})
+	spec.Add("POST", "/users", openapi.Operation{
+		Summary: "İstifadəçi yarat", Tag: "users", Permission: manage,
+		Request: UserRequest{}, Response: userResponse{}, Status: http.StatusCreated,
+	})
+	spec.Add("GET", "/users/{id}", openapi.Operation{
+		Summary: "İstifadəçi", Tag: "users", Permission: manage, Response: userResponse{},
+	})
+	spec.Add("PUT", "/users/{id}", openapi.Operation{
+		Summary: "İstifadəçini yenilə", Tag: "users", Permission: manage,
+		Request: UserRequest{}, Response: userResponse{},
+	})
+	spec.Add("POST", "/users/{id}/deactivate", openapi.Operation{
+		Summary: "İstifadəçini deaktiv et", Tag: "users", Permission: manage, Response: userResponse{}, Conflict: true,
+	})
+	spec.Add("POST", "/users/{id}/activate", openapi.Operation{
+		Summary: "İstifadəçini aktiv et", Tag: "users", Permission: manage, Response: userResponse{},
+	})
+	spec.Add("POST", "/users/{id}/force-password-change", openapi.Operation{
+		Summary: "Şifrə dəyişikliyini tələb et", Tag: "users", Permission: manage, Response: userResponse{},
+	})
+}
@@ -0,0 +1,281 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/auth"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/rbac"
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const defaultPerPage = 20
+
+var (
+	// ErrNotFound istifadəçi tapılmadıqda qaytarılır
+	ErrNotFound = errors.New("istifadəçi tapılmadı")
+	// ErrSelfDeactivate administrator öz hesabını deaktiv etməyə çalışdıqda qaytarılır
+	ErrSelfDeactivate = errors.New("öz hesabınızı deaktiv edə bilməzsiniz")
+)
+
+// usernamePattern icazə verilən istifadəçi adı formatıdır
+var usernamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{2,99}$`)
+
+// ValidationError istifadəçiyə göstəriləcək form xətasını təmsil edir
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// Service istifadəçilərin idarəsi biznes məntiqini müəyyən edir
+type Service interface {
+	List(ctx context.Context, filter ListFilter) (*UserList, error)
+	Get(ctx context.Context, id int) (*User, error)
+	RoleIDs(ctx context.Context, id int) ([]int, error)
+	Roles(ctx context.Context) ([]rbac.Role, error)
+	Create(ctx context.Context, form UserForm) (*User, error)
+	Update(ctx context.Context, id int, form UserForm) (*User, error)
+	Deactivate(ctx context.Context, id, actorUserID int) error
+	Activate(ctx context.Context, id int) error
+	ForcePasswordChange(ctx context.Context, id int) error
+}
+
+// UserService Service interfeysini həyata keçirir
+type UserService struct {
+	repo   Repository
+	roles  rbac.Service
+	audit  audit.Recorder
+	policy auth.PasswordPolicy
+}
+
+// NewUserService yeni UserService yaradır
+func NewUserService(repo Repository, roles rbac.Service, recorder audit.Recorder, policy auth.PasswordPolicy) *UserService {
+	return &UserService{repo: repo, roles: roles, audit: recorder, policy: policy}
+}
+
+// List filtrə uyğun səhifələnmiş istifadəçi siyahısını qaytarır
+func (s *UserService) List(ctx context.Context, filter ListFilter) (*UserList, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PerPage < 1 || filter.PerPage > 100 {
+		filter.PerPage = defaultPerPage
+	}
+
+	users, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserList{
+		Items:   users,
+		Total:   total,
+		Page:    filter.Page,
+		PerPage: filter.PerPage,
+	}, nil
+}
+
+// Get istifadəçini ID-yə görə qaytarır
+func (s *UserService) Get(ctx context.Context, id int) (*User, error) {
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, ErrNotFound
+	}
+
+	return user, nil
+}
+
+// RoleIDs istifadəçiyə təyin edilmiş rolların ID-lərini qaytarır
+func (s *UserService) RoleIDs(ctx context.Context, id int) ([]int, error) {
+	return s.repo.RoleIDs(ctx, id)
+}
+
+// Roles formda seçilə bilən bütün rolları qaytarır
+func (s *UserService) Roles(ctx context.Context) ([]rbac.Role, error) {
+	return s.roles.ListRoles(ctx)
+}
+
+// Create formdakı məlumatlarla yeni istifadəçi yaradır; şifrə bcrypt ilə heşlənir
+func (s *UserService) Create(ctx context.Context, form UserForm) (*User, error) {
+	form = normalizeForm(form)
+
+	if !usernamePattern.MatchString(form.Username) {
+		return nil, &ValidationError{Message: "istifadəçi adı 3-100 simvol olmalı və yalnız kiçik latın hərfləri, rəqəmlər, \".\", \"_\" və \"-\" içərməlidir"}
+	}
+	if err := s.validateForm(ctx, form, 0); err != nil {
+		return nil, err
+	}
+	if form.Password != form.ConfirmPassword {
+		return nil, &ValidationError{Message: "şifrələr üst-üstə düşmür"}
+	}
+	if err := s.policy.Validate(form.Password, form.Username); err != nil {
+		return nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(form.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &User{
+		Username:           form.Username,
+		Email:              form.Email,
+		FullName:           form.FullName,
+		IsActive:           true,
+		MustChangePassword: form.MustChangePassword,
+	}
+
+	if err := s.repo.Create(ctx, user, string(hash), form.RoleIDs); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "user.created", user.ID, nil, &userResponse{User: user, RoleIDs: form.RoleIDs})
+
+	return user, nil
+}
+
+// Update istifadəçinin e-poçt ünvanını, tam adını və rollarını yeniləyir
+func (s *UserService) Update(ctx context.Context, id int, form UserForm) (*User, error) {
+	user, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	form = normalizeForm(form)
+	if err := s.validateForm(ctx, form, id); err != nil {
+		return nil, err
+	}
+
+	roleIDs, err := s.repo.RoleIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := *user
+
+	user.Email = form.Email
+	user.FullName = form.FullName
+
+	if err := s.repo.Update(ctx, user, form.RoleIDs); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "user.updated", id,
+		&userResponse{User: &before, RoleIDs: roleIDs},
+		&userResponse{User: user, RoleIDs: form.RoleIDs})
+
+	return user, nil
+}
+
+// Deactivate istifadəçini deaktiv edir; administrator öz hesabını deaktiv edə bilməz
+func (s *UserService) Deactivate(ctx context.Context, id, actorUserID int) error {
+	if id == actorUserID {
+		return ErrSelfDeactivate
+	}
+
+	return s.setActive(ctx, id, false, "user.deactivated")
+}
+
+// Activate deaktiv edilmiş istifadəçini yenidən aktiv edir
+func (s *UserService) Activate(ctx context.Context, id int) error {
+	return s.setActive(ctx, id, true, "user.activated")
+}
+
+// setActive istifadəçinin statusunu dəyişir və dəyişikliyi audit jurnalına yazır
+func (s *UserService) setActive(ctx context.Context, id int, active bool, action string) error {
+	user, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.SetActive(ctx, id, active); err != nil {
+		return err
+	}
+
+	before := *user
+	user.IsActive = active
+	s.record(ctx, action, id, &before, user)
+
+	return nil
+}
+
+// ForcePasswordChange istifadəçidən növbəti girişdə şifrəsini dəyişməyi tələb edir
+func (s *UserService) ForcePasswordChange(ctx context.Context, id int) error {
+	user, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.SetMustChangePassword(ctx, id, true); err != nil {
+		return err
+	}
+
+	before := *user
+	user.MustChangePassword = true
+	s.record(ctx, "user.password_change_forced", id, &before, user)
+
+	return nil
+}
+
+// record istifadəçi üzərində əməliyyatı audit jurnalına yazır
+func (s *UserService) record(ctx context.Context, action string, id int, before, after interface{}) {
+	s.audit.Record(ctx, audit.Event{
+		Action:     action,
+		EntityType: "user",
+		EntityID:   strconv.Itoa(id),
+		Before:     before,
+		After:      after,
+	})
+}
+
+// validateForm yaratma və redaktə üçün ümumi sahələri yoxlayır
+func (s *UserService) validateForm(ctx context.Context, form UserForm, id int) error {
+	if form.FullName == "" {
+		return &ValidationError{Message: "tam ad tələb olunur"}
+	}
+
+	if _, err := mail.ParseAddress(form.Email); err != nil {
+		return &ValidationError{Message: "e-poçt ünvanı yanlışdır"}
+	}
+
+	// Şifrə bərpası e-poçta görə aparıldığı üçün ünvan unikal olmalıdır
+	taken, err := s.repo.EmailTaken(ctx, form.Email, id)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return &ValidationError{Message: "bu e-poçt ünvanı başqa istifadəçiyə məxsusdur"}
+	}
+
+	return nil
+}
+
+// normalizeForm form dəyərlərindəki artıq boşluqları və təkrarlanan rolları təmizləyir
+func normalizeForm(form UserForm) UserForm {
+	form.Username = strings.ToLower(strings.TrimSpace(form.Username))
+	form.Email = strings.TrimSpace(form.Email)
+	form.FullName = strings.TrimSpace(form.FullName)
+
+	seen := make(map[int]bool, len(form.RoleIDs))
+	roleIDs := make([]int, 0, len(form.RoleIDs))
+	for _, id := range form.RoleIDs {
+		if id > 0 && !seen[id] {
+			seen[id] = true
+			roleIDs = append(roleIDs, id)
+		}
+	}
+	form.RoleIDs = roleIDs
+
+	return form
+}
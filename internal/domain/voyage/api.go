@@ -0,0 +1,341 @@
+package voyage
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/api"
+	"github.com/gorilla/mux"
+)
+
+// VoyageRequest API vasitəsilə reys yaratma və yeniləmə sorğusunun gövdəsidir
+type VoyageRequest struct {
+	CarrierID    int    `json:"carrierId"`
+	VesselID     *int   `json:"vesselId"`
+	VoyageNumber string `json:"voyageNumber"`
+	Notes        string `json:"notes"`
+}
+
+// form sorğunu HTML formu ilə eyni yoxlamalardan keçən forma çevirir
+func (req VoyageRequest) form() VoyageForm {
+	form := VoyageForm{
+		CarrierID:    itoa(req.CarrierID),
+		VoyageNumber: req.VoyageNumber,
+		Notes:        req.Notes,
+	}
+	if req.VesselID != nil {
+		form.VesselID = strconv.Itoa(*req.VesselID)
+	}
+	return form
+}
+
+// CallRequest reysə məntəqə əlavə etmə sorğusunun gövdəsidir; məntəqə locationId və ya unlocode ilə göstərilir
+type CallRequest struct {
+	LocationID *int       `json:"locationId"`
+	UNLOCODE   string     `json:"unlocode"`
+	ETA        *time.Time `json:"eta"`
+	ETD        *time.Time `json:"etd"`
+}
+
+// form sorğunu HTML formu ilə eyni yoxlamalardan keçən forma çevirir
+func (req CallRequest) form() CallForm {
+	form := CallForm{
+		UNLOCODE: req.UNLOCODE,
+		ETA:      formatDateTime(req.ETA),
+		ETD:      formatDateTime(req.ETD),
+	}
+	if req.LocationID != nil {
+		form.LocationID = strconv.Itoa(*req.LocationID)
+	}
+	return form
+}
+
+// ScheduleRequest reys məntəqəsinin vaxtlarını dəyişmə sorğusunun gövdəsidir
+type ScheduleRequest struct {
+	ETA *time.Time `json:"eta"`
+	ETD *time.Time `json:"etd"`
+}
+
+// BookingRequest daşınmanı reysə təyin etmə sorğusunun gövdəsidir
+type BookingRequest struct {
+	ShipmentID      int `json:"shipmentId"`
+	LoadCallID      int `json:"loadCallId"`
+	DischargeCallID int `json:"dischargeCallId"`
+}
+
+// APIHandler reys JSON API sorğularını işləyir
+type APIHandler struct {
+	service Service
+}
+
+// NewAPIHandler yeni reys API işləyicisi yaradır
+func NewAPIHandler(service Service) *APIHandler {
+	return &APIHandler{service: service}
+}
+
+// List reys siyahısını qaytarır: ?q=, ?carrierId=, ?vesselId=, ?sort=, ?cursor=, ?limit=
+func (h *APIHandler) List(w http.ResponseWriter, r *http.Request) {
+	params, err := api.ParseList(r, sortColumns)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	carrierID, err := queryID(r, "carrierId")
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	vesselID, err := queryID(r, "vesselId")
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	voyages, err := h.service.List(r.Context(), ListFilter{
+		Query:     r.URL.Query().Get("q"),
+		CarrierID: carrierID,
+		VesselID:  vesselID,
+		Sort:      params.Sort,
+		Page:      params.Page,
+		PerPage:   params.PerPage,
+	})
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteList(w, voyages.Items, voyages.Total, params)
+}
+
+// Get reysi məntəqələri və təyin edilmiş daşınmaları ilə qaytarır
+func (h *APIHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	voyage, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, voyage)
+}
+
+// Create yeni reys yaradır
+func (h *APIHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req VoyageRequest
+	if err := api.Decode(w, r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	voyage, err := h.service.Create(r.Context(), req.form())
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	h.writeVoyage(w, r, voyage.ID, http.StatusCreated)
+}
+
+// Update reysin məlumatlarını tam əvəz edir
+func (h *APIHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	var req VoyageRequest
+	if err := api.Decode(w, r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	if _, err := h.service.Update(r.Context(), id, req.form()); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	h.writeVoyage(w, r, id, http.StatusOK)
+}
+
+// AddCall reysin rotasiyasının sonuna məntəqə əlavə edir
+func (h *APIHandler) AddCall(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	var req CallRequest
+	if err := api.Decode(w, r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	call, err := h.service.AddCall(r.Context(), id, req.form())
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusCreated, call)
+}
+
+// UpdateCall reys məntəqəsinin vaxtlarını dəyişir; dəyişiklik təyin edilmiş daşınmaların ETD/ETA vaxtlarına ötürülür
+func (h *APIHandler) UpdateCall(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	callID, err := pathInt(r, "callId")
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	var req ScheduleRequest
+	if err := api.Decode(w, r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	call, err := h.service.UpdateCall(r.Context(), id, callID, ScheduleForm{
+		ETA: formatDateTime(req.ETA),
+		ETD: formatDateTime(req.ETD),
+	})
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, call)
+}
+
+// DeleteCall reys məntəqəsini silir
+func (h *APIHandler) DeleteCall(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	callID, err := pathInt(r, "callId")
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	if err := h.service.DeleteCall(r.Context(), id, callID); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.NoContent(w)
+}
+
+// AddBooking daşınmanı reysə təyin edir
+func (h *APIHandler) AddBooking(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	var req BookingRequest
+	if err := api.Decode(w, r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	booking, err := h.service.AddBooking(r.Context(), id, BookingForm{
+		ShipmentID:      itoa(req.ShipmentID),
+		LoadCallID:      itoa(req.LoadCallID),
+		DischargeCallID: itoa(req.DischargeCallID),
+	})
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusCreated, booking)
+}
+
+// DeleteBooking daşınmanın reysə təyinatını silir
+func (h *APIHandler) DeleteBooking(w http.ResponseWriter, r *http.Request) {
+	id, err := api.PathID(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	bookingID, err := pathInt(r, "bookingId")
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	if err := h.service.DeleteBooking(r.Context(), id, bookingID); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.NoContent(w)
+}
+
+// writeVoyage reysi məntəqələri ilə birlikdə yenidən oxuyur və qaytarır
+func (h *APIHandler) writeVoyage(w http.ResponseWriter, r *http.Request, id int, status int) {
+	voyage, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	api.WriteJSON(w, status, voyage)
+}
+
+// pathInt yoldakı əlavə ID parametrini oxuyur
+func pathInt(r *http.Request, name string) (int, error) {
+	id, err := strconv.Atoi(mux.Vars(r)[name])
+	if err != nil || id < 1 {
+		return 0, api.NewError(http.StatusBadRequest, api.CodeBadRequest, name+" yanlışdır")
+	}
+	return id, nil
+}
+
+// queryID sorğu parametrindəki istəyə bağlı ID-ni oxuyur; boş dəyər sıfırdır
+func queryID(r *http.Request, name string) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.Atoi(value)
+	if err != nil || id < 1 {
+		return 0, api.NewError(http.StatusBadRequest, api.CodeBadRequest, name+" müsbət tam ədəd olmalıdır")
+	}
+	return id, nil
+}
+
+// writeAPIError servis xətasını uyğun HTTP statusu ilə yazır
+func writeAPIError(w http.ResponseWriter, err error) {
+	var validationErr *ValidationError
+	switch {
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrCallNotFound), errors.Is(err, ErrBookingNotFound):
+		api.WriteError(w, api.NewError(http.StatusNotFound, api.CodeNotFound, err.Error()))
+	case errors.As(err, &validationErr):
+		api.WriteError(w, api.NewError(http.StatusUnprocessableEntity, api.CodeValidation, validationErr.Message))
+	default:
+		api.WriteError(w, err)
+	}
+}
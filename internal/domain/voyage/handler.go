@@ -0,0 +1,336 @@
+package voyage
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/Zam83-AZE/logistics_system/pkg/view"
+	"github.com/gorilla/mux"
+)
+
+// Handler reys HTTP sorğularını işləyir
+type Handler struct {
+	service        Service
+	tmpl           *template.Template
+	sessionManager *session.Manager
+}
+
+// NewHandler yeni reys işləyicisi yaradır
+func NewHandler(service Service, tmpl *template.Template, sessionManager *session.Manager) *Handler {
+	return &Handler{
+		service:        service,
+		tmpl:           tmpl,
+		sessionManager: sessionManager,
+	}
+}
+
+// List reys siyahısını axtarış, daşıyıcı filtri və səhifələmə ilə göstərir
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	carrierID, _ := strconv.Atoi(r.URL.Query().Get("carrier_id"))
+	filter := ListFilter{
+		Query:     r.URL.Query().Get("q"),
+		CarrierID: carrierID,
+		Page:      page,
+	}
+
+	voyages, err := h.service.List(ctx, filter)
+	if err != nil {
+		http.Error(w, "Reys siyahısı əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	carriers, err := h.service.CarrierOptions(ctx)
+	if err != nil {
+		http.Error(w, "Daşıyıcı siyahısı əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	data := ListPage{
+		Voyages:     *voyages,
+		Filter:      filter,
+		Carriers:    carriers,
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "voyages",
+	}
+
+	view.Render(w, r, h.tmpl, "voyage/list.html", data)
+}
+
+// New yeni reys formunu göstərir
+func (h *Handler) New(w http.ResponseWriter, r *http.Request) {
+	h.renderForm(w, r, VoyageForm{}, 0, http.StatusOK, "")
+}
+
+// Create yeni reys yaradır
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	form := parseForm(r)
+
+	voyage, err := h.service.Create(r.Context(), form)
+	if err != nil {
+		h.renderFormError(w, r, form, 0, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/voyages/%d", voyage.ID), http.StatusSeeOther)
+}
+
+// Detail reysin detallarını, rotasiyasını və təyin edilmiş daşınmaları göstərir
+func (h *Handler) Detail(w http.ResponseWriter, r *http.Request) {
+	voyage, ok := h.loadVoyage(w, r)
+	if !ok {
+		return
+	}
+
+	h.renderDetail(w, r, voyage, CallForm{}, BookingForm{}, http.StatusOK, "")
+}
+
+// Edit mövcud reysin redaktə formunu göstərir
+func (h *Handler) Edit(w http.ResponseWriter, r *http.Request) {
+	voyage, ok := h.loadVoyage(w, r)
+	if !ok {
+		return
+	}
+
+	h.renderForm(w, r, FormFromVoyage(voyage), voyage.ID, http.StatusOK, "")
+}
+
+// Update mövcud reysin məlumatlarını yeniləyir
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	voyage, ok := h.loadVoyage(w, r)
+	if !ok {
+		return
+	}
+
+	form := parseForm(r)
+
+	if _, err := h.service.Update(r.Context(), voyage.ID, form); err != nil {
+		h.renderFormError(w, r, form, voyage.ID, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/voyages/%d", voyage.ID), http.StatusSeeOther)
+}
+
+// AddCall reysin rotasiyasına məntəqə əlavə edir
+func (h *Handler) AddCall(w http.ResponseWriter, r *http.Request) {
+	voyage, ok := h.loadVoyage(w, r)
+	if !ok {
+		return
+	}
+
+	form := CallForm{
+		UNLOCODE: r.FormValue("unlocode"),
+		ETA:      r.FormValue("eta"),
+		ETD:      r.FormValue("etd"),
+	}
+
+	if _, err := h.service.AddCall(r.Context(), voyage.ID, form); err != nil {
+		h.renderActionError(w, r, voyage, form, BookingForm{}, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/voyages/%d", voyage.ID), http.StatusSeeOther)
+}
+
+// UpdateCall reys məntəqəsinin vaxtlarını dəyişir
+func (h *Handler) UpdateCall(w http.ResponseWriter, r *http.Request) {
+	voyage, ok := h.loadVoyage(w, r)
+	if !ok {
+		return
+	}
+
+	callID, err := strconv.Atoi(mux.Vars(r)["callId"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	form := ScheduleForm{
+		ETA: r.FormValue("eta"),
+		ETD: r.FormValue("etd"),
+	}
+
+	if _, err := h.service.UpdateCall(r.Context(), voyage.ID, callID, form); err != nil {
+		h.renderActionError(w, r, voyage, CallForm{}, BookingForm{}, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/voyages/%d", voyage.ID), http.StatusSeeOther)
+}
+
+// DeleteCall reys məntəqəsini silir
+func (h *Handler) DeleteCall(w http.ResponseWriter, r *http.Request) {
+	voyage, ok := h.loadVoyage(w, r)
+	if !ok {
+		return
+	}
+
+	callID, err := strconv.Atoi(mux.Vars(r)["callId"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := h.service.DeleteCall(r.Context(), voyage.ID, callID); err != nil {
+		h.renderActionError(w, r, voyage, CallForm{}, BookingForm{}, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/voyages/%d", voyage.ID), http.StatusSeeOther)
+}
+
+// AddBooking daşınmanı reysə təyin edir
+func (h *Handler) AddBooking(w http.ResponseWriter, r *http.Request) {
+	voyage, ok := h.loadVoyage(w, r)
+	if !ok {
+		return
+	}
+
+	form := BookingForm{
+		Shipment:        r.FormValue("shipment"),
+		LoadCallID:      r.FormValue("load_call_id"),
+		DischargeCallID: r.FormValue("discharge_call_id"),
+	}
+
+	if _, err := h.service.AddBooking(r.Context(), voyage.ID, form); err != nil {
+		h.renderActionError(w, r, voyage, CallForm{}, form, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/voyages/%d", voyage.ID), http.StatusSeeOther)
+}
+
+// DeleteBooking daşınmanın reysə təyinatını silir
+func (h *Handler) DeleteBooking(w http.ResponseWriter, r *http.Request) {
+	voyage, ok := h.loadVoyage(w, r)
+	if !ok {
+		return
+	}
+
+	bookingID, err := strconv.Atoi(mux.Vars(r)["bookingId"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := h.service.DeleteBooking(r.Context(), voyage.ID, bookingID); err != nil {
+		h.renderActionError(w, r, voyage, CallForm{}, BookingForm{}, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/voyages/%d", voyage.ID), http.StatusSeeOther)
+}
+
+// loadVoyage URL-dəki ID-yə görə reysi əldə edir, tapılmadıqda cavabı özü yazır
+func (h *Handler) loadVoyage(w http.ResponseWriter, r *http.Request) (*Voyage, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return nil, false
+	}
+
+	voyage, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			http.NotFound(w, r)
+			return nil, false
+		}
+		http.Error(w, "Reys məlumatlarını əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	return voyage, true
+}
+
+// renderActionError rotasiya və ya təyinat əməliyyatının xətasını detallar səhifəsində göstərir
+func (h *Handler) renderActionError(w http.ResponseWriter, r *http.Request, voyage *Voyage, callForm CallForm, bookingForm BookingForm, err error) {
+	if errors.Is(err, ErrCallNotFound) || errors.Is(err, ErrBookingNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		http.Error(w, "Reys cədvəlini saxlayarkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderDetail(w, r, voyage, callForm, bookingForm, http.StatusUnprocessableEntity, validationErr.Message)
+}
+
+// renderDetail reysin detallar səhifəsini verilmiş formlarla göstərir
+func (h *Handler) renderDetail(w http.ResponseWriter, r *http.Request, voyage *Voyage, callForm CallForm, bookingForm BookingForm, status int, message string) {
+	data := DetailPage{
+		Voyage:      *voyage,
+		CallForm:    callForm,
+		BookingForm: bookingForm,
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "voyages",
+		Error:       message,
+	}
+
+	w.WriteHeader(status)
+	view.Render(w, r, h.tmpl, "voyage/detail.html", data)
+}
+
+// renderFormError formu xəta mesajı ilə yenidən göstərir
+func (h *Handler) renderFormError(w http.ResponseWriter, r *http.Request, form VoyageForm, id int, err error) {
+	if errors.Is(err, ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+
+	var validationErr *ValidationError
+	message := "Reys məlumatlarını saxlayarkən xəta baş verdi"
+	if errors.As(err, &validationErr) {
+		message = validationErr.Message
+	}
+
+	h.renderForm(w, r, form, id, http.StatusUnprocessableEntity, message)
+}
+
+// renderForm reys formunu daşıyıcı və gəmi siyahıları ilə göstərir; id sıfır olduqda yeni reys formudur
+func (h *Handler) renderForm(w http.ResponseWriter, r *http.Request, form VoyageForm, id int, status int, message string) {
+	carriers, err := h.service.CarrierOptions(r.Context())
+	if err != nil {
+		http.Error(w, "Daşıyıcı siyahısı əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	vessels, err := h.service.VesselOptions(r.Context())
+	if err != nil {
+		http.Error(w, "Gəmi siyahısı əldə edərkən xəta baş verdi", http.StatusInternalServerError)
+		return
+	}
+
+	data := FormPage{
+		Form:        form,
+		VoyageID:    id,
+		IsEdit:      id > 0,
+		Carriers:    carriers,
+		Vessels:     vessels,
+		UserName:    h.sessionManager.GetUsername(r),
+		CurrentPage: "voyages",
+		Error:       message,
+	}
+
+	w.WriteHeader(status)
+	view.Render(w, r, h.tmpl, "voyage/form.html", data)
+}
+
+// parseForm sorğudan reys formunun dəyərlərini oxuyur
+func parseForm(r *http.Request) VoyageForm {
+	return VoyageForm{
+		CarrierID:    r.FormValue("carrier_id"),
+		VesselID:     r.FormValue("vessel_id"),
+		VoyageNumber: r.FormValue("voyage_number"),
+		Notes:        r.FormValue("notes"),
+	}
+}
@@ -0,0 +1,225 @@
+package voyage
+
+import (
+	"time"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/carrier"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/shipment"
+)
+
+// dateTimeLayout HTML datetime-local sahəsinin formatıdır
+const dateTimeLayout = "2006-01-02T15:04"
+
+// Voyage verilənlər bazasından gələn reys məlumatlarını təmsil edir.
+// Departure və Arrival ilk məntəqədən çıxış və son məntəqəyə çatma vaxtlarıdır.
+type Voyage struct {
+	ID           int        `db:"id" json:"id"`
+	CarrierID    int        `db:"carrier_id" json:"carrierId"`
+	CarrierName  string     `db:"carrier_name" json:"carrierName"`
+	CarrierMode  string     `db:"carrier_mode" json:"carrierMode"`
+	VesselID     *int       `db:"vessel_id" json:"vesselId"`
+	VesselName   *string    `db:"vessel_name" json:"vesselName"`
+	VesselIMO    *string    `db:"vessel_imo" json:"vesselImo"`
+	VoyageNumber string     `db:"voyage_number" json:"voyageNumber"`
+	Notes        string     `db:"notes" json:"notes"`
+	Route        string     `db:"route" json:"route"`
+	Departure    *time.Time `db:"departure" json:"departure"`
+	Arrival      *time.Time `db:"arrival" json:"arrival"`
+	CreatedAt    time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt    time.Time  `db:"updated_at" json:"updatedAt"`
+	Calls        []Call     `db:"-" json:"calls"`
+	Bookings     []Booking  `db:"-" json:"shipments"`
+}
+
+// ModeLabel reysin daşıyıcısının nəqliyyat növünün adını qaytarır
+func (v Voyage) ModeLabel() string {
+	return carrier.ModeLabel(v.CarrierMode)
+}
+
+// Call reysin məntəqəsini (port rotasiyasındakı dayanacağı) təmsil edir
+type Call struct {
+	ID           int        `db:"id" json:"id"`
+	VoyageID     int        `db:"voyage_id" json:"-"`
+	Seq          int        `db:"seq" json:"seq"`
+	LocationID   int        `db:"location_id" json:"locationId"`
+	LocationName string     `db:"location_name" json:"locationName"`
+	UNLOCODE     string     `db:"unlocode" json:"unlocode"`
+	ETA          *time.Time `db:"eta" json:"eta"`
+	ETD          *time.Time `db:"etd" json:"etd"`
+}
+
+// ETAInput gəlmə vaxtını datetime-local sahəsi üçün formatlayır
+func (c Call) ETAInput() string {
+	return formatDateTime(c.ETA)
+}
+
+// ETDInput çıxış vaxtını datetime-local sahəsi üçün formatlayır
+func (c Call) ETDInput() string {
+	return formatDateTime(c.ETD)
+}
+
+// Booking daşınmanın reysin yükləmə və boşaltma məntəqələri arasındakı hissəsinə təyinatıdır
+type Booking struct {
+	ID                int             `db:"id" json:"id"`
+	VoyageID          int             `db:"voyage_id" json:"-"`
+	ShipmentID        int             `db:"shipment_id" json:"shipmentId"`
+	ShipmentReference string          `db:"shipment_reference" json:"shipmentReference"`
+	ShipmentStatus    shipment.Status `db:"shipment_status" json:"shipmentStatus"`
+	LoadCallID        int             `db:"load_call_id" json:"loadCallId"`
+	LoadLocation      string          `db:"load_location" json:"loadLocation"`
+	LoadETD           *time.Time      `db:"load_etd" json:"loadEtd"`
+	DischargeCallID   int             `db:"discharge_call_id" json:"dischargeCallId"`
+	DischargeLocation string          `db:"discharge_location" json:"dischargeLocation"`
+	DischargeETA      *time.Time      `db:"discharge_eta" json:"dischargeEta"`
+	CreatedAt         time.Time       `db:"created_at" json:"createdAt"`
+}
+
+// LocationRef reys məntəqəsi üçün seçilmiş məntəqəni təmsil edir
+type LocationRef struct {
+	ID   int    `db:"id"`
+	Name string `db:"name"`
+}
+
+// ShipmentRef reysə təyin ediləcək daşınmanı təmsil edir
+type ShipmentRef struct {
+	ID        int             `db:"id"`
+	Reference string          `db:"reference"`
+	Status    shipment.Status `db:"status"`
+}
+
+// ScheduleChange reys cədvəlinə görə dəyişmiş daşınma vaxtlarını təmsil edir
+type ScheduleChange struct {
+	ShipmentID int        `db:"id"`
+	OldETD     *time.Time `db:"old_etd"`
+	OldETA     *time.Time `db:"old_eta"`
+	ETD        *time.Time `db:"etd"`
+	ETA        *time.Time `db:"eta"`
+}
+
+// ListFilter reys siyahısı üçün axtarış və səhifələmə parametrlərini saxlayır
+type ListFilter struct {
+	Query     string
+	CarrierID int
+	VesselID  int
+	Sort      string
+	Page      int
+	PerPage   int
+}
+
+// VoyageList səhifələnmiş reys siyahısını təmsil edir
+type VoyageList struct {
+	Items   []Voyage
+	Total   int
+	Page    int
+	PerPage int
+}
+
+// HasPrev əvvəlki səhifənin olub-olmadığını göstərir
+func (l VoyageList) HasPrev() bool {
+	return l.Page > 1
+}
+
+// HasNext növbəti səhifənin olub-olmadığını göstərir
+func (l VoyageList) HasNext() bool {
+	return l.Page*l.PerPage < l.Total
+}
+
+// PrevPage əvvəlki səhifənin nömrəsini qaytarır
+func (l VoyageList) PrevPage() int {
+	return l.Page - 1
+}
+
+// NextPage növbəti səhifənin nömrəsini qaytarır
+func (l VoyageList) NextPage() int {
+	return l.Page + 1
+}
+
+// VoyageForm reys yaratma və redaktə formunu təmsil edir
+type VoyageForm struct {
+	CarrierID    string
+	VesselID     string
+	VoyageNumber string
+	Notes        string
+}
+
+// CallForm reysə məntəqə əlavə etmə formunu təmsil edir.
+// Məntəqə ID ilə və ya UN/LOCODE ilə göstərilir; ETA və ETD datetime-local formatındadır.
+type CallForm struct {
+	LocationID string
+	UNLOCODE   string
+	ETA        string
+	ETD        string
+}
+
+// ScheduleForm reys məntəqəsinin vaxtlarını dəyişmə formunu təmsil edir
+type ScheduleForm struct {
+	ETA string
+	ETD string
+}
+
+// BookingForm daşınmanı reysə təyin etmə formunu təmsil edir.
+// Daşınma ID ilə və ya istinad nömrəsi (Shipment) ilə göstərilir.
+type BookingForm struct {
+	ShipmentID      string
+	Shipment        string
+	LoadCallID      string
+	DischargeCallID string
+}
+
+// CarrierOption formda seçilə bilən daşıyıcını təmsil edir
+type CarrierOption struct {
+	ID   int    `db:"id"`
+	Name string `db:"name"`
+}
+
+// VesselOption formda seçilə bilən gəmini təmsil edir
+type VesselOption struct {
+	ID   int    `db:"id"`
+	Name string `db:"name"`
+	IMO  string `db:"imo"`
+}
+
+// ListPage reys siyahısı səhifəsi üçün məlumatları təmsil edir
+type ListPage struct {
+	Voyages     VoyageList
+	Filter      ListFilter
+	Carriers    []CarrierOption
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// FormPage reys formu səhifəsi üçün məlumatları təmsil edir
+type FormPage struct {
+	Form        VoyageForm
+	VoyageID    int
+	IsEdit      bool
+	Carriers    []CarrierOption
+	Vessels     []VesselOption
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// DetailPage reys detalları səhifəsi üçün məlumatları təmsil edir
+type DetailPage struct {
+	Voyage      Voyage
+	CallForm    CallForm
+	BookingForm BookingForm
+	UserName    string
+	CurrentPage string
+	Error       string
+}
+
+// FormFromVoyage mövcud reysdən redaktə formu yaradır
+func FormFromVoyage(v *Voyage) VoyageForm {
+	form := VoyageForm{
+		CarrierID:    itoa(v.CarrierID),
+		VoyageNumber: v.VoyageNumber,
+		Notes:        v.Notes,
+	}
+	if v.VesselID != nil {
+		form.VesselID = itoa(*v.VesselID)
+	}
+	return form
+}
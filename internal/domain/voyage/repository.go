@@ -0,0 +1,406 @@
+package voyage
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/db"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// PostgreSQL məhdudiyyət pozulması kodları
+const (
+	uniqueViolation     = "23505"
+	foreignKeyViolation = "23503"
+)
+
+// Repository reys, reys məntəqəsi və təyinat məlumatları əməliyyatlarını müəyyən edir
+type Repository interface {
+	List(ctx context.Context, filter ListFilter) ([]Voyage, int, error)
+	GetByID(ctx context.Context, id int) (*Voyage, error)
+	Create(ctx context.Context, voyage *Voyage) error
+	Update(ctx context.Context, voyage *Voyage) error
+
+	Calls(ctx context.Context, voyageID int) ([]Call, error)
+	AddCall(ctx context.Context, call *Call) error
+	UpdateCall(ctx context.Context, call *Call) error
+	DeleteCall(ctx context.Context, voyageID, callID int) error
+
+	Bookings(ctx context.Context, voyageID int) ([]Booking, error)
+	AddBooking(ctx context.Context, booking *Booking) error
+	DeleteBooking(ctx context.Context, voyageID, bookingID int) error
+
+	FindLocation(ctx context.Context, id int, unlocode string) (*LocationRef, error)
+	FindShipment(ctx context.Context, id int, reference string) (*ShipmentRef, error)
+	PropagateSchedule(ctx context.Context, shipmentIDs []int) ([]ScheduleChange, error)
+
+	CarrierOptions(ctx context.Context) ([]CarrierOption, error)
+	VesselOptions(ctx context.Context) ([]VesselOption, error)
+}
+
+// sortColumns siyahının sıralana biləcəyi sahələri və onlara uyğun sütunları saxlayır
+var sortColumns = map[string]string{
+	"voyageNumber": "v.voyage_number",
+	"carrierName":  "c.name",
+	"departure":    "departure",
+	"arrival":      "arrival",
+	"createdAt":    "v.created_at",
+	"updatedAt":    "v.updated_at",
+}
+
+// selectVoyage reysi daşıyıcı, gəmi və məntəqələrdən hesablanan marşrut və vaxtlarla seçir
+const selectVoyage = `
+	SELECT v.id, v.carrier_id, c.name AS carrier_name, c.mode AS carrier_mode,
+		v.vessel_id, vs.name AS vessel_name, vs.imo AS vessel_imo,
+		v.voyage_number, v.notes,
+		COALESCE((
+			SELECT string_agg(l.name, ' → ' ORDER BY vc.seq)
+			FROM voyage_calls vc JOIN locations l ON l.id = vc.location_id
+			WHERE vc.voyage_id = v.id
+		), '') AS route,
+		(SELECT MIN(vc.etd) FROM voyage_calls vc WHERE vc.voyage_id = v.id) AS departure,
+		(SELECT MAX(vc.eta) FROM voyage_calls vc WHERE vc.voyage_id = v.id) AS arrival,
+		v.created_at, v.updated_at
+	FROM voyages v
+	JOIN carriers c ON c.id = v.carrier_id
+	LEFT JOIN vessels vs ON vs.id = v.vessel_id
+`
+
+const selectBooking = `
+	SELECT sv.id, sv.voyage_id, sv.shipment_id, s.reference AS shipment_reference,
+		s.status AS shipment_status,
+		sv.load_call_id, ll.name AS load_location, lc.etd AS load_etd,
+		sv.discharge_call_id, dl.name AS discharge_location, dc.eta AS discharge_eta,
+		sv.created_at
+	FROM shipment_voyages sv
+	JOIN shipments s ON s.id = sv.shipment_id
+	JOIN voyage_calls lc ON lc.id = sv.load_call_id
+	JOIN locations ll ON ll.id = lc.location_id
+	JOIN voyage_calls dc ON dc.id = sv.discharge_call_id
+	JOIN locations dl ON dl.id = dc.location_id
+`
+
+// PostgresRepository Repository interfeysini həyata keçirir
+type PostgresRepository struct {
+	db *sqlx.DB
+}
+
+// NewPostgresRepository yeni PostgresRepository yaradır
+func NewPostgresRepository(db *sqlx.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+// List filtrə uyğun reysləri səhifələmə ilə əldə edir və ümumi sayı qaytarır
+func (r *PostgresRepository) List(ctx context.Context, filter ListFilter) ([]Voyage, int, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	if filter.CarrierID > 0 {
+		args = append(args, filter.CarrierID)
+		conditions = append(conditions, "v.carrier_id = $"+strconv.Itoa(len(args)))
+	}
+
+	if filter.VesselID > 0 {
+		args = append(args, filter.VesselID)
+		conditions = append(conditions, "v.vessel_id = $"+strconv.Itoa(len(args)))
+	}
+
+	if q := strings.TrimSpace(filter.Query); q != "" {
+		args = append(args, "%"+q+"%")
+		n := strconv.Itoa(len(args))
+		conditions = append(conditions, "(v.voyage_number ILIKE $"+n+" OR c.name ILIKE $"+n+" OR vs.name ILIKE $"+n+")")
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	countQuery := `
+		SELECT COUNT(*) FROM voyages v
+		JOIN carriers c ON c.id = v.carrier_id
+		LEFT JOIN vessels vs ON vs.id = v.vessel_id` + where
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, 0, err
+	}
+
+	query := selectVoyage + where + `
+		ORDER BY ` + db.OrderBy(filter.Sort, sortColumns, "departure DESC NULLS LAST, v.id DESC", "v.id") + `
+		LIMIT $` + strconv.Itoa(len(args)+1) + ` OFFSET $` + strconv.Itoa(len(args)+2)
+
+	args = append(args, filter.PerPage, (filter.Page-1)*filter.PerPage)
+
+	voyages := []Voyage{}
+	if err := r.db.SelectContext(ctx, &voyages, query, args...); err != nil {
+		return nil, 0, err
+	}
+
+	return voyages, total, nil
+}
+
+// GetByID reysi ID-yə görə əldə edir
+func (r *PostgresRepository) GetByID(ctx context.Context, id int) (*Voyage, error) {
+	voyage := &Voyage{}
+	err := r.db.GetContext(ctx, voyage, selectVoyage+" WHERE v.id = $1", id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Reys tapılmadı
+		}
+		return nil, err
+	}
+
+	return voyage, nil
+}
+
+// Create yeni reys əlavə edir
+func (r *PostgresRepository) Create(ctx context.Context, voyage *Voyage) error {
+	query := `
+		INSERT INTO voyages (carrier_id, vessel_id, voyage_number, notes)
+		VALUES (:carrier_id, :vessel_id, :voyage_number, :notes)
+		RETURNING id, created_at, updated_at
+	`
+
+	rows, err := r.db.NamedQueryContext(ctx, query, voyage)
+	if err != nil {
+		return mapError(err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		return rows.Scan(&voyage.ID, &voyage.CreatedAt, &voyage.UpdatedAt)
+	}
+
+	return rows.Err()
+}
+
+// Update mövcud reysin məlumatlarını yeniləyir
+func (r *PostgresRepository) Update(ctx context.Context, voyage *Voyage) error {
+	query := `
+		UPDATE voyages
+		SET carrier_id = :carrier_id, vessel_id = :vessel_id, voyage_number = :voyage_number,
+			notes = :notes, updated_at = NOW()
+		WHERE id = :id
+	`
+
+	_, err := r.db.NamedExecContext(ctx, query, voyage)
+	return mapError(err)
+}
+
+// Calls reysin məntəqələrini ardıcıllıq sırası ilə əldə edir
+func (r *PostgresRepository) Calls(ctx context.Context, voyageID int) ([]Call, error) {
+	query := `
+		SELECT vc.id, vc.voyage_id, vc.seq, vc.location_id, l.name AS location_name, l.unlocode,
+			vc.eta, vc.etd
+		FROM voyage_calls vc
+		JOIN locations l ON l.id = vc.location_id
+		WHERE vc.voyage_id = $1
+		ORDER BY vc.seq
+	`
+
+	calls := []Call{}
+	if err := r.db.SelectContext(ctx, &calls, query, voyageID); err != nil {
+		return nil, err
+	}
+
+	return calls, nil
+}
+
+// AddCall reysin sonuna yeni məntəqə əlavə edir
+func (r *PostgresRepository) AddCall(ctx context.Context, call *Call) error {
+	query := `
+		INSERT INTO voyage_calls (voyage_id, seq, location_id, eta, etd)
+		SELECT $1, COALESCE(MAX(seq), 0) + 1, $2, $3, $4
+		FROM voyage_calls WHERE voyage_id = $1
+		RETURNING id, seq
+	`
+
+	err := r.db.QueryRowxContext(ctx, query, call.VoyageID, call.LocationID, call.ETA, call.ETD).
+		Scan(&call.ID, &call.Seq)
+	return mapError(err)
+}
+
+// UpdateCall reys məntəqəsinin vaxtlarını yeniləyir
+func (r *PostgresRepository) UpdateCall(ctx context.Context, call *Call) error {
+	query := `UPDATE voyage_calls SET eta = $1, etd = $2 WHERE id = $3 AND voyage_id = $4`
+
+	_, err := r.db.ExecContext(ctx, query, call.ETA, call.ETD, call.ID, call.VoyageID)
+	return err
+}
+
+// DeleteCall reys məntəqəsini silir
+func (r *PostgresRepository) DeleteCall(ctx context.Context, voyageID, callID int) error {
+	query := `DELETE FROM voyage_calls WHERE id = $1 AND voyage_id = $2`
+
+	_, err := r.db.ExecContext(ctx, query, callID, voyageID)
+	return mapError(err)
+}
+
+// Bookings reysə təyin edilmiş daşınmaları əldə edir
+func (r *PostgresRepository) Bookings(ctx context.Context, voyageID int) ([]Booking, error) {
+	bookings := []Booking{}
+	err := r.db.SelectContext(ctx, &bookings, selectBooking+" WHERE sv.voyage_id = $1 ORDER BY lc.seq, s.reference", voyageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return bookings, nil
+}
+
+// AddBooking daşınmanı reysə təyin edir
+func (r *PostgresRepository) AddBooking(ctx context.Context, booking *Booking) error {
+	query := `
+		INSERT INTO shipment_voyages (shipment_id, voyage_id, load_call_id, discharge_call_id)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRowxContext(ctx, query, booking.ShipmentID, booking.VoyageID, booking.LoadCallID, booking.DischargeCallID).
+		Scan(&booking.ID, &booking.CreatedAt)
+	return mapError(err)
+}
+
+// DeleteBooking daşınmanın reysə təyinatını silir
+func (r *PostgresRepository) DeleteBooking(ctx context.Context, voyageID, bookingID int) error {
+	query := `DELETE FROM shipment_voyages WHERE id = $1 AND voyage_id = $2`
+
+	_, err := r.db.ExecContext(ctx, query, bookingID, voyageID)
+	return err
+}
+
+// FindLocation aktiv məntəqəni ID-yə və ya UN/LOCODE-a görə tapır.
+// Eyni kodlu bir neçə məntəqə olduqda limana üstünlük verilir.
+func (r *PostgresRepository) FindLocation(ctx context.Context, id int, unlocode string) (*LocationRef, error) {
+	location := &LocationRef{}
+
+	var err error
+	if id > 0 {
+		err = r.db.GetContext(ctx, location, `SELECT id, name FROM locations WHERE id = $1 AND is_active = true`, id)
+	} else {
+		query := `
+			SELECT id, name FROM locations
+			WHERE unlocode = $1 AND is_active = true
+			ORDER BY kind = 'port' DESC, source = 'unlocode' DESC, id
+			LIMIT 1
+		`
+		err = r.db.GetContext(ctx, location, query, unlocode)
+	}
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Məntəqə tapılmadı
+		}
+		return nil, err
+	}
+
+	return location, nil
+}
+
+// FindShipment daşınmanı ID-yə və ya istinad nömrəsinə görə tapır
+func (r *PostgresRepository) FindShipment(ctx context.Context, id int, reference string) (*ShipmentRef, error) {
+	shipment := &ShipmentRef{}
+
+	var err error
+	if id > 0 {
+		err = r.db.GetContext(ctx, shipment, `SELECT id, reference, status FROM shipments WHERE id = $1`, id)
+	} else {
+		err = r.db.GetContext(ctx, shipment, `SELECT id, reference, status FROM shipments WHERE reference = $1`, reference)
+	}
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Daşınma tapılmadı
+		}
+		return nil, err
+	}
+
+	return shipment, nil
+}
+
+// PropagateSchedule daşınmaların ETD və ETA vaxtlarını onların təyin edildiyi reyslərdən yenidən hesablayır:
+// ETD ilk yükləmə məntəqəsindən çıxış, ETA son boşaltma məntəqəsinə çatma vaxtıdır.
+// Tamamlanmış və ləğv edilmiş daşınmalar, həmçinin vaxtı dəyişməyənlər yenilənmir.
+// Dəyişmiş daşınmaların əvvəlki və yeni vaxtları qaytarılır.
+func (r *PostgresRepository) PropagateSchedule(ctx context.Context, shipmentIDs []int) ([]ScheduleChange, error) {
+	changes := []ScheduleChange{}
+	if len(shipmentIDs) == 0 {
+		return changes, nil
+	}
+
+	// old eyni cədvəlin yenilənmədən əvvəlki görüntüsüdür və RETURNING-də əvvəlki vaxtları verir
+	query := `
+		WITH legs AS (
+			SELECT sv.shipment_id, MIN(lc.etd) AS etd, MAX(dc.eta) AS eta
+			FROM shipment_voyages sv
+			JOIN voyage_calls lc ON lc.id = sv.load_call_id
+			JOIN voyage_calls dc ON dc.id = sv.discharge_call_id
+			WHERE sv.shipment_id = ANY($1)
+			GROUP BY sv.shipment_id
+		)
+		UPDATE shipments s
+		SET etd = COALESCE(legs.etd, s.etd), eta = COALESCE(legs.eta, s.eta), updated_at = NOW()
+		FROM legs, shipments old
+		WHERE s.id = legs.shipment_id AND old.id = s.id
+			AND s.status NOT IN ('delivered', 'cancelled')
+			AND (s.etd IS DISTINCT FROM COALESCE(legs.etd, s.etd) OR s.eta IS DISTINCT FROM COALESCE(legs.eta, s.eta))
+		RETURNING s.id, old.etd AS old_etd, old.eta AS old_eta, s.etd, s.eta
+	`
+
+	if err := r.db.SelectContext(ctx, &changes, query, pq.Array(shipmentIDs)); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// CarrierOptions reys formunda seçilə bilən aktiv daşıyıcıları əldə edir
+func (r *PostgresRepository) CarrierOptions(ctx context.Context) ([]CarrierOption, error) {
+	options := []CarrierOption{}
+	err := r.db.SelectContext(ctx, &options, `SELECT id, name FROM carriers WHERE is_active = true ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
+// VesselOptions reys formunda seçilə bilən aktiv gəmiləri əldə edir
+func (r *PostgresRepository) VesselOptions(ctx context.Context) ([]VesselOption, error) {
+	options := []VesselOption{}
+	err := r.db.SelectContext(ctx, &options, `SELECT id, name, imo FROM vessels WHERE is_active = true ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
+// mapError verilənlər bazası xətalarını domen xətalarına çevirir
+func mapError(err error) error {
+	pqErr, ok := err.(*pq.Error)
+	if !ok {
+		return err
+	}
+
+	switch {
+	case pqErr.Code == uniqueViolation && pqErr.Constraint == "voyages_carrier_id_voyage_number_key":
+		return &ValidationError{Message: "bu daşıyıcının eyni nömrəli reysi artıq mövcuddur"}
+	case pqErr.Code == uniqueViolation && pqErr.Constraint == "shipment_voyages_shipment_id_voyage_id_key":
+		return &ValidationError{Message: "daşınma bu reysə artıq təyin edilib"}
+	case pqErr.Code == uniqueViolation && pqErr.Constraint == "voyage_calls_voyage_id_seq_key":
+		return &ValidationError{Message: "reysin məntəqələri eyni anda dəyişdirildi, yenidən cəhd edin"}
+	case pqErr.Code == foreignKeyViolation && (pqErr.Constraint == "shipment_voyages_load_call_id_fkey" ||
+		pqErr.Constraint == "shipment_voyages_discharge_call_id_fkey"):
+		return &ValidationError{Message: "məntəqəyə daşınma təyin edilib; əvvəlcə təyinatı silin"}
+	case pqErr.Code == foreignKeyViolation && pqErr.Constraint == "voyages_vessel_id_fkey":
+		return &ValidationError{Message: "seçilmiş gəmi mövcud deyil"}
+	case pqErr.Code == foreignKeyViolation && pqErr.Constraint == "voyages_carrier_id_fkey":
+		return &ValidationError{Message: "seçilmiş daşıyıcı mövcud deyil"}
+	}
+
+	return err
+}
@@ -0,0 +1,105 @@
+package voyage
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/rbac"
+	"github.com/Zam83-AZE/logistics_system/internal/middleware"
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+	"github.com/Zam83-AZE/logistics_system/pkg/openapi"
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/gorilla/mux"
+	"github.com/jmoiron/sqlx"
+)
+
+// RegisterRoutes reys marşrutlarını qeydə alır
+func RegisterRoutes(router *mux.Router, db *sqlx.DB, tmpl *template.Template, sessionManager *session.Manager) {
+	repo := NewPostgresRepository(db)
+	service := NewVoyageService(repo, audit.NewRecorder(db))
+	handler := NewHandler(service, tmpl, sessionManager)
+
+	// İcazə yoxlamaları
+	canView := middleware.RequirePermission(rbac.SchedulesView)
+	canManage := middleware.RequirePermission(rbac.SchedulesManage)
+
+	// Siyahı və yaratma
+	router.Handle("/voyages", canView(http.HandlerFunc(handler.List))).Methods("GET")
+	router.Handle("/voyages/new", canManage(http.HandlerFunc(handler.New))).Methods("GET")
+	router.Handle("/voyages", canManage(http.HandlerFunc(handler.Create))).Methods("POST")
+
+	// Detallar və redaktə
+	router.Handle("/voyages/{id:[0-9]+}", canView(http.HandlerFunc(handler.Detail))).Methods("GET")
+	router.Handle("/voyages/{id:[0-9]+}/edit", canManage(http.HandlerFunc(handler.Edit))).Methods("GET")
+	router.Handle("/voyages/{id:[0-9]+}", canManage(http.HandlerFunc(handler.Update))).Methods("POST")
+
+	// Rotasiya və daşınmaların təyinatı
+	router.Handle("/voyages/{id:[0-9]+}/calls", canManage(http.HandlerFunc(handler.AddCall))).Methods("POST")
+	router.Handle("/voyages/{id:[0-9]+}/calls/{callId:[0-9]+}", canManage(http.HandlerFunc(handler.UpdateCall))).Methods("POST")
+	router.Handle("/voyages/{id:[0-9]+}/calls/{callId:[0-9]+}/delete", canManage(http.HandlerFunc(handler.DeleteCall))).Methods("POST")
+	router.Handle("/voyages/{id:[0-9]+}/shipments", canManage(http.HandlerFunc(handler.AddBooking))).Methods("POST")
+	router.Handle("/voyages/{id:[0-9]+}/shipments/{bookingId:[0-9]+}/delete", canManage(http.HandlerFunc(handler.DeleteBooking))).Methods("POST")
+}
+
+// RegisterAPIRoutes reys JSON API marşrutlarını qeydə alır
+func RegisterAPIRoutes(router *mux.Router, db *sqlx.DB) {
+	handler := NewAPIHandler(NewVoyageService(NewPostgresRepository(db), audit.NewRecorder(db)))
+
+	canView := middleware.RequirePermission(rbac.SchedulesView)
+	canManage := middleware.RequirePermission(rbac.SchedulesManage)
+
+	router.Handle("/voyages", canView(http.HandlerFunc(handler.List))).Methods("GET")
+	router.Handle("/voyages", canManage(http.HandlerFunc(handler.Create))).Methods("POST")
+	router.Handle("/voyages/{id:[0-9]+}", canView(http.HandlerFunc(handler.Get))).Methods("GET")
+	router.Handle("/voyages/{id:[0-9]+}", canManage(http.HandlerFunc(handler.Update))).Methods("PUT")
+	router.Handle("/voyages/{id:[0-9]+}/calls", canManage(http.HandlerFunc(handler.AddCall))).Methods("POST")
+	router.Handle("/voyages/{id:[0-9]+}/calls/{callId:[0-9]+}", canManage(http.HandlerFunc(handler.UpdateCall))).Methods("PUT")
+	router.Handle("/voyages/{id:[0-9]+}/calls/{callId:[0-9]+}", canManage(http.HandlerFunc(handler.DeleteCall))).Methods("DELETE")
+	router.Handle("/voyages/{id:[0-9]+}/shipments", canManage(http.HandlerFunc(handler.AddBooking))).Methods("POST")
+	router.Handle("/voyages/{id:[0-9]+}/shipments/{bookingId:[0-9]+}", canManage(http.HandlerFunc(handler.DeleteBooking))).Methods("DELETE")
+}
+
+// DescribeAPI reys API marşrutlarını OpenAPI sənədinə əlavə edir
+func DescribeAPI(spec *openapi.Spec) {
+	view := string(rbac.SchedulesView)
+	manage := string(rbac.SchedulesManage)
+
+	spec.Add("GET", "/voyages", openapi.Operation{
+		Summary: "Reys siyahısı", Tag: "voyages", Permission: view,
+		Query: []openapi.Param{
+			{Name: "q", Description: "Reys nömrəsi, daşıyıcı və ya gəmi adı üzrə axtarış"},
+			{Name: "carrierId", Type: "integer", Description: "Daşıyıcının ID-si"},
+			{Name: "vesselId", Type: "integer", Description: "Gəminin ID-si"},
+		},
+		List: true, Sort: sortColumns, Response: Voyage{},
+	})
+	spec.Add("POST", "/voyages", openapi.Operation{
+		Summary: "Reys yarat", Tag: "voyages", Permission: manage,
+		Request: VoyageRequest{}, Response: Voyage{}, Status: http.StatusCreated,
+	})
+	spec.Add("GET", "/voyages/{id}", openapi.Operation{
+		Summary: "Reys (rotasiya və təyin edilmiş daşınmalarla)", Tag: "voyages", Permission: view, Response: Voyage{},
+	})
+	spec.Add("PUT", "/voyages/{id}", openapi.Operation{
+		Summary: "Reysi yenilə", Tag: "voyages", Permission: manage,
+		Request: VoyageRequest{}, Response: Voyage{},
+	})
+	spec.Add("POST", "/voyages/{id}/calls", openapi.Operation{
+		Summary: "Rotasiyaya məntəqə əlavə et", Tag: "voyages", Permission: manage,
+		Request: CallRequest{}, Response: Call{}, Status: http.StatusCreated,
+	})
+	spec.Add("PUT", "/voyages/{id}/calls/{callId}", openapi.Operation{
+		Summary: "Məntəqənin ETA/ETD vaxtlarını dəyiş (daşınmalara ötürülür)", Tag: "voyages", Permission: manage,
+		Request: ScheduleRequest{}, Response: Call{},
+	})
+	spec.Add("DELETE", "/voyages/{id}/calls/{callId}", openapi.Operation{
+		Summary: "Rotasiyadan məntəqəni sil", Tag: "voyages", Permission: manage, Status: http.StatusNoContent,
+	})
+	spec.Add("POST", "/voyages/{id}/shipments", openapi.Operation{
+		Summary: "Daşınmanı reysə təyin et", Tag: "voyages", Permission: manage,
+		Request: BookingRequest{}, Response: Booking{}, Status: http.StatusCreated,
+	})
+	spec.Add("DELETE", "/voyages/{id}/shipments/{bookingId}", openapi.Operation{
+		Summary: "Daşınmanın reysə təyinatını sil", Tag: "voyages", Permission: manage, Status: http.StatusNoContent,
+	})
+}
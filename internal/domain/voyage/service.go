@@ -0,0 +1,605 @@
+package voyage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+)
+
+const (
+	defaultPerPage = 20
+
+	// maxVoyageNumberLength reys nömrəsinin maksimal uzunluğudur
+	maxVoyageNumberLength = 20
+)
+
+// Xətalar
+var (
+	ErrNotFound        = errors.New("reys tapılmadı")
+	ErrCallNotFound    = errors.New("reys məntəqəsi tapılmadı")
+	ErrBookingNotFound = errors.New("daşınmanın reysə təyinatı tapılmadı")
+)
+
+// ValidationError istifadəçiyə göstəriləcək form xətasını təmsil edir
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// Service reys biznes məntiqini müəyyən edir
+type Service interface {
+	List(ctx context.Context, filter ListFilter) (*VoyageList, error)
+	Get(ctx context.Context, id int) (*Voyage, error)
+	Create(ctx context.Context, form VoyageForm) (*Voyage, error)
+	Update(ctx context.Context, id int, form VoyageForm) (*Voyage, error)
+
+	AddCall(ctx context.Context, voyageID int, form CallForm) (*Call, error)
+	UpdateCall(ctx context.Context, voyageID, callID int, form ScheduleForm) (*Call, error)
+	DeleteCall(ctx context.Context, voyageID, callID int) error
+
+	AddBooking(ctx context.Context, voyageID int, form BookingForm) (*Booking, error)
+	DeleteBooking(ctx context.Context, voyageID, bookingID int) error
+
+	CarrierOptions(ctx context.Context) ([]CarrierOption, error)
+	VesselOptions(ctx context.Context) ([]VesselOption, error)
+}
+
+// VoyageService Service interfeysini həyata keçirir
+type VoyageService struct {
+	repo  Repository
+	audit audit.Recorder
+}
+
+// NewVoyageService yeni VoyageService yaradır
+func NewVoyageService(repo Repository, recorder audit.Recorder) *VoyageService {
+	return &VoyageService{repo: repo, audit: recorder}
+}
+
+// List filtrə uyğun səhifələnmiş reys siyahısını qaytarır
+func (s *VoyageService) List(ctx context.Context, filter ListFilter) (*VoyageList, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PerPage < 1 || filter.PerPage > 100 {
+		filter.PerPage = defaultPerPage
+	}
+
+	voyages, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VoyageList{
+		Items:   voyages,
+		Total:   total,
+		Page:    filter.Page,
+		PerPage: filter.PerPage,
+	}, nil
+}
+
+// Get reysi məntəqələri və təyin edilmiş daşınmaları ilə birlikdə qaytarır
+func (s *VoyageService) Get(ctx context.Context, id int) (*Voyage, error) {
+	voyage, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if voyage.Calls, err = s.repo.Calls(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if voyage.Bookings, err = s.repo.Bookings(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return voyage, nil
+}
+
+// Create formdakı məlumatlarla yeni reys yaradır
+func (s *VoyageService) Create(ctx context.Context, form VoyageForm) (*Voyage, error) {
+	voyage := &Voyage{}
+	if err := applyForm(voyage, form); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, voyage); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "voyage.created", voyage.ID, nil, voyage, nil)
+
+	return voyage, nil
+}
+
+// Update mövcud reysin məlumatlarını formdakı dəyərlərlə yeniləyir
+func (s *VoyageService) Update(ctx context.Context, id int, form VoyageForm) (*Voyage, error) {
+	voyage, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *voyage
+	if err := applyForm(voyage, form); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, voyage); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "voyage.updated", voyage.ID, &before, voyage, nil)
+
+	return voyage, nil
+}
+
+// AddCall reysin rotasiyasının sonuna yeni məntəqə əlavə edir
+func (s *VoyageService) AddCall(ctx context.Context, voyageID int, form CallForm) (*Call, error) {
+	if _, err := s.get(ctx, voyageID); err != nil {
+		return nil, err
+	}
+
+	location, err := s.findLocation(ctx, form)
+	if err != nil {
+		return nil, err
+	}
+
+	call := &Call{VoyageID: voyageID, LocationID: location.ID, LocationName: location.Name}
+	if err := applySchedule(call, ScheduleForm{ETA: form.ETA, ETD: form.ETD}); err != nil {
+		return nil, err
+	}
+
+	calls, err := s.repo.Calls(ctx, voyageID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRotation(append(calls, *call)); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.AddCall(ctx, call); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "voyage.call_added", voyageID, nil, nil, map[string]interface{}{
+		"callId":   call.ID,
+		"seq":      call.Seq,
+		"location": call.LocationName,
+		"eta":      call.ETA,
+		"etd":      call.ETD,
+	})
+
+	return call, nil
+}
+
+// UpdateCall reys məntəqəsinin ETA və ETD vaxtlarını dəyişir və dəyişikliyi
+// bu reysə təyin edilmiş daşınmaların vaxtlarına ötürür
+func (s *VoyageService) UpdateCall(ctx context.Context, voyageID, callID int, form ScheduleForm) (*Call, error) {
+	calls, err := s.repo.Calls(ctx, voyageID)
+	if err != nil {
+		return nil, err
+	}
+
+	index := -1
+	for i := range calls {
+		if calls[i].ID == callID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, ErrCallNotFound
+	}
+
+	call := calls[index]
+	before := map[string]interface{}{"eta": call.ETA, "etd": call.ETD}
+	if err := applySchedule(&call, form); err != nil {
+		return nil, err
+	}
+
+	calls[index] = call
+	if err := validateRotation(calls); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateCall(ctx, &call); err != nil {
+		return nil, err
+	}
+
+	after := map[string]interface{}{"eta": call.ETA, "etd": call.ETD}
+	s.record(ctx, "voyage.call_updated", voyageID, before, after, map[string]interface{}{
+		"callId":   call.ID,
+		"location": call.LocationName,
+	})
+
+	bookings, err := s.repo.Bookings(ctx, voyageID)
+	if err != nil {
+		return nil, err
+	}
+
+	shipmentIDs := make([]int, 0, len(bookings))
+	for _, booking := range bookings {
+		shipmentIDs = append(shipmentIDs, booking.ShipmentID)
+	}
+
+	if err := s.propagate(ctx, voyageID, shipmentIDs); err != nil {
+		return nil, err
+	}
+
+	return &call, nil
+}
+
+// DeleteCall reys məntəqəsini silir; daşınma təyin edilmiş məntəqə silinə bilməz
+func (s *VoyageService) DeleteCall(ctx context.Context, voyageID, callID int) error {
+	calls, err := s.repo.Calls(ctx, voyageID)
+	if err != nil {
+		return err
+	}
+
+	var call *Call
+	for i := range calls {
+		if calls[i].ID == callID {
+			call = &calls[i]
+			break
+		}
+	}
+	if call == nil {
+		return ErrCallNotFound
+	}
+
+	if err := s.repo.DeleteCall(ctx, voyageID, callID); err != nil {
+		return err
+	}
+
+	s.record(ctx, "voyage.call_deleted", voyageID, nil, nil, map[string]interface{}{
+		"callId":   call.ID,
+		"seq":      call.Seq,
+		"location": call.LocationName,
+	})
+
+	return nil
+}
+
+// AddBooking daşınmanı reysin yükləmə və boşaltma məntəqələri arasındakı hissəsinə təyin edir
+// və daşınmanın ETD/ETA vaxtlarını reysin cədvəlinə uyğunlaşdırır
+func (s *VoyageService) AddBooking(ctx context.Context, voyageID int, form BookingForm) (*Booking, error) {
+	if _, err := s.get(ctx, voyageID); err != nil {
+		return nil, err
+	}
+
+	ref, err := s.findShipment(ctx, form)
+	if err != nil {
+		return nil, err
+	}
+
+	calls, err := s.repo.Calls(ctx, voyageID)
+	if err != nil {
+		return nil, err
+	}
+
+	load := findCall(calls, form.LoadCallID)
+	if load == nil {
+		return nil, &ValidationError{Message: "yükləmə məntəqəsi seçilməlidir"}
+	}
+
+	discharge := findCall(calls, form.DischargeCallID)
+	if discharge == nil {
+		return nil, &ValidationError{Message: "boşaltma məntəqəsi seçilməlidir"}
+	}
+
+	if discharge.Seq <= load.Seq {
+		return nil, &ValidationError{Message: "boşaltma məntəqəsi rotasiyada yükləmə məntəqəsindən sonra olmalıdır"}
+	}
+
+	booking := &Booking{
+		VoyageID:          voyageID,
+		ShipmentID:        ref.ID,
+		ShipmentReference: ref.Reference,
+		ShipmentStatus:    ref.Status,
+		LoadCallID:        load.ID,
+		LoadLocation:      load.LocationName,
+		LoadETD:           load.ETD,
+		DischargeCallID:   discharge.ID,
+		DischargeLocation: discharge.LocationName,
+		DischargeETA:      discharge.ETA,
+	}
+	if err := s.repo.AddBooking(ctx, booking); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "voyage.shipment_assigned", voyageID, nil, nil, map[string]interface{}{
+		"shipmentId": booking.ShipmentID,
+		"shipment":   booking.ShipmentReference,
+		"load":       booking.LoadLocation,
+		"discharge":  booking.DischargeLocation,
+	})
+
+	if err := s.propagate(ctx, voyageID, []int{booking.ShipmentID}); err != nil {
+		return nil, err
+	}
+
+	return booking, nil
+}
+
+// DeleteBooking daşınmanın reysə təyinatını silir; daşınmanın digər reyslər üzrə vaxtları yenidən hesablanır
+func (s *VoyageService) DeleteBooking(ctx context.Context, voyageID, bookingID int) error {
+	bookings, err := s.repo.Bookings(ctx, voyageID)
+	if err != nil {
+		return err
+	}
+
+	var booking *Booking
+	for i := range bookings {
+		if bookings[i].ID == bookingID {
+			booking = &bookings[i]
+			break
+		}
+	}
+	if booking == nil {
+		return ErrBookingNotFound
+	}
+
+	if err := s.repo.DeleteBooking(ctx, voyageID, bookingID); err != nil {
+		return err
+	}
+
+	s.record(ctx, "voyage.shipment_unassigned", voyageID, nil, nil, map[string]interface{}{
+		"shipmentId": booking.ShipmentID,
+		"shipment":   booking.ShipmentReference,
+	})
+
+	return s.propagate(ctx, voyageID, []int{booking.ShipmentID})
+}
+
+// CarrierOptions reys formunda seçilə bilən daşıyıcıları qaytarır
+func (s *VoyageService) CarrierOptions(ctx context.Context) ([]CarrierOption, error) {
+	return s.repo.CarrierOptions(ctx)
+}
+
+// VesselOptions reys formunda seçilə bilən gəmiləri qaytarır
+func (s *VoyageService) VesselOptions(ctx context.Context) ([]VesselOption, error) {
+	return s.repo.VesselOptions(ctx)
+}
+
+// get reysi məntəqələri olmadan qaytarır
+func (s *VoyageService) get(ctx context.Context, id int) (*Voyage, error) {
+	voyage, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if voyage == nil {
+		return nil, ErrNotFound
+	}
+
+	return voyage, nil
+}
+
+// propagate reys cədvəlindəki dəyişikliyi daşınmaların ETD/ETA vaxtlarına ötürür
+// və hər dəyişmiş daşınma üçün audit jurnalına qeyd yazır
+func (s *VoyageService) propagate(ctx context.Context, voyageID int, shipmentIDs []int) error {
+	changes, err := s.repo.PropagateSchedule(ctx, shipmentIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		s.audit.Record(ctx, audit.Event{
+			Action:     "shipment.schedule_updated",
+			EntityType: "shipment",
+			EntityID:   strconv.Itoa(change.ShipmentID),
+			Before:     map[string]interface{}{"etd": change.OldETD, "eta": change.OldETA},
+			After:      map[string]interface{}{"etd": change.ETD, "eta": change.ETA},
+			Details:    map[string]interface{}{"voyageId": voyageID},
+		})
+	}
+
+	return nil
+}
+
+// findLocation formda ID və ya UN/LOCODE ilə göstərilmiş aktiv məntəqəni tapır
+func (s *VoyageService) findLocation(ctx context.Context, form CallForm) (*LocationRef, error) {
+	var id int
+	if value := strings.TrimSpace(form.LocationID); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			return nil, &ValidationError{Message: "məntəqə yanlış göstərilib"}
+		}
+		id = parsed
+	}
+
+	unlocode := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(form.UNLOCODE), " ", ""))
+	if id == 0 && unlocode == "" {
+		return nil, &ValidationError{Message: "məntəqə (UN/LOCODE) göstərilməlidir"}
+	}
+
+	location, err := s.repo.FindLocation(ctx, id, unlocode)
+	if err != nil {
+		return nil, err
+	}
+
+	if location == nil {
+		return nil, &ValidationError{Message: "məntəqə tapılmadı və ya deaktivdir"}
+	}
+
+	return location, nil
+}
+
+// findShipment formda ID və ya istinad nömrəsi ilə göstərilmiş daşınmanı tapır və onun reysə təyin oluna bilməsini yoxlayır
+func (s *VoyageService) findShipment(ctx context.Context, form BookingForm) (*ShipmentRef, error) {
+	var id int
+	if value := strings.TrimSpace(form.ShipmentID); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			return nil, &ValidationError{Message: "daşınma yanlış göstərilib"}
+		}
+		id = parsed
+	}
+
+	reference := strings.TrimSpace(form.Shipment)
+	if id == 0 && reference == "" {
+		return nil, &ValidationError{Message: "daşınmanın istinad nömrəsi göstərilməlidir"}
+	}
+
+	ref, err := s.repo.FindShipment(ctx, id, reference)
+	if err != nil {
+		return nil, err
+	}
+
+	if ref == nil {
+		return nil, &ValidationError{Message: "daşınma tapılmadı"}
+	}
+
+	if ref.Status.IsTerminal() {
+		return nil, &ValidationError{Message: "tamamlanmış və ya ləğv edilmiş daşınma reysə təyin edilə bilməz"}
+	}
+
+	return ref, nil
+}
+
+// record reys üzərində əməliyyatı audit jurnalına yazır
+func (s *VoyageService) record(ctx context.Context, action string, id int, before, after interface{}, details map[string]interface{}) {
+	s.audit.Record(ctx, audit.Event{
+		Action:     action,
+		EntityType: "voyage",
+		EntityID:   strconv.Itoa(id),
+		Before:     before,
+		After:      after,
+		Details:    details,
+	})
+}
+
+// applyForm formu yoxlayır və dəyərləri reys obyektinə köçürür
+func applyForm(voyage *Voyage, form VoyageForm) error {
+	carrierID, err := strconv.Atoi(strings.TrimSpace(form.CarrierID))
+	if err != nil || carrierID <= 0 {
+		return &ValidationError{Message: "daşıyıcı seçilməlidir"}
+	}
+
+	var vesselID *int
+	if value := strings.TrimSpace(form.VesselID); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil || id <= 0 {
+			return &ValidationError{Message: "gəmi yanlış seçilib"}
+		}
+		vesselID = &id
+	}
+
+	number := strings.ToUpper(strings.TrimSpace(form.VoyageNumber))
+	if number == "" {
+		return &ValidationError{Message: "reys nömrəsi tələb olunur"}
+	}
+	if len([]rune(number)) > maxVoyageNumberLength {
+		return &ValidationError{Message: fmt.Sprintf("reys nömrəsi %d simvoldan uzun ola bilməz", maxVoyageNumberLength)}
+	}
+
+	voyage.CarrierID = carrierID
+	voyage.VesselID = vesselID
+	voyage.VoyageNumber = number
+	voyage.Notes = strings.TrimSpace(form.Notes)
+
+	return nil
+}
+
+// applySchedule formdakı ETA və ETD vaxtlarını yoxlayır və məntəqəyə köçürür
+func applySchedule(call *Call, form ScheduleForm) error {
+	eta, err := parseDateTime(form.ETA)
+	if err != nil {
+		return &ValidationError{Message: "gəlmə vaxtı (ETA) yanlış formatdadır"}
+	}
+
+	etd, err := parseDateTime(form.ETD)
+	if err != nil {
+		return &ValidationError{Message: "çıxış vaxtı (ETD) yanlış formatdadır"}
+	}
+
+	call.ETA = eta
+	call.ETD = etd
+
+	return nil
+}
+
+// validateRotation məntəqələrin vaxtlarının ardıcıl olduğunu yoxlayır: hər məntəqədə ETD ETA-dan
+// əvvəl ola bilməz və növbəti məntəqəyə əvvəlkindən çıxmazdan əvvəl çatmaq mümkün deyil.
+// Vaxtı hələ məlum olmayan məntəqələr yoxlamada nəzərə alınmır.
+func validateRotation(calls []Call) error {
+	var (
+		last     *time.Time
+		lastName string
+	)
+
+	for _, call := range calls {
+		if call.ETA != nil && call.ETD != nil && call.ETD.Before(*call.ETA) {
+			return &ValidationError{Message: fmt.Sprintf("%s: çıxış vaxtı (ETD) gəlmə vaxtından (ETA) əvvəl ola bilməz", call.LocationName)}
+		}
+
+		first := call.ETA
+		if first == nil {
+			first = call.ETD
+		}
+		if first != nil && last != nil && first.Before(*last) {
+			return &ValidationError{Message: fmt.Sprintf("%s: vaxt əvvəlki məntəqədən (%s) çıxış vaxtından əvvəl ola bilməz", call.LocationName, lastName)}
+		}
+
+		if call.ETD != nil {
+			last, lastName = call.ETD, call.LocationName
+		} else if call.ETA != nil {
+			last, lastName = call.ETA, call.LocationName
+		}
+	}
+
+	return nil
+}
+
+// findCall formda seçilmiş məntəqəni reysin məntəqələri arasında tapır
+func findCall(calls []Call, value string) *Call {
+	id, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return nil
+	}
+
+	for i := range calls {
+		if calls[i].ID == id {
+			return &calls[i]
+		}
+	}
+
+	return nil
+}
+
+// parseDateTime datetime-local formatındakı vaxtı oxuyur; boş dəyər nil qaytarır
+func parseDateTime(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.ParseInLocation(dateTimeLayout, value, time.Local)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// formatDateTime vaxtı datetime-local sahəsi üçün formatlayır
+func formatDateTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.In(time.Local).Format(dateTimeLayout)
+}
+
+func itoa(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+)
+
+// Audit sorğunun icraçısını, IP ünvanını və identifikatorunu audit yazıları üçün kontekstə əlavə edir.
+// API tokeni ilə gələn sorğularda icraçı sonradan TokenAuth tərəfindən təyin edilir.
+func Audit(sessionManager *session.Manager) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := audit.WithSource(r.Context(), audit.Source{
+				UserID:    sessionManager.GetUserID(r),
+				IP:        ClientIP(r),
+				RequestID: RequestIDFromContext(r.Context()),
+			})
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
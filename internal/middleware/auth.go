@@ -2,16 +2,24 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
 
+	"github.com/Zam83-AZE/logistics_system/pkg/api"
 	"github.com/Zam83-AZE/logistics_system/pkg/session"
 )
 
-// RequireAuth istifadəçi girişini tələb edən middleware
+// RequireAuth istifadəçi girişini tələb edən middleware.
+// API sorğuları giriş səhifəsinə yönləndirilmir, 401 cavabı alır.
 func RequireAuth(sessionManager *session.Manager) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// İstifadəçi girişini yoxla
 			if !sessionManager.IsAuthenticated(r) {
+				if api.IsRequest(r) {
+					w.Header().Set("WWW-Authenticate", "Bearer")
+					api.WriteError(w, api.NewError(http.StatusUnauthorized, api.CodeUnauthorized, "Autentifikasiya tələb olunur"))
+					return
+				}
 				http.Redirect(w, r, "/login", http.StatusFound)
 				return
 			}
@@ -20,3 +28,43 @@ func RequireAuth(sessionManager *session.Manager) func(http.Handler) http.Handle
 		})
 	}
 }
+
+// RequireTwoFactorEnrollment rolu iki faktorlu autentifikasiya tələb edən, lakin onu hələ qurmamış
+// istifadəçiləri setupPath səhifəsinə yönləndirir
+func RequireTwoFactorEnrollment(sessionManager *session.Manager, setupPath string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if sessionManager.TwoFactorEnrollmentRequired(r) && !strings.HasPrefix(r.URL.Path, setupPath) {
+				if api.IsRequest(r) {
+					api.WriteError(w, api.NewError(http.StatusForbidden, api.CodeForbidden,
+						"Davam etmək üçün iki faktorlu autentifikasiyanı qurun"))
+					return
+				}
+				http.Redirect(w, r, setupPath, http.StatusFound)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequirePasswordChange administratorun tələbi ilə şifrəsini dəyişməli olan istifadəçiləri
+// changePath səhifəsinə yönləndirir
+func RequirePasswordChange(sessionManager *session.Manager, changePath string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if sessionManager.PasswordChangeRequired(r) && !strings.HasPrefix(r.URL.Path, changePath) {
+				if api.IsRequest(r) {
+					api.WriteError(w, api.NewError(http.StatusForbidden, api.CodeForbidden,
+						"Davam etmək üçün şifrənizi dəyişin"))
+					return
+				}
+				http.Redirect(w, r, changePath, http.StatusFound)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
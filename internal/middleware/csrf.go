@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/api"
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/Zam83-AZE/logistics_system/pkg/view"
+)
+
+const (
+	// CSRFField formlardakı gizli sahənin adıdır
+	CSRFField = "csrf_token"
+	// CSRFHeader JavaScript və kuki sessiyası ilə işləyən API müştərilərinin tokeni göndərdiyi başlıqdır
+	CSRFHeader = "X-CSRF-Token"
+)
+
+// CSRF vəziyyəti dəyişən sorğuları (POST, PUT, PATCH, DELETE) sessiyaya bağlı token ilə qoruyur.
+// Token formda csrf_token sahəsində və ya X-CSRF-Token başlığında göndərilməlidir.
+// Şablonlara {{csrfField}} (gizli sahə) və {{csrfToken}} funksiyaları əlavə edilir.
+// Yalnız sessiya kukisi olmayan "Authorization: Bearer" sorğuları yoxlanılmır: brauzer Bearer başlığını
+// başqa saytdan avtomatik göndərmir, kuki olmadıqda isə sorğu yalnız TokenAuth-un yoxladığı token ilə
+// autentifikasiya oluna bilər. Brauzerin özü göndərdiyi "Basic" başlığı yoxlamanı söndürmür.
+func CSRF(sessionManager *session.Manager) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if bearerOnly(r, sessionManager) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			var token string
+			if safeMethod(r.Method) {
+				// Token yalnız HTML səhifələr üçün yaradılır ki, statik fayllar və
+				// monitorinq sorğuları boş sessiyalar yaratmasın
+				if acceptsHTML(r) {
+					var err error
+					if token, err = sessionManager.CSRFToken(w, r); err != nil {
+						http.Error(w, "Sessiya xətası", http.StatusInternalServerError)
+						return
+					}
+				} else {
+					token = sessionManager.StoredCSRFToken(r)
+				}
+			} else {
+				token = sessionManager.StoredCSRFToken(r)
+				if !validCSRF(token, submittedCSRF(r)) {
+					fail(w, r, http.StatusForbidden, api.CodeForbidden,
+						"CSRF tokeni etibarsızdır və ya vaxtı keçib. Səhifəni yeniləyib yenidən cəhd edin")
+					return
+				}
+			}
+
+			ctx := view.WithFuncs(r.Context(), template.FuncMap{
+				"csrfToken": func() string { return token },
+				"csrfField": func() template.HTML {
+					return template.HTML(`<input type="hidden" name="` + CSRFField + `" value="` +
+						template.HTMLEscapeString(token) + `">`)
+				},
+			})
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// bearerOnly sorğunun kukisiz, yalnız Bearer tokeni ilə göndərildiyini göstərir
+func bearerOnly(r *http.Request, sessionManager *session.Manager) bool {
+	scheme, _, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+	return strings.EqualFold(scheme, "Bearer") && !sessionManager.HasCookie(r)
+}
+
+// safeMethod metodun serverdə vəziyyəti dəyişmədiyini göstərir
+func safeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+// acceptsHTML sorğunun brauzer səhifə keçidi olub-olmadığını Accept başlığına görə müəyyən edir
+func acceptsHTML(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/html")
+}
+
+// submittedCSRF sorğu ilə göndərilmiş tokeni başlıqdan və ya form sahəsindən oxuyur
+func submittedCSRF(r *http.Request) string {
+	if token := r.Header.Get(CSRFHeader); token != "" {
+		return token
+	}
+	return r.PostFormValue(CSRFField)
+}
+
+// validCSRF tokenləri sabit müddətli müqayisə ilə yoxlayır
+func validCSRF(expected, submitted string) bool {
+	if expected == "" || submitted == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(submitted)) == 1
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/gorilla/sessions"
+)
+
+// newCSRFHandler CSRF ilə qorunan və token tələb olunmayan halda 200 qaytaran işləyici yaradır
+func newCSRFHandler() (http.Handler, *session.Manager) {
+	manager := session.NewManager(sessions.NewCookieStore([]byte("0123456789abcdef0123456789abcdef")))
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return manager.Middleware(CSRF(manager)(ok)), manager
+}
+
+// sessionWithToken HTML səhifə sorğusu ilə sessiya kukisi və onun CSRF tokenini alır
+func sessionWithToken(t *testing.T, handler http.Handler, manager *session.Manager) (*http.Cookie, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "text/html")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	cookies := rec.Result().Cookies()
+	if len(cookies) == 0 {
+		t.Fatal("sessiya kukisi yaradılmadı")
+	}
+
+	check := httptest.NewRequest(http.MethodGet, "/", nil)
+	check.AddCookie(cookies[0])
+	token := manager.StoredCSRFToken(check)
+	if token == "" {
+		t.Fatal("sessiyada CSRF tokeni yoxdur")
+	}
+
+	return cookies[0], token
+}
+
+// TestCSRF yoxlamanın yalnız kukisiz Bearer sorğuları üçün ötürüldüyünü yoxlayır
+func TestCSRF(t *testing.T) {
+	handler, manager := newCSRFHandler()
+	cookie, token := sessionWithToken(t, handler, manager)
+
+	tests := []struct {
+		name          string
+		authorization string
+		cookie        bool
+		token         string
+		want          int
+	}{
+		{name: "Bearer tokeni, kuki yoxdur", authorization: "Bearer lgs_abc", want: http.StatusOK},
+		{name: "başlıq və token yoxdur", want: http.StatusForbidden},
+		{name: "Basic başlığı", authorization: "Basic dXNlcjpwYXNz", want: http.StatusForbidden},
+		{name: "kuki və Bearer başlığı", authorization: "Bearer lgs_abc", cookie: true, want: http.StatusForbidden},
+		{name: "kuki və Bearer başlığı, etibarlı token", authorization: "Bearer lgs_abc", cookie: true, token: token, want: http.StatusOK},
+		{name: "kuki, yanlış token", cookie: true, token: "yanlış", want: http.StatusForbidden},
+		{name: "kuki, etibarlı token", cookie: true, token: token, want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := url.Values{}
+			if tt.token != "" {
+				form.Set(CSRFField, tt.token)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/logout", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.cookie {
+				req.AddCookie(cookie)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, gözlənilən %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
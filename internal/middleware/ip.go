@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+)
+
+// ClientIP sorğunun gəldiyi IP ünvanını portsuz qaytarır.
+// Loglama, audit, sorğu limiti, giriş məhdudiyyəti və tokenin son istifadəsi eyni ünvanı görsün deyə
+// bütün yerlərdə bu funksiya istifadə olunur.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
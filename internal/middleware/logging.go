@@ -4,26 +4,87 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/Zam83-AZE/logistics_system/pkg/logger"
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
 	"github.com/sirupsen/logrus"
 )
 
-// Logging HTTP sorğularını loglamaq üçün middleware
-func Logging(logger *logrus.Logger) func(http.Handler) http.Handler {
+// Logging HTTP sorğularını loglamaq üçün middleware.
+// Sorğu identifikatoru və istifadəçi ID-si ilə zənginləşdirilmiş logger kontekstə əlavə edilir
+// (logger.FromContext); RequestID və sessiya middleware-lərindən sonra qoşulmalıdır.
+func Logging(log *logrus.Logger, sessionManager *session.Manager) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
 
+			fields := logrus.Fields{"request_id": RequestIDFromContext(r.Context())}
+			if userID := sessionManager.GetUserID(r); userID > 0 {
+				fields["user_id"] = userID
+			}
+			ctx := logger.WithContext(r.Context(), log.WithFields(fields))
+
 			// Sorğunu emal et
-			next.ServeHTTP(w, r)
+			rec := &statusRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r.WithContext(ctx))
 
 			// Sorğu məlumatlarını logla
-			logger.WithFields(logrus.Fields{
+			entry := logger.FromContext(ctx).WithFields(logrus.Fields{
 				"method":     r.Method,
 				"path":       r.URL.Path,
-				"remote_ip":  r.RemoteAddr,
+				"status":     rec.Status(),
+				"bytes":      rec.bytes,
+				"remote_ip":  ClientIP(r),
 				"user_agent": r.UserAgent(),
 				"duration":   time.Since(start),
-			}).Info("HTTP request")
+			})
+			if rec.Status() >= http.StatusInternalServerError {
+				entry.Error("HTTP request")
+				return
+			}
+			entry.Info("HTTP request")
 		})
 	}
 }
+
+// statusRecorder cavabın status kodunu və yazılmış baytların sayını yadda saxlayır
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += int64(n)
+	return n, err
+}
+
+// Status cavabın status kodunu qaytarır; heç nə yazılmayıbsa 200 qaytarılır
+func (r *statusRecorder) Status() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+// Flush axınla göndərilən cavabların (məs. CSV ixracı) ötürülməsi üçün əsas yazıcıya ötürülür
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap http.ResponseController-in əsas yazıcıya çatmasına imkan verir
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/metrics"
+	"github.com/gorilla/mux"
+)
+
+// Metrics HTTP sorğularının sayını və müddətini marşrut şablonu, metod və status üzrə toplayır.
+// Etiketdə konkret yol deyil, mux şablonu (məs. /customers/{id:[0-9]+}) istifadə olunur ki,
+// seriyaların sayı ID-lərlə artmasın.
+func Metrics(registry *metrics.Registry) func(http.Handler) http.Handler {
+	requests := registry.NewCounter("logistics_http_requests_total",
+		"HTTP sorğularının sayı", "method", "route", "status")
+	duration := registry.NewHistogram("logistics_http_request_duration_seconds",
+		"HTTP sorğularının emal müddəti (saniyə)", metrics.DefBuckets, "method", "route", "status")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			rec := &statusRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r)
+
+			route := "unmatched"
+			if current := mux.CurrentRoute(r); current != nil {
+				if template, err := current.GetPathTemplate(); err == nil {
+					route = template
+				}
+			}
+			status := strconv.Itoa(rec.Status())
+
+			requests.Inc(r.Method, route, status)
+			duration.Observe(time.Since(start).Seconds(), r.Method, route, status)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/metrics"
+	"github.com/gorilla/mux"
+)
+
+// TestMetricsRouteLabel route etiketinin konkret yol deyil, mux şablonu olduğunu yoxlayır
+func TestMetricsRouteLabel(t *testing.T) {
+	registry := metrics.NewRegistry()
+
+	router := mux.NewRouter()
+	router.Use(Metrics(registry))
+	api := router.PathPrefix("/api/v1").Subrouter()
+	api.HandleFunc("/customers/{id:[0-9]+}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}).Methods("GET")
+
+	for _, path := range []string{"/api/v1/customers/1", "/api/v1/customers/42"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	var buf strings.Builder
+	if err := registry.Write(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	want := `logistics_http_requests_total{method="GET",route="/api/v1/customers/{id:[0-9]+}",status="204"} 2`
+	if !strings.Contains(out, want) {
+		t.Errorf("çıxışda %q yoxdur:\n%s", want, out)
+	}
+	if strings.Contains(out, "/customers/42") {
+		t.Errorf("etiketdə konkret yol istifadə olundu:\n%s", out)
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/logger"
+	"github.com/Zam83-AZE/logistics_system/pkg/ratelimit"
+)
+
+// RateLimit sorğuları IP ünvanı üzrə məhdudlaşdırır; limit aşıldıqda 429 və Retry-After qaytarır
+func RateLimit(limiter *ratelimit.Limiter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := ClientIP(r)
+			if ok, retryAfter := limiter.Allow(ip); !ok {
+				logger.FromContext(r.Context()).WithField("ip", ip).Warn("Sorğu limiti aşıldı")
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				http.Error(w, "Çox sayda sorğu göndərildi, bir az sonra yenidən cəhd edin", http.StatusTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/apitoken"
+	"github.com/Zam83-AZE/logistics_system/internal/domain/rbac"
+	"github.com/Zam83-AZE/logistics_system/pkg/api"
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/Zam83-AZE/logistics_system/pkg/view"
+)
+
+// LoadPermissions giriş etmiş istifadəçinin icazələrini kontekstə və şablonların "can" funksiyasına əlavə edir
+func LoadPermissions(sessionManager *session.Manager, service rbac.Service) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			permissions, err := service.Permissions(r.Context(), sessionManager.GetUserID(r))
+			if err != nil {
+				fail(w, r, http.StatusInternalServerError, api.CodeInternal, "İcazələr yüklənərkən xəta baş verdi")
+				return
+			}
+
+			// Token ilə gələn sorğular yalnız tokenin əhatə dairəsindəki icazələrə malikdir
+			if token := apitoken.FromContext(r.Context()); token != nil {
+				permissions = permissions.Restrict(token.Permissions())
+			}
+
+			ctx := rbac.WithPermissions(r.Context(), permissions)
+			ctx = view.WithFuncs(ctx, template.FuncMap{"can": permissions.Can})
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequirePermission marşrut üçün verilmiş icazəni tələb edən middleware
+func RequirePermission(permission rbac.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !rbac.FromContext(r.Context()).Has(permission) {
+				fail(w, r, http.StatusForbidden, api.CodeForbidden, "Bu əməliyyat üçün icazəniz yoxdur")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// fail xətanı API sorğuları üçün JSON zərfində, digər sorğular üçün mətn kimi yazır
+func fail(w http.ResponseWriter, r *http.Request, status int, code, message string) {
+	if api.IsRequest(r) {
+		api.WriteError(w, api.NewError(status, code, message))
+		return
+	}
+	http.Error(w, message, status)
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// RequestIDHeader sorğu identifikatorunun ötürüldüyü başlıqdır
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength qəbul edilən identifikatorun maksimal uzunluğudur (audit_events.request_id)
+const maxRequestIDLength = 64
+
+type requestIDKey struct{}
+
+// RequestID hər sorğuya identifikator təyin edir və onu cavab başlığında qaytarır.
+// Proksinin göndərdiyi X-Request-ID düzgündürsə saxlanılır, əks halda yenisi yaradılır.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+
+		w.Header().Set(RequestIDHeader, id)
+		ctx := context.WithValue(r.Context(), requestIDKey{}, id)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequestIDFromContext sorğunun identifikatorunu qaytarır; yoxdursa boş sətir qaytarır
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// validRequestID identifikatorun yalnız hərf, rəqəm və "-", "_", "." simvollarından ibarət olduğunu yoxlayır
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_', c == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/apitoken"
+	"github.com/Zam83-AZE/logistics_system/pkg/api"
+	"github.com/Zam83-AZE/logistics_system/pkg/audit"
+	"github.com/Zam83-AZE/logistics_system/pkg/logger"
+	"github.com/Zam83-AZE/logistics_system/pkg/session"
+	"github.com/sirupsen/logrus"
+)
+
+// TokenAuth "Authorization: Bearer" başlığındakı API tokenini yoxlayır və istifadəçini kontekstə əlavə edir.
+// Başlıq olmadıqda sorğu dəyişmədən ötürülür və kuki sessiyası ilə autentifikasiya davam edir.
+func TokenAuth(service apitoken.Service) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			if header == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			scheme, raw, ok := strings.Cut(header, " ")
+			if !ok || !strings.EqualFold(scheme, "Bearer") {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_request"`)
+				fail(w, r, http.StatusUnauthorized, api.CodeUnauthorized, "Authorization başlığı yanlışdır")
+				return
+			}
+
+			token, err := service.Authenticate(r.Context(), strings.TrimSpace(raw), ClientIP(r))
+			if err != nil {
+				if errors.Is(err, apitoken.ErrInvalidToken) {
+					w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+					fail(w, r, http.StatusUnauthorized, api.CodeUnauthorized, err.Error())
+					return
+				}
+				fail(w, r, http.StatusInternalServerError, api.CodeInternal, "Token yoxlanılarkən xəta baş verdi")
+				return
+			}
+
+			ctx := session.WithIdentity(r.Context(), token.UserID, token.Username)
+			ctx = apitoken.WithToken(ctx, token)
+			ctx = audit.WithActor(ctx, token.UserID)
+			logger.AddFields(ctx, logrus.Fields{"user_id": token.UserID, "api_token_id": token.ID})
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// SessionOnly marşrutu API tokeni ilə gələn sorğular üçün bağlayır. Şifrə, iki faktorlu autentifikasiya
+// və tokenlərin idarəsi yalnız kuki sessiyası ilə mümkündür, beləliklə sızmış token hesabı ələ keçirə bilmir.
+func SessionOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if apitoken.FromContext(r.Context()) != nil {
+			fail(w, r, http.StatusForbidden, api.CodeForbidden, "Bu əməliyyat yalnız veb interfeysdən icra edilə bilər")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
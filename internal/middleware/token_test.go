@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Zam83-AZE/logistics_system/internal/domain/apitoken"
+)
+
+// TestSessionOnly API tokeni ilə gələn sorğuların hesab ayarlarına buraxılmadığını yoxlayır
+func TestSessionOnly(t *testing.T) {
+	handler := SessionOnly(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name  string
+		token *apitoken.Token
+		want  int
+	}{
+		{name: "kuki sessiyası", want: http.StatusOK},
+		{name: "API tokeni", token: &apitoken.Token{ID: 1, UserID: 1}, want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/account/password", nil)
+			if tt.token != nil {
+				req = req.WithContext(apitoken.WithToken(req.Context(), tt.token))
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, gözlənilən %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
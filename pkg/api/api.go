@@ -0,0 +1,133 @@
+// Package api /api/v1 altındakı JSON API üçün ümumi cavab zərfini, xətaları,
+// kursor səhifələməsini və məzmun razılaşdırmasını təmin edir
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// Prefix API-nin cari versiyasının yol prefiksidir
+const Prefix = "/api/v1"
+
+// maxBodyBytes sorğu gövdəsinin maksimal ölçüsüdür
+const maxBodyBytes = 1 << 20
+
+// Xəta kodları
+const (
+	CodeBadRequest       = "bad_request"
+	CodeUnauthorized     = "unauthorized"
+	CodeForbidden        = "forbidden"
+	CodeNotFound         = "not_found"
+	CodeMethodNotAllowed = "method_not_allowed"
+	CodeNotAcceptable    = "not_acceptable"
+	CodeConflict         = "conflict"
+	CodeUnsupportedMedia = "unsupported_media_type"
+	CodeValidation       = "validation_failed"
+	CodeInternal         = "internal_error"
+)
+
+// Error API-nin qaytardığı xətanı təmsil edir
+type Error struct {
+	Status  int    `json:"-"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// NewError verilmiş status, kod və mesajla xəta yaradır
+func NewError(status int, code, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+// errorEnvelope bütün xəta cavablarının ümumi formasıdır: {"error": {"code": ..., "message": ...}}
+type errorEnvelope struct {
+	Error *Error `json:"error"`
+}
+
+// dataEnvelope uğurlu cavabların ümumi formasıdır: {"data": ..., "meta": ...}
+type dataEnvelope struct {
+	Data interface{} `json:"data"`
+	Meta *ListMeta   `json:"meta,omitempty"`
+}
+
+// IsRequest sorğunun API-yə aid olduğunu göstərir
+func IsRequest(r *http.Request) bool {
+	return r.URL.Path == Prefix || strings.HasPrefix(r.URL.Path, Prefix+"/")
+}
+
+// WriteJSON dəyəri {"data": ...} zərfində verilmiş statusla yazır
+func WriteJSON(w http.ResponseWriter, status int, data interface{}) {
+	write(w, status, dataEnvelope{Data: data})
+}
+
+// WriteError xətanı {"error": ...} zərfində yazır.
+// *Error olmayan xətaların mətni müştəriyə göstərilmir.
+func WriteError(w http.ResponseWriter, err error) {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		apiErr = NewError(http.StatusInternalServerError, CodeInternal, "Sorğu icra edilərkən xəta baş verdi")
+	}
+	write(w, apiErr.Status, errorEnvelope{Error: apiErr})
+}
+
+// NoContent gövdəsiz uğurlu cavab yazır
+func NoContent(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Decode application/json sorğu gövdəsini dst-yə oxuyur.
+// Naməlum sahələr və bir neçə JSON dəyəri xəta sayılır.
+func Decode(w http.ResponseWriter, r *http.Request, dst interface{}) error {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "application/json" {
+		return NewError(http.StatusUnsupportedMediaType, CodeUnsupportedMedia, "Sorğu gövdəsi application/json olmalıdır")
+	}
+
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		return NewError(http.StatusBadRequest, CodeBadRequest, "Sorğu gövdəsi yanlışdır: "+err.Error())
+	}
+	if decoder.More() {
+		return NewError(http.StatusBadRequest, CodeBadRequest, "Sorğu gövdəsində yalnız bir JSON obyekti olmalıdır")
+	}
+
+	return nil
+}
+
+// PathID marşrutdakı {id} parametrini qaytarır
+func PathID(r *http.Request) (int, error) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || id < 1 {
+		return 0, NewError(http.StatusBadRequest, CodeBadRequest, "ID yanlışdır")
+	}
+	return id, nil
+}
+
+// NotFound marşrutu tapılmayan API sorğuları üçün işləyicidir
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	WriteError(w, NewError(http.StatusNotFound, CodeNotFound, "Resurs tapılmadı"))
+}
+
+// MethodNotAllowed marşrutun dəstəkləmədiyi metodlar üçün işləyicidir
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	WriteError(w, NewError(http.StatusMethodNotAllowed, CodeMethodNotAllowed, "Bu metod dəstəklənmir"))
+}
+
+// write dəyəri JSON kimi yazır
+func write(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultLimit bir cavabdakı elementlərin standart sayıdır
+	DefaultLimit = 20
+	// MaxLimit bir cavabdakı elementlərin maksimal sayıdır
+	MaxLimit = 100
+)
+
+// ListParams siyahı sorğusunun ?cursor=, ?limit= və ?sort= parametrlərini saxlayır
+type ListParams struct {
+	Page    int
+	PerPage int
+	Sort    string
+}
+
+// ListMeta siyahı cavabının səhifələmə məlumatlarıdır.
+// NextCursor növbəti sorğuda ?cursor= kimi göndərilir; son səhifədə boş olur.
+type ListMeta struct {
+	Total      int    `json:"total"`
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"nextCursor,omitempty"`
+	PrevCursor string `json:"prevCursor,omitempty"`
+}
+
+// cursor müştəri üçün qeyri-şəffaf olan səhifə mövqeyidir
+type cursor struct {
+	Page    int    `json:"p"`
+	PerPage int    `json:"n"`
+	Sort    string `json:"s,omitempty"`
+}
+
+// ParseList siyahı parametrlərini oxuyur. Sıralama açarı ("sahə" və ya "-sahə")
+// sortable xəritəsinin açarlarından biri olmalıdır.
+// Kursor verildikdə səhifə, limit və sıralama ondan götürülür ki, səhifələr ardıcıl qalsın;
+// filtr parametrləri (?q=, ?status= və s.) hər sorğuda yenidən göndərilməlidir.
+func ParseList(r *http.Request, sortable map[string]string) (ListParams, error) {
+	query := r.URL.Query()
+
+	if raw := query.Get("cursor"); raw != "" {
+		c, err := decodeCursor(raw)
+		if err != nil {
+			return ListParams{}, err
+		}
+		return ListParams{Page: c.Page, PerPage: c.PerPage, Sort: c.Sort}, nil
+	}
+
+	params := ListParams{Page: 1, PerPage: DefaultLimit}
+
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > MaxLimit {
+			return ListParams{}, NewError(http.StatusBadRequest, CodeBadRequest,
+				"limit 1 ilə "+strconv.Itoa(MaxLimit)+" arasında olmalıdır")
+		}
+		params.PerPage = limit
+	}
+
+	if sort := query.Get("sort"); sort != "" {
+		if _, ok := sortable[strings.TrimPrefix(sort, "-")]; !ok {
+			return ListParams{}, NewError(http.StatusBadRequest, CodeBadRequest,
+				"Bu sahəyə görə sıralama dəstəklənmir: "+sort)
+		}
+		params.Sort = sort
+	}
+
+	return params, nil
+}
+
+// WriteList elementləri səhifələmə məlumatları ilə {"data": [...], "meta": {...}} zərfində yazır
+func WriteList(w http.ResponseWriter, items interface{}, total int, params ListParams) {
+	meta := &ListMeta{Total: total, Limit: params.PerPage}
+
+	if params.Page*params.PerPage < total {
+		meta.NextCursor = encodeCursor(cursor{Page: params.Page + 1, PerPage: params.PerPage, Sort: params.Sort})
+	}
+	if params.Page > 1 {
+		meta.PrevCursor = encodeCursor(cursor{Page: params.Page - 1, PerPage: params.PerPage, Sort: params.Sort})
+	}
+
+	write(w, http.StatusOK, dataEnvelope{Data: items, Meta: meta})
+}
+
+// QueryBool ?name=true/false parametrini oxuyur; parametr yoxdursa false qaytarır
+func QueryBool(r *http.Request, name string) (bool, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return false, nil
+	}
+
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, NewError(http.StatusBadRequest, CodeBadRequest, name+" parametri true və ya false olmalıdır")
+	}
+	return value, nil
+}
+
+// encodeCursor kursoru URL-də təhlükəsiz sətrə çevirir
+func encodeCursor(c cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor kursoru açır və dəyərlərini yoxlayır
+func decodeCursor(raw string) (cursor, error) {
+	invalid := NewError(http.StatusBadRequest, CodeBadRequest, "Kursor yanlışdır")
+
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return cursor{}, invalid
+	}
+
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return cursor{}, invalid
+	}
+	if c.Page < 1 || c.PerPage < 1 || c.PerPage > MaxLimit {
+		return cursor{}, invalid
+	}
+
+	return c, nil
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// Negotiate Accept başlığına görə müştərinin JSON qəbul etdiyini yoxlayır.
+// Başlıq yoxdursa JSON qəbul edilmiş sayılır, əks halda 406 qaytarılır.
+func Negotiate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept")
+
+		if !AcceptsJSON(r.Header.Get("Accept")) {
+			WriteError(w, NewError(http.StatusNotAcceptable, CodeNotAcceptable,
+				"API yalnız application/json formatında cavab verir"))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// AcceptsJSON Accept başlığının application/json cavabını qəbul etdiyini yoxlayır.
+// q=0 ilə açıq şəkildə rədd edilən növlər nəzərə alınmır.
+func AcceptsJSON(accept string) bool {
+	if strings.TrimSpace(accept) == "" {
+		return true
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		if q, ok := params["q"]; ok && strings.Trim(q, "0.") == "" {
+			continue
+		}
+
+		switch mediaType {
+		case "application/json", "application/*", "*/*":
+			return true
+		}
+	}
+
+	return false
+}
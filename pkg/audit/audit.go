@@ -0,0 +1,185 @@
+// Package audit təhlükəsizlik və biznes hadisələrini yalnız əlavə edilən audit_events cədvəlinə yazır.
+// İcraçı, IP ünvanı və sorğu identifikatoru sorğu kontekstindən götürülür (bax: WithSource).
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+// Event audit jurnalına yazılan hadisəni təmsil edir
+type Event struct {
+	Action     string
+	EntityType string
+	EntityID   string
+	// ActorUserID verilmədikdə icraçı kontekstdən götürülür (məs. girişdə hələ sessiya yoxdur)
+	ActorUserID *int
+	// Before və After obyektin dəyişiklikdən əvvəlki və sonrakı vəziyyətidir; yalnız fərqlənən sahələr saxlanılır
+	Before  interface{}
+	After   interface{}
+	Details map[string]interface{}
+}
+
+// Recorder hadisələri audit jurnalına yazır.
+// Hadisə biznes dəyişikliyi tamamlandıqdan sonra yazılır, ona görə də yazma xətası sorğunu
+// uğursuz etmir: dəyişiklik artıq saxlanılıb və istifadəçiyə 500 qaytarmaq onu geri almır.
+// Xəta loggerə yazılır.
+type Recorder interface {
+	Record(ctx context.Context, event Event)
+}
+
+// Source hadisənin mənbəyidir: icraçı istifadəçi, IP ünvanı və sorğu identifikatoru
+type Source struct {
+	UserID    int
+	IP        string
+	RequestID string
+}
+
+type sourceKey struct{}
+
+// WithSource sorğunun mənbə məlumatlarını kontekstə əlavə edir
+func WithSource(ctx context.Context, source Source) context.Context {
+	return context.WithValue(ctx, sourceKey{}, source)
+}
+
+// WithActor kontekstdəki icraçını dəyişir (məs. API tokeni ilə autentifikasiyadan sonra)
+func WithActor(ctx context.Context, userID int) context.Context {
+	source := SourceFromContext(ctx)
+	source.UserID = userID
+	return WithSource(ctx, source)
+}
+
+// SourceFromContext kontekstdəki mənbə məlumatlarını qaytarır; yoxdursa boş dəyər qaytarılır
+func SourceFromContext(ctx context.Context) Source {
+	source, _ := ctx.Value(sourceKey{}).(Source)
+	return source
+}
+
+// Change bir sahənin əvvəlki və sonrakı dəyəridir
+type Change struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// ignoredFields hər yeniləmədə dəyişən və fərqdə göstərilməyən sahələrdir
+var ignoredFields = map[string]bool{
+	"updatedAt": true,
+}
+
+// Diff iki vəziyyətin JSON təsvirlərini müqayisə edir və fərqlənən sahələri qaytarır.
+// Yaradılmada before, silinmədə after nil ola bilər.
+func Diff(before, after interface{}) (map[string]Change, error) {
+	old, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+	current, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]Change{}
+	for name, value := range current {
+		if ignoredFields[name] {
+			continue
+		}
+		previous, ok := old[name]
+		if (ok || value != nil) && !reflect.DeepEqual(previous, value) {
+			changes[name] = Change{Before: previous, After: value}
+		}
+	}
+	for name, value := range old {
+		if _, ok := current[name]; !ok && value != nil && !ignoredFields[name] {
+			changes[name] = Change{Before: value}
+		}
+	}
+
+	return changes, nil
+}
+
+// fields dəyəri json teqlərinə görə sahələr xəritəsinə çevirir; obyekt olmayan dəyərlər "value" açarı altında saxlanılır
+func fields(v interface{}) (map[string]interface{}, error) {
+	if v == nil {
+		return map[string]interface{}{}, nil
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return map[string]interface{}{}, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+
+	if object, ok := decoded.(map[string]interface{}); ok {
+		return object, nil
+	}
+	return map[string]interface{}{"value": decoded}, nil
+}
+
+// PostgresRecorder Recorder interfeysini PostgreSQL üzərində həyata keçirir
+type PostgresRecorder struct {
+	db *sqlx.DB
+}
+
+// NewRecorder yeni PostgresRecorder yaradır
+func NewRecorder(db *sqlx.DB) *PostgresRecorder {
+	return &PostgresRecorder{db: db}
+}
+
+// Record hadisəni kontekstdəki mənbə məlumatları ilə birlikdə jurnala yazır; xəta yalnız loggerə yazılır
+func (r *PostgresRecorder) Record(ctx context.Context, event Event) {
+	if err := r.write(ctx, event); err != nil {
+		logger.FromContext(ctx).WithError(err).WithFields(logrus.Fields{
+			"action":      event.Action,
+			"entity_type": event.EntityType,
+			"entity_id":   event.EntityID,
+		}).Error("Audit hadisəsi yazılmadı")
+	}
+}
+
+// write hadisəni audit_events cədvəlinə əlavə edir
+func (r *PostgresRecorder) write(ctx context.Context, event Event) error {
+	source := SourceFromContext(ctx)
+
+	actor := event.ActorUserID
+	if actor == nil && source.UserID > 0 {
+		actor = &source.UserID
+	}
+
+	if event.Details == nil {
+		event.Details = map[string]interface{}{}
+	}
+	details, err := json.Marshal(event.Details)
+	if err != nil {
+		return err
+	}
+
+	changes, err := Diff(event.Before, event.After)
+	if err != nil {
+		return err
+	}
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO audit_events (actor_user_id, action, entity_type, entity_id, ip, request_id, details, changes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		actor, event.Action, event.EntityType, event.EntityID, source.IP, source.RequestID, details, changesJSON)
+	return err
+}
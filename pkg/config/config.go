@@ -0,0 +1,351 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// EnvPrefix mühit dəyişənləri ilə konfiqurasiyanı əvəz etmək üçün prefiksdir.
+// Məsələn, app.port sahəsi LOGISTICS_APP_PORT, db.host sahəsi LOGISTICS_DB_HOST ilə dəyişdirilir.
+const EnvPrefix = "LOGISTICS"
+
+// Config tətbiqin bütün konfiqurasiyasını saxlayır
+type Config struct {
+	App      AppConfig      `yaml:"app"`
+	Session  SessionConfig  `yaml:"session"`
+	Auth     AuthConfig     `yaml:"auth"`
+	Mail     MailConfig     `yaml:"mail"`
+	Log      LogConfig      `yaml:"log"`
+	Metrics  MetricsConfig  `yaml:"metrics"`
+	Tracking TrackingConfig `yaml:"tracking"`
+	DB       DBConfig       `yaml:"db"`
+}
+
+// AppConfig configs/app.yaml faylındakı tətbiq parametrlərini saxlayır
+type AppConfig struct {
+	Name        string        `yaml:"name"`
+	Version     string        `yaml:"version"`
+	Environment string        `yaml:"environment"`
+	Port        int           `yaml:"port"`
+	BaseURL     string        `yaml:"base_url"`
+	Timeout     TimeoutConfig `yaml:"timeout"`
+}
+
+// TimeoutConfig HTTP server vaxt limitlərini saxlayır
+type TimeoutConfig struct {
+	Server time.Duration `yaml:"server"`
+	Read   time.Duration `yaml:"read"`
+	Write  time.Duration `yaml:"write"`
+	Idle   time.Duration `yaml:"idle"`
+	// Drain bağlanma siqnalından sonra /readyz uğursuz olduğu halda yeni sorğuların qəbul edildiyi müddətdir
+	Drain time.Duration `yaml:"drain"`
+	// Ready /readyz yoxlamalarının hər birinin maksimal müddətidir
+	Ready time.Duration `yaml:"ready"`
+}
+
+// SessionConfig sessiyaların parametrlərini saxlayır.
+// Secret yeni kukiləri imzalayır; PreviousSecrets açar rotasiyası zamanı köhnə kukiləri yoxlamaq üçündür.
+type SessionConfig struct {
+	Secret          string        `yaml:"secret"`
+	PreviousSecrets []string      `yaml:"previous_secrets"`
+	MaxAge          time.Duration `yaml:"max_age"`
+	IdleTimeout     time.Duration `yaml:"idle_timeout"`
+	SweepInterval   time.Duration `yaml:"sweep_interval"`
+	Secure          bool          `yaml:"secure"`
+}
+
+// AuthConfig uğursuz giriş cəhdlərinin məhdudlaşdırılması, şifrə bərpası və şifrə siyasəti parametrlərini saxlayır
+type AuthConfig struct {
+	LockoutThreshold   int           `yaml:"lockout_threshold"`
+	IPLockoutThreshold int           `yaml:"ip_lockout_threshold"`
+	LockoutDuration    time.Duration `yaml:"lockout_duration"`
+	BackoffBase        time.Duration `yaml:"backoff_base"`
+	BackoffMax         time.Duration `yaml:"backoff_max"`
+	FailureWindow      time.Duration `yaml:"failure_window"`
+	ResetTokenTTL      time.Duration `yaml:"reset_token_ttl"`
+	PasswordMinLength  int           `yaml:"password_min_length"`
+}
+
+// MailConfig e-poçt göndərilməsi parametrlərini saxlayır.
+// Driver "smtp" və ya "log" ola bilər; "log" məktubları File faylına, File boşdursa loqa yazır.
+type MailConfig struct {
+	Driver   string `yaml:"driver"`
+	From     string `yaml:"from"`
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	File     string `yaml:"file"`
+}
+
+// LogConfig tətbiq loqlarının parametrlərini saxlayır.
+// Format "text" (inkişaf üçün) və ya "json" (loq toplayan sistemlər üçün) ola bilər.
+type LogConfig struct {
+	Format string `yaml:"format"`
+	Level  string `yaml:"level"`
+}
+
+// MetricsConfig /metrics ünvanının parametrlərini saxlayır.
+// Token boş deyilsə Prometheus "Authorization: Bearer <token>" başlığı göndərməlidir.
+type MetricsConfig struct {
+	Token string `yaml:"token"`
+}
+
+// TrackingConfig açıq izləmə səhifəsinin (/track) parametrlərini saxlayır.
+// Hər IP ünvanından RateWindow müddətində ən çox RateLimit sorğu qəbul edilir ki,
+// izləmə nömrələrini ardıcıl yoxlamaqla tapmaq mümkün olmasın.
+type TrackingConfig struct {
+	RateLimit  int           `yaml:"rate_limit"`
+	RateWindow time.Duration `yaml:"rate_window"`
+}
+
+// DBConfig configs/db.yaml faylındakı verilənlər bazası parametrlərini saxlayır
+type DBConfig struct {
+	ConnectionString string        `yaml:"connection_string"`
+	Host             string        `yaml:"host"`
+	Port             int           `yaml:"port"`
+	User             string        `yaml:"user"`
+	Password         string        `yaml:"password"`
+	DBName           string        `yaml:"dbname"`
+	SSLMode          string        `yaml:"sslmode"`
+	MaxOpenConns     int           `yaml:"max_open_conns"`
+	MaxIdleConns     int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime  time.Duration `yaml:"conn_max_lifetime"`
+}
+
+// Addr HTTP serverin dinləyəcəyi ünvanı qaytarır
+func (c AppConfig) Addr() string {
+	return fmt.Sprintf(":%d", c.Port)
+}
+
+// IsProduction mühitin production olub-olmadığını göstərir
+func (c AppConfig) IsProduction() bool {
+	return c.Environment == "production"
+}
+
+// Secrets imzalama açarlarını qaytarır: birinci cari açardır, qalanları yalnız yoxlama üçündür
+func (c SessionConfig) Secrets() []string {
+	return append([]string{c.Secret}, c.PreviousSecrets...)
+}
+
+// DSN bağlantı sətrini qaytarır; connection_string verilməyibsə ayrı sahələrdən qurur
+func (c DBConfig) DSN() string {
+	if c.ConnectionString != "" {
+		return c.ConnectionString
+	}
+
+	u := url.URL{
+		Scheme: "postgres",
+		Host:   fmt.Sprintf("%s:%d", c.Host, c.Port),
+		Path:   "/" + c.DBName,
+	}
+	if c.User != "" {
+		u.User = url.UserPassword(c.User, c.Password)
+	}
+	if c.SSLMode != "" {
+		u.RawQuery = url.Values{"sslmode": {c.SSLMode}}.Encode()
+	}
+
+	return u.String()
+}
+
+// Default standart dəyərlərlə doldurulmuş konfiqurasiya qaytarır
+func Default() *Config {
+	return &Config{
+		App: AppConfig{
+			Name:        "Logistics System",
+			Environment: "development",
+			Port:        8080,
+			BaseURL:     "http://localhost:8080",
+			Timeout: TimeoutConfig{
+				Server: 15 * time.Second,
+				Read:   15 * time.Second,
+				Write:  15 * time.Second,
+				Idle:   60 * time.Second,
+				Drain:  5 * time.Second,
+				Ready:  2 * time.Second,
+			},
+		},
+		Session: SessionConfig{
+			MaxAge:        24 * time.Hour,
+			IdleTimeout:   2 * time.Hour,
+			SweepInterval: 15 * time.Minute,
+		},
+		Auth: AuthConfig{
+			LockoutThreshold:   5,
+			IPLockoutThreshold: 20,
+			LockoutDuration:    15 * time.Minute,
+			BackoffBase:        time.Second,
+			BackoffMax:         30 * time.Second,
+			FailureWindow:      15 * time.Minute,
+			ResetTokenTTL:      time.Hour,
+			PasswordMinLength:  10,
+		},
+		Mail: MailConfig{
+			Driver: "log",
+			From:   "no-reply@localhost",
+			Port:   587,
+		},
+		Log: LogConfig{
+			Format: "text",
+			Level:  "info",
+		},
+		Tracking: TrackingConfig{
+			RateLimit:  30,
+			RateWindow: time.Minute,
+		},
+		DB: DBConfig{
+			Port:            5432,
+			SSLMode:         "disable",
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 30 * time.Minute,
+		},
+	}
+}
+
+// Load dir qovluğundakı app.yaml və db.yaml fayllarını oxuyur, mühit dəyişənlərini tətbiq edir
+// və nəticəni yoxlayır
+func Load(dir string) (*Config, error) {
+	cfg := Default()
+
+	// app.yaml bir neçə bölmədən ibarətdir (app, session, auth, mail, log, metrics)
+	if err := readYAML(filepath.Join(dir, "app.yaml"), cfg); err != nil {
+		return nil, err
+	}
+
+	// db.yaml yalnız verilənlər bazası sahələrini saxlayır
+	if err := readYAML(filepath.Join(dir, "db.yaml"), &cfg.DB); err != nil {
+		return nil, err
+	}
+
+	if err := applyEnv(cfg, EnvPrefix, os.LookupEnv); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Validate tələb olunan sahələri yoxlayır və bütün xətaları birlikdə qaytarır
+func (c *Config) Validate() error {
+	var problems []string
+
+	if c.App.Port < 1 || c.App.Port > 65535 {
+		problems = append(problems, "app.port 1-65535 aralığında olmalıdır")
+	}
+	switch c.App.Environment {
+	case "development", "staging", "production":
+	default:
+		problems = append(problems, "app.environment development, staging və ya production olmalıdır")
+	}
+	if u, err := url.Parse(c.App.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		problems = append(problems, "app.base_url tam http(s) ünvanı olmalıdır (məs. https://logistics.example.az)")
+	}
+	if c.App.Timeout.Read <= 0 || c.App.Timeout.Write <= 0 || c.App.Timeout.Idle <= 0 || c.App.Timeout.Server <= 0 {
+		problems = append(problems, "app.timeout sahələri müsbət müddət olmalıdır (məs. 15s)")
+	}
+	if c.App.Timeout.Drain < 0 || c.App.Timeout.Ready <= 0 {
+		problems = append(problems, "app.timeout.drain mənfi olmamalı, app.timeout.ready müsbət olmalıdır")
+	}
+
+	if len(c.Session.Secret) < 32 {
+		problems = append(problems, "session.secret ən azı 32 simvol olmalıdır ("+EnvPrefix+"_SESSION_SECRET)")
+	}
+	if c.App.IsProduction() && strings.HasPrefix(c.Session.Secret, "development-only") {
+		problems = append(problems, "production mühitində inkişaf üçün nəzərdə tutulmuş session.secret istifadə edilə bilməz")
+	}
+	for _, secret := range c.Session.PreviousSecrets {
+		if len(secret) < 32 {
+			problems = append(problems, "session.previous_secrets açarları ən azı 32 simvol olmalıdır")
+			break
+		}
+	}
+	if c.Session.MaxAge <= 0 {
+		problems = append(problems, "session.max_age müsbət müddət olmalıdır")
+	}
+	if c.Session.IdleTimeout <= 0 || c.Session.IdleTimeout > c.Session.MaxAge {
+		problems = append(problems, "session.idle_timeout müsbət olmalı və session.max_age-dən böyük olmamalıdır")
+	}
+	if c.Session.SweepInterval <= 0 {
+		problems = append(problems, "session.sweep_interval müsbət müddət olmalıdır")
+	}
+
+	if c.Auth.LockoutThreshold < 1 || c.Auth.IPLockoutThreshold < 1 {
+		problems = append(problems, "auth.lockout_threshold və auth.ip_lockout_threshold müsbət olmalıdır")
+	}
+	if c.Auth.LockoutDuration <= 0 || c.Auth.FailureWindow <= 0 {
+		problems = append(problems, "auth.lockout_duration və auth.failure_window müsbət müddət olmalıdır")
+	}
+	if c.Auth.BackoffBase < 0 || c.Auth.BackoffMax < c.Auth.BackoffBase {
+		problems = append(problems, "auth.backoff_max auth.backoff_base-dən kiçik olmamalıdır")
+	}
+	if c.Auth.ResetTokenTTL <= 0 {
+		problems = append(problems, "auth.reset_token_ttl müsbət müddət olmalıdır")
+	}
+	if c.Auth.PasswordMinLength < 8 {
+		problems = append(problems, "auth.password_min_length ən azı 8 olmalıdır")
+	}
+
+	switch c.Mail.Driver {
+	case "log":
+	case "smtp":
+		if c.Mail.Host == "" || c.Mail.Port < 1 || c.Mail.Port > 65535 {
+			problems = append(problems, "mail.driver smtp olduqda mail.host və mail.port tələb olunur")
+		}
+	default:
+		problems = append(problems, "mail.driver smtp və ya log olmalıdır")
+	}
+	if c.Mail.From == "" {
+		problems = append(problems, "mail.from tələb olunur")
+	}
+
+	switch c.Log.Format {
+	case "text", "json":
+	default:
+		problems = append(problems, "log.format text və ya json olmalıdır")
+	}
+	switch c.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		problems = append(problems, "log.level debug, info, warn və ya error olmalıdır")
+	}
+
+	if c.Tracking.RateLimit < 1 || c.Tracking.RateWindow <= 0 {
+		problems = append(problems, "tracking.rate_limit və tracking.rate_window müsbət olmalıdır")
+	}
+
+	if c.DB.ConnectionString == "" && (c.DB.Host == "" || c.DB.DBName == "") {
+		problems = append(problems, "db.connection_string və ya db.host və db.dbname tələb olunur")
+	}
+
+	if len(problems) > 0 {
+		return errors.New("konfiqurasiya xətası: " + strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// readYAML YAML faylını verilmiş struktura oxuyur
+func readYAML(path string, out interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%s konfiqurasiyasının oxunması xətası: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("%s konfiqurasiyasının emalı xətası: %w", path, err)
+	}
+
+	return nil
+}
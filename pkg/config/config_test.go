@@ -0,0 +1,101 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// validConfig Validate-dən keçən konfiqurasiya qaytarır
+func validConfig() *Config {
+	cfg := Default()
+	cfg.Session.Secret = strings.Repeat("s", 32)
+	cfg.DB.Host = "localhost"
+	cfg.DB.DBName = "logistics"
+	return cfg
+}
+
+// TestValidate hər qaydanın ayrıca xəta verdiyini yoxlayır
+func TestValidate(t *testing.T) {
+	if err := validConfig().Validate(); err != nil {
+		t.Fatalf("standart konfiqurasiya: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		want   string
+	}{
+		{name: "port", modify: func(c *Config) { c.App.Port = 70000 }, want: "app.port"},
+		{name: "mühit", modify: func(c *Config) { c.App.Environment = "test" }, want: "app.environment"},
+		{name: "nisbi base_url", modify: func(c *Config) { c.App.BaseURL = "/logistics" }, want: "app.base_url"},
+		{name: "sıfır timeout", modify: func(c *Config) { c.App.Timeout.Write = 0 }, want: "app.timeout sahələri"},
+		{name: "ready timeout", modify: func(c *Config) { c.App.Timeout.Ready = 0 }, want: "app.timeout.ready"},
+		{name: "sessiya açarı yoxdur", modify: func(c *Config) { c.Session.Secret = "" }, want: "session.secret"},
+		{name: "qısa sessiya açarı", modify: func(c *Config) { c.Session.Secret = "qısa" }, want: "LOGISTICS_SESSION_SECRET"},
+		{
+			name: "production-da inkişaf açarı",
+			modify: func(c *Config) {
+				c.App.Environment = "production"
+				c.Session.Secret = "development-only-" + strings.Repeat("x", 32)
+			},
+			want: "inkişaf üçün nəzərdə tutulmuş",
+		},
+		{name: "qısa köhnə açar", modify: func(c *Config) { c.Session.PreviousSecrets = []string{"qısa"} }, want: "session.previous_secrets"},
+		{name: "idle_timeout max_age-dən böyük", modify: func(c *Config) { c.Session.IdleTimeout = 48 * time.Hour }, want: "session.idle_timeout"},
+		{name: "sweep_interval", modify: func(c *Config) { c.Session.SweepInterval = 0 }, want: "session.sweep_interval"},
+		{name: "lockout_threshold", modify: func(c *Config) { c.Auth.IPLockoutThreshold = 0 }, want: "auth.lockout_threshold"},
+		{name: "backoff", modify: func(c *Config) { c.Auth.BackoffMax = time.Millisecond }, want: "auth.backoff_max"},
+		{name: "reset_token_ttl", modify: func(c *Config) { c.Auth.ResetTokenTTL = 0 }, want: "auth.reset_token_ttl"},
+		{name: "password_min_length", modify: func(c *Config) { c.Auth.PasswordMinLength = 6 }, want: "auth.password_min_length"},
+		{name: "mail driver", modify: func(c *Config) { c.Mail.Driver = "sendmail" }, want: "mail.driver smtp və ya log"},
+		{name: "smtp host", modify: func(c *Config) { c.Mail.Driver = "smtp" }, want: "mail.host"},
+		{name: "mail from", modify: func(c *Config) { c.Mail.From = "" }, want: "mail.from"},
+		{name: "log format", modify: func(c *Config) { c.Log.Format = "xml" }, want: "log.format"},
+		{name: "log level", modify: func(c *Config) { c.Log.Level = "trace" }, want: "log.level"},
+		{name: "tracking rate_limit", modify: func(c *Config) { c.Tracking.RateLimit = 0 }, want: "tracking.rate_limit"},
+		{name: "tracking rate_window", modify: func(c *Config) { c.Tracking.RateWindow = -time.Second }, want: "tracking.rate_limit"},
+		{name: "db", modify: func(c *Config) { c.DB.Host = "" }, want: "db.connection_string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if err == nil {
+				t.Fatalf("xəta gözlənilirdi (%s)", tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("xəta = %q, %q gözlənilirdi", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestValidateAllProblems bütün xətaların bir mesajda qaytarıldığını yoxlayır
+func TestValidateAllProblems(t *testing.T) {
+	cfg := validConfig()
+	cfg.Session.Secret = ""
+	cfg.Log.Format = "xml"
+	cfg.Tracking.RateLimit = 0
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("xəta gözlənilirdi")
+	}
+	for _, want := range []string{"session.secret", "log.format", "tracking.rate_limit"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("xəta = %q, %q gözlənilirdi", err, want)
+		}
+	}
+
+	// connection_string verildikdə host və dbname tələb olunmur
+	cfg = validConfig()
+	cfg.DB.Host, cfg.DB.DBName = "", ""
+	cfg.DB.ConnectionString = "postgres://db/logistics"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("connection_string ilə: %v", err)
+	}
+}
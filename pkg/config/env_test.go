@@ -0,0 +1,87 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestApplyEnv mühit dəyişənlərinin iç-içə bölmələrdəki müxtəlif tipli sahələrə yazıldığını yoxlayır
+func TestApplyEnv(t *testing.T) {
+	env := map[string]string{
+		"LOGISTICS_APP_PORT":                 "9090",
+		"LOGISTICS_APP_TIMEOUT_READ":         "3s",
+		"LOGISTICS_SESSION_SECURE":           "true",
+		"LOGISTICS_SESSION_PREVIOUS_SECRETS": " köhnə-1 ,, köhnə-2 ",
+		"LOGISTICS_AUTH_LOCKOUT_DURATION":    "1h30m",
+		"LOGISTICS_METRICS_TOKEN":            "gizli",
+		"LOGISTICS_DB_CONNECTION_STRING":     "postgres://db/logistics",
+		"LOGISTICS_DB_CONN_MAX_LIFETIME":     "5m",
+		"LOGISTICS_TRACKING_RATE_LIMIT":      "5",
+		"LOGISTICS_UNKNOWN_SECTION_FIELD":    "nəzərə alınmır",
+		"OTHER_APP_PORT":                     "1",
+	}
+	lookup := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+
+	cfg := Default()
+	if err := applyEnv(cfg, EnvPrefix, lookup); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{name: "app.port", got: cfg.App.Port, want: 9090},
+		{name: "app.timeout.read", got: cfg.App.Timeout.Read, want: 3 * time.Second},
+		{name: "app.timeout.write", got: cfg.App.Timeout.Write, want: 15 * time.Second},
+		{name: "session.secure", got: cfg.Session.Secure, want: true},
+		{name: "session.previous_secrets", got: cfg.Session.PreviousSecrets, want: []string{"köhnə-1", "köhnə-2"}},
+		{name: "auth.lockout_duration", got: cfg.Auth.LockoutDuration, want: 90 * time.Minute},
+		{name: "metrics.token", got: cfg.Metrics.Token, want: "gizli"},
+		{name: "db.connection_string", got: cfg.DB.ConnectionString, want: "postgres://db/logistics"},
+		{name: "db.conn_max_lifetime", got: cfg.DB.ConnMaxLifetime, want: 5 * time.Minute},
+		{name: "tracking.rate_limit", got: cfg.Tracking.RateLimit, want: 5},
+		{name: "db.port", got: cfg.DB.Port, want: 5432},
+	}
+
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s = %v, gözlənilən %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+// TestApplyEnvInvalid yanlış dəyərin dəyişənin adını göstərən xəta qaytardığını yoxlayır
+func TestApplyEnvInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "LOGISTICS_APP_PORT", value: "səkkiz"},
+		{name: "LOGISTICS_APP_TIMEOUT_IDLE", value: "60"},
+		{name: "LOGISTICS_SESSION_SECURE", value: "bəli"},
+		{name: "LOGISTICS_AUTH_PASSWORD_MIN_LENGTH", value: "10.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookup := func(name string) (string, bool) {
+				return tt.value, name == tt.name
+			}
+
+			err := applyEnv(Default(), EnvPrefix, lookup)
+			if err == nil {
+				t.Fatal("xəta gözlənilirdi")
+			}
+			if !strings.Contains(err.Error(), tt.name) {
+				t.Errorf("xəta = %q, dəyişənin adı %s gözlənilirdi", err, tt.name)
+			}
+		})
+	}
+}
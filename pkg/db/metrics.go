@@ -0,0 +1,47 @@
+package db
+
+import (
+	"context"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/metrics"
+	"github.com/jmoiron/sqlx"
+)
+
+// RegisterMetrics bağlantı hovuzunun statistikasını (sql.DBStats) reyestrdə qeydə alır
+func RegisterMetrics(registry *metrics.Registry, db *sqlx.DB) {
+	gauge := func(name, help string, value func() float64) {
+		registry.NewGaugeFunc(name, help, func(context.Context) ([]metrics.Sample, error) {
+			return metrics.Value(value()), nil
+		})
+	}
+	counter := func(name, help string, value func() float64) {
+		registry.NewCounterFunc(name, help, func(context.Context) ([]metrics.Sample, error) {
+			return metrics.Value(value()), nil
+		})
+	}
+
+	gauge("logistics_db_max_open_connections", "Hovuzdakı bağlantıların maksimal sayı", func() float64 {
+		return float64(db.Stats().MaxOpenConnections)
+	})
+	gauge("logistics_db_open_connections", "Açıq bağlantıların sayı (istifadədə və boş)", func() float64 {
+		return float64(db.Stats().OpenConnections)
+	})
+	gauge("logistics_db_in_use_connections", "İstifadədə olan bağlantıların sayı", func() float64 {
+		return float64(db.Stats().InUse)
+	})
+	gauge("logistics_db_idle_connections", "Boş bağlantıların sayı", func() float64 {
+		return float64(db.Stats().Idle)
+	})
+	counter("logistics_db_wait_count_total", "Bağlantı gözləmələrinin ümumi sayı", func() float64 {
+		return float64(db.Stats().WaitCount)
+	})
+	counter("logistics_db_wait_duration_seconds_total", "Bağlantı gözləməsinə sərf olunan ümumi vaxt (saniyə)", func() float64 {
+		return db.Stats().WaitDuration.Seconds()
+	})
+	counter("logistics_db_max_idle_closed_total", "max_idle_conns səbəbindən bağlanan bağlantıların sayı", func() float64 {
+		return float64(db.Stats().MaxIdleClosed)
+	})
+	counter("logistics_db_max_lifetime_closed_total", "conn_max_lifetime səbəbindən bağlanan bağlantıların sayı", func() float64 {
+		return float64(db.Stats().MaxLifetimeClosed)
+	})
+}
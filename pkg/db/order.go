@@ -0,0 +1,21 @@
+package db
+
+import "strings"
+
+// OrderBy "sahə" və ya "-sahə" formatındakı sıralama açarını icazə verilmiş sütunlara əsasən
+// ORDER BY ifadəsinə çevirir. Açar boş və ya tanınmayan olduqda fallback qaytarılır.
+// Səhifələmənin sabit qalması üçün tiebreak sütunu həmişə sona əlavə edilir.
+func OrderBy(sort string, columns map[string]string, fallback, tiebreak string) string {
+	direction := "ASC"
+	if strings.HasPrefix(sort, "-") {
+		direction = "DESC"
+		sort = sort[1:]
+	}
+
+	column, ok := columns[sort]
+	if !ok {
+		return fallback
+	}
+
+	return column + " " + direction + ", " + tiebreak + " " + direction
+}
@@ -0,0 +1,141 @@
+// Package health orkestrator üçün canlılıq (/healthz) və hazırlıq (/readyz) yoxlamalarını təqdim edir.
+// Hazırlıq yoxlaması təmiz bağlanma başlayan kimi uğursuz olur ki, yeni trafik digər instansiyalara yönəlsin.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CheckFunc asılılığı yoxlayır; nil xəta asılılığın hazır olduğunu bildirir
+type CheckFunc func(ctx context.Context) error
+
+// Yoxlamaların nəticə statusları
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// CheckResult bir yoxlamanın nəticəsidir
+type CheckResult struct {
+	Status     string  `json:"status"`
+	DurationMs float64 `json:"durationMs"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// Report /readyz cavabıdır
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+type check struct {
+	name string
+	fn   CheckFunc
+}
+
+// Checker hazırlıq yoxlamalarını saxlayır və icra edir
+type Checker struct {
+	timeout  time.Duration
+	checks   []check
+	draining atomic.Bool
+}
+
+// New yeni Checker yaradır; timeout hər yoxlamanın maksimal müddətidir
+func New(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// Add hazırlıq yoxlamasını əlavə edir
+func (c *Checker) Add(name string, fn CheckFunc) {
+	c.checks = append(c.checks, check{name: name, fn: fn})
+}
+
+// Drain instansiyanı bağlanma rejiminə keçirir: bundan sonra /readyz 503 qaytarır
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+// Draining bağlanma rejiminin başlayıb-başlamadığını göstərir
+func (c *Checker) Draining() bool {
+	return c.draining.Load()
+}
+
+// Run bütün yoxlamaları paralel icra edir və nəticəni qaytarır
+func (c *Checker) Run(ctx context.Context) Report {
+	report := Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(c.checks)+1)}
+
+	if c.Draining() {
+		report.Status = StatusFail
+		report.Checks["shutdown"] = CheckResult{Status: StatusFail, Error: "server bağlanır"}
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, chk := range c.checks {
+		wg.Add(1)
+		go func(chk check) {
+			defer wg.Done()
+			result := c.run(ctx, chk.fn)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[chk.name] = result
+			if result.Status != StatusOK {
+				report.Status = StatusFail
+			}
+		}(chk)
+	}
+	wg.Wait()
+
+	return report
+}
+
+// run yoxlamanı vaxt limiti ilə icra edir
+func (c *Checker) run(ctx context.Context, fn CheckFunc) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := fn(ctx)
+	result := CheckResult{Status: StatusOK, DurationMs: float64(time.Since(start).Microseconds()) / 1000}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// LiveHandler prosesin işlədiyini bildirir; asılılıqları yoxlamır ki, verilənlər bazasındakı
+// problem konteynerin yenidən başladılmasına səbəb olmasın
+func (c *Checker) LiveHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": StatusOK})
+	})
+}
+
+// ReadyHandler yoxlamaları icra edir; hər hansı biri uğursuz olduqda 503 qaytarır
+func (c *Checker) ReadyHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := c.Run(r.Context())
+
+		status := http.StatusOK
+		if report.Status != StatusOK {
+			status = http.StatusServiceUnavailable
+		}
+		writeJSON(w, status, report)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
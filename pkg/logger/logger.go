@@ -1,12 +1,16 @@
 package logger
 
 import (
+	"context"
+	"fmt"
 	"os"
 
+	"github.com/Zam83-AZE/logistics_system/pkg/config"
 	"github.com/sirupsen/logrus"
 )
 
-// NewLogger yeni logrus loggeri yaradır və konfiqurasiya edir
+// NewLogger yeni logrus loggeri yaradır və konfiqurasiya edir.
+// Konfiqurasiya yüklənənə qədər mətn formatı və Info səviyyəsi istifadə olunur (bax: Configure).
 func NewLogger() *logrus.Logger {
 	logger := logrus.New()
 
@@ -23,3 +27,53 @@ func NewLogger() *logrus.Logger {
 
 	return logger
 }
+
+// Configure loggerin formatını və səviyyəsini konfiqurasiyaya uyğun dəyişir
+func Configure(logger *logrus.Logger, cfg config.LogConfig) error {
+	level, err := logrus.ParseLevel(cfg.Level)
+	if err != nil {
+		return fmt.Errorf("log.level yanlışdır: %w", err)
+	}
+	logger.SetLevel(level)
+
+	switch cfg.Format {
+	case "json":
+		logger.SetFormatter(&logrus.JSONFormatter{})
+	case "text":
+		logger.SetFormatter(&logrus.TextFormatter{
+			FullTimestamp: true,
+		})
+	default:
+		return fmt.Errorf("log.format yanlışdır: %q", cfg.Format)
+	}
+
+	return nil
+}
+
+// requestLogger sorğunun loggerini saxlayır; sahələr sorğu boyu əlavə oluna bilir (bax: AddFields)
+type requestLogger struct {
+	entry *logrus.Entry
+}
+
+type contextKey struct{}
+
+// WithContext sorğunun loggerini kontekstə əlavə edir
+func WithContext(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, contextKey{}, &requestLogger{entry: entry})
+}
+
+// FromContext sorğunun loggerini qaytarır; kontekstdə yoxdursa standart logrus loggeri istifadə olunur
+func FromContext(ctx context.Context) *logrus.Entry {
+	if rl, ok := ctx.Value(contextKey{}).(*requestLogger); ok {
+		return rl.entry
+	}
+	return logrus.NewEntry(logrus.StandardLogger())
+}
+
+// AddFields sorğunun loggerinə sahələr əlavə edir (məs. API tokeni ilə müəyyən edilmiş istifadəçi).
+// Dəyişiklik WithContext ilə loggeri əlavə etmiş middleware-in yekun yazısında da görünür.
+func AddFields(ctx context.Context, fields logrus.Fields) {
+	if rl, ok := ctx.Value(contextKey{}).(*requestLogger); ok {
+		rl.entry = rl.entry.WithFields(fields)
+	}
+}
@@ -0,0 +1,60 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LogMailer məktubları göndərmək əvəzinə tətbiq loquna yazır (lokal inkişaf üçün)
+type LogMailer struct {
+	from string
+	log  *logrus.Logger
+}
+
+// NewLogMailer yeni LogMailer yaradır
+func NewLogMailer(from string, log *logrus.Logger) *LogMailer {
+	return &LogMailer{from: from, log: log}
+}
+
+// Send məktubu loqa yazır
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	m.log.WithFields(logrus.Fields{
+		"from":    m.from,
+		"to":      msg.To,
+		"subject": msg.Subject,
+	}).Info("Məktub (göndərilmədi):\n" + msg.Body)
+	return nil
+}
+
+// FileMailer məktubları faylın sonuna əlavə edir (lokal inkişaf üçün)
+type FileMailer struct {
+	from string
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileMailer yeni FileMailer yaradır
+func NewFileMailer(from, path string) *FileMailer {
+	return &FileMailer{from: from, path: path}
+}
+
+// Send məktubu fayla yazır
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, err := os.OpenFile(m.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("məktub faylı açılmadı: %w", err)
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "Date: %s\nFrom: %s\nTo: %s\nSubject: %s\n\n%s\n\n----\n\n",
+		time.Now().Format(time.RFC1123Z), m.from, msg.To, msg.Subject, msg.Body)
+	return err
+}
@@ -0,0 +1,37 @@
+// Package mail tətbiqin göndərdiyi e-poçt məktublarını dəyişdirilə bilən göndəricilər vasitəsilə çatdırır
+package mail
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/config"
+	"github.com/sirupsen/logrus"
+)
+
+// Message göndəriləcək sadə mətnli məktubu təmsil edir
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer məktubları çatdıran göndərici interfeysidir
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// New konfiqurasiyadakı driver sahəsinə uyğun göndəricini yaradır
+func New(cfg config.MailConfig, log *logrus.Logger) (Mailer, error) {
+	switch cfg.Driver {
+	case "smtp":
+		return NewSMTPMailer(cfg), nil
+	case "log":
+		if cfg.File != "" {
+			return NewFileMailer(cfg.From, cfg.File), nil
+		}
+		return NewLogMailer(cfg.From, log), nil
+	default:
+		return nil, fmt.Errorf("naməlum mail driver: %q", cfg.Driver)
+	}
+}
@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/logger"
+)
+
+// ContentType Prometheus mətn formatının MIME tipidir
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Handler reyestrin ölçülərini qaytaran HTTP işləyicisidir.
+// token boş deyilsə sorğu "Authorization: Bearer <token>" başlığını göndərməlidir.
+func (r *Registry) Handler(token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if token != "" && subtle.ConstantTimeCompare([]byte(req.Header.Get("Authorization")), []byte("Bearer "+token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="metrics"`)
+			http.Error(w, "İcazə yoxdur", http.StatusUnauthorized)
+			return
+		}
+
+		// Hesablanan ölçülərdən biri alınmadıqda qalanları yenə də qaytarılır
+		var buf bytes.Buffer
+		if err := r.Write(req.Context(), &buf); err != nil {
+			logger.FromContext(req.Context()).WithError(err).Warn("Ölçü hesablanarkən xəta baş verdi")
+		}
+
+		w.Header().Set("Content-Type", ContentType)
+		w.Write(buf.Bytes())
+	})
+}
@@ -0,0 +1,318 @@
+// Package metrics ölçüləri toplayır və onları Prometheus mətn formatında (/metrics) təqdim edir.
+// Paket xarici asılılıq tələb etmir; Registry.Write ölçüləri istənilən io.Writer-ə yazır,
+// ona görə də testlərdə Prometheus serveri lazım deyil.
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Default tətbiqin ümumi ölçü reyestridir; domen paketləri ölçülərini burada qeydə alır
+var Default = NewRegistry()
+
+// DefBuckets HTTP sorğularının müddəti üçün standart histogram intervallarıdır (saniyə)
+var DefBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Sample hesablanan ölçünün bir dəyəridir; Labels etiket adlarının sırası ilə dəyərlərdir
+type Sample struct {
+	Labels []string
+	Value  float64
+}
+
+// CollectFunc ölçünün dəyərlərini hər /metrics sorğusunda hesablayır
+type CollectFunc func(ctx context.Context) ([]Sample, error)
+
+// Value etiketsiz ölçü üçün tək dəyər qaytarır
+func Value(v float64) []Sample {
+	return []Sample{{Value: v}}
+}
+
+// metric reyestrdə saxlanılan ölçünün ümumi interfeysidir
+type metric interface {
+	write(ctx context.Context, w *bufio.Writer) error
+}
+
+// Registry ölçüləri adlarına görə saxlayır
+type Registry struct {
+	mu      sync.RWMutex
+	metrics map[string]metric
+}
+
+// NewRegistry boş reyestr yaradır
+func NewRegistry() *Registry {
+	return &Registry{metrics: map[string]metric{}}
+}
+
+// register ölçünü qeydə alır; eyni ad iki dəfə qeydə alına bilməz
+func (r *Registry) register(name string, m metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.metrics[name]; exists {
+		panic("metrics: " + name + " artıq qeydə alınıb")
+	}
+	r.metrics[name] = m
+}
+
+// Write bütün ölçüləri adlarına görə sıralanmış şəkildə Prometheus mətn formatında yazır.
+// Hesablanan ölçülərdən biri xəta qaytardıqda qalanları yazılır və ilk xəta qaytarılır.
+func (r *Registry) Write(ctx context.Context, w io.Writer) error {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.metrics))
+	for name := range r.metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	metrics := make([]metric, len(names))
+	for i, name := range names {
+		metrics[i] = r.metrics[name]
+	}
+	r.mu.RUnlock()
+
+	buf := bufio.NewWriter(w)
+	var firstErr error
+	for i, m := range metrics {
+		if err := m.write(ctx, buf); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("%s: %w", names[i], err)
+		}
+	}
+	if err := buf.Flush(); err != nil {
+		return err
+	}
+
+	return firstErr
+}
+
+// desc ölçünün adı, təsviri və etiket adlarıdır
+type desc struct {
+	name   string
+	help   string
+	typ    string
+	labels []string
+}
+
+func (d desc) writeHeader(w *bufio.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", d.name, escapeHelp(d.help), d.name, d.typ)
+}
+
+// key etiket dəyərlərini seriyanın açarına çevirir
+func (d desc) key(values []string) string {
+	if len(values) != len(d.labels) {
+		panic(fmt.Sprintf("metrics: %s üçün %d etiket dəyəri gözlənilir, %d verilib", d.name, len(d.labels), len(values)))
+	}
+	return strings.Join(values, "\xff")
+}
+
+// series seriyanın sətrini yazır: ad{etiket="dəyər",...} dəyər
+func (d desc) series(w *bufio.Writer, suffix string, values []string, extra string, value float64) {
+	w.WriteString(d.name + suffix)
+
+	pairs := make([]string, 0, len(values)+1)
+	for i, label := range d.labels {
+		pairs = append(pairs, label+`="`+escapeLabel(values[i])+`"`)
+	}
+	if extra != "" {
+		pairs = append(pairs, extra)
+	}
+	if len(pairs) > 0 {
+		w.WriteString("{" + strings.Join(pairs, ",") + "}")
+	}
+
+	w.WriteString(" " + formatFloat(value) + "\n")
+}
+
+// Counter yalnız artan ölçüdür (məs. sorğuların sayı)
+type Counter struct {
+	desc
+	mu     sync.Mutex
+	values map[string]*counterValue
+}
+
+type counterValue struct {
+	labels []string
+	value  float64
+}
+
+// NewCounter reyestrdə etiketli sayğac yaradır
+func (r *Registry) NewCounter(name, help string, labels ...string) *Counter {
+	c := &Counter{
+		desc:   desc{name: name, help: help, typ: "counter", labels: labels},
+		values: map[string]*counterValue{},
+	}
+	r.register(name, c)
+	return c
+}
+
+// Inc etiket dəyərlərinə uyğun sayğacı bir vahid artırır
+func (c *Counter) Inc(labels ...string) {
+	c.Add(1, labels...)
+}
+
+// Add etiket dəyərlərinə uyğun sayğaca v əlavə edir; mənfi dəyərlər nəzərə alınmır
+func (c *Counter) Add(v float64, labels ...string) {
+	if v < 0 {
+		return
+	}
+	key := c.key(labels)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	value, ok := c.values[key]
+	if !ok {
+		value = &counterValue{labels: append([]string(nil), labels...)}
+		c.values[key] = value
+	}
+	value.value += v
+}
+
+func (c *Counter) write(_ context.Context, w *bufio.Writer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.writeHeader(w)
+	for _, key := range sortedKeys(c.values) {
+		value := c.values[key]
+		c.series(w, "", value.labels, "", value.value)
+	}
+	return nil
+}
+
+// Histogram müşahidələri intervallar üzrə paylayır (məs. sorğunun müddəti)
+type Histogram struct {
+	desc
+	buckets []float64
+	mu      sync.Mutex
+	values  map[string]*histogramValue
+}
+
+type histogramValue struct {
+	labels []string
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewHistogram reyestrdə etiketli histogram yaradır; buckets artan sırada olmalıdır
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	h := &Histogram{
+		desc:    desc{name: name, help: help, typ: "histogram", labels: labels},
+		buckets: append([]float64(nil), buckets...),
+		values:  map[string]*histogramValue{},
+	}
+	sort.Float64s(h.buckets)
+	r.register(name, h)
+	return h
+}
+
+// Observe etiket dəyərlərinə uyğun histograma müşahidə əlavə edir
+func (h *Histogram) Observe(v float64, labels ...string) {
+	key := h.key(labels)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	value, ok := h.values[key]
+	if !ok {
+		value = &histogramValue{labels: append([]string(nil), labels...), counts: make([]uint64, len(h.buckets))}
+		h.values[key] = value
+	}
+	for i, bound := range h.buckets {
+		if v <= bound {
+			value.counts[i]++
+		}
+	}
+	value.count++
+	value.sum += v
+}
+
+func (h *Histogram) write(_ context.Context, w *bufio.Writer) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.writeHeader(w)
+	for _, key := range sortedKeys(h.values) {
+		value := h.values[key]
+		for i, bound := range h.buckets {
+			h.series(w, "_bucket", value.labels, `le="`+formatFloat(bound)+`"`, float64(value.counts[i]))
+		}
+		h.series(w, "_bucket", value.labels, `le="+Inf"`, float64(value.count))
+		h.series(w, "_sum", value.labels, "", value.sum)
+		h.series(w, "_count", value.labels, "", float64(value.count))
+	}
+	return nil
+}
+
+// funcMetric dəyərləri hər yazılışda CollectFunc ilə hesablanan ölçüdür
+type funcMetric struct {
+	desc
+	collect CollectFunc
+}
+
+// NewGaugeFunc dəyəri hər /metrics sorğusunda hesablanan göstərici yaradır (məs. aktiv daşınmaların sayı)
+func (r *Registry) NewGaugeFunc(name, help string, collect CollectFunc, labels ...string) {
+	r.register(name, &funcMetric{desc: desc{name: name, help: help, typ: "gauge", labels: labels}, collect: collect})
+}
+
+// NewCounterFunc dəyəri başqa mənbədən oxunan sayğac yaradır (məs. bağlantı hovuzunun statistikası)
+func (r *Registry) NewCounterFunc(name, help string, collect CollectFunc, labels ...string) {
+	r.register(name, &funcMetric{desc: desc{name: name, help: help, typ: "counter", labels: labels}, collect: collect})
+}
+
+func (m *funcMetric) write(ctx context.Context, w *bufio.Writer) error {
+	samples, err := m.collect(ctx)
+	if err != nil {
+		return err
+	}
+
+	values := map[string]Sample{}
+	for _, sample := range samples {
+		values[m.key(sample.Labels)] = sample
+	}
+
+	m.writeHeader(w)
+	for _, key := range sortedKeys(values) {
+		m.series(w, "", values[key].Labels, "", values[key].Value)
+	}
+	return nil
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
@@ -0,0 +1,131 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// writeString reyestrin çıxışını sətir kimi qaytarır
+func writeString(t *testing.T, r *Registry) (string, error) {
+	t.Helper()
+
+	var buf strings.Builder
+	err := r.Write(context.Background(), &buf)
+	return buf.String(), err
+}
+
+// TestWriteFormat çıxışın Prometheus mətn formatını, ölçülərin və seriyaların sıralanmasını yoxlayır
+func TestWriteFormat(t *testing.T) {
+	r := NewRegistry()
+
+	requests := r.NewCounter("b_requests_total", "Sorğuların sayı", "method")
+	requests.Inc("POST")
+	requests.Add(2, "GET")
+	requests.Add(-5, "GET")
+	r.NewGaugeFunc("a_active", "Aktiv\ndaşınmalar", func(ctx context.Context) ([]Sample, error) {
+		return Value(7), nil
+	})
+
+	got, err := writeString(t, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP a_active Aktiv\ndaşınmalar
+# TYPE a_active gauge
+a_active 7
+# HELP b_requests_total Sorğuların sayı
+# TYPE b_requests_total counter
+b_requests_total{method="GET"} 2
+b_requests_total{method="POST"} 1
+`
+	if got != want {
+		t.Errorf("çıxış:\n%s\ngözlənilən:\n%s", got, want)
+	}
+}
+
+// TestLabelEscaping etiket dəyərlərində tərs xətt, dırnaq və yeni sətrin ekranlaşdırıldığını yoxlayır
+func TestLabelEscaping(t *testing.T) {
+	r := NewRegistry()
+	r.NewCounter("errors_total", "Xətalar", "message").Inc("yol \"C:\\tmp\"\nsətir")
+
+	got, err := writeString(t, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `errors_total{message="yol \"C:\\tmp\"\nsətir"} 1` + "\n"
+	if !strings.HasSuffix(got, want) {
+		t.Errorf("çıxış:\n%s\ngözlənilən sətir:\n%s", got, want)
+	}
+}
+
+// TestHistogramBuckets intervalların kumulyativ olduğunu və +Inf intervalının _count ilə bərabər olduğunu yoxlayır
+func TestHistogramBuckets(t *testing.T) {
+	r := NewRegistry()
+	h := r.NewHistogram("duration_seconds", "Müddət", []float64{1, 0.1, 0.5}, "route")
+	for _, v := range []float64{0.05, 0.1, 0.3, 0.7, 2, 5} {
+		h.Observe(v, "/track")
+	}
+
+	got, err := writeString(t, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP duration_seconds Müddət
+# TYPE duration_seconds histogram
+duration_seconds_bucket{route="/track",le="0.1"} 2
+duration_seconds_bucket{route="/track",le="0.5"} 3
+duration_seconds_bucket{route="/track",le="1"} 4
+duration_seconds_bucket{route="/track",le="+Inf"} 6
+duration_seconds_sum{route="/track"} 8.15
+duration_seconds_count{route="/track"} 6
+`
+	if got != want {
+		t.Errorf("çıxış:\n%s\ngözlənilən:\n%s", got, want)
+	}
+}
+
+// TestWriteCollectError hesablanan ölçü xəta qaytardıqda digər ölçülərin yazıldığını və xətanın qaytarıldığını yoxlayır
+func TestWriteCollectError(t *testing.T) {
+	r := NewRegistry()
+	collectErr := errors.New("verilənlər bazası əlçatan deyil")
+
+	r.NewGaugeFunc("a_broken", "Xətalı ölçü", func(ctx context.Context) ([]Sample, error) {
+		return nil, collectErr
+	})
+	r.NewCounter("b_total", "Sayğac").Inc()
+	r.NewGaugeFunc("c_shipments", "Daşınmalar", func(ctx context.Context) ([]Sample, error) {
+		return []Sample{{Labels: []string{"in_transit"}, Value: 3}}, nil
+	}, "status")
+
+	got, err := writeString(t, r)
+	if !errors.Is(err, collectErr) || !strings.Contains(err.Error(), "a_broken") {
+		t.Errorf("xəta = %v, gözlənilən a_broken ölçüsünün xətası", err)
+	}
+
+	if strings.Contains(got, "a_broken") {
+		t.Error("xətalı ölçünün başlığı yazıldı")
+	}
+	for _, line := range []string{"b_total 1\n", `c_shipments{status="in_transit"} 3` + "\n"} {
+		if !strings.Contains(got, line) {
+			t.Errorf("çıxışda %q yoxdur:\n%s", line, got)
+		}
+	}
+}
+
+// TestLabelCountPanic etiket dəyərlərinin sayı yanlış olduqda panic verildiyini yoxlayır
+func TestLabelCountPanic(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounter("requests_total", "Sorğular", "method", "status")
+
+	defer func() {
+		if recover() == nil {
+			t.Error("yanlış sayda etiket üçün panic gözlənilirdi")
+		}
+	}()
+	c.Inc("GET")
+}
@@ -0,0 +1,341 @@
+package migrate
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// lockID eyni anda işləyən instansiyaların miqrasiyaları paralel tətbiq etməsinin
+// qarşısını alan PostgreSQL advisory lock açarıdır
+const lockID = 7_283_001_005
+
+// Migration bir versiyalı sxem dəyişikliyini təmsil edir
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status miqrasiyanın tətbiq vəziyyətini təmsil edir
+type Status struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// Migrator miqrasiyaları verilənlər bazasına tətbiq edir və geri qaytarır
+type Migrator struct {
+	db         *sqlx.DB
+	migrations []Migration
+}
+
+// New daxil edilmiş SQL fayllarından yeni Migrator yaradır
+func New(db *sqlx.DB) (*Migrator, error) {
+	migrations, err := Load(migrationFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Load fayl sistemindəki NNNN_ad.up.sql və NNNN_ad.down.sql fayllarını versiya sırası ilə oxuyur
+func Load(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, file := range files {
+		base := path.Base(file)
+
+		var direction string
+		switch {
+		case strings.HasSuffix(base, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(base, ".down.sql"):
+			direction = "down"
+		default:
+			return nil, fmt.Errorf("miqrasiya faylının adı yanlışdır: %s", base)
+		}
+
+		stem := strings.TrimSuffix(base, "."+direction+".sql")
+		parts := strings.SplitN(stem, "_", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("miqrasiya faylının adı yanlışdır: %s", base)
+		}
+
+		version, err := strconv.Atoi(parts[0])
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("miqrasiya versiyası yanlışdır: %s", base)
+		}
+
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: parts[1]}
+			byVersion[version] = m
+		} else if m.Name != parts[1] {
+			return nil, fmt.Errorf("%d versiyası üçün fərqli adlar: %s və %s", version, m.Name, parts[1])
+		}
+
+		if direction == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("%04d_%s miqrasiyasının up və ya down faylı yoxdur", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Latest mövcud ən son miqrasiya versiyasını qaytarır
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up bütün tətbiq edilməmiş miqrasiyaları tətbiq edir
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	return m.To(ctx, m.Latest())
+}
+
+// Down son tətbiq edilmiş miqrasiyanı geri qaytarır
+func (m *Migrator) Down(ctx context.Context) ([]Migration, error) {
+	var result []Migration
+
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; ok {
+				if err := revert(ctx, conn, migration); err != nil {
+					return err
+				}
+				result = append(result, migration)
+				return nil
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// To sxemi verilmiş versiyaya gətirir: lazım olduqda miqrasiyaları tətbiq edir və ya geri qaytarır
+func (m *Migrator) To(ctx context.Context, target int) ([]Migration, error) {
+	if target < 0 || target > m.Latest() {
+		return nil, fmt.Errorf("hədəf versiya 0 ilə %d arasında olmalıdır", m.Latest())
+	}
+
+	var result []Migration
+
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		// Hədəfdən yuxarı tətbiq edilmiş miqrasiyaları tərs sıra ilə geri qaytar
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; ok && migration.Version > target {
+				if err := revert(ctx, conn, migration); err != nil {
+					return err
+				}
+				result = append(result, migration)
+			}
+		}
+
+		// Hədəfə qədər tətbiq edilməmiş miqrasiyaları sıra ilə tətbiq et
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; !ok && migration.Version <= target {
+				if err := apply(ctx, conn, migration); err != nil {
+					return err
+				}
+				result = append(result, migration)
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// Status hər miqrasiyanın tətbiq vəziyyətini qaytarır
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var result []Status
+
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			status := Status{Version: migration.Version, Name: migration.Name}
+			if appliedAt, ok := applied[migration.Version]; ok {
+				at := appliedAt
+				status.Applied = true
+				status.AppliedAt = &at
+			}
+			result = append(result, status)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// Pending tətbiq edilməmiş miqrasiyaların sayını qaytarır
+func (m *Migrator) Pending(ctx context.Context) (int, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	pending := 0
+	for _, s := range statuses {
+		if !s.Applied {
+			pending++
+		}
+	}
+
+	return pending, nil
+}
+
+// Current verilənlər bazasında tətbiq edilmiş ən son versiyanı qaytarır.
+// Status-dan fərqli olaraq kilid götürmür və cədvəl yaratmır, ona görə də tez-tez çağırıla bilər (məs. /readyz).
+func (m *Migrator) Current(ctx context.Context) (int, error) {
+	var version int
+	err := m.db.GetContext(ctx, &version, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`)
+	return version, err
+}
+
+// withLock ayrıca bağlantı üzərində advisory lock götürür, izləmə cədvəlini yaradır və fn-i icra edir
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sqlx.Conn) error) error {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return fmt.Errorf("miqrasiya bağlantısı xətası: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockID); err != nil {
+		return fmt.Errorf("miqrasiya kilidi götürülmədi: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockID)
+
+	_, err = conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    BIGINT PRIMARY KEY,
+			name       TEXT        NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("schema_migrations cədvəli yaradılmadı: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// appliedVersions tətbiq edilmiş versiyaları və tətbiq vaxtlarını qaytarır
+func appliedVersions(ctx context.Context, conn *sqlx.Conn) (map[int]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int]time.Time{}
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+
+	return applied, rows.Err()
+}
+
+// apply miqrasiyanı tranzaksiya daxilində tətbiq edir və qeydə alır
+func apply(ctx context.Context, conn *sqlx.Conn, migration Migration) error {
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+		return fmt.Errorf("%04d_%s miqrasiyası tətbiq edilmədi: %w", migration.Version, migration.Name, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`,
+		migration.Version, migration.Name)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// revert miqrasiyanı tranzaksiya daxilində geri qaytarır və qeydini silir
+func revert(ctx context.Context, conn *sqlx.Conn, migration Migration) error {
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+		return fmt.Errorf("%04d_%s miqrasiyası geri qaytarılmadı: %w", migration.Version, migration.Name, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = $1`, migration.Version); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,19 @@
+-- Müştərilər cədvəli
+CREATE TABLE IF NOT EXISTS customers (
+    id           SERIAL PRIMARY KEY,
+    name         VARCHAR(255) NOT NULL,
+    tax_id       VARCHAR(20)  NOT NULL DEFAULT '',
+    contact_name VARCHAR(255) NOT NULL DEFAULT '',
+    email        VARCHAR(255) NOT NULL DEFAULT '',
+    phone        VARCHAR(50)  NOT NULL DEFAULT '',
+    address      TEXT         NOT NULL DEFAULT '',
+    city         VARCHAR(100) NOT NULL DEFAULT '',
+    country      VARCHAR(100) NOT NULL DEFAULT '',
+    notes        TEXT         NOT NULL DEFAULT '',
+    is_active    BOOLEAN      NOT NULL DEFAULT TRUE,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_customers_name ON customers (LOWER(name));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_customers_tax_id ON customers (tax_id) WHERE tax_id <> '';
@@ -0,0 +1,18 @@
+-- Konteynerlər reyestri (ISO 6346)
+CREATE TABLE IF NOT EXISTS containers (
+    id           SERIAL PRIMARY KEY,
+    owner_code   CHAR(4)      NOT NULL,
+    serial       CHAR(6)      NOT NULL,
+    check_digit  SMALLINT     NOT NULL CHECK (check_digit BETWEEN 0 AND 9),
+    size_type    CHAR(4)      NOT NULL,
+    tare_kg      INTEGER      NOT NULL DEFAULT 0 CHECK (tare_kg >= 0),
+    max_gross_kg INTEGER      NOT NULL DEFAULT 0 CHECK (max_gross_kg >= 0),
+    owner        VARCHAR(255) NOT NULL DEFAULT '',
+    status       VARCHAR(20)  NOT NULL DEFAULT 'available',
+    notes        TEXT         NOT NULL DEFAULT '',
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    UNIQUE (owner_code, serial)
+);
+
+CREATE INDEX IF NOT EXISTS idx_containers_status ON containers (status);
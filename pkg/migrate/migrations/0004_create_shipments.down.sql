@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS shipment_status_history;
+DROP TABLE IF EXISTS shipment_cargo_lines;
+DROP TABLE IF EXISTS shipment_containers;
+DROP TABLE IF EXISTS shipments;
+DROP SEQUENCE IF EXISTS shipment_reference_seq;
@@ -0,0 +1,48 @@
+-- Daşınmalar və onlarla əlaqəli cədvəllər
+CREATE SEQUENCE IF NOT EXISTS shipment_reference_seq;
+
+CREATE TABLE IF NOT EXISTS shipments (
+    id          SERIAL PRIMARY KEY,
+    reference   VARCHAR(20)  NOT NULL UNIQUE DEFAULT ('SHP' || LPAD(nextval('shipment_reference_seq')::text, 8, '0')),
+    customer_id INTEGER      NOT NULL REFERENCES customers (id),
+    origin      VARCHAR(255) NOT NULL,
+    destination VARCHAR(255) NOT NULL,
+    etd         TIMESTAMPTZ,
+    eta         TIMESTAMPTZ,
+    status      VARCHAR(20)  NOT NULL DEFAULT 'draft',
+    notes       TEXT         NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_shipments_status ON shipments (status);
+CREATE INDEX IF NOT EXISTS idx_shipments_customer ON shipments (customer_id);
+
+CREATE TABLE IF NOT EXISTS shipment_containers (
+    shipment_id  INTEGER NOT NULL REFERENCES shipments (id) ON DELETE CASCADE,
+    container_id INTEGER NOT NULL REFERENCES containers (id),
+    PRIMARY KEY (shipment_id, container_id)
+);
+
+CREATE TABLE IF NOT EXISTS shipment_cargo_lines (
+    id              SERIAL PRIMARY KEY,
+    shipment_id     INTEGER       NOT NULL REFERENCES shipments (id) ON DELETE CASCADE,
+    line_no         INTEGER       NOT NULL,
+    description     VARCHAR(255)  NOT NULL,
+    hs_code         VARCHAR(12)   NOT NULL DEFAULT '',
+    packages        INTEGER       NOT NULL DEFAULT 0,
+    package_type    VARCHAR(50)   NOT NULL DEFAULT '',
+    gross_weight_kg NUMERIC(12,2) NOT NULL DEFAULT 0,
+    volume_m3       NUMERIC(12,3) NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS shipment_status_history (
+    id          SERIAL PRIMARY KEY,
+    shipment_id INTEGER     NOT NULL REFERENCES shipments (id) ON DELETE CASCADE,
+    from_status VARCHAR(20) NOT NULL,
+    to_status   VARCHAR(20) NOT NULL,
+    note        TEXT        NOT NULL DEFAULT '',
+    changed_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_shipment_status_history_shipment ON shipment_status_history (shipment_id);
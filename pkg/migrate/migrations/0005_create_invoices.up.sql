@@ -0,0 +1,45 @@
+-- Fakturalar, sətirlər və illik nömrələmə ardıcıllığı
+CREATE TABLE IF NOT EXISTS invoice_sequences (
+    year        INTEGER PRIMARY KEY,
+    last_number INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS invoices (
+    id                 SERIAL PRIMARY KEY,
+    number             VARCHAR(20)  NOT NULL DEFAULT '',
+    customer_id        INTEGER      NOT NULL REFERENCES customers (id),
+    shipment_id        INTEGER      REFERENCES shipments (id),
+    currency           CHAR(3)      NOT NULL DEFAULT 'AZN',
+    status             VARCHAR(10)  NOT NULL DEFAULT 'draft',
+    issue_date         DATE,
+    due_date           DATE,
+    payment_terms_days INTEGER      NOT NULL DEFAULT 30,
+    vat_rate           NUMERIC(5,2) NOT NULL DEFAULT 18,
+    subtotal           BIGINT       NOT NULL DEFAULT 0,
+    discount_total     BIGINT       NOT NULL DEFAULT 0,
+    vat_total          BIGINT       NOT NULL DEFAULT 0,
+    total              BIGINT       NOT NULL DEFAULT 0,
+    notes              TEXT         NOT NULL DEFAULT '',
+    paid_at            TIMESTAMPTZ,
+    created_at         TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_number ON invoices (number) WHERE number <> '';
+CREATE INDEX IF NOT EXISTS idx_invoices_status ON invoices (status);
+CREATE INDEX IF NOT EXISTS idx_invoices_customer ON invoices (customer_id);
+
+CREATE TABLE IF NOT EXISTS invoice_lines (
+    id               SERIAL PRIMARY KEY,
+    invoice_id       INTEGER       NOT NULL REFERENCES invoices (id) ON DELETE CASCADE,
+    line_no          INTEGER       NOT NULL,
+    description      VARCHAR(255)  NOT NULL,
+    quantity         NUMERIC(12,3) NOT NULL,
+    unit_price       BIGINT        NOT NULL,
+    discount_percent NUMERIC(5,2)  NOT NULL DEFAULT 0,
+    vat_rate         NUMERIC(5,2)  NOT NULL,
+    net_amount       BIGINT        NOT NULL,
+    discount_amount  BIGINT        NOT NULL,
+    vat_amount       BIGINT        NOT NULL,
+    total_amount     BIGINT        NOT NULL
+);
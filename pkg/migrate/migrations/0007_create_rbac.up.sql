@@ -0,0 +1,93 @@
+-- Rollar
+CREATE TABLE IF NOT EXISTS roles (
+    id         SERIAL PRIMARY KEY,
+    code       VARCHAR(50)  NOT NULL UNIQUE,
+    name       VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+-- İcazələr (kodlar internal/domain/rbac paketindəki Permission sabitləri ilə eynidir)
+CREATE TABLE IF NOT EXISTS permissions (
+    code        VARCHAR(100) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id         INTEGER      NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+    permission_code VARCHAR(100) NOT NULL REFERENCES permissions (code) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission_code)
+);
+
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role_id INTEGER NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+    PRIMARY KEY (user_id, role_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_roles_role_id ON user_roles (role_id);
+
+INSERT INTO roles (code, name) VALUES
+    ('admin', 'Administrator'),
+    ('dispatcher', 'Dispetçer'),
+    ('accountant', 'Mühasib'),
+    ('customs_broker', 'Gömrük brokeri'),
+    ('warehouse_operator', 'Anbar operatoru'),
+    ('customer_portal', 'Müştəri portalı istifadəçisi')
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO permissions (code, description) VALUES
+    ('dashboard.view', 'Dashboard-a baxış'),
+    ('customers.view', 'Müştərilərə baxış'),
+    ('customers.manage', 'Müştərilərin yaradılması və redaktəsi'),
+    ('containers.view', 'Konteynerlərə baxış'),
+    ('containers.manage', 'Konteynerlərin yaradılması və redaktəsi'),
+    ('shipments.view', 'Daşınmalara baxış'),
+    ('shipments.manage', 'Daşınmaların yaradılması və redaktəsi'),
+    ('shipments.status', 'Daşınma statusunun dəyişdirilməsi'),
+    ('invoices.view', 'Fakturalara baxış'),
+    ('invoices.manage', 'Fakturaların yaradılması, təsdiqi və ödənişi'),
+    ('users.manage', 'İstifadəçilərin və rolların idarəsi')
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_code)
+SELECT r.id, p.code
+FROM roles r
+JOIN permissions p ON (r.code, p.code) IN (
+    ('dispatcher', 'dashboard.view'),
+    ('dispatcher', 'customers.view'),
+    ('dispatcher', 'customers.manage'),
+    ('dispatcher', 'containers.view'),
+    ('dispatcher', 'shipments.view'),
+    ('dispatcher', 'shipments.manage'),
+    ('dispatcher', 'shipments.status'),
+    ('accountant', 'dashboard.view'),
+    ('accountant', 'customers.view'),
+    ('accountant', 'shipments.view'),
+    ('accountant', 'invoices.view'),
+    ('accountant', 'invoices.manage'),
+    ('customs_broker', 'dashboard.view'),
+    ('customs_broker', 'containers.view'),
+    ('customs_broker', 'shipments.view'),
+    ('customs_broker', 'shipments.status'),
+    ('warehouse_operator', 'dashboard.view'),
+    ('warehouse_operator', 'containers.view'),
+    ('warehouse_operator', 'containers.manage'),
+    ('warehouse_operator', 'shipments.view'),
+    ('warehouse_operator', 'shipments.status')
+)
+ON CONFLICT DO NOTHING;
+
+-- Administrator bütün icazələrə malikdir; müştəri portalı rolunun icazələri ayrıca təyin ediləcək
+INSERT INTO role_permissions (role_id, permission_code)
+SELECT r.id, p.code
+FROM roles r CROSS JOIN permissions p
+WHERE r.code = 'admin'
+ON CONFLICT DO NOTHING;
+
+-- Əvvəllər hər bir istifadəçi bütün bölmələrə giriş imkanına malik idi;
+-- girişi itirməmələri üçün mövcud istifadəçilərə administrator rolu verilir
+INSERT INTO user_roles (user_id, role_id)
+SELECT u.id, r.id
+FROM users u CROSS JOIN roles r
+WHERE r.code = 'admin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,27 @@
+-- Uğursuz giriş cəhdləri istifadəçi adı və IP ünvanı üzrə ayrıca izlənilir
+CREATE TABLE IF NOT EXISTS login_failures (
+    scope          VARCHAR(20)  NOT NULL,
+    key            VARCHAR(255) NOT NULL,
+    failures       INTEGER      NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    blocked_until  TIMESTAMPTZ,
+    locked_until   TIMESTAMPTZ,
+    PRIMARY KEY (scope, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_failures_locked_until ON login_failures (locked_until) WHERE locked_until IS NOT NULL;
+
+-- Təhlükəsizlik hadisələrinin jurnalı (yalnız əlavə edilir)
+CREATE TABLE IF NOT EXISTS audit_events (
+    id            BIGSERIAL PRIMARY KEY,
+    occurred_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    actor_user_id INTEGER      REFERENCES users (id) ON DELETE SET NULL,
+    action        VARCHAR(100) NOT NULL,
+    entity_type   VARCHAR(100) NOT NULL DEFAULT '',
+    entity_id     VARCHAR(255) NOT NULL DEFAULT '',
+    ip            VARCHAR(64)  NOT NULL DEFAULT '',
+    details       JSONB        NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_occurred_at ON audit_events (occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_events_action ON audit_events (action);
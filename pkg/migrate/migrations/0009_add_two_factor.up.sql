@@ -0,0 +1,19 @@
+-- TOTP iki faktorlu autentifikasiya
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS totp_secret    VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS totp_enabled   BOOLEAN     NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS totp_last_step BIGINT      NOT NULL DEFAULT 0;
+
+-- Birdəfəlik bərpa kodları (yalnız SHA-256 heşləri saxlanılır)
+CREATE TABLE IF NOT EXISTS recovery_codes (
+    id         SERIAL PRIMARY KEY,
+    user_id    INTEGER     NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    code_hash  VARCHAR(64) NOT NULL,
+    used_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_recovery_codes_user_id ON recovery_codes (user_id);
+
+-- Administrator müəyyən rollar üçün iki faktorlu autentifikasiyanı məcburi edə bilər
+ALTER TABLE roles ADD COLUMN IF NOT EXISTS require_two_factor BOOLEAN NOT NULL DEFAULT FALSE;
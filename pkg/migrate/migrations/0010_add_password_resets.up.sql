@@ -0,0 +1,12 @@
+-- Şifrə bərpası üçün birdəfəlik tokenlər (yalnız SHA-256 heşləri saxlanılır)
+CREATE TABLE IF NOT EXISTS password_resets (
+    id           SERIAL PRIMARY KEY,
+    user_id      INTEGER     NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    token_hash   VARCHAR(64) NOT NULL UNIQUE,
+    requested_ip VARCHAR(64) NOT NULL DEFAULT '',
+    expires_at   TIMESTAMPTZ NOT NULL,
+    used_at      TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_resets_user_id ON password_resets (user_id, created_at);
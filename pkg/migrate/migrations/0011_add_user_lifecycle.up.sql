@@ -0,0 +1,4 @@
+-- İstifadəçilərin idarəsi: növbəti girişdə şifrə dəyişikliyi tələbi və son giriş vaxtı
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS must_change_password BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS last_login_at        TIMESTAMPTZ;
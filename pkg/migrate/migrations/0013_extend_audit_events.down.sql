@@ -0,0 +1,12 @@
+DELETE FROM role_permissions WHERE permission_code = 'audit.view';
+DELETE FROM permissions WHERE code = 'audit.view';
+
+DROP TRIGGER IF EXISTS audit_events_no_truncate ON audit_events;
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+DROP FUNCTION IF EXISTS audit_events_append_only();
+
+DROP INDEX IF EXISTS idx_audit_events_entity;
+DROP INDEX IF EXISTS idx_audit_events_actor;
+
+ALTER TABLE audit_events DROP COLUMN IF EXISTS changes;
+ALTER TABLE audit_events DROP COLUMN IF EXISTS request_id;
@@ -0,0 +1,43 @@
+-- Audit jurnalı biznes əməliyyatlarını da əhatə edir: dəyişikliklər (əvvəl/sonra) və sorğu identifikatoru saxlanılır
+ALTER TABLE audit_events ADD COLUMN IF NOT EXISTS request_id VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE audit_events ADD COLUMN IF NOT EXISTS changes JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor ON audit_events (actor_user_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_events_entity ON audit_events (entity_type, entity_id, occurred_at DESC);
+
+-- Jurnal yalnız əlavə edilir. Yeganə icazə verilən dəyişiklik istifadəçi silindikdə
+-- actor_user_id sütununun NULL edilməsidir (ON DELETE SET NULL)
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND NEW.actor_user_id IS NULL
+        AND (NEW.id, NEW.occurred_at, NEW.action, NEW.entity_type, NEW.entity_id,
+             NEW.ip, NEW.details, NEW.request_id, NEW.changes)
+        IS NOT DISTINCT FROM
+            (OLD.id, OLD.occurred_at, OLD.action, OLD.entity_type, OLD.entity_id,
+             OLD.ip, OLD.details, OLD.request_id, OLD.changes) THEN
+        RETURN NEW;
+    END IF;
+
+    RAISE EXCEPTION 'audit_events cədvəli yalnız əlavə edilir (%)', TG_OP;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE PROCEDURE audit_events_append_only();
+
+DROP TRIGGER IF EXISTS audit_events_no_truncate ON audit_events;
+CREATE TRIGGER audit_events_no_truncate
+    BEFORE TRUNCATE ON audit_events
+    FOR EACH STATEMENT EXECUTE PROCEDURE audit_events_append_only();
+
+INSERT INTO permissions (code, description) VALUES
+    ('audit.view', 'Audit jurnalına baxış və ixrac')
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_code)
+SELECT r.id, 'audit.view'
+FROM roles r
+WHERE r.code = 'admin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,16 @@
+-- Daşınma və konteynerlərin izləmə hadisələri (gate-in, yükləmə, yola düşmə və s.)
+CREATE TABLE IF NOT EXISTS tracking_events (
+    id           SERIAL PRIMARY KEY,
+    shipment_id  INTEGER      NOT NULL REFERENCES shipments (id) ON DELETE CASCADE,
+    container_id INTEGER      REFERENCES containers (id),
+    code         VARCHAR(20)  NOT NULL,
+    location     VARCHAR(255) NOT NULL DEFAULT '',
+    occurred_at  TIMESTAMPTZ  NOT NULL,
+    source       VARCHAR(50)  NOT NULL DEFAULT 'manual',
+    note         TEXT         NOT NULL DEFAULT '',
+    recorded_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_tracking_events_shipment ON tracking_events (shipment_id, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_tracking_events_container ON tracking_events (container_id, occurred_at) WHERE container_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_tracking_events_occurred ON tracking_events (occurred_at DESC);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_shipments_bl_number;
+DROP INDEX IF EXISTS idx_shipments_tracking_number;
+
+ALTER TABLE shipments DROP COLUMN IF EXISTS bl_number;
+ALTER TABLE shipments DROP COLUMN IF EXISTS tracking_number;
@@ -0,0 +1,25 @@
+-- İctimai izləmə səhifəsi üçün nömrələr. reference ardıcıl olduğu üçün təxmin edilə bilər,
+-- ona görə də müştərilərə təsadüfi tracking_number verilir; bl_number konosament nömrəsidir.
+ALTER TABLE shipments ADD COLUMN IF NOT EXISTS tracking_number VARCHAR(16);
+ALTER TABLE shipments ADD COLUMN IF NOT EXISTS bl_number VARCHAR(35) NOT NULL DEFAULT '';
+
+-- Mövcud daşınmalar üçün nömrələr tətbiqdəki NewTrackingNumber ilə eyni formatdadır: "LGS" və
+-- kriptoqrafik təsadüfi baytlardan (bayt mod 32) Crockford base32 əlifbası ilə 10 simvol
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+-- Funksiya VOLATILE olduğu üçün hər sətir üçün ayrıca çağırılır
+CREATE FUNCTION pg_temp.new_tracking_number() RETURNS TEXT VOLATILE LANGUAGE sql AS $$
+    SELECT 'LGS' || STRING_AGG(SUBSTR('0123456789ABCDEFGHJKMNPQRSTVWXYZ', GET_BYTE(r.bytes, i) % 32 + 1, 1), '' ORDER BY i)
+    FROM (SELECT gen_random_bytes(10) AS bytes) r, GENERATE_SERIES(0, 9) AS i
+$$;
+
+UPDATE shipments
+SET tracking_number = pg_temp.new_tracking_number()
+WHERE tracking_number IS NULL;
+
+DROP FUNCTION pg_temp.new_tracking_number();
+
+ALTER TABLE shipments ALTER COLUMN tracking_number SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_shipments_tracking_number ON shipments (tracking_number);
+CREATE INDEX IF NOT EXISTS idx_shipments_bl_number ON shipments (bl_number) WHERE bl_number <> '';
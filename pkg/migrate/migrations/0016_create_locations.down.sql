@@ -0,0 +1,4 @@
+DELETE FROM role_permissions WHERE permission_code IN ('locations.view', 'locations.manage');
+DELETE FROM permissions WHERE code IN ('locations.view', 'locations.manage');
+
+DROP TABLE IF EXISTS locations;
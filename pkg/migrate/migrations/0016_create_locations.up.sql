@@ -0,0 +1,44 @@
+-- Məntəqələr: limanlar, terminallar, anbarlar, sərhəd keçidləri və müştəri ünvanları.
+-- unlocode UN/LOCODE kodudur (ölkə + 3 simvol); UN/LOCODE siyahısından idxal edilmiş
+-- məntəqələr üçün unikaldır, əl ilə əlavə edilənlər (məs. limanın terminalı) eyni kodu daşıya bilər.
+CREATE TABLE IF NOT EXISTS locations (
+    id          SERIAL PRIMARY KEY,
+    unlocode    VARCHAR(5)   NOT NULL DEFAULT '',
+    name        VARCHAR(255) NOT NULL,
+    kind        VARCHAR(20)  NOT NULL,
+    country     CHAR(2)      NOT NULL,
+    subdivision VARCHAR(3)   NOT NULL DEFAULT '',
+    latitude    NUMERIC(8,5),
+    longitude   NUMERIC(8,5),
+    timezone    VARCHAR(64)  NOT NULL DEFAULT '',
+    address     TEXT         NOT NULL DEFAULT '',
+    customer_id INTEGER      REFERENCES customers (id),
+    source      VARCHAR(20)  NOT NULL DEFAULT 'manual',
+    is_active   BOOLEAN      NOT NULL DEFAULT TRUE,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_locations_unlocode_import ON locations (unlocode) WHERE source = 'unlocode';
+CREATE INDEX IF NOT EXISTS idx_locations_unlocode ON locations (unlocode) WHERE unlocode <> '';
+CREATE INDEX IF NOT EXISTS idx_locations_country_kind ON locations (country, kind);
+CREATE INDEX IF NOT EXISTS idx_locations_customer ON locations (customer_id) WHERE customer_id IS NOT NULL;
+
+INSERT INTO permissions (code, description) VALUES
+    ('locations.view', 'Məntəqələrə baxış'),
+    ('locations.manage', 'Məntəqələrin yaradılması və redaktəsi')
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_code)
+SELECT r.id, p.code
+FROM roles r
+JOIN permissions p ON (r.code, p.code) IN (
+    ('admin', 'locations.view'),
+    ('admin', 'locations.manage'),
+    ('dispatcher', 'locations.view'),
+    ('dispatcher', 'locations.manage'),
+    ('accountant', 'locations.view'),
+    ('customs_broker', 'locations.view'),
+    ('warehouse_operator', 'locations.view')
+)
+ON CONFLICT DO NOTHING;
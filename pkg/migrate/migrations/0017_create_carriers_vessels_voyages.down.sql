@@ -0,0 +1,8 @@
+DELETE FROM role_permissions WHERE permission_code IN ('schedules.view', 'schedules.manage');
+DELETE FROM permissions WHERE code IN ('schedules.view', 'schedules.manage');
+
+DROP TABLE IF EXISTS shipment_voyages;
+DROP TABLE IF EXISTS voyage_calls;
+DROP TABLE IF EXISTS voyages;
+DROP TABLE IF EXISTS vessels;
+DROP TABLE IF EXISTS carriers;
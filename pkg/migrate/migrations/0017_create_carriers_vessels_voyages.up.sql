@@ -0,0 +1,84 @@
+-- Daşıyıcılar (dəniz, dəmir yolu, avtomobil, hava), gəmilər və reys cədvəlləri
+CREATE TABLE IF NOT EXISTS carriers (
+    id         SERIAL PRIMARY KEY,
+    name       VARCHAR(255) NOT NULL,
+    mode       VARCHAR(10)  NOT NULL,
+    scac       VARCHAR(4)   NOT NULL DEFAULT '',
+    iata       VARCHAR(2)   NOT NULL DEFAULT '',
+    is_active  BOOLEAN      NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_carriers_scac ON carriers (scac) WHERE scac <> '';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_carriers_iata ON carriers (iata) WHERE iata <> '';
+
+-- imo yoxlama rəqəmi ilə birlikdə 7 rəqəmli IMO nömrəsidir
+CREATE TABLE IF NOT EXISTS vessels (
+    id         SERIAL PRIMARY KEY,
+    name       VARCHAR(255) NOT NULL,
+    imo        CHAR(7)      NOT NULL UNIQUE,
+    carrier_id INTEGER      REFERENCES carriers (id),
+    flag       CHAR(2)      NOT NULL DEFAULT '',
+    is_active  BOOLEAN      NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS voyages (
+    id            SERIAL PRIMARY KEY,
+    carrier_id    INTEGER     NOT NULL REFERENCES carriers (id),
+    vessel_id     INTEGER     REFERENCES vessels (id),
+    voyage_number VARCHAR(20) NOT NULL,
+    notes         TEXT        NOT NULL DEFAULT '',
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (carrier_id, voyage_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_voyages_vessel ON voyages (vessel_id) WHERE vessel_id IS NOT NULL;
+
+-- Reysin məntəqələri (port rotasiyası); seq reys daxilində ardıcıllıqdır
+CREATE TABLE IF NOT EXISTS voyage_calls (
+    id          SERIAL PRIMARY KEY,
+    voyage_id   INTEGER     NOT NULL REFERENCES voyages (id) ON DELETE CASCADE,
+    seq         INTEGER     NOT NULL,
+    location_id INTEGER     NOT NULL REFERENCES locations (id),
+    eta         TIMESTAMPTZ,
+    etd         TIMESTAMPTZ,
+    UNIQUE (voyage_id, seq)
+);
+
+CREATE INDEX IF NOT EXISTS idx_voyage_calls_location ON voyage_calls (location_id);
+
+-- Daşınmanın reysin yükləmə və boşaltma məntəqələri arasındakı hissəsinə təyin edilməsi
+CREATE TABLE IF NOT EXISTS shipment_voyages (
+    id                SERIAL PRIMARY KEY,
+    shipment_id       INTEGER     NOT NULL REFERENCES shipments (id) ON DELETE CASCADE,
+    voyage_id         INTEGER     NOT NULL REFERENCES voyages (id),
+    load_call_id      INTEGER     NOT NULL REFERENCES voyage_calls (id),
+    discharge_call_id INTEGER     NOT NULL REFERENCES voyage_calls (id),
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (shipment_id, voyage_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_shipment_voyages_voyage ON shipment_voyages (voyage_id);
+
+INSERT INTO permissions (code, description) VALUES
+    ('schedules.view', 'Daşıyıcılara, gəmilərə və reyslərə baxış'),
+    ('schedules.manage', 'Daşıyıcıların, gəmilərin və reyslərin idarəsi, daşınmaların reyslərə təyini')
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_code)
+SELECT r.id, p.code
+FROM roles r
+JOIN permissions p ON (r.code, p.code) IN (
+    ('admin', 'schedules.view'),
+    ('admin', 'schedules.manage'),
+    ('dispatcher', 'schedules.view'),
+    ('dispatcher', 'schedules.manage'),
+    ('accountant', 'schedules.view'),
+    ('customs_broker', 'schedules.view'),
+    ('warehouse_operator', 'schedules.view')
+)
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS shipment_leg_containers;
+DROP TABLE IF EXISTS shipment_legs;
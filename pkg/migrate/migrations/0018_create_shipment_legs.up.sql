@@ -0,0 +1,25 @@
+-- Daşınmanın marşrut hissələri (məs. dənizlə Potiyə, dəmir yolu ilə Bakıya, avtomobillə müştəriyə).
+-- seq daşınma daxilində ardıcıllıqdır; hər hissə əvvəlkinin çatdığı məntəqədən başlamalıdır.
+CREATE TABLE IF NOT EXISTS shipment_legs (
+    id                SERIAL PRIMARY KEY,
+    shipment_id       INTEGER     NOT NULL REFERENCES shipments (id) ON DELETE CASCADE,
+    seq               INTEGER     NOT NULL,
+    mode              VARCHAR(10) NOT NULL,
+    carrier_id        INTEGER     REFERENCES carriers (id),
+    from_location_id  INTEGER     NOT NULL REFERENCES locations (id),
+    to_location_id    INTEGER     NOT NULL REFERENCES locations (id),
+    planned_departure TIMESTAMPTZ,
+    planned_arrival   TIMESTAMPTZ,
+    actual_departure  TIMESTAMPTZ,
+    actual_arrival    TIMESTAMPTZ,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (shipment_id, seq)
+);
+
+-- Hissədə daşınan konteynerlər; boş olduqda hissə daşınmanın bütün konteynerlərinə aiddir
+CREATE TABLE IF NOT EXISTS shipment_leg_containers (
+    leg_id       INTEGER NOT NULL REFERENCES shipment_legs (id) ON DELETE CASCADE,
+    container_id INTEGER NOT NULL REFERENCES containers (id),
+    PRIMARY KEY (leg_id, container_id)
+);
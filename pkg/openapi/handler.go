@@ -0,0 +1,38 @@
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"sync"
+)
+
+//go:embed docs.html
+var docsPage []byte
+
+// Handler sənədi application/json kimi verir. Sənəd ilk sorğuda bir dəfə qurulur.
+func (s *Spec) Handler() http.Handler {
+	var once sync.Once
+	var body []byte
+	var err error
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		once.Do(func() {
+			body, err = json.MarshalIndent(s.Document(), "", "  ")
+		})
+		if err != nil {
+			http.Error(w, "API sənədi hazırlanarkən xəta baş verdi", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write(body)
+	})
+}
+
+// DocsHandler sənədi oxuyub göstərən daxili sənədləşmə səhifəsini verir.
+// Səhifə xarici skript yükləmir; sənədi eyni qovluqdakı openapi.json ünvanından alır.
+func DocsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(docsPage)
+}
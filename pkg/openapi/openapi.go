@@ -0,0 +1,306 @@
+// Package openapi domenlərin təsvir etdiyi JSON API əməliyyatlarından OpenAPI 3.1 sənədi qurur.
+// Sorğu və cavab sxemləri Go tiplərindən json teqlərinə əsasən yaradılır.
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Version sənədin OpenAPI versiyasıdır
+const Version = "3.1.0"
+
+// Param əməliyyatın əlavə sorğu parametrini təsvir edir
+type Param struct {
+	Name        string
+	Description string
+	Type        string // string (standart), integer, boolean
+	Enum        []string
+}
+
+// Operation bir marşrutun təsviridir. Spec onu OpenAPI əməliyyatına çevirir.
+type Operation struct {
+	Summary    string
+	Tag        string
+	Permission string
+	Query      []Param
+	// List cavabın {"data": [...], "meta": {...}} zərfində siyahı olduğunu və
+	// ?cursor=, ?limit= parametrlərinin qəbul edildiyini göstərir
+	List bool
+	// Sort ?sort= parametrinin qəbul etdiyi sahələrdir (xəritənin açarları)
+	Sort     map[string]string
+	Request  interface{}
+	Response interface{}
+	Status   int
+	// Conflict əməliyyatın vəziyyət ziddiyyətində 409 qaytara bildiyini göstərir
+	Conflict bool
+}
+
+// Spec OpenAPI sənədini qurur
+type Spec struct {
+	title       string
+	version     string
+	description string
+	server      string
+	paths       map[string]map[string]Operation
+	schemas     *schemaRegistry
+}
+
+// New boş sənəd yaradır; server bütün yolların prefiksidir (məs. /api/v1)
+func New(title, version, description, server string) *Spec {
+	return &Spec{
+		title:       title,
+		version:     version,
+		description: description,
+		server:      server,
+		paths:       map[string]map[string]Operation{},
+		schemas:     newSchemaRegistry(),
+	}
+}
+
+// Add marşrutu sənədə əlavə edir. Yol mux formatında ola bilər: /customers/{id:[0-9]+}
+func (s *Spec) Add(method, path string, op Operation) {
+	path = NormalizePath(path)
+	if s.paths[path] == nil {
+		s.paths[path] = map[string]Operation{}
+	}
+	s.paths[path][strings.ToUpper(method)] = op
+}
+
+// Has sənəddə verilmiş metod və yolun olub-olmadığını yoxlayır
+func (s *Spec) Has(method, path string) bool {
+	_, ok := s.paths[NormalizePath(path)][strings.ToUpper(method)]
+	return ok
+}
+
+// Operations sənəddəki bütün "METOD /yol" cütlərini sıralanmış şəkildə qaytarır
+func (s *Spec) Operations() []string {
+	var ops []string
+	for path, methods := range s.paths {
+		for method := range methods {
+			ops = append(ops, method+" "+path)
+		}
+	}
+	sort.Strings(ops)
+	return ops
+}
+
+// muxParam mux yol parametrindəki regex hissəsini tapır: {id:[0-9]+} -> {id}
+var muxParam = regexp.MustCompile(`\{([^}:]+):[^}]*\}`)
+
+// NormalizePath mux yol şablonunu OpenAPI formatına çevirir
+func NormalizePath(path string) string {
+	return muxParam.ReplaceAllString(path, "{$1}")
+}
+
+// Document sənədi JSON-a çevrilə bilən formada qaytarır
+func (s *Spec) Document() map[string]interface{} {
+	paths := map[string]interface{}{}
+	for path, methods := range s.paths {
+		item := map[string]interface{}{}
+		for method, op := range methods {
+			item[strings.ToLower(method)] = s.operation(method, path, op)
+		}
+		paths[path] = item
+	}
+
+	return map[string]interface{}{
+		"openapi": Version,
+		"info": map[string]interface{}{
+			"title":       s.title,
+			"version":     s.version,
+			"description": s.description,
+		},
+		"servers": []interface{}{map[string]interface{}{"url": s.server}},
+		"security": []interface{}{
+			map[string]interface{}{"bearerAuth": []string{}},
+			map[string]interface{}{"cookieAuth": []string{}},
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": s.schemas.components(),
+			"securitySchemes": map[string]interface{}{
+				"bearerAuth": map[string]interface{}{
+					"type":        "http",
+					"scheme":      "bearer",
+					"description": "Hesab ayarlarında yaradılan şəxsi API tokeni (lgs_...)",
+				},
+				"cookieAuth": map[string]interface{}{
+					"type": "apiKey",
+					"in":   "cookie",
+					"name": "logistics-session",
+				},
+			},
+			"responses": map[string]interface{}{
+				"Error": map[string]interface{}{
+					"description": "Xəta",
+					"content":     jsonContent(ref("Error")),
+				},
+			},
+		},
+	}
+}
+
+// operation Operation təsvirini OpenAPI əməliyyat obyektinə çevirir
+func (s *Spec) operation(method, path string, op Operation) map[string]interface{} {
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	var params []interface{}
+	for _, name := range pathParams(path) {
+		params = append(params, map[string]interface{}{
+			"name":     name,
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]interface{}{"type": "integer", "minimum": 1},
+		})
+	}
+	for _, p := range op.Query {
+		params = append(params, queryParam(p))
+	}
+	if op.List {
+		params = append(params,
+			queryParam(Param{Name: "cursor", Description: "Əvvəlki cavabın meta.nextCursor və ya meta.prevCursor dəyəri"}),
+			queryParam(Param{Name: "limit", Type: "integer", Description: "Bir cavabdakı elementlərin sayı (1-100, standart 20)"}),
+		)
+	}
+	if len(op.Sort) > 0 {
+		var keys []string
+		for key := range op.Sort {
+			keys = append(keys, key, "-"+key)
+		}
+		sort.Strings(keys)
+		params = append(params, queryParam(Param{
+			Name:        "sort",
+			Description: "Sıralama sahəsi; \"-\" prefiksi azalan sıra deməkdir",
+			Enum:        keys,
+		}))
+	}
+
+	responses := map[string]interface{}{}
+	success := map[string]interface{}{"description": http.StatusText(status)}
+	if op.Response != nil {
+		schema := s.schemas.schema(op.Response, false)
+		envelope := map[string]interface{}{
+			"type":       "object",
+			"required":   []string{"data"},
+			"properties": map[string]interface{}{"data": schema},
+		}
+		if op.List {
+			envelope["required"] = []string{"data", "meta"}
+			envelope["properties"] = map[string]interface{}{
+				"data": map[string]interface{}{"type": "array", "items": schema},
+				"meta": ref("ListMeta"),
+			}
+		}
+		success["content"] = jsonContent(envelope)
+	}
+	responses[strconv.Itoa(status)] = success
+
+	errorCodes := []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotAcceptable}
+	if op.List || len(params) > 0 || op.Request != nil {
+		errorCodes = append(errorCodes, http.StatusBadRequest)
+	}
+	if len(pathParams(path)) > 0 {
+		errorCodes = append(errorCodes, http.StatusNotFound)
+	}
+	if op.Request != nil {
+		errorCodes = append(errorCodes, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity)
+	}
+	if op.Conflict {
+		errorCodes = append(errorCodes, http.StatusConflict)
+	}
+	for _, code := range errorCodes {
+		responses[strconv.Itoa(code)] = map[string]interface{}{"$ref": "#/components/responses/Error"}
+	}
+
+	result := map[string]interface{}{
+		"operationId": operationID(method, path),
+		"summary":     op.Summary,
+		"responses":   responses,
+	}
+	if op.Tag != "" {
+		result["tags"] = []string{op.Tag}
+	}
+	if op.Permission != "" {
+		result["description"] = "Tələb olunan icazə: `" + op.Permission + "`"
+		result["x-permission"] = op.Permission
+	}
+	if len(params) > 0 {
+		result["parameters"] = params
+	}
+	if op.Request != nil {
+		result["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content":  jsonContent(s.schemas.schema(op.Request, true)),
+		}
+	}
+
+	return result
+}
+
+// pathParams yoldakı {ad} parametrlərinin adlarını qaytarır
+func pathParams(path string) []string {
+	var names []string
+	for _, part := range strings.Split(path, "/") {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			names = append(names, strings.Trim(part, "{}"))
+		}
+	}
+	return names
+}
+
+// queryParam sorğu parametrinin OpenAPI təsvirini qurur
+func queryParam(p Param) map[string]interface{} {
+	typ := p.Type
+	if typ == "" {
+		typ = "string"
+	}
+
+	schema := map[string]interface{}{"type": typ}
+	if len(p.Enum) > 0 {
+		schema["enum"] = p.Enum
+	}
+
+	param := map[string]interface{}{"name": p.Name, "in": "query", "schema": schema}
+	if p.Description != "" {
+		param["description"] = p.Description
+	}
+	return param
+}
+
+// operationID metod və yoldan unikal identifikator yaradır: GET /customers/{id} -> getCustomersById
+func operationID(method, path string) string {
+	id := strings.ToLower(method)
+	for _, part := range strings.Split(path, "/") {
+		if part == "" {
+			continue
+		}
+		if strings.HasPrefix(part, "{") {
+			part = "by-" + strings.Trim(part, "{}")
+		}
+		for _, word := range strings.Split(part, "-") {
+			if word != "" {
+				id += strings.ToUpper(word[:1]) + word[1:]
+			}
+		}
+	}
+	return id
+}
+
+// jsonContent sxemi application/json məzmunu kimi təsvir edir
+func jsonContent(schema interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"application/json": map[string]interface{}{"schema": schema},
+	}
+}
+
+// ref sxem komponentinə istinad yaradır
+func ref(name string) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
@@ -0,0 +1,74 @@
+package openapi
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// Undocumented routerdə sənədin server prefiksi altında qeydə alınmış, lakin sənəddə olmayan
+// marşrutları "METOD /yol" formatında qaytarır
+func (s *Spec) Undocumented(router *mux.Router) ([]string, error) {
+	registered, err := s.registered(router)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, op := range registered {
+		method, path, _ := strings.Cut(op, " ")
+		if !s.Has(method, path) {
+			missing = append(missing, op)
+		}
+	}
+	return missing, nil
+}
+
+// Unregistered sənəddə təsvir edilmiş, lakin routerdə olmayan marşrutları qaytarır
+func (s *Spec) Unregistered(router *mux.Router) ([]string, error) {
+	registered, err := s.registered(router)
+	if err != nil {
+		return nil, err
+	}
+
+	known := map[string]bool{}
+	for _, op := range registered {
+		known[op] = true
+	}
+
+	var stale []string
+	for _, op := range s.Operations() {
+		if !known[op] {
+			stale = append(stale, op)
+		}
+	}
+	return stale, nil
+}
+
+// registered routerdəki server prefiksi altında olan bütün "METOD /yol" cütlərini qaytarır.
+// Metodu olmayan marşrutlar (alt-routerlər) nəzərə alınmır.
+func (s *Spec) registered(router *mux.Router) ([]string, error) {
+	var ops []string
+
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil || !strings.HasPrefix(template, s.server+"/") {
+			return nil
+		}
+
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+
+		path := NormalizePath(strings.TrimPrefix(template, s.server))
+		for _, method := range methods {
+			ops = append(ops, method+" "+path)
+		}
+		return nil
+	})
+
+	sort.Strings(ops)
+	return ops, err
+}
@@ -0,0 +1,174 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/api"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaRegistry adlandırılmış struct tiplərinin sxemlərini components/schemas bölməsi üçün toplayır
+type schemaRegistry struct {
+	schemas map[string]interface{}
+	names   map[reflect.Type]string
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	r := &schemaRegistry{
+		schemas: map[string]interface{}{},
+		names:   map[reflect.Type]string{},
+	}
+
+	r.define("ListMeta", reflect.TypeOf(api.ListMeta{}))
+	r.schemas["Error"] = map[string]interface{}{
+		"type":     "object",
+		"required": []string{"error"},
+		"properties": map[string]interface{}{
+			"error": map[string]interface{}{
+				"type":     "object",
+				"required": []string{"code", "message"},
+				"properties": map[string]interface{}{
+					"code":    map[string]interface{}{"type": "string", "examples": []string{api.CodeValidation}},
+					"message": map[string]interface{}{"type": "string"},
+				},
+			},
+		},
+	}
+
+	return r
+}
+
+// components toplanmış sxemləri qaytarır
+func (r *schemaRegistry) components() map[string]interface{} {
+	return r.schemas
+}
+
+// schema dəyərin tipinin sxemini qaytarır; adlandırılmış struct-lar komponent kimi qeydə alınır.
+// Sorğu gövdələrində (request) sahələr məcburi göstərilmir, çünki onları servis yoxlayır.
+func (r *schemaRegistry) schema(v interface{}, request bool) map[string]interface{} {
+	return r.typeSchema(reflect.TypeOf(v), request)
+}
+
+// define tipi verilmiş adla komponent kimi qeydə alır
+func (r *schemaRegistry) define(name string, t reflect.Type) {
+	r.names[t] = name
+	r.schemas[name] = r.structSchema(t, false)
+}
+
+func (r *schemaRegistry) typeSchema(t reflect.Type, request bool) map[string]interface{} {
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := r.typeSchema(t.Elem(), request)
+		if _, isRef := schema["$ref"]; isRef {
+			return map[string]interface{}{"anyOf": []interface{}{schema, map[string]interface{}{"type": "null"}}}
+		}
+		schema["type"] = []interface{}{schema["type"], "null"}
+		return schema
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": r.typeSchema(t.Elem(), request)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": r.typeSchema(t.Elem(), request)}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.structSchema(t, request)
+		}
+		name, ok := r.names[t]
+		if !ok {
+			name = componentName(t)
+			r.names[t] = name
+			r.schemas[name] = r.structSchema(t, request)
+		}
+		return ref(name)
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// structSchema struct-ın ixrac edilmiş sahələrindən json teqlərinə görə obyekt sxemi qurur.
+// Daxil edilmiş (anonim) struct-ların sahələri encoding/json kimi yuxarı səviyyəyə çıxarılır.
+func (r *schemaRegistry) structSchema(t reflect.Type, request bool) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []string
+	r.collectFields(t, request, properties, &required)
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 && !request {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func (r *schemaRegistry) collectFields(t reflect.Type, request bool, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				r.collectFields(embedded, request, properties, required)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = r.typeSchema(field.Type, request)
+		if !strings.Contains(options, "omitempty") && field.Type.Kind() != reflect.Ptr {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// componentName tipin paketinə görə unikal komponent adı seçir:
+// customer.Customer -> Customer, shipment.Container -> ShipmentContainer
+func componentName(t reflect.Type) string {
+	pkg := t.PkgPath()
+	if i := strings.LastIndex(pkg, "/"); i >= 0 {
+		pkg = pkg[i+1:]
+	}
+
+	name := capitalize(t.Name())
+	if strings.HasPrefix(strings.ToLower(name), strings.ToLower(pkg)) {
+		return name
+	}
+	return capitalize(pkg) + name
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	runes := []rune(s)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
@@ -0,0 +1,527 @@
+// Package qrcode QR kodlarını (ISO/IEC 18004) bayt rejimində, M səviyyəli xəta korreksiyası ilə yaradır.
+// Yalnız 1-10 versiyaları dəstəklənir (213 bayta qədər), bu da otpauth:// URI-ləri üçün kifayətdir.
+package qrcode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrTooLong məlumat dəstəklənən ən böyük versiyaya sığmadıqda qaytarılır
+var ErrTooLong = errors.New("məlumat QR kodu üçün çox uzundur")
+
+// blockInfo M səviyyəsi üçün versiyanın blok strukturunu təsvir edir
+type blockInfo struct {
+	ecPerBlock int
+	groups     [][2]int // {blok sayı, blokdakı məlumat kod sözləri}
+}
+
+var blocksM = [...]blockInfo{
+	1:  {10, [][2]int{{1, 16}}},
+	2:  {16, [][2]int{{1, 28}}},
+	3:  {26, [][2]int{{1, 44}}},
+	4:  {18, [][2]int{{2, 32}}},
+	5:  {24, [][2]int{{2, 43}}},
+	6:  {16, [][2]int{{4, 27}}},
+	7:  {18, [][2]int{{4, 31}}},
+	8:  {22, [][2]int{{2, 38}, {2, 39}}},
+	9:  {22, [][2]int{{3, 36}, {2, 37}}},
+	10: {26, [][2]int{{4, 43}, {1, 44}}},
+}
+
+var alignmentPositions = [...][]int{
+	1:  nil,
+	2:  {6, 18},
+	3:  {6, 22},
+	4:  {6, 26},
+	5:  {6, 30},
+	6:  {6, 34},
+	7:  {6, 22, 38},
+	8:  {6, 24, 42},
+	9:  {6, 26, 46},
+	10: {6, 28, 50},
+}
+
+const maxVersion = 10
+
+// Code hazır QR kodunun modul matrisidir
+type Code struct {
+	Size    int
+	modules [][]bool
+}
+
+// Dark (x, y) modulunun tünd olub-olmadığını qaytarır
+func (c *Code) Dark(x, y int) bool {
+	return c.modules[y][x]
+}
+
+// SVG kodu verilmiş modul ölçüsü və 4 modulluq boş kənarla SVG şəklində qaytarır
+func (c *Code) SVG(moduleSize int) string {
+	const quiet = 4
+	total := (c.Size + 2*quiet) * moduleSize
+
+	var path strings.Builder
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.modules[y][x] {
+				fmt.Fprintf(&path, "M%d %dh1v1h-1z", x+quiet, y+quiet)
+			}
+		}
+	}
+
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+
+		`<rect width="100%%" height="100%%" fill="#fff"/><path d="%s" fill="#000"/></svg>`,
+		total, total, c.Size+2*quiet, c.Size+2*quiet, path.String())
+}
+
+// Encode mətni ən kiçik uyğun versiyada QR koduna çevirir
+func Encode(text string) (*Code, error) {
+	data := []byte(text)
+
+	version := 0
+	for v := 1; v <= maxVersion; v++ {
+		if 4+countBits(v)+len(data)*8 <= dataCodewords(v)*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, ErrTooLong
+	}
+
+	codewords := addErrorCorrection(version, encodeData(version, data))
+
+	q := newBuilder(version)
+	q.drawFunctionPatterns()
+	q.drawCodewords(codewords)
+
+	// Ən az cərimə balı verən maskanı seç
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		q.applyMask(mask)
+		q.drawFormatBits(mask)
+		if penalty := q.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		q.applyMask(mask) // XOR maskanı geri qaytarır
+	}
+	q.applyMask(best)
+	q.drawFormatBits(best)
+
+	return &Code{Size: q.size, modules: q.modules}, nil
+}
+
+// countBits bayt rejimində simvol sayı sahəsinin uzunluğudur
+func countBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+func dataCodewords(version int) int {
+	total := 0
+	for _, g := range blocksM[version].groups {
+		total += g[0] * g[1]
+	}
+	return total
+}
+
+// encodeData rejim, uzunluq, məlumat və doldurma baytlarından ibarət kod sözlərini qaytarır
+func encodeData(version int, data []byte) []byte {
+	capacity := dataCodewords(version) * 8
+	var bits bitBuffer
+
+	bits.append(0b0100, 4) // bayt rejimi
+	bits.append(len(data), countBits(version))
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+
+	// Terminator və bayt sərhədinə qədər sıfırlar
+	terminator := capacity - len(bits)
+	if terminator > 4 {
+		terminator = 4
+	}
+	bits.append(0, terminator)
+	bits.append(0, (8-len(bits)%8)%8)
+
+	// Doldurma baytları
+	for pad := 0xEC; len(bits) < capacity; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	result := make([]byte, len(bits)/8)
+	for i, bit := range bits {
+		if bit {
+			result[i>>3] |= 1 << (7 - uint(i&7))
+		}
+	}
+	return result
+}
+
+// addErrorCorrection məlumatı bloklara bölür, Reed-Solomon kodlarını əlavə edir və blokları növbələşdirir
+func addErrorCorrection(version int, data []byte) []byte {
+	info := blocksM[version]
+	divisor := rsDivisor(info.ecPerBlock)
+
+	var dataBlocks, ecBlocks [][]byte
+	offset := 0
+	for _, g := range info.groups {
+		for i := 0; i < g[0]; i++ {
+			block := data[offset : offset+g[1]]
+			offset += g[1]
+			dataBlocks = append(dataBlocks, block)
+			ecBlocks = append(ecBlocks, rsRemainder(block, divisor))
+		}
+	}
+
+	var result []byte
+	longest := dataBlocks[len(dataBlocks)-1]
+	for i := range longest {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < info.ecPerBlock; i++ {
+		for _, block := range ecBlocks {
+			result = append(result, block[i])
+		}
+	}
+
+	return result
+}
+
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, (value>>uint(i))&1 == 1)
+	}
+}
+
+// builder modul matrisini və funksional modulların xəritəsini saxlayır
+type builder struct {
+	version    int
+	size       int
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+func newBuilder(version int) *builder {
+	size := version*4 + 17
+	b := &builder{version: version, size: size}
+	b.modules = make([][]bool, size)
+	b.isFunction = make([][]bool, size)
+	for i := range b.modules {
+		b.modules[i] = make([]bool, size)
+		b.isFunction[i] = make([]bool, size)
+	}
+	return b
+}
+
+func (b *builder) setFunction(x, y int, dark bool) {
+	b.modules[y][x] = dark
+	b.isFunction[y][x] = true
+}
+
+func (b *builder) drawFunctionPatterns() {
+	// Zamanlama xətləri
+	for i := 0; i < b.size; i++ {
+		b.setFunction(6, i, i%2 == 0)
+		b.setFunction(i, 6, i%2 == 0)
+	}
+
+	// Axtarış nümunələri (ayırıcılarla birlikdə)
+	b.drawFinder(3, 3)
+	b.drawFinder(b.size-4, 3)
+	b.drawFinder(3, b.size-4)
+
+	// Düzləndirmə nümunələri
+	positions := alignmentPositions[b.version]
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			b.drawAlignment(x, y)
+		}
+	}
+
+	// Format sahələrini rezerv et (sonra real dəyərlə yazılır)
+	b.drawFormatBits(0)
+	b.drawVersion()
+}
+
+func (b *builder) drawFinder(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || x >= b.size || y < 0 || y >= b.size {
+				continue
+			}
+			dist := maxInt(abs(dx), abs(dy))
+			b.setFunction(x, y, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (b *builder) drawAlignment(cx, cy int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			b.setFunction(cx+dx, cy+dy, maxInt(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// drawFormatBits M səviyyəsi və maska nömrəsini BCH kodu ilə iki nüsxədə yazır
+func (b *builder) drawFormatBits(mask int) {
+	const levelM = 0
+	data := levelM<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+
+	bit := func(i int) bool { return (bits>>uint(i))&1 == 1 }
+
+	for i := 0; i <= 5; i++ {
+		b.setFunction(8, i, bit(i))
+	}
+	b.setFunction(8, 7, bit(6))
+	b.setFunction(8, 8, bit(7))
+	b.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		b.setFunction(14-i, 8, bit(i))
+	}
+
+	for i := 0; i < 8; i++ {
+		b.setFunction(b.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		b.setFunction(8, b.size-15+i, bit(i))
+	}
+	b.setFunction(8, b.size-8, true) // həmişə tünd modul
+}
+
+// drawVersion 7 və daha yüksək versiyalar üçün versiya məlumatını yazır
+func (b *builder) drawVersion() {
+	if b.version < 7 {
+		return
+	}
+
+	rem := b.version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := b.version<<12 | rem
+
+	for i := 0; i < 18; i++ {
+		dark := (bits>>uint(i))&1 == 1
+		a := b.size - 11 + i%3
+		c := i / 3
+		b.setFunction(a, c, dark)
+		b.setFunction(c, a, dark)
+	}
+}
+
+// drawCodewords kod sözlərini sağ aşağı küncdən başlayaraq ziqzaq şəklində yerləşdirir
+func (b *builder) drawCodewords(data []byte) {
+	i := 0
+	for right := b.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < b.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				upward := (right+1)&2 == 0
+				y := vert
+				if upward {
+					y = b.size - 1 - vert
+				}
+				if !b.isFunction[y][x] && i < len(data)*8 {
+					b.modules[y][x] = (data[i>>3]>>uint(7-(i&7)))&1 == 1
+					i++
+				}
+			}
+		}
+	}
+}
+
+// applyMask maskanı funksional olmayan modullara XOR ilə tətbiq edir
+func (b *builder) applyMask(mask int) {
+	for y := 0; y < b.size; y++ {
+		for x := 0; x < b.size; x++ {
+			if b.isFunction[y][x] {
+				continue
+			}
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert {
+				b.modules[y][x] = !b.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty standartdakı dörd qaydaya görə cərimə balını hesablayır
+func (b *builder) penalty() int {
+	score := 0
+	n := b.size
+	at := func(x, y int, vertical bool) bool {
+		if vertical {
+			return b.modules[x][y]
+		}
+		return b.modules[y][x]
+	}
+
+	for _, vertical := range []bool{false, true} {
+		for y := 0; y < n; y++ {
+			// Eyni rəngli ardıcıl modullar
+			run := 1
+			for x := 1; x < n; x++ {
+				if at(x, y, vertical) == at(x-1, y, vertical) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					score += run - 2
+				}
+				run = 1
+			}
+			if run >= 5 {
+				score += run - 2
+			}
+
+			// Axtarış nümunəsinə bənzər ardıcıllıqlar: 1011101 və 4 boş modul
+			for x := 0; x+10 < n; x++ {
+				pattern := [11]bool{}
+				for k := range pattern {
+					pattern[k] = at(x+k, y, vertical)
+				}
+				if matchesFinderLike(pattern) {
+					score += 40
+				}
+			}
+		}
+	}
+
+	// 2x2 eyni rəngli bloklar
+	dark := 0
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			if b.modules[y][x] {
+				dark++
+			}
+			if x+1 < n && y+1 < n {
+				c := b.modules[y][x]
+				if c == b.modules[y][x+1] && c == b.modules[y+1][x] && c == b.modules[y+1][x+1] {
+					score += 3
+				}
+			}
+		}
+	}
+
+	// Tünd modulların nisbətinin 50%-dən fərqi
+	total := n * n
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	if k > 0 {
+		score += k * 10
+	}
+
+	return score
+}
+
+func matchesFinderLike(p [11]bool) bool {
+	core := [7]bool{true, false, true, true, true, false, true}
+	matchAt := func(offset int) bool {
+		for i, v := range core {
+			if p[offset+i] != v {
+				return false
+			}
+		}
+		return true
+	}
+	if matchAt(0) && !p[7] && !p[8] && !p[9] && !p[10] {
+		return true
+	}
+	return matchAt(4) && !p[0] && !p[1] && !p[2] && !p[3]
+}
+
+// rsDivisor Reed-Solomon generator polinomunu qaytarır
+func rsDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+// rsRemainder məlumat blokunun xəta korreksiya kod sözlərini qaytarır
+func rsRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i := range result {
+			result[i] ^= gfMultiply(divisor[i], factor)
+		}
+	}
+	return result
+}
+
+// gfMultiply GF(2^8) sahəsində 0x11D polinomu ilə vurmadır
+func gfMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>uint(i))&1) * int(x)
+	}
+	return byte(z)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,70 @@
+// Package ratelimit açar (məs. IP ünvanı) üzrə sorğu sayını yaddaşda məhdudlaşdırır.
+// Sayğaclar instansiyaya aiddir; bir neçə instansiya olduqda ümumi limit instansiyaların sayına vurulur.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// counter bir açarın cari pəncərədəki sayğacıdır
+type counter struct {
+	start time.Time
+	count int
+}
+
+// Limiter hər açar üçün window müddətində ən çox limit sorğuya icazə verir (sabit pəncərə)
+type Limiter struct {
+	limit  int
+	window time.Duration
+
+	mu        sync.Mutex
+	counters  map[string]*counter
+	nextSweep time.Time
+	now       func() time.Time
+}
+
+// New yeni Limiter yaradır
+func New(limit int, window time.Duration) *Limiter {
+	return &Limiter{
+		limit:    limit,
+		window:   window,
+		counters: make(map[string]*counter),
+		now:      time.Now,
+	}
+}
+
+// Allow açar üçün sorğunu qeydə alır. Limit aşıldıqda false və növbəti pəncərəyə qədər
+// gözləmə müddəti qaytarılır.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	c, ok := l.counters[key]
+	if !ok || now.Sub(c.start) >= l.window {
+		c = &counter{start: now}
+		l.counters[key] = c
+	}
+
+	if c.count >= l.limit {
+		return false, c.start.Add(l.window).Sub(now)
+	}
+	c.count++
+	return true, 0
+}
+
+// sweep vaxtı bitmiş pəncərələri silir ki, yaddaş görülmüş bütün IP-lərlə böyüməsin
+func (l *Limiter) sweep(now time.Time) {
+	if now.Before(l.nextSweep) {
+		return
+	}
+	for key, c := range l.counters {
+		if now.Sub(c.start) >= l.window {
+			delete(l.counters, key)
+		}
+	}
+	l.nextSweep = now.Add(l.window)
+}
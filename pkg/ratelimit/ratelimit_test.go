@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+// newTestLimiter idarə olunan saatla Limiter yaradır
+func newTestLimiter(limit int, window time.Duration) (*Limiter, *time.Time) {
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	l := New(limit, window)
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+// TestAllowWindow limitin pəncərə daxilində tətbiq edildiyini və pəncərə bitdikdə sayğacın sıfırlandığını yoxlayır
+func TestAllowWindow(t *testing.T) {
+	l, now := newTestLimiter(3, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := l.Allow("10.0.0.1"); !ok {
+			t.Fatalf("sorğu %d rədd edildi", i+1)
+		}
+	}
+
+	*now = now.Add(20 * time.Second)
+	ok, wait := l.Allow("10.0.0.1")
+	if ok {
+		t.Fatal("limitdən artıq sorğu qəbul edildi")
+	}
+	if wait != 40*time.Second {
+		t.Errorf("gözləmə = %s, gözlənilən 40s", wait)
+	}
+
+	// Digər açarların öz sayğacı var
+	if ok, _ := l.Allow("10.0.0.2"); !ok {
+		t.Error("başqa IP rədd edildi")
+	}
+
+	// Pəncərənin sonuna bir an qalmış hələ də rədd edilir, sərhəddə isə yeni pəncərə başlayır
+	*now = now.Add(40*time.Second - time.Nanosecond)
+	if ok, _ := l.Allow("10.0.0.1"); ok {
+		t.Error("pəncərə bitməmiş sorğu qəbul edildi")
+	}
+
+	*now = now.Add(time.Nanosecond)
+	for i := 0; i < 3; i++ {
+		if ok, _ := l.Allow("10.0.0.1"); !ok {
+			t.Fatalf("yeni pəncərədə sorğu %d rədd edildi", i+1)
+		}
+	}
+	if ok, _ := l.Allow("10.0.0.1"); ok {
+		t.Error("yeni pəncərədə limitdən artıq sorğu qəbul edildi")
+	}
+}
+
+// TestSweep vaxtı bitmiş sayğacların silindiyini və aktiv sayğacların saxlanıldığını yoxlayır
+func TestSweep(t *testing.T) {
+	l, now := newTestLimiter(5, time.Minute)
+
+	l.Allow("10.0.0.1")
+	l.Allow("10.0.0.2")
+
+	*now = now.Add(30 * time.Second)
+	l.Allow("10.0.0.3")
+
+	// Təmizləmə ən tez bir pəncərədən sonra işləyir
+	if got := len(l.counters); got != 3 {
+		t.Fatalf("sayğaclar = %d, gözlənilən 3", got)
+	}
+
+	*now = now.Add(30 * time.Second)
+	l.Allow("10.0.0.3")
+
+	if _, ok := l.counters["10.0.0.1"]; ok {
+		t.Error("vaxtı bitmiş sayğac silinmədi")
+	}
+	if _, ok := l.counters["10.0.0.2"]; ok {
+		t.Error("vaxtı bitmiş sayğac silinmədi")
+	}
+	if c, ok := l.counters["10.0.0.3"]; !ok || c.count != 2 {
+		t.Errorf("aktiv sayğac = %+v, gözlənilən 2 sorğu", c)
+	}
+}
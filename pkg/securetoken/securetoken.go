@@ -0,0 +1,17 @@
+// Package securetoken təsadüfi yaradılan gizli dəyərlərin (API tokenləri, bərpa kodları,
+// şifrə bərpası tokenləri) verilənlər bazasında saxlanılan heşini hesablayır.
+package securetoken
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Hash dəyərin SHA-256 heşini hex formatında qaytarır.
+// Dəyərlər yüksək entropiyalı təsadüfi baytlardan yaradıldığı üçün lüğət hücumu mümkün deyil və
+// bcrypt kimi yavaş heş funksiyasına ehtiyac yoxdur; sürətli heş isə dəyəri birbaşa axtarmağa imkan verir.
+// İstifadəçi adı və ya şifrə kimi insanın seçdiyi dəyərlər üçün istifadə edilməməlidir.
+func Hash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
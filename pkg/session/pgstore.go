@@ -0,0 +1,248 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/base32"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Zam83-AZE/logistics_system/pkg/config"
+	"github.com/gorilla/securecookie"
+	"github.com/gorilla/sessions"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+// touchInterval last_seen_at sahəsinin hər sorğuda deyil, ən çox bu intervalda yenilənməsini təmin edir
+const touchInterval = time.Minute
+
+// PGStore sessiyaları PostgreSQL-də saxlayan sessions.Store implementasiyasıdır.
+// Kukidə yalnız imzalanmış sessiya ID-si olur, ona görə sessiyalar server tərəfdən ləğv edilə bilər.
+type PGStore struct {
+	db          *sqlx.DB
+	codecs      []securecookie.Codec
+	idleTimeout time.Duration
+	Options     *sessions.Options
+}
+
+// NewPGStore konfiqurasiyaya əsasən yeni PGStore yaradır.
+// Cari açar yeni kukiləri imzalayır, əvvəlki açarlar isə yalnız mövcud kukiləri yoxlayır.
+func NewPGStore(db *sqlx.DB, cfg config.SessionConfig) *PGStore {
+	maxAge := int(cfg.MaxAge.Seconds())
+
+	var codecs []securecookie.Codec
+	for _, secret := range cfg.Secrets() {
+		codec := securecookie.New([]byte(secret), nil)
+		codec.MaxAge(maxAge)
+		codecs = append(codecs, codec)
+	}
+
+	return &PGStore{
+		db:          db,
+		codecs:      codecs,
+		idleTimeout: cfg.IdleTimeout,
+		Options: &sessions.Options{
+			Path:     "/",
+			MaxAge:   maxAge,
+			HttpOnly: true,
+			Secure:   cfg.Secure,
+			SameSite: http.SameSiteLaxMode,
+		},
+	}
+}
+
+// Get sorğu üçün keşlənmiş sessiyanı qaytarır və ya yenisini yükləyir
+func (s *PGStore) Get(r *http.Request, name string) (*sessions.Session, error) {
+	return sessions.GetRegistry(r).Get(s, name)
+}
+
+// New kukidəki ID-yə görə sessiyanı verilənlər bazasından yükləyir.
+// Kuki yoxdursa, imzası yanlışdırsa və ya sessiya bitibsə boş yeni sessiya qaytarılır.
+func (s *PGStore) New(r *http.Request, name string) (*sessions.Session, error) {
+	session := sessions.NewSession(s, name)
+	opts := *s.Options
+	session.Options = &opts
+	session.IsNew = true
+
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return session, nil
+	}
+
+	var id string
+	if err := securecookie.DecodeMulti(name, cookie.Value, &id, s.codecs...); err != nil {
+		return session, nil
+	}
+
+	found, err := s.load(r.Context(), session, id)
+	if err != nil {
+		return session, err
+	}
+	if found {
+		session.ID = id
+		session.IsNew = false
+	}
+
+	return session, nil
+}
+
+// Save sessiyanı verilənlər bazasına yazır və imzalanmış ID-ni kukiyə qoyur.
+// MaxAge mənfi olduqda sessiya silinir.
+func (s *PGStore) Save(r *http.Request, w http.ResponseWriter, session *sessions.Session) error {
+	ctx := r.Context()
+
+	if session.Options.MaxAge < 0 {
+		if session.ID != "" {
+			if err := s.Revoke(ctx, session.ID); err != nil {
+				return err
+			}
+		}
+		http.SetCookie(w, sessions.NewCookie(session.Name(), "", session.Options))
+		return nil
+	}
+
+	if session.ID == "" {
+		key := securecookie.GenerateRandomKey(32)
+		if key == nil {
+			return errors.New("sessiya ID-si yaradılmadı")
+		}
+		session.ID = strings.TrimRight(base32.StdEncoding.EncodeToString(key), "=")
+	}
+
+	data, err := encodeValues(session.Values)
+	if err != nil {
+		return err
+	}
+
+	var userID *int
+	if id, ok := session.Values[userIDKey].(int); ok && id > 0 {
+		userID = &id
+	}
+
+	// Bitmə vaxtı sessiya yaradılanda təyin edilir və sonrakı yazılarda uzadılmır
+	query := `
+		INSERT INTO sessions (id, user_id, data, expires_at)
+		VALUES ($1, $2, $3, NOW() + $4 * INTERVAL '1 second')
+		ON CONFLICT (id) DO UPDATE
+		SET user_id = EXCLUDED.user_id, data = EXCLUDED.data, last_seen_at = NOW()
+	`
+	if _, err := s.db.ExecContext(ctx, query, session.ID, userID, data, session.Options.MaxAge); err != nil {
+		return fmt.Errorf("sessiya saxlanılmadı: %w", err)
+	}
+
+	// Yeni kukilər yalnız cari açarla imzalanır
+	encoded, err := securecookie.EncodeMulti(session.Name(), session.ID, s.codecs[0])
+	if err != nil {
+		return err
+	}
+	http.SetCookie(w, sessions.NewCookie(session.Name(), encoded, session.Options))
+
+	return nil
+}
+
+// Revoke verilmiş sessiyanı ləğv edir
+func (s *PGStore) Revoke(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM sessions WHERE id = $1`, id)
+	return err
+}
+
+// RevokeUser istifadəçinin bütün sessiyalarını ləğv edir və silinən sessiyaların sayını qaytarır
+func (s *PGStore) RevokeUser(ctx context.Context, userID int) (int64, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM sessions WHERE user_id = $1`, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// RevokeOthers istifadəçinin keepID-dən başqa bütün sessiyalarını ləğv edir və silinən sessiyaların sayını qaytarır
+func (s *PGStore) RevokeOthers(ctx context.Context, userID int, keepID string) (int64, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM sessions WHERE user_id = $1 AND id <> $2`, userID, keepID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// Sweep vaxtı bitmiş və ya uzun müddət istifadə edilməyən sessiyaları silir
+func (s *PGStore) Sweep(ctx context.Context) (int64, error) {
+	result, err := s.db.ExecContext(ctx, `
+		DELETE FROM sessions
+		WHERE expires_at <= NOW() OR last_seen_at <= NOW() - $1 * INTERVAL '1 second'
+	`, s.idleTimeout.Seconds())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// RunSweeper kontekst ləğv edilənə qədər hər interval-da Sweep çağırır
+func (s *PGStore) RunSweeper(ctx context.Context, interval time.Duration, log *logrus.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			removed, err := s.Sweep(ctx)
+			if err != nil {
+				log.WithError(err).Error("Köhnə sessiyaların silinməsi xətası")
+				continue
+			}
+			if removed > 0 {
+				log.Infof("%d köhnə sessiya silindi", removed)
+			}
+		}
+	}
+}
+
+// load sessiyanı ID-yə görə oxuyur, bitmiş və ya boş qalmış sessiyaları nəzərə almır
+func (s *PGStore) load(ctx context.Context, session *sessions.Session, id string) (bool, error) {
+	var row struct {
+		Data       []byte    `db:"data"`
+		LastSeenAt time.Time `db:"last_seen_at"`
+	}
+
+	query := `
+		SELECT data, last_seen_at
+		FROM sessions
+		WHERE id = $1 AND expires_at > NOW() AND last_seen_at > NOW() - $2 * INTERVAL '1 second'
+	`
+	err := s.db.GetContext(ctx, &row, query, id, s.idleTimeout.Seconds())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if err := gob.NewDecoder(bytes.NewReader(row.Data)).Decode(&session.Values); err != nil {
+		return false, fmt.Errorf("sessiya məlumatları oxunmadı: %w", err)
+	}
+
+	// Fəaliyyətsizlik müddətini yenilə
+	if time.Since(row.LastSeenAt) > touchInterval {
+		if _, err := s.db.ExecContext(ctx, `UPDATE sessions SET last_seen_at = NOW() WHERE id = $1`, id); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// encodeValues sessiya dəyərlərini gob formatında kodlaşdırır
+func encodeValues(values map[interface{}]interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(values); err != nil {
+		return nil, fmt.Errorf("sessiya məlumatları kodlaşdırılmadı: %w", err)
+	}
+	return buf.Bytes(), nil
+}
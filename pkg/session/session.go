@@ -2,7 +2,11 @@ package session
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/sessions"
 )
@@ -12,8 +16,27 @@ const (
 	userIDKey        = "user_id"
 	usernameKey      = "username"
 	authenticatedKey = "authenticated"
+
+	pendingUserIDKey = "pending_user_id"
+	pendingSinceKey  = "pending_since"
+	enrollmentKey    = "two_factor_enrollment"
+	passwordKey      = "password_change"
+	csrfKey          = "csrf_token"
 )
 
+// pendingTimeout şifrə yoxlandıqdan sonra ikinci addımın tamamlanması üçün verilən müddətdir
+const pendingTimeout = 5 * time.Minute
+
+// ErrRevocationUnsupported sessiya mağazası server tərəfdən ləğvi dəstəkləmədikdə qaytarılır
+var ErrRevocationUnsupported = errors.New("sessiya mağazası sessiyaların ləğvini dəstəkləmir")
+
+// Revoker sessiyaları server tərəfdən ləğv edə bilən mağazalar üçün interfeysdir
+type Revoker interface {
+	Revoke(ctx context.Context, id string) error
+	RevokeUser(ctx context.Context, userID int) (int64, error)
+	RevokeOthers(ctx context.Context, userID int, keepID string) (int64, error)
+}
+
 // Manager sessiya idarəsini təmin edir
 type Manager struct {
 	store sessions.Store
@@ -45,8 +68,6 @@ func (m *Manager) Middleware(next http.Handler) http.Handler {
 
 		// Yeni kontekstlə davam et
 		next.ServeHTTP(w, r.WithContext(ctx))
-
-		next.ServeHTTP(w, r)
 	})
 }
 
@@ -54,6 +75,18 @@ func (m *Manager) Middleware(next http.Handler) http.Handler {
 func (m *Manager) Login(w http.ResponseWriter, r *http.Request, userID int, username string) error {
 	session, _ := m.store.Get(r, sessionName)
 
+	// Sessiya fiksasiyasının qarşısını almaq üçün girişdə köhnə sessiya ləğv edilir və yeni ID verilir
+	if revoker, ok := m.store.(Revoker); ok && session.ID != "" {
+		if err := revoker.Revoke(r.Context(), session.ID); err != nil {
+			return err
+		}
+	}
+	session.ID = ""
+
+	delete(session.Values, pendingUserIDKey)
+	delete(session.Values, pendingSinceKey)
+	// Girişdən əvvəl görünmüş CSRF tokeni yeni sessiyada etibarlı qalmamalıdır
+	delete(session.Values, csrfKey)
 	session.Values[userIDKey] = userID
 	session.Values[usernameKey] = username
 	session.Values[authenticatedKey] = true
@@ -68,12 +101,83 @@ func (m *Manager) Logout(w http.ResponseWriter, r *http.Request) error {
 	delete(session.Values, userIDKey)
 	delete(session.Values, usernameKey)
 	delete(session.Values, authenticatedKey)
+	delete(session.Values, enrollmentKey)
+	delete(session.Values, passwordKey)
+	session.Options.MaxAge = -1
 
 	return session.Save(r, w)
 }
 
+// LogoutEverywhere istifadəçinin bütün cihazlardakı sessiyalarını ləğv edir
+func (m *Manager) LogoutEverywhere(w http.ResponseWriter, r *http.Request) error {
+	revoker, ok := m.store.(Revoker)
+	if !ok {
+		return ErrRevocationUnsupported
+	}
+
+	if userID := m.GetUserID(r); userID > 0 {
+		if _, err := revoker.RevokeUser(r.Context(), userID); err != nil {
+			return err
+		}
+	}
+
+	return m.Logout(w, r)
+}
+
+// HasCookie sorğuda sessiya kukisinin olub-olmadığını göstərir
+func (m *Manager) HasCookie(r *http.Request) bool {
+	_, err := r.Cookie(sessionName)
+	return err == nil
+}
+
+// RevokeUser verilmiş istifadəçinin bütün sessiyalarını ləğv edir (məs. şifrə dəyişdirildikdə)
+func (m *Manager) RevokeUser(ctx context.Context, userID int) error {
+	revoker, ok := m.store.(Revoker)
+	if !ok {
+		return ErrRevocationUnsupported
+	}
+
+	_, err := revoker.RevokeUser(ctx, userID)
+	return err
+}
+
+// RevokeOthers istifadəçinin cari sorğudakı sessiyadan başqa bütün sessiyalarını ləğv edir (məs. şifrə dəyişdirildikdə)
+func (m *Manager) RevokeOthers(r *http.Request, userID int) error {
+	revoker, ok := m.store.(Revoker)
+	if !ok {
+		return ErrRevocationUnsupported
+	}
+
+	session, _ := m.store.Get(r, sessionName)
+	_, err := revoker.RevokeOthers(r.Context(), userID, session.ID)
+	return err
+}
+
+// identityKey kukisiz autentifikasiya edilmiş sorğunun istifadəçisini kontekstdə saxlamaq üçündür
+type identityKey struct{}
+
+type identity struct {
+	userID   int
+	username string
+}
+
+// WithIdentity kukisiz (məs. API tokeni ilə) autentifikasiya edilmiş istifadəçini kontekstə əlavə edir.
+// Belə sorğularda IsAuthenticated, GetUserID və GetUsername sessiya əvəzinə bu dəyərləri qaytarır.
+func WithIdentity(ctx context.Context, userID int, username string) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity{userID: userID, username: username})
+}
+
+func identityFromRequest(r *http.Request) (identity, bool) {
+	id, ok := r.Context().Value(identityKey{}).(identity)
+	return id, ok
+}
+
 // IsAuthenticated istifadəçinin giriş etdiyini yoxlayır
 func (m *Manager) IsAuthenticated(r *http.Request) bool {
+	if _, ok := identityFromRequest(r); ok {
+		return true
+	}
+
 	session, _ := m.store.Get(r, sessionName)
 
 	auth, ok := session.Values[authenticatedKey].(bool)
@@ -82,6 +186,10 @@ func (m *Manager) IsAuthenticated(r *http.Request) bool {
 
 // GetUserID sessiyadan istifadəçi ID-sini əldə edir
 func (m *Manager) GetUserID(r *http.Request) int {
+	if id, ok := identityFromRequest(r); ok {
+		return id.userID
+	}
+
 	session, _ := m.store.Get(r, sessionName)
 
 	if id, ok := session.Values[userIDKey].(int); ok {
@@ -93,6 +201,10 @@ func (m *Manager) GetUserID(r *http.Request) int {
 
 // GetUsername sessiyadan istifadəçi adını əldə edir
 func (m *Manager) GetUsername(r *http.Request) string {
+	if id, ok := identityFromRequest(r); ok {
+		return id.username
+	}
+
 	session, _ := m.store.Get(r, sessionName)
 
 	if username, ok := session.Values[usernameKey].(string); ok {
@@ -101,3 +213,98 @@ func (m *Manager) GetUsername(r *http.Request) string {
 
 	return ""
 }
+
+// BeginTwoFactor şifrəsi yoxlanılmış, lakin ikinci addımı gözləyən istifadəçini sessiyada saxlayır
+func (m *Manager) BeginTwoFactor(w http.ResponseWriter, r *http.Request, userID int) error {
+	session, _ := m.store.Get(r, sessionName)
+
+	session.Values[pendingUserIDKey] = userID
+	session.Values[pendingSinceKey] = time.Now().Unix()
+
+	return session.Save(r, w)
+}
+
+// PendingTwoFactor ikinci addımı gözləyən istifadəçinin ID-sini qaytarır; müddət bitibsə 0 qaytarır
+func (m *Manager) PendingTwoFactor(r *http.Request) int {
+	session, _ := m.store.Get(r, sessionName)
+
+	userID, _ := session.Values[pendingUserIDKey].(int)
+	since, _ := session.Values[pendingSinceKey].(int64)
+	if userID == 0 || time.Since(time.Unix(since, 0)) > pendingTimeout {
+		return 0
+	}
+
+	return userID
+}
+
+// SetTwoFactorEnrollment istifadəçinin iki faktorlu autentifikasiyanı qurmalı olduğunu qeyd edir
+func (m *Manager) SetTwoFactorEnrollment(w http.ResponseWriter, r *http.Request, required bool) error {
+	session, _ := m.store.Get(r, sessionName)
+
+	if required {
+		session.Values[enrollmentKey] = true
+	} else {
+		delete(session.Values, enrollmentKey)
+	}
+
+	return session.Save(r, w)
+}
+
+// TwoFactorEnrollmentRequired istifadəçinin iki faktorlu autentifikasiyanı qurmalı olub-olmadığını göstərir
+func (m *Manager) TwoFactorEnrollmentRequired(r *http.Request) bool {
+	session, _ := m.store.Get(r, sessionName)
+
+	required, _ := session.Values[enrollmentKey].(bool)
+	return required
+}
+
+// SetPasswordChange istifadəçinin davam etməzdən əvvəl şifrəsini dəyişməli olduğunu qeyd edir
+func (m *Manager) SetPasswordChange(w http.ResponseWriter, r *http.Request, required bool) error {
+	session, _ := m.store.Get(r, sessionName)
+
+	if required {
+		session.Values[passwordKey] = true
+	} else {
+		delete(session.Values, passwordKey)
+	}
+
+	return session.Save(r, w)
+}
+
+// PasswordChangeRequired istifadəçinin şifrəsini dəyişməli olub-olmadığını göstərir
+func (m *Manager) PasswordChangeRequired(r *http.Request) bool {
+	session, _ := m.store.Get(r, sessionName)
+
+	required, _ := session.Values[passwordKey].(bool)
+	return required
+}
+
+// CSRFToken sessiyanın CSRF tokenini qaytarır; token yoxdursa yenisini yaradır və sessiyanı saxlayır.
+// Yeni token kuki ilə göndərildiyi üçün metod cavabın gövdəsi yazılmazdan əvvəl çağırılmalıdır.
+func (m *Manager) CSRFToken(w http.ResponseWriter, r *http.Request) (string, error) {
+	session, _ := m.store.Get(r, sessionName)
+
+	if token, ok := session.Values[csrfKey].(string); ok && token != "" {
+		return token, nil
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+
+	session.Values[csrfKey] = token
+	if err := session.Save(r, w); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// StoredCSRFToken sessiyada saxlanılan CSRF tokenini qaytarır; token yoxdursa boş sətir qaytarır
+func (m *Manager) StoredCSRFToken(r *http.Request) string {
+	session, _ := m.store.Get(r, sessionName)
+
+	token, _ := session.Values[csrfKey].(string)
+	return token
+}
@@ -0,0 +1,97 @@
+// Package totp RFC 6238 zamana əsaslanan birdəfəlik şifrələri (HMAC-SHA1, 30 saniyə, 6 rəqəm) həyata keçirir
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Period bir kodun etibarlı olduğu addımın uzunluğudur
+	Period = 30 * time.Second
+	// Digits kodun rəqəm sayıdır
+	Digits = 6
+	// Skew saat fərqini nəzərə almaq üçün qəbul edilən əvvəlki/sonrakı addımların sayıdır
+	Skew = 1
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret 160 bitlik təsadüfi açarı base32 formatında qaytarır
+func GenerateSecret() (string, error) {
+	key := make([]byte, 20)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(key), nil
+}
+
+// Step verilmiş vaxtın addım nömrəsini qaytarır
+func Step(t time.Time) int64 {
+	return t.Unix() / int64(Period/time.Second)
+}
+
+// Code verilmiş addım üçün kodu hesablayır
+func Code(secret string, step int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimSpace(secret)))
+	if err != nil {
+		return "", fmt.Errorf("TOTP açarı yanlışdır: %w", err)
+	}
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// RFC 4226 dinamik kəsmə
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", Digits, value%1_000_000), nil
+}
+
+// Validate kodu t vaxtı ətrafında Skew addım daxilində yoxlayır və uyğun gələn addımı qaytarır.
+// Təkrar istifadənin qarşısını almaq üçün çağıran tərəf addımın əvvəlkindən böyük olduğunu yoxlamalıdır.
+func Validate(secret, code string, t time.Time) (int64, bool) {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	current := Step(t)
+	for step := current - Skew; step <= current+Skew; step++ {
+		expected, err := Code(secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// URI autentifikator tətbiqləri üçün otpauth:// ünvanını qaytarır
+func URI(issuer, account, secret string) string {
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+	query := url.Values{
+		"secret":    {secret},
+		"issuer":    {issuer},
+		"algorithm": {"SHA1"},
+		"digits":    {fmt.Sprint(Digits)},
+		"period":    {fmt.Sprint(int(Period / time.Second))},
+	}
+	// Bəzi tətbiqlər "+" işarəsini boşluq kimi qəbul etmir
+	return "otpauth://totp/" + label + "?" + strings.ReplaceAll(query.Encode(), "+", "%20")
+}
@@ -0,0 +1,117 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret RFC 6238 B əlavəsindəki SHA1 açarının ("12345678901234567890") base32 formasıdır
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// TestCodeRFC6238 kodları RFC 6238 B əlavəsinin SHA1 nümunələri ilə müqayisə edir.
+// RFC 8 rəqəmli kodlar verir; 6 rəqəmli kod onların son 6 rəqəmidir.
+func TestCodeRFC6238(t *testing.T) {
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "94287082"},
+		{unix: 1111111109, want: "07081804"},
+		{unix: 1111111111, want: "14050471"},
+		{unix: 1234567890, want: "89005924"},
+		{unix: 2000000000, want: "69279037"},
+		{unix: 20000000000, want: "65353130"},
+	}
+
+	for _, tt := range tests {
+		at := time.Unix(tt.unix, 0)
+		want := tt.want[len(tt.want)-Digits:]
+
+		got, err := Code(rfcSecret, Step(at))
+		if err != nil {
+			t.Fatalf("Code(%d): %v", tt.unix, err)
+		}
+		if got != want {
+			t.Errorf("Code(%d) = %s, gözlənilən %s", tt.unix, got, want)
+		}
+
+		step, ok := Validate(rfcSecret, want, at)
+		if !ok || step != Step(at) {
+			t.Errorf("Validate(%d) = %d, %v, gözlənilən %d, true", tt.unix, step, ok, Step(at))
+		}
+	}
+}
+
+// TestValidateSkew kodun yalnız Skew addım daxilində qəbul edildiyini yoxlayır
+func TestValidateSkew(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	current := Step(now)
+
+	tests := []struct {
+		offset int64
+		want   bool
+	}{
+		{offset: -2, want: false},
+		{offset: -1, want: true},
+		{offset: 0, want: true},
+		{offset: 1, want: true},
+		{offset: 2, want: false},
+	}
+
+	for _, tt := range tests {
+		code, err := Code(rfcSecret, current+tt.offset)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		step, ok := Validate(rfcSecret, code, now)
+		if ok != tt.want {
+			t.Errorf("addım %+d: qəbul = %v, gözlənilən %v", tt.offset, ok, tt.want)
+			continue
+		}
+		if ok && step != current+tt.offset {
+			t.Errorf("addım %+d: qaytarılan addım = %d, gözlənilən %d", tt.offset, step, current+tt.offset)
+		}
+	}
+}
+
+// TestValidateInput kodun formatının və açarın yoxlanmasını yoxlayır
+func TestValidateInput(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+
+	if _, ok := Validate(rfcSecret, " 005 924 ", now); !ok {
+		t.Error("boşluqlu kod qəbul edilmədi")
+	}
+	if _, ok := Validate(strings.ToLower(rfcSecret), "005924", now); !ok {
+		t.Error("kiçik hərfli açar qəbul edilmədi")
+	}
+
+	invalid := []string{"", "00592", "0059240", "abcdef", "005925"}
+	for _, code := range invalid {
+		if _, ok := Validate(rfcSecret, code, now); ok {
+			t.Errorf("Validate(%q) qəbul edildi", code)
+		}
+	}
+
+	if _, ok := Validate("yanlış açar!", "005924", now); ok {
+		t.Error("yanlış açarla kod qəbul edildi")
+	}
+}
+
+// TestGenerateSecret açarın 160 bit olduğunu və kod hesablamaq üçün yararlı olduğunu yoxlayır
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := encoding.DecodeString(secret)
+	if err != nil || len(key) != 20 {
+		t.Errorf("açar = %q, uzunluq %d, xəta %v", secret, len(key), err)
+	}
+
+	if _, err := Code(secret, 1); err != nil {
+		t.Errorf("Code: %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package view
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+)
+
+// placeholders şablonların emalı zamanı tanınmalı olan funksiyalardır.
+// Həqiqi dəyərlər sorğu zamanı WithFuncs ilə kontekstə əlavə edilir və Render tərəfindən bağlanır.
+var placeholders = template.FuncMap{
+	"can":       func(string) bool { return false },
+	"csrfField": func() template.HTML { return "" },
+	"csrfToken": func() string { return "" },
+}
+
+type contextKey struct{}
+
+// Parse şablonları sorğuya bağlı funksiyaların yer tutucuları ilə emal edir
+func Parse(patterns ...string) (*template.Template, error) {
+	tmpl := template.New("").Funcs(placeholders)
+	for _, pattern := range patterns {
+		var err error
+		if tmpl, err = tmpl.ParseGlob(pattern); err != nil {
+			return nil, err
+		}
+	}
+	return tmpl, nil
+}
+
+// WithFuncs şablon funksiyalarını sorğu kontekstinə əlavə edir; əvvəlki funksiyalar saxlanılır
+func WithFuncs(ctx context.Context, funcs template.FuncMap) context.Context {
+	merged := template.FuncMap{}
+	for name, fn := range funcsFromContext(ctx) {
+		merged[name] = fn
+	}
+	for name, fn := range funcs {
+		merged[name] = fn
+	}
+	return context.WithValue(ctx, contextKey{}, merged)
+}
+
+// Render şablonu sorğunun funksiyaları ilə icra edir.
+// Əsas şablon dəsti heç vaxt birbaşa icra edilmir, çünki icra edilmiş html/template klonlana bilmir.
+func Render(w http.ResponseWriter, r *http.Request, tmpl *template.Template, name string, data interface{}) error {
+	clone, err := tmpl.Clone()
+	if err != nil {
+		http.Error(w, "Səhifə hazırlanarkən xəta baş verdi", http.StatusInternalServerError)
+		return err
+	}
+
+	if funcs := funcsFromContext(r.Context()); len(funcs) > 0 {
+		clone.Funcs(funcs)
+	}
+
+	return clone.ExecuteTemplate(w, name, data)
+}
+
+func funcsFromContext(ctx context.Context) template.FuncMap {
+	funcs, _ := ctx.Value(contextKey{}).(template.FuncMap)
+	return funcs
+}